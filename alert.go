@@ -0,0 +1,204 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "time"
+
+// Severity ranks alerts.
+type Severity int
+
+// Alert severities.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// AlertEvent is the Data of the "/alert/<name>" events.
+type AlertEvent struct {
+	Name     string
+	Severity Severity
+	Firing   bool // false when the alert resolved
+}
+
+// Alert is a rule over widget data. While Cond holds the alert fires and
+// Widget, if set, is drawn in the alert style: a red border and a blinking
+// title.
+type Alert struct {
+	Name     string
+	Severity Severity
+	Widget   interface {
+		GetBlock() *Block
+	}
+	Cond   func() bool
+	firing bool
+}
+
+// Firing tells if a is firing.
+func (a *Alert) Firing() bool {
+	return a.firing
+}
+
+// Alerts evaluates alert rules and emits an "/alert/<name>" event each time
+// one starts firing or resolves. Eval reads widget data, so it is called on
+// the UI goroutine, typically from a timer handler or after new data is set.
+/*
+  as := termui.NewAlerts()
+  as.Add(&termui.Alert{
+      Name:     "cpu",
+      Severity: termui.SeverityCritical,
+      Widget:   g,
+      Cond:     termui.GaugeAbove(g, 90),
+  })
+  as.Add(&termui.Alert{
+      Name:   "latency",
+      Widget: lc,
+      Cond:   termui.SlopeAbove(lc, "p99", 10, 5),
+  })
+  as.Bells[termui.SeverityCritical] = termui.BellBoth
+
+  termui.Handle("/timer/1s", func(termui.Event) {
+      as.Eval()
+      termui.Render(g, lc)
+  })
+  termui.Handle("/alert", func(e termui.Event) {
+      a := e.Data.(termui.AlertEvent)
+      if a.Firing && a.Severity == termui.SeverityCritical {
+          page(a.Name)
+      }
+  })
+*/
+type Alerts struct {
+	Emit func(path string, data interface{}) // defaults to SendCustomEvt
+	// Bells tells how an alert of each severity is signaled when it starts
+	// firing; by default critical alerts ring the terminal bell.
+	Bells  map[Severity]BellMode
+	alerts []*Alert
+}
+
+// NewAlerts returns an empty *Alerts.
+func NewAlerts() *Alerts {
+	return &Alerts{
+		Emit:  SendCustomEvt,
+		Bells: map[Severity]BellMode{SeverityCritical: BellAudible},
+	}
+}
+
+// Add registers a.
+func (as *Alerts) Add(a *Alert) {
+	as.alerts = append(as.alerts, a)
+}
+
+// Remove unregisters the alert named name, restoring the style of its widget.
+func (as *Alerts) Remove(name string) {
+	for i, a := range as.alerts {
+		if a.Name == name {
+			as.alerts = append(as.alerts[:i], as.alerts[i+1:]...)
+			if a.Widget != nil {
+				a.Widget.GetBlock().alert = false
+			}
+			as.restyle()
+			return
+		}
+	}
+}
+
+// Firing returns the alerts currently firing.
+func (as *Alerts) Firing() []*Alert {
+	var fs []*Alert
+	for _, a := range as.alerts {
+		if a.firing {
+			fs = append(fs, a)
+		}
+	}
+	return fs
+}
+
+// Eval evaluates every alert, emits events for the ones whose state changed
+// and restyles their widgets.
+func (as *Alerts) Eval() {
+	for _, a := range as.alerts {
+		on := a.Cond != nil && a.Cond()
+		if on == a.firing {
+			continue
+		}
+		a.firing = on
+		if on {
+			as.Bells[a.Severity].Ring()
+		}
+		if as.Emit != nil {
+			as.Emit("/alert/"+a.Name, AlertEvent{Name: a.Name, Severity: a.Severity, Firing: on})
+		}
+	}
+	as.restyle()
+}
+
+// restyle puts the widgets with at least one firing alert in the alert style.
+func (as *Alerts) restyle() {
+	on := make(map[*Block]bool)
+	for _, a := range as.alerts {
+		if a.Widget != nil {
+			b := a.Widget.GetBlock()
+			on[b] = on[b] || a.firing
+		}
+	}
+	for b, v := range on {
+		b.alert = v
+	}
+}
+
+// GaugeAbove returns an alert condition holding while g is above pct percent.
+func GaugeAbove(g *Gauge, pct int) func() bool {
+	return func() bool {
+		if g.Total > 0 {
+			return g.Current/g.Total*100 > float64(pct)
+		}
+		return g.Percent > pct
+	}
+}
+
+// SlopeAbove returns an alert condition holding while the least squares
+// slope of the last n points of the series, in units per point, is above x.
+func SlopeAbove(lc *LineChart, series string, n int, x float64) func() bool {
+	return func() bool {
+		data := lc.Data[series]
+		if n > 0 && len(data) > n {
+			data = data[len(data)-n:]
+		}
+		return len(data) > 1 && Slope(data) > x
+	}
+}
+
+// Slope returns the least squares slope of data over its indexes.
+func Slope(data []float64) float64 {
+	n := float64(len(data))
+	if n < 2 {
+		return 0
+	}
+	var sx, sy, sxy, sxx float64
+	for i, v := range data {
+		x := float64(i)
+		sx += x
+		sy += v
+		sxy += x * v
+		sxx += x * x
+	}
+	return (n*sxy - sx*sy) / (n*sxx - sx*sx)
+}
+
+// blink tells if blinking text is shown at t; it toggles every 500ms.
+func blink(t time.Time) bool {
+	return t.UnixNano()/int64(500*time.Millisecond)%2 == 0
+}
+
+// alertStyle returns a copy of b in the alert style.
+func (b Block) alertStyle() Block {
+	b.BorderFg = ThemeAttr("alert.border.fg")
+	b.BorderLabelFg = ThemeAttr("alert.label.fg")
+	if blink(time.Now()) {
+		b.BorderLabelFg |= AttrReverse
+	}
+	return b
+}
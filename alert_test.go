@@ -0,0 +1,70 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlerts(t *testing.T) {
+	g := NewGauge()
+	var evts []AlertEvent
+	as := NewAlerts()
+	as.Emit = func(path string, data interface{}) {
+		if path != "/alert/cpu" {
+			t.Errorf("unexpected path %q", path)
+		}
+		evts = append(evts, data.(AlertEvent))
+	}
+	var out bytes.Buffer
+	old := rawOut
+	rawOut = &out
+	defer func() { rawOut = old }()
+	as.Add(&Alert{Name: "cpu", Severity: SeverityCritical, Widget: g, Cond: GaugeAbove(g, 90)})
+
+	g.Percent = 50
+	as.Eval()
+	if len(evts) != 0 || g.alert {
+		t.Fatal("alert should not fire below the threshold")
+	}
+
+	g.Percent = 95
+	as.Eval()
+	as.Eval()
+	if len(evts) != 1 || !evts[0].Firing || evts[0].Severity != SeverityCritical {
+		t.Fatalf("expected one firing event, got %+v", evts)
+	}
+	if out.String() != "\a" {
+		t.Errorf("critical alert should ring the bell once, got %q", out.String())
+	}
+	if !g.alert || len(as.Firing()) != 1 {
+		t.Error("gauge should be in the alert style")
+	}
+	buf := g.Buffer()
+	if c := buf.At(0, 1); c.Fg != ThemeAttr("alert.border.fg") {
+		t.Errorf("border should be in the alert color, got %v", c.Fg)
+	}
+
+	g.Percent = 10
+	as.Eval()
+	if len(evts) != 2 || evts[1].Firing || g.alert {
+		t.Errorf("alert should resolve, got %+v", evts)
+	}
+}
+
+func TestSlope(t *testing.T) {
+	if s := Slope([]float64{1, 3, 5, 7}); s != 2 {
+		t.Errorf("expected slope 2, got %v", s)
+	}
+	lc := NewLineChart()
+	lc.Data["p99"] = []float64{100, 0, 0, 1, 2, 3}
+	if !SlopeAbove(lc, "p99", 4, 0.5)() {
+		t.Error("the last 4 points rise by 1 per point")
+	}
+	if SlopeAbove(lc, "p99", 0, 0.5)() {
+		t.Error("the whole series falls")
+	}
+}
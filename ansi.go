@@ -0,0 +1,145 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ANSITxBuilder implements TextBuilder interface, coloring text with the
+// SGR escape sequences programs write to terminals, e.g. "\033[1;31m". The
+// 8 colors and their bright variants, drawn bold, the 256 colors and 24
+// bit colors, approximated, are kept with bold, underline and reverse
+// video; other escape sequences and control characters are dropped.
+type ANSITxBuilder struct{}
+
+// NewANSITxBuilder returns a TextBuilder of ANSI colored text.
+func NewANSITxBuilder() TextBuilder {
+	return ANSITxBuilder{}
+}
+
+// Build implements TextBuilder interface.
+func (ANSITxBuilder) Build(s string, fg, bg Attribute) []Cell {
+	var cs []Cell
+	cfg, cbg := fg, bg
+	col := 0 // column in the line, for tabs
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		if r != '\033' {
+			switch {
+			case r == '\t':
+				for n := 8 - col%8; n > 0; n-- {
+					cs = append(cs, Cell{Ch: ' ', Fg: cfg, Bg: cbg})
+					col++
+				}
+			case r == '\n':
+				cs = append(cs, Cell{Ch: r, Fg: cfg, Bg: cbg})
+				col = 0
+			case r >= ' ' && r != 0x7f:
+				cs = append(cs, Cell{Ch: r, Fg: cfg, Bg: cbg})
+				col += charWidth(r)
+			}
+			continue
+		}
+		if i+1 >= len(rs) {
+			break
+		}
+		switch rs[i+1] {
+		case '[':
+			// CSI: parameters up to a final byte in @ to ~
+			j := i + 2
+			for j < len(rs) && (rs[j] < '@' || rs[j] > '~') {
+				j++
+			}
+			if j < len(rs) && rs[j] == 'm' {
+				cfg, cbg = sgr(string(rs[i+2:j]), cfg, cbg, fg, bg)
+			}
+			i = j
+		case ']':
+			// OSC: up to BEL or ST
+			j := i + 2
+			for j < len(rs) && rs[j] != '\a' && !(rs[j] == '\033' && j+1 < len(rs) && rs[j+1] == '\\') {
+				j++
+			}
+			if j < len(rs) && rs[j] == '\033' {
+				j++
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	return cs
+}
+
+// sgr returns the colors fg and bg set by the parameters ps of an SGR
+// sequence, def being those a reset goes back to.
+func sgr(ps string, fg, bg, defFg, defBg Attribute) (Attribute, Attribute) {
+	if ps == "" {
+		return defFg, defBg
+	}
+	const styles = AttrBold | AttrUnderline | AttrReverse
+	var ns []int
+	for _, p := range strings.Split(ps, ";") {
+		n, _ := strconv.Atoi(p)
+		ns = append(ns, n)
+	}
+	for i := 0; i < len(ns); i++ {
+		switch n := ns[i]; {
+		case n == 0:
+			fg, bg = defFg, defBg
+		case n == 1:
+			fg |= AttrBold
+		case n == 4:
+			fg |= AttrUnderline
+		case n == 7:
+			fg |= AttrReverse
+		case n == 22:
+			fg &^= AttrBold
+		case n == 24:
+			fg &^= AttrUnderline
+		case n == 27:
+			fg &^= AttrReverse
+		case n >= 30 && n <= 37:
+			fg = fg&styles | Attribute(n-30+1)
+		case n == 39:
+			fg = fg&styles | defFg&^styles
+		case n >= 40 && n <= 47:
+			bg = Attribute(n - 40 + 1)
+		case n == 49:
+			bg = defBg
+		case n >= 90 && n <= 97:
+			fg = fg&styles | Attribute(n-90+1) | AttrBold
+		case n >= 100 && n <= 107:
+			bg = Attribute(n - 100 + 1)
+		case n == 38 || n == 48:
+			c, used := extColor(ns[i+1:])
+			i += used
+			if used == 0 {
+				continue
+			}
+			if n == 38 {
+				fg = fg&styles | c
+			} else {
+				bg = c
+			}
+		}
+	}
+	return fg, bg
+}
+
+// extColor returns the 256 or 24 bit color of the parameters following 38
+// or 48, and the number of them it used.
+func extColor(ns []int) (Attribute, int) {
+	switch {
+	case len(ns) >= 2 && ns[0] == 5:
+		return Attribute(clamp(ns[1], 0, 255) + 1), 2
+	case len(ns) >= 4 && ns[0] == 2:
+		return ColorRGB24(ns[1], ns[2], ns[3]), 4
+	}
+	return 0, 0
+}
@@ -0,0 +1,28 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestANSITxBuilder(t *testing.T) {
+	cs := ANSITxBuilder{}.Build("a\033[1;31mb\033[38;5;196mc\033[0m\033]0;title\ad\033[2Ke", ColorWhite, ColorDefault)
+	if s := CellsToStr(cs); s != "abcde" {
+		t.Fatalf("unexpected text %q", s)
+	}
+	expect := []Attribute{ColorWhite, ColorRed | AttrBold, 197 | AttrBold, ColorWhite, ColorWhite}
+	for i, fg := range expect {
+		if cs[i].Fg != fg {
+			t.Errorf("cell %d: expected fg %v, got %v", i, fg, cs[i].Fg)
+		}
+	}
+
+	cs = ANSITxBuilder{}.Build("\033[44;92mx\033[39;49my\tz", ColorDefault, ColorDefault)
+	if cs[0].Fg != ColorGreen|AttrBold || cs[0].Bg != ColorBlue || cs[1].Fg != AttrBold || cs[1].Bg != ColorDefault {
+		t.Errorf("unexpected colors %v", cs[:2])
+	}
+	if len(cs) != 9 || cs[8].Ch != 'z' {
+		t.Errorf("expected a tab to column 8, got %q", CellsToStr(cs))
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// minMaxBlock is the number of points of the smallest block of a
+// minMaxIndex; ranges shorter than that are scanned.
+const minMaxBlock = 16
+
+// minMax is the minimum and maximum of a range of points, and their
+// indices, the first ones on ties.
+type minMax struct {
+	min, max   float64
+	imin, imax int
+	ok         bool
+}
+
+// merge returns the minMax of the range of a followed by that of b.
+func (a minMax) merge(b minMax) minMax {
+	switch {
+	case !a.ok:
+		return b
+	case !b.ok:
+		return a
+	}
+	if b.min < a.min {
+		a.min, a.imin = b.min, b.imin
+	}
+	if b.max > a.max {
+		a.max, a.imax = b.max, b.imax
+	}
+	return a
+}
+
+// scanMinMax returns the minMax of d[lo:hi].
+func scanMinMax(d []float64, lo, hi int) minMax {
+	if lo >= hi {
+		return minMax{}
+	}
+	m := minMax{min: d[lo], max: d[lo], imin: lo, imax: lo, ok: true}
+	for i := lo + 1; i < hi; i++ {
+		if d[i] < m.min {
+			m.min, m.imin = d[i], i
+		}
+		if d[i] > m.max {
+			m.max, m.imax = d[i], i
+		}
+	}
+	return m
+}
+
+// minMaxIndex keeps the minMax of the blocks of minMaxBlock points of a
+// series, and of the pairs of them up, so that the minimum and maximum of
+// any range are found in logarithmic time. Points appended to the series
+// are added to it as they come.
+type minMaxIndex struct {
+	base   *float64 // first point of the series indexed
+	n      int      // number of points indexed
+	levels [][]minMax
+}
+
+// extend indexes the points of d after the n already indexed, d starting
+// with them.
+func (x *minMaxIndex) extend(d []float64) {
+	if len(d) > 0 {
+		x.base = &d[0]
+	}
+	for ; x.n+minMaxBlock <= len(d); x.n += minMaxBlock {
+		m := scanMinMax(d, x.n, x.n+minMaxBlock)
+		for l := 0; ; l++ {
+			if l == len(x.levels) {
+				x.levels = append(x.levels, nil)
+			}
+			x.levels[l] = append(x.levels[l], m)
+			k := len(x.levels[l])
+			if k%2 == 1 {
+				break
+			}
+			m = x.levels[l][k-2].merge(m)
+		}
+	}
+}
+
+// valid tells if x indexes the points of d, d being the series indexed or
+// its beginning.
+func (x *minMaxIndex) valid(d []float64) bool {
+	return len(d) > 0 && x.base == &d[0]
+}
+
+// query returns the minMax of d[lo:hi], d being the series indexed.
+func (x *minMaxIndex) query(d []float64, lo, hi int) minMax {
+	bl := (lo + minMaxBlock - 1) / minMaxBlock
+	bh := hi / minMaxBlock
+	if bh*minMaxBlock > x.n {
+		bh = x.n / minMaxBlock
+	}
+	if bl >= bh {
+		return scanMinMax(d, lo, hi)
+	}
+	left := scanMinMax(d, lo, bl*minMaxBlock)
+	right := scanMinMax(d, bh*minMaxBlock, hi)
+	for l := 0; bl < bh; l++ {
+		if bl%2 == 1 {
+			left = left.merge(x.levels[l][bl])
+			bl++
+		}
+		if bh%2 == 1 {
+			bh--
+			right = x.levels[l][bh].merge(right)
+		}
+		bl, bh = bl/2, bh/2
+	}
+	return left.merge(right)
+}
+
+// Append appends values to series. Unlike AddPoint it keeps every point,
+// and it indexes their minimum and maximum as they come, so that zooming
+// out over long histories does not go over all the points on every frame.
+/*
+  termui.Handle("/timer/1s", func(termui.Event) {
+      lc.Append("latency", samples()...)
+      termui.Render(lc)
+  })
+*/
+func (lc *LineChart) Append(series string, values ...float64) {
+	d := append(lc.Data[series], values...)
+	lc.Data[series] = d
+	if lc.minMax == nil {
+		lc.minMax = make(map[string]*minMaxIndex)
+	}
+	x, ok := lc.minMax[series]
+	if !ok {
+		x = &minMaxIndex{}
+		lc.minMax[series] = x
+	}
+	if x.n > len(d) {
+		*x = minMaxIndex{}
+	}
+	x.extend(d)
+	lc.changed(series, false)
+}
+
+// SetPoint sets the point i of series to v in place, dropping what was
+// drawn or indexed of its points before, unlike assigning to Data.
+func (lc *LineChart) SetPoint(series string, i int, v float64) {
+	lc.Data[series][i] = v
+	delete(lc.minMax, series)
+	lc.changed(series, true)
+}
+
+// indexOf returns the minMaxIndex of the series name, d being its points
+// or their beginning, brought up to date; nil if the series was never
+// appended to or was replaced since.
+func (lc *LineChart) indexOf(name string, d []float64) *minMaxIndex {
+	x := lc.minMax[name]
+	if x == nil || !x.valid(d) {
+		return nil
+	}
+	if len(d) > x.n {
+		x.extend(d)
+	}
+	return x
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMinMaxIndex(t *testing.T) {
+	lc := NewLineChart()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		vs := make([]float64, r.Intn(40))
+		for j := range vs {
+			vs[j] = float64(r.Intn(100))
+		}
+		lc.Append("s", vs...)
+	}
+	d := lc.Data["s"]
+	x := lc.indexOf("s", d)
+	if x == nil || x.n != len(d)/minMaxBlock*minMaxBlock {
+		t.Fatalf("expected the %d points to be indexed, got %v", len(d), x)
+	}
+	for i := 0; i < 500; i++ {
+		lo := r.Intn(len(d))
+		hi := lo + 1 + r.Intn(len(d)-lo)
+		if got, want := x.query(d, lo, hi), scanMinMax(d, lo, hi); got != want {
+			t.Fatalf("[%d:%d]: %+v, want %+v", lo, hi, got, want)
+		}
+	}
+	for _, z := range []int{2, 6, 64, 100} {
+		if got, want := lc.zoomIndexed(d, z, x), lc.zoomSeries(d, z); !reflect.DeepEqual(got, want) {
+			t.Errorf("groups of %d differ from the scan", z)
+		}
+	}
+
+	// a replaced series is scanned again
+	lc.Data["s"] = append([]float64(nil), d...)
+	if lc.indexOf("s", lc.Data["s"]) != nil {
+		t.Error("expected the index of a replaced series to be dropped")
+	}
+}
+
+func TestLineChartAppend(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 30
+	lc.Height = 10
+	lc.Zoom = 4
+	for i := 0; i < 1000; i++ {
+		lc.Append("up", float64(i%10))
+	}
+	lc.Buffer()
+	z := lc.decim.zoom["data/up"]
+
+	// a whole group extends the zoom
+	lc.Append("up", 1, 2, 3, 4, 5, 6, 7, 8)
+	lc.Buffer()
+	e := lc.decim.zoom["data/up"]
+	if e.n != 1008 || len(e.out) != len(z.out)+2 {
+		t.Fatalf("expected the zoom to grow by a group, got %d points zoomed into %d", e.n, len(e.out))
+	}
+	if !reflect.DeepEqual(e.out, lc.zoomSeries(lc.Data["up"], 8)) {
+		t.Error("expected the extended zoom to match that of all the points")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "sync"
+
+// AsyncBufferer is a Bufferer whose buffer is computed off the render loop.
+// Buffer returns the last completed buffer right away; Ready tells if one
+// has been completed at all.
+type AsyncBufferer interface {
+	Bufferer
+	Ready() bool
+}
+
+// Async wraps a slow Bufferer (a big table, syntax highlighted text...) so
+// its Buffer is computed in a worker goroutine. Until a new buffer is ready
+// the previous one keeps being drawn, so one slow widget does not stall
+// the frame rate of the others.
+/*
+  tbl := termui.NewAsync(bigTable)
+  termui.Render(tbl, gauge) // gauge draws immediately, tbl once ready
+*/
+type Async struct {
+	sync.Mutex
+	Widget  Bufferer
+	OnReady func(*Async) // called from the worker, defaults to rendering the widget again
+	buf     Buffer
+	ready   bool
+	busy    bool
+	dirty   bool
+	swapped bool
+}
+
+// NewAsync returns an *Async computing w's buffer in the background.
+func NewAsync(w Bufferer) *Async {
+	return &Async{
+		Widget: w,
+		buf:    NewBuffer(),
+		OnReady: func(a *Async) {
+			if renderJobs != nil {
+				Render(a)
+			}
+		},
+	}
+}
+
+// Ready implements AsyncBufferer.
+func (a *Async) Ready() bool {
+	a.Lock()
+	defer a.Unlock()
+	return a.ready
+}
+
+// Buffer implements Bufferer interface. It returns the last completed
+// buffer and schedules a fresh one to be computed, unless this frame was
+// triggered by OnReady to swap in a buffer that was just completed.
+func (a *Async) Buffer() Buffer {
+	a.Lock()
+	defer a.Unlock()
+
+	switch {
+	case a.busy:
+		// recompute once the running worker is done
+		a.dirty = true
+	case a.swapped:
+		a.swapped = false
+	default:
+		a.busy = true
+		go a.work()
+	}
+	return a.buf
+}
+
+func (a *Async) work() {
+	buf := a.Widget.Buffer()
+
+	a.Lock()
+	a.buf = buf
+	a.ready = true
+	a.busy = false
+	// a dirty widget is not swapped, so the next frame computes it again
+	a.swapped = !a.dirty
+	a.dirty = false
+	onReady := a.OnReady
+	a.Unlock()
+
+	if onReady != nil {
+		onReady(a)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestAsync(t *testing.T) {
+	p := NewPar("slow")
+	p.Width = 10
+	p.Height = 3
+
+	ready := make(chan struct{}, 1)
+	a := NewAsync(p)
+	a.OnReady = func(*Async) { ready <- struct{}{} }
+
+	if buf := a.Buffer(); len(buf.CellMap) != 0 || a.Ready() {
+		t.Error("first frame should be empty until the worker is done")
+	}
+	<-ready
+
+	if buf := a.Buffer(); len(buf.CellMap) == 0 || !a.Ready() {
+		t.Error("computed buffer should be swapped in")
+	}
+	if a.busy {
+		t.Error("swapping frame should not start a new worker")
+	}
+
+	a.Buffer()
+	<-ready
+}
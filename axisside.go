@@ -0,0 +1,104 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "image"
+
+// mirrored tells if the layout of lc differs from the default one, see
+// YAxisSide and XAxisSide.
+func (lc *LineChart) mirrored() bool {
+	return lc.YAxisSide == "right" || lc.XAxisSide == "top"
+}
+
+// mirror returns buf with the axes drawn in it moved to the sides
+// YAxisSide and XAxisSide say, area being the inner area of lc and
+// lc.innerArea the plot laid out in it. The columns of the y axes and their
+// labels trade places, the axis of each staying next to the plot, and the
+// row of the x labels moves above the plot; the plot itself is kept as
+// drawn, the newest points on the right, followed by the EndLabels.
+func (lc *LineChart) mirror(buf Buffer, area image.Rectangle) Buffer {
+	axisX := area.Min.X + lc.labelYSpace // left y axis
+	right := area.Max.X - lc.innerArea.Max.X - lc.endLabelSpace
+	labelRow := area.Max.Y - 1
+
+	// col returns where the cells of column x go
+	col := func(x int) int {
+		if lc.YAxisSide != "right" {
+			return x
+		}
+		switch {
+		case x < axisX:
+			// left labels, now after the left axis
+			return area.Max.X - lc.labelYSpace + x - area.Min.X
+		case x == axisX:
+			return area.Max.X - lc.labelYSpace - 1
+		case x < lc.innerArea.Max.X:
+			return x - axisX - 1 + area.Min.X + right
+		case x == lc.innerArea.Max.X && right > 0:
+			// right axis, now before the plot
+			return area.Min.X + right - 1
+		case x < lc.innerArea.Max.X+right:
+			return x - lc.innerArea.Max.X - 1 + area.Min.X
+		}
+		// end labels, now between the plot and the left axis
+		return x - axisX - 1 + area.Min.X
+	}
+	// shift is how far the x labels move, those under the axis going to
+	// the column before the plot
+	shift := col(axisX+1) - 1 - axisX
+	if axisX+shift < area.Min.X {
+		shift = area.Min.X - axisX
+	}
+
+	cells := make(map[image.Point]Cell, len(buf.CellMap))
+	for p, c := range buf.CellMap {
+		if !p.In(area) {
+			cells[p] = c
+			continue
+		}
+		q := p
+		if p.Y == labelRow {
+			q.X += shift
+			if !q.In(area) {
+				continue
+			}
+		} else {
+			q.X = col(p.X)
+		}
+		if lc.XAxisSide == "top" {
+			if p.Y == labelRow {
+				q.Y = area.Min.Y
+			} else {
+				q.Y++
+			}
+		}
+		if p.X == axisX && p.Y == area.Max.Y-2 && lc.YAxisSide == "right" {
+			c.Ch = BOTTOM_RIGHT
+		}
+		cells[q] = c
+	}
+	if lc.YAxisSide == "right" {
+		// the x axis stops a column short of the right of the plot, and
+		// of the end labels, now next to the y axis
+		y := area.Max.Y - 2
+		if lc.XAxisSide == "top" {
+			y++
+		}
+		for x := col(lc.innerArea.Max.X - 1); x < col(axisX); x++ {
+			cells[image.Pt(x, y)] = Cell{Ch: HDASH, Fg: lc.AxesColor, Bg: lc.Bg}
+		}
+	}
+	// blank the cells the x labels moved off
+	if lc.XAxisSide == "top" {
+		labelRow = area.Min.Y
+	}
+	for x := area.Min.X; x < area.Max.X; x++ {
+		if _, ok := cells[image.Pt(x, labelRow)]; !ok {
+			cells[image.Pt(x, labelRow)] = Cell{Ch: ' ', Bg: lc.Bg}
+		}
+	}
+	buf.CellMap = cells
+	return buf
+}
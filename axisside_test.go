@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLineChartAxisSide(t *testing.T) {
+	chart := func(ySide, xSide string) (*LineChart, Buffer) {
+		lc := NewLineChart()
+		lc.Border = false
+		lc.Width = 30
+		lc.Height = 8
+		lc.YAxisSide, lc.XAxisSide = ySide, xSide
+		lc.Data["a"] = []float64{1, 3, 2, 5, 4, 6, 2, 8, 7, 9}
+		return lc, lc.Buffer()
+	}
+	lc, def := chart("left", "bottom")
+	ls := lc.labelYSpace
+	_, right := chart("right", "bottom")
+
+	// the plot moves left by the width of the axis, the labels go right of it
+	for y := 0; y < 7; y++ {
+		for x := ls + 1; x < 30; x++ {
+			if x == 29 && y == 6 {
+				continue // the end of the x axis, joined to the y axis
+			}
+			if a, b := def.At(x, y), right.At(x-ls-1, y); a.Ch != b.Ch {
+				t.Fatalf("cell %d,%d of the plot drawn %q at %d, want %q", x, y, b.Ch, x-ls-1, a.Ch)
+			}
+		}
+		for i := 0; i < ls; i++ {
+			if a, b := def.At(i, y), right.At(30-ls+i, y); a.Ch != b.Ch {
+				t.Fatalf("y label cell %d,%d drawn %q, want %q", i, y, b.Ch, a.Ch)
+			}
+		}
+	}
+	if c := right.At(30-ls-1, 6); c.Ch != BOTTOM_RIGHT {
+		t.Errorf("origin drawn %q, want %q", c.Ch, BOTTOM_RIGHT)
+	}
+	if c := right.At(30-ls-1, 3); c.Ch != VDASH {
+		t.Errorf("axis drawn %q, want %q", c.Ch, VDASH)
+	}
+	if a, b := def.At(ls, 7), right.At(0, 7); a.Ch != b.Ch {
+		t.Errorf("first x label drawn %q, want %q", b.Ch, a.Ch)
+	}
+
+	// the x labels above the plot, which moves down a row
+	_, top := chart("left", "top")
+	for x := 0; x < 30; x++ {
+		if a, b := def.At(x, 7), top.At(x, 0); a.Ch != b.Ch {
+			t.Fatalf("x label cell %d drawn %q, want %q", x, b.Ch, a.Ch)
+		}
+		if a, b := def.At(x, 3), top.At(x, 4); a.Ch != b.Ch {
+			t.Fatalf("cell %d,3 drawn %q one row down, want %q", x, b.Ch, a.Ch)
+		}
+	}
+}
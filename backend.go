@@ -0,0 +1,101 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"io"
+
+	tm "github.com/nsf/termbox-go"
+)
+
+// backend is the screen frames are drawn to: the terminal through termbox,
+// or an in-memory Headless screen.
+type backend interface {
+	setCell(x, y int, c Cell)
+	flush()
+	clear(bg Attribute)
+	sync()
+	size() (int, int)
+	close()
+	showCursor(x, y int, s CursorStyle)
+	hideCursor()
+}
+
+type termboxBackend struct{}
+
+func (termboxBackend) setCell(x, y int, c Cell) {
+	tm.SetCell(x, y, c.Ch, toTmAttr(c.Fg), toTmAttr(c.Bg))
+	w, h := tm.Size()
+	scrollScreen.set(w, h, x, y, c)
+}
+
+// flush writes the frame as told by SetFlushStrategy. termbox's buffers
+// are kept up to date whatever the strategy, for sync to redraw from.
+func (termboxBackend) flush() {
+	switch scrollScreen.current() {
+	case FlushScroll:
+		w, h := tm.Size()
+		scrollScreen.flush(w, h, rawOut)
+	case FlushFull:
+		tm.Sync()
+	default:
+		tm.Flush()
+	}
+}
+
+func (termboxBackend) clear(bg Attribute) {
+	tm.Clear(tm.ColorDefault, toTmAttr(bg))
+	w, h := tm.Size()
+	scrollScreen.clear(w, h, bg)
+}
+
+func (termboxBackend) sync() {
+	tm.Sync()
+	scrollScreen.invalidate()
+}
+
+func (termboxBackend) size() (int, int) {
+	return tm.Size()
+}
+
+func (termboxBackend) close() {
+	if tmCursorStyle != (CursorStyle{}) {
+		io.WriteString(rawOut, CursorStyle{}.decscusr())
+		tmCursorStyle = CursorStyle{}
+	}
+	tm.Close()
+}
+
+// tmCursorStyle is the style the terminal cursor was given.
+var tmCursorStyle CursorStyle
+
+// showCursor places the cursor once the frame is flushed: through termbox,
+// which flushes nothing else but the cursor, or straight to the terminal
+// when termbox is bypassed by FlushScroll.
+func (termboxBackend) showCursor(x, y int, s CursorStyle) {
+	if scrollScreen.current() == FlushScroll {
+		fmt.Fprintf(rawOut, "\033[%d;%dH\033[?25h", y+1, x+1)
+	} else {
+		tm.SetCursor(x, y)
+		tm.Flush()
+	}
+	if s != tmCursorStyle {
+		io.WriteString(rawOut, s.decscusr())
+		tmCursorStyle = s
+	}
+}
+
+func (termboxBackend) hideCursor() {
+	if scrollScreen.current() == FlushScroll {
+		io.WriteString(rawOut, "\033[?25l")
+		return
+	}
+	tm.HideCursor()
+	tm.Flush()
+}
+
+// screen is the backend of the running app.
+var screen backend = termboxBackend{}
@@ -20,21 +20,30 @@ import "fmt"
    bc.BarColor = termui.ColorRed
    bc.NumColor = termui.ColorYellow
 */
+// Bars can also be stacked, each label carrying several segments drawn one
+// above the other, with the total of the bar on top:
+/*
+   bc.Stacks = [][]int{{3, 1}, {2, 4, 1}, {5, 2}}
+   bc.StackColors = []termui.Attribute{termui.ColorBlue, termui.ColorGreen, termui.ColorRed}
+   bc.ShowTotal = true
+*/
 type BarChart struct {
 	Block
-	BarColor   Attribute
-	TextColor  Attribute
-	NumColor   Attribute
-	Data       []int
-	DataLabels []string
-	BarWidth   int
-	BarGap     int
-	CellChar   rune
-	labels     [][]rune
-	dataNum    [][]rune
-	numBar     int
-	scale      float64
-	max        int
+	BarColor    Attribute
+	TextColor   Attribute
+	NumColor    Attribute
+	Data        []int
+	DataLabels  []string
+	BarWidth    int
+	BarGap      int
+	CellChar    rune
+	Stacks      [][]int     // segments of every bar, the bottom one first; drawn instead of Data when set
+	StackColors []Attribute // colors of the segments, cycled; BarColor and the colors after it by default
+	ShowTotal   bool        // draw the total of every bar above it
+	labels      [][]rune
+	numBar      int
+	scale       float64
+	max         int
 }
 
 // NewBarChart returns a new *BarChart with current theme.
@@ -49,16 +58,48 @@ func NewBarChart() *BarChart {
 	return bc
 }
 
+// bars returns the number of bars, stacked or not.
+func (bc *BarChart) bars() int {
+	if bc.Stacks != nil {
+		return len(bc.Stacks)
+	}
+	return len(bc.Data)
+}
+
+// segments returns the values stacked in the bar i.
+func (bc *BarChart) segments(i int) []int {
+	if bc.Stacks != nil {
+		return bc.Stacks[i]
+	}
+	return bc.Data[i : i+1]
+}
+
+// total returns the height of the bar i.
+func (bc *BarChart) total(i int) int {
+	t := 0
+	for _, v := range bc.segments(i) {
+		t += v
+	}
+	return t
+}
+
+// segmentColor returns the color of the segment s of stacked bars.
+func (bc *BarChart) segmentColor(s int) Attribute {
+	if len(bc.StackColors) > 0 {
+		return bc.StackColors[s%len(bc.StackColors)]
+	}
+	if bc.Stacks == nil || s == 0 || bc.BarColor < ColorBlack || bc.BarColor > ColorWhite {
+		return bc.BarColor
+	}
+	return (bc.BarColor-ColorBlack+Attribute(s))%NumberofColors + ColorBlack
+}
+
 func (bc *BarChart) layout() {
 	bc.numBar = bc.innerArea.Dx() / (bc.BarGap + bc.BarWidth)
 	bc.labels = make([][]rune, bc.numBar)
-	bc.dataNum = make([][]rune, len(bc.Data))
 
-	for i := 0; i < bc.numBar && i < len(bc.DataLabels) && i < len(bc.Data); i++ {
+	for i := 0; i < bc.numBar && i < len(bc.DataLabels) && i < bc.bars(); i++ {
 		bc.labels[i] = trimStr2Runes(bc.DataLabels[i], bc.BarWidth)
-		n := bc.Data[i]
-		s := fmt.Sprint(n)
-		bc.dataNum[i] = trimStr2Runes(s, bc.BarWidth)
 	}
 
 	//bc.max = bc.Data[0] //  what if Data is nil? Sometimes when bar graph is nill it produces panic with panic: runtime error: index out of range
@@ -66,12 +107,16 @@ func (bc *BarChart) layout() {
 	if bc.max == 0 {
 		bc.max = -1
 	}
-	for i := 0; i < len(bc.Data); i++ {
-		if bc.max < bc.Data[i] {
-			bc.max = bc.Data[i]
+	for i := 0; i < bc.bars(); i++ {
+		if t := bc.total(i); bc.max < t {
+			bc.max = t
 		}
 	}
-	bc.scale = float64(bc.max) / float64(bc.innerArea.Dy()-1)
+	rows := bc.innerArea.Dy() - 1
+	if bc.ShowTotal {
+		rows-- // the total above the highest bar
+	}
+	bc.scale = float64(bc.max) / float64(rows)
 }
 
 func (bc *BarChart) SetMax(max int) {
@@ -84,37 +129,83 @@ func (bc *BarChart) SetMax(max int) {
 // Buffer implements Bufferer interface.
 func (bc *BarChart) Buffer() Buffer {
 	buf := bc.Block.Buffer()
+	if bc.drawState(buf) {
+		return buf
+	}
+	if bc.Skeleton && bc.bars() == 0 {
+		bc.drawSkeleton(buf, true)
+		return buf
+	}
 	bc.layout()
 
-	for i := 0; i < bc.numBar && i < len(bc.Data) && i < len(bc.DataLabels); i++ {
-		h := int(float64(bc.Data[i]) / bc.scale)
+	for i := 0; i < bc.numBar && i < bc.bars() && i < len(bc.DataLabels); i++ {
 		oftX := i * (bc.BarWidth + bc.BarGap)
+		bottom := bc.innerArea.Min.Y + bc.innerArea.Dy() - 2
+
+		// plot the segments, each one from the top of the previous
+		sum, top := 0, 0
+		for s, v := range bc.segments(i) {
+			sum += v
+			base := top
+			top = int(float64(sum) / bc.scale)
+			if bc.Stacks == nil {
+				top = int(float64(v) / bc.scale)
+			}
+			h := top - base
 
-		barBg := bc.Bg
-		barFg := bc.BarColor
+			barBg := bc.Bg
+			barFg := bc.segmentColor(s)
 
-		if bc.CellChar == ' ' {
-			barBg = bc.BarColor
-			barFg = ColorDefault
-			if bc.BarColor == ColorDefault { // the same as above
-				barBg |= AttrReverse
+			if bc.CellChar == ' ' {
+				barBg = barFg
+				barFg = ColorDefault
+				if barBg == ColorDefault { // the same as above
+					barBg |= AttrReverse
+				}
 			}
-		}
 
-		// plot bar
-		for j := 0; j < bc.BarWidth; j++ {
-			for k := 0; k < h; k++ {
+			// plot bar
+			for j := 0; j < bc.BarWidth; j++ {
+				for k := 0; k < h; k++ {
+					c := Cell{
+						Ch: bc.CellChar,
+						Bg: barBg,
+						Fg: barFg,
+					}
+
+					x := bc.innerArea.Min.X + oftX + j
+					y := bottom - base - k
+					buf.Set(x, y, c)
+				}
+			}
+			// plot num, on the bottom row of the segment
+			if bc.Stacks != nil && h <= 0 {
+				continue
+			}
+			num := trimStr2Runes(fmt.Sprint(v), bc.BarWidth)
+			for j := 0; j < len(num); j++ {
 				c := Cell{
-					Ch: bc.CellChar,
+					Ch: num[j],
+					Fg: bc.NumColor,
 					Bg: barBg,
-					Fg: barFg,
 				}
 
-				x := bc.innerArea.Min.X + i*(bc.BarWidth+bc.BarGap) + j
-				y := bc.innerArea.Min.Y + bc.innerArea.Dy() - 2 - k
+				if h <= 0 {
+					c.Bg = bc.Bg
+				}
+				x := bc.innerArea.Min.X + oftX + (bc.BarWidth-len(num))/2 + j
+				y := bottom - base
 				buf.Set(x, y, c)
 			}
 		}
+		// plot total
+		if bc.ShowTotal && bottom-top >= bc.innerArea.Min.Y {
+			num := trimStr2Runes(fmt.Sprint(sum), bc.BarWidth)
+			for j := 0; j < len(num); j++ {
+				x := bc.innerArea.Min.X + oftX + (bc.BarWidth-len(num))/2 + j
+				buf.Set(x, bottom-top, Cell{Ch: num[j], Fg: bc.TextColor, Bg: bc.Bg})
+			}
+		}
 		// plot text
 		for j, k := 0, 0; j < len(bc.labels[i]); j++ {
 			w := charWidth(bc.labels[i][j])
@@ -128,21 +219,6 @@ func (bc *BarChart) Buffer() Buffer {
 			buf.Set(x, y, c)
 			k += w
 		}
-		// plot num
-		for j := 0; j < len(bc.dataNum[i]); j++ {
-			c := Cell{
-				Ch: bc.dataNum[i][j],
-				Fg: bc.NumColor,
-				Bg: barBg,
-			}
-
-			if h == 0 {
-				c.Bg = bc.Bg
-			}
-			x := bc.innerArea.Min.X + oftX + (bc.BarWidth-len(bc.dataNum[i]))/2 + j
-			y := bc.innerArea.Min.Y + bc.innerArea.Dy() - 2
-			buf.Set(x, y, c)
-		}
 	}
 
 	return buf
@@ -0,0 +1,55 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestBarChartStacks(t *testing.T) {
+	bc := NewBarChart()
+	bc.Border = false
+	bc.Width = 8
+	bc.Height = 6
+	bc.DataLabels = []string{"a", "b"}
+	bc.Stacks = [][]int{{1, 1}, {2, 2}}
+	bc.StackColors = []Attribute{ColorBlue, ColorGreen}
+	bc.ShowTotal = true
+
+	// 4 rows for the bars, 1 for the totals, 1 for the labels
+	buf := bc.Buffer()
+	expect := map[[2]int]Attribute{
+		{0, 4}: ColorBlue, {0, 3}: ColorGreen, {0, 2}: ColorDefault,
+		{4, 4}: ColorBlue, {4, 3}: ColorBlue, {4, 2}: ColorGreen, {4, 1}: ColorGreen,
+	}
+	for p, bg := range expect {
+		if c := buf.At(p[0], p[1]); c.Bg != bg {
+			t.Errorf("cell %v: expected bg %v, got %v", p, bg, c.Bg)
+		}
+	}
+	if c := buf.At(1, 2); c.Ch != '2' {
+		t.Errorf("expected the total above the first bar, got %q", c.Ch)
+	}
+	if c := buf.At(5, 0); c.Ch != '4' {
+		t.Errorf("expected the total above the second bar, got %q", c.Ch)
+	}
+	if c := buf.At(5, 2); c.Ch != '2' || c.Bg != ColorGreen {
+		t.Errorf("expected the value at the bottom of the segment, got %q", c.Ch)
+	}
+	if c := buf.At(0, 5); c.Ch != 'a' {
+		t.Errorf("expected the label, got %q", c.Ch)
+	}
+}
+
+func TestBarChartSegmentColor(t *testing.T) {
+	bc := NewBarChart()
+	bc.BarColor = ColorWhite
+	bc.Stacks = [][]int{{1, 1}}
+	if c := bc.segmentColor(1); c != ColorBlack {
+		t.Errorf("expected the colors to cycle, got %v", c)
+	}
+	bc.Stacks = nil
+	if c := bc.segmentColor(0); c != ColorWhite {
+		t.Errorf("expected BarColor, got %v", c)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"io"
+	"time"
+)
+
+// BellMode is how a notification is signaled to the user.
+type BellMode int
+
+// Bell modes.
+const (
+	BellNone BellMode = iota
+	BellAudible
+	BellVisual
+	BellBoth
+)
+
+// VisualBellDuration is how long the screen stays inverted by VisualBell.
+var VisualBellDuration = 100 * time.Millisecond
+
+// Bell rings the terminal bell.
+func Bell() {
+	renderLock.Lock()
+	io.WriteString(rawOut, "\a")
+	renderLock.Unlock()
+}
+
+// VisualBell briefly inverts the whole screen, for terminals with a muted
+// bell or users who prefer not to hear it.
+func VisualBell() {
+	renderLock.Lock()
+	io.WriteString(rawOut, "\033[?5h")
+	renderLock.Unlock()
+	time.AfterFunc(VisualBellDuration, func() {
+		renderLock.Lock()
+		io.WriteString(rawOut, "\033[?5l")
+		renderLock.Unlock()
+	})
+}
+
+// Ring signals the user as told by m.
+func (m BellMode) Ring() {
+	if m == BellAudible || m == BellBoth {
+		Bell()
+	}
+	if m == BellVisual || m == BellBoth {
+		VisualBell()
+	}
+}
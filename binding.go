@@ -0,0 +1,166 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Observable wraps a model value and notifies subscribers whenever it is set.
+// Set may be called from any goroutine: the subscribers are called on the
+// event loop, which renders the widgets they change, when it dispatches the
+// "/usr/observable" event Set posts. Values set before the loop gets to it
+// are coalesced, the subscribers only seeing the latest.
+/*
+  cpu := termui.NewObservable(0.0)
+  g := termui.NewGauge()
+  termui.Bind(cpu, g)
+  termui.Handle("/usr/observable", func(termui.Event) {
+      termui.Render(g) // g.Percent is now 42
+  })
+
+  // somewhere in the model
+  go cpu.Set(42.5)
+*/
+type Observable struct {
+	sync.RWMutex
+	val     interface{}
+	subs    []func(interface{})
+	pending bool // a "/usr/observable" event is posted, not dispatched yet
+	// Emit posts the "/usr/observable" events, it defaults to SendCustomEvt.
+	// Without it Set calls the subscribers itself.
+	Emit func(path string, data interface{})
+}
+
+// NewObservable returns an *Observable holding v.
+func NewObservable(v interface{}) *Observable {
+	return &Observable{val: v, Emit: SendCustomEvt}
+}
+
+// Get returns the current value.
+func (o *Observable) Get() interface{} {
+	o.RLock()
+	defer o.RUnlock()
+	return o.val
+}
+
+// Set stores v and posts it to the subscribers.
+func (o *Observable) Set(v interface{}) {
+	o.Lock()
+	o.val = v
+	emit, post := o.Emit, !o.pending
+	o.pending = emit != nil
+	o.Unlock()
+
+	switch {
+	case emit == nil:
+		o.deliver()
+	case post:
+		// not to block Set when called on the loop, which the event waits for
+		go emit("/usr/observable", o)
+	}
+}
+
+// deliver calls every subscriber with the latest value set.
+func (o *Observable) deliver() {
+	o.Lock()
+	v := o.val
+	o.pending = false
+	subs := make([]func(interface{}), len(o.subs))
+	copy(subs, o.subs)
+	o.Unlock()
+
+	for _, f := range subs {
+		f(v)
+	}
+}
+
+// Subscribe registers f to be called with every new value.
+func (o *Observable) Subscribe(f func(interface{})) {
+	o.Lock()
+	o.subs = append(o.subs, f)
+	o.Unlock()
+}
+
+// Binder is implemented by widgets that can display a bound model value.
+type Binder interface {
+	BindValue(v interface{})
+}
+
+// Bind keeps w in sync with o: w receives the current value immediately and
+// every value set afterwards, on the event loop. Rendering is still up to the
+// caller, e.g. on "/usr/observable" events.
+func Bind(o *Observable, w Binder) {
+	o.Subscribe(w.BindValue)
+	w.BindValue(o.Get())
+}
+
+// toFloat converts numeric values to float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// BindValue implements Binder. A number sets Current when Total is set and
+// Percent otherwise.
+func (g *Gauge) BindValue(v interface{}) {
+	f, ok := toFloat(v)
+	if !ok {
+		return
+	}
+	if g.Total > 0 {
+		g.Current = f
+	} else {
+		g.Percent = int(f)
+	}
+}
+
+// BindValue implements Binder, displaying v as the paragraph text.
+func (p *Par) BindValue(v interface{}) {
+	p.Text = fmt.Sprint(v)
+}
+
+// BindValue implements Binder, accepting a []string of items.
+func (l *List) BindValue(v interface{}) {
+	if items, ok := v.([]string); ok {
+		l.Items = items
+	}
+}
+
+// BindValue implements Binder, accepting a number pushed as the new value.
+func (st *StatTile) BindValue(v interface{}) {
+	if f, ok := toFloat(v); ok {
+		st.Push(f)
+	}
+}
+
+// BindValue implements Binder, accepting either a map of series or a single
+// []float64 shown as the "default" series.
+func (lc *LineChart) BindValue(v interface{}) {
+	switch d := v.(type) {
+	case map[string][]float64:
+		lc.Data = d
+	case []float64:
+		lc.Data["default"] = d
+	}
+}
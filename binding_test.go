@@ -0,0 +1,58 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestBind(t *testing.T) {
+	o := NewObservable(10)
+	o.Emit = nil
+	g := NewGauge()
+	p := NewPar("")
+	Bind(o, g)
+	Bind(o, p)
+
+	if g.Percent != 10 || p.Text != "10" {
+		t.Errorf("initial value not bound: %d %q", g.Percent, p.Text)
+	}
+
+	o.Set(55.5)
+	if g.Percent != 55 || p.Text != "55.5" {
+		t.Errorf("new value not bound: %d %q", g.Percent, p.Text)
+	}
+
+	o.Set("n/a")
+	if g.Percent != 55 {
+		t.Error("gauge should ignore non-numeric values")
+	}
+}
+
+func TestBindOnLoop(t *testing.T) {
+	posted := make(chan Event, 2)
+	o := NewObservable(10)
+	o.Emit = func(path string, data interface{}) {
+		posted <- Event{Path: path, Data: data}
+	}
+	g := NewGauge()
+	Bind(o, g)
+
+	o.Set(20)
+	o.Set(30)
+	e := <-posted
+	if g.Percent != 10 {
+		t.Errorf("the gauge should only change on the loop, got %d", g.Percent)
+	}
+
+	es := NewEvtStream()
+	rendered := -1
+	es.Handle("/usr/observable", func(Event) { rendered = g.Percent })
+	es.dispatch(e)
+	if g.Percent != 30 || rendered != 30 {
+		t.Errorf("expected the latest value before the handlers, got %d, rendered %d", g.Percent, rendered)
+	}
+	if len(posted) != 0 {
+		t.Error("values set before the loop got to them should be coalesced")
+	}
+}
@@ -70,16 +70,16 @@ func (b Block) drawBorder(buf Buffer) {
 
 	// draw corners
 	if b.BorderTop && b.BorderLeft && b.area.Dx() > 0 && b.area.Dy() > 0 {
-		buf.Set(x0, y0, Cell{TOP_LEFT, b.BorderFg, b.BorderBg})
+		buf.Set(x0, y0, Cell{Ch: TOP_LEFT, Fg: b.BorderFg, Bg: b.BorderBg})
 	}
 	if b.BorderTop && b.BorderRight && b.area.Dx() > 1 && b.area.Dy() > 0 {
-		buf.Set(x1, y0, Cell{TOP_RIGHT, b.BorderFg, b.BorderBg})
+		buf.Set(x1, y0, Cell{Ch: TOP_RIGHT, Fg: b.BorderFg, Bg: b.BorderBg})
 	}
 	if b.BorderBottom && b.BorderLeft && b.area.Dx() > 0 && b.area.Dy() > 1 {
-		buf.Set(x0, y1, Cell{BOTTOM_LEFT, b.BorderFg, b.BorderBg})
+		buf.Set(x0, y1, Cell{Ch: BOTTOM_LEFT, Fg: b.BorderFg, Bg: b.BorderBg})
 	}
 	if b.BorderBottom && b.BorderRight && b.area.Dx() > 1 && b.area.Dy() > 1 {
-		buf.Set(x1, y1, Cell{BOTTOM_RIGHT, b.BorderFg, b.BorderBg})
+		buf.Set(x1, y1, Cell{Ch: BOTTOM_RIGHT, Fg: b.BorderFg, Bg: b.BorderBg})
 	}
 }
 
@@ -87,10 +87,13 @@ func (b Block) drawBorderLabel(buf Buffer) {
 	maxTxtW := b.area.Dx() - 2
 	tx := DTrimTxCls(DefaultTxBuilder.Build(b.BorderLabel, b.BorderLabelFg, b.BorderLabelBg), maxTxtW)
 
-	for i, w := 0, 0; i < len(tx); i++ {
+	w := 0
+	for i := 0; i < len(tx); i++ {
 		buf.Set(b.area.Min.X+1+w, b.area.Min.Y, tx[i])
 		w += tx[i].Width()
 	}
+
+	b.drawTitleSegments(buf, w)
 }
 
 // Block is a base struct for all other upper level widgets,
@@ -111,6 +114,9 @@ type Block struct {
 	BorderLabel   string
 	BorderLabelFg Attribute
 	BorderLabelBg Attribute
+	TitleSegments []TitleSegment
+	Collapsed     bool // only the title bar is drawn and laid out
+	Skeleton      bool // placeholders are drawn until the first data arrives
 	Display       bool
 	Bg            Attribute
 	Width         int
@@ -121,6 +127,10 @@ type Block struct {
 	PaddingRight  int
 	id            string
 	Float         Align
+	loading       bool
+	err           error
+	retry         func()
+	alert         bool
 }
 
 // NewBlock returns a *Block which inherits styles from current theme.
@@ -148,6 +158,11 @@ func (b Block) Id() string {
 	return b.id
 }
 
+// GetBlock returns b itself, giving access to the Block embedded in widgets.
+func (b *Block) GetBlock() *Block {
+	return b
+}
+
 // Align computes box model
 func (b *Block) Align() {
 	// outer
@@ -180,6 +195,13 @@ func (b *Block) Align() {
 			b.innerArea.Max.Y--
 		}
 	}
+
+	// collapsed: keep the title bar, leave no room for content
+	if b.Collapsed {
+		b.area.Max.Y = b.area.Min.Y + 1
+		b.innerArea.Min.Y = b.area.Max.Y
+		b.innerArea.Max.Y = b.area.Max.Y
+	}
 }
 
 // InnerBounds returns the internal bounds of the block after aligning and
@@ -198,18 +220,55 @@ func (b *Block) Buffer() Buffer {
 	buf.SetArea(b.area)
 	buf.Fill(' ', ColorDefault, b.Bg)
 
-	b.drawBorder(buf)
-	b.drawBorderLabel(buf)
+	if b.alert {
+		s := b.alertStyle()
+		s.drawBorder(buf)
+		s.drawBorderLabel(buf)
+	} else {
+		b.drawBorder(buf)
+		b.drawBorderLabel(buf)
+	}
 
 	return buf
 }
 
 // GetHeight implements GridBufferer.
-// It returns current height of the block.
+// It returns current height of the block, 1 if collapsed.
 func (b Block) GetHeight() int {
+	if b.Collapsed {
+		return 1
+	}
 	return b.Height
 }
 
+// IsCollapsed tells if only the title bar of b is shown.
+func (b Block) IsCollapsed() bool {
+	return b.Collapsed
+}
+
+// ToggleCollapse collapses an expanded block or expands a collapsed one.
+func (b *Block) ToggleCollapse() {
+	b.Collapsed = !b.Collapsed
+}
+
+// CollapseOn toggles b's collapsed state on events of path, e.g.
+// "/sys/kbd/c". For "/sys/mouse" only left button presses on the title bar
+// toggle it, not the release ending the click. redraw is called after
+// toggling, typically to re-align and render Body.
+func (b *Block) CollapseOn(path string, redraw func()) {
+	b.Handle(path, func(e Event) {
+		if m, ok := e.Data.(EvtMouse); ok {
+			if m.Press != "left" || m.Y != b.area.Min.Y || m.X < b.area.Min.X || m.X >= b.area.Max.X {
+				return
+			}
+		}
+		b.ToggleCollapse()
+		if redraw != nil {
+			redraw()
+		}
+	})
+}
+
 // SetX implements GridBufferer interface, which sets block's x position.
 func (b *Block) SetX(x int) {
 	b.X = x
@@ -225,6 +284,11 @@ func (b *Block) SetWidth(w int) {
 	b.Width = w
 }
 
+// SetHeight sets block's height.
+func (b *Block) SetHeight(h int) {
+	b.Height = h
+}
+
 func (b Block) InnerWidth() int {
 	return b.innerArea.Dx()
 }
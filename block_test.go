@@ -70,3 +70,68 @@ func TestBlockInnerBounds(t *testing.T) {
 	b.PaddingRight = 5
 	assert("border, 2b 3t 4l 5r padding", 15, 15, 1, 6)
 }
+
+func TestBlockTitleSegments(t *testing.T) {
+	b := NewBlock()
+	b.Width = 20
+	b.Height = 3
+	b.BorderLabel = "ab"
+	b.TitleSegments = []TitleSegment{
+		{Text: "L"},
+		{Provider: func() string { return "R" }, Align: AlignRight},
+	}
+	buf := b.Buffer()
+
+	if c := buf.At(4, 0).Ch; c != 'L' {
+		t.Errorf("left segment should follow the label, got %c", c)
+	}
+	if c := buf.At(18, 0).Ch; c != 'R' {
+		t.Errorf("right segment should end at the border, got %c", c)
+	}
+}
+
+func TestBlockCollapsed(t *testing.T) {
+	b := NewBlock()
+	b.Width = 10
+	b.Height = 5
+	b.Collapsed = true
+
+	if b.GetHeight() != 1 {
+		t.Errorf("collapsed block should be laid out with height 1, got %d", b.GetHeight())
+	}
+	if area := b.InnerBounds(); area.Dy() != 0 {
+		t.Errorf("collapsed block should have no inner area, got %v", area)
+	}
+	b.ToggleCollapse()
+	if b.GetHeight() != 5 || b.InnerBounds().Dy() != 3 {
+		t.Error("expanded block should get its space back")
+	}
+}
+
+func TestBlockCollapseOnClick(t *testing.T) {
+	old := DefaultWgtMgr
+	defer func() { DefaultWgtMgr = old }()
+	DefaultWgtMgr = NewWgtMgr()
+
+	b := NewBlock()
+	b.Width = 10
+	b.Height = 5
+	b.Buffer()
+	toggles := 0
+	b.CollapseOn("/sys/mouse", func() { toggles++ })
+
+	hook := DefaultWgtMgr.WgtHandlersHook()
+	click := func(x, y int) {
+		for _, press := range []string{"left", "release"} {
+			hook(Event{Path: "/sys/mouse", Data: EvtMouse{X: x, Y: y, Press: press}})
+		}
+	}
+	click(3, 0)
+	if !b.Collapsed || toggles != 1 {
+		t.Errorf("a click on the title should toggle once, toggled %d times", toggles)
+	}
+	click(3, 2)
+	if toggles != 1 {
+		t.Error("a click below the title should not toggle")
+	}
+}
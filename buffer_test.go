@@ -21,3 +21,21 @@ func TestBufferUnion(t *testing.T) {
 		t.Errorf("Buffer.Merge unions Area failed: should:%v, actual %v,%v", image.Rect(0, 0, 50, 0).Union(image.Rect(0, 0, 100, 100)), b1.Area, b0.Area)
 	}
 }
+
+func TestBufferCompose(t *testing.T) {
+	b0 := NewBuffer()
+	b0.Set(0, 0, Cell{Ch: '┐'})
+	b0.Set(1, 0, Cell{Ch: 'a'})
+
+	b1 := NewBuffer()
+	b1.Set(0, 0, Cell{Ch: '┌'})
+	b1.Set(1, 0, Cell{Ch: '─'})
+
+	b0.Compose(b1)
+	if c := b0.At(0, 0).Ch; c != '┬' {
+		t.Errorf("expected junction ┬, got %c", c)
+	}
+	if c := b0.At(1, 0).Ch; c != '─' {
+		t.Errorf("non box runes should be replaced, got %c", c)
+	}
+}
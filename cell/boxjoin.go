@@ -0,0 +1,62 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+// box-drawing connection directions
+const (
+	boxUp uint8 = 1 << iota
+	boxDown
+	boxLeft
+	boxRight
+)
+
+var boxConns = map[rune]uint8{
+	'─': boxLeft | boxRight,
+	'│': boxUp | boxDown,
+	'┌': boxDown | boxRight,
+	'┐': boxDown | boxLeft,
+	'└': boxUp | boxRight,
+	'┘': boxUp | boxLeft,
+	'├': boxUp | boxDown | boxRight,
+	'┤': boxUp | boxDown | boxLeft,
+	'┬': boxDown | boxLeft | boxRight,
+	'┴': boxUp | boxLeft | boxRight,
+	'┼': boxUp | boxDown | boxLeft | boxRight,
+}
+
+var boxRunes = func() map[uint8]rune {
+	m := make(map[uint8]rune, len(boxConns))
+	for r, c := range boxConns {
+		m[c] = r
+	}
+	return m
+}()
+
+// JoinBoxRunes merges two light box-drawing runes drawn on the same cell,
+// e.g. '┐' and '┌' become '┬'. ok is false if either rune is not a light
+// box-drawing rune, in which case b should simply replace a.
+func JoinBoxRunes(a, b rune) (r rune, ok bool) {
+	ca, oka := boxConns[a]
+	cb, okb := boxConns[b]
+	if !oka || !okb {
+		return b, false
+	}
+	return boxRunes[ca|cb], true
+}
+
+// Compose merges bs onto b like Merge, but joins box-drawing runes where
+// borders of different buffers meet, so tiled widgets sharing a border look
+// like a single frame.
+func (b *Buffer) Compose(bs ...Buffer) {
+	for _, buf := range bs {
+		for p, v := range buf.CellMap {
+			if old, ok := b.CellMap[p]; ok {
+				v.Ch, _ = JoinBoxRunes(old.Ch, v.Ch)
+			}
+			b.Set(p.X, p.Y, v)
+		}
+		b.SetArea(b.Area.Union(buf.Area))
+	}
+}
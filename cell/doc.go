@@ -0,0 +1,22 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+/*
+Package cell holds termui's cell compositing and markup parsing: Cell,
+Buffer, Attribute and the text measuring helpers. It has no terminal
+backend dependency, so tools producing non-interactive output can reuse it,
+e.g. to print a rendered widget or a colored report to a pipe.
+
+	b := cell.NewBuffer()
+	cs := cell.NewMarkdownTxBuilder().Build("[ok](fg-green) 12 tests", cell.ColorWhite, cell.ColorDefault)
+	for i, c := range cs {
+		b.Set(i, 0, c)
+	}
+	b.Sync()
+	cell.WriteANSI(os.Stdout, b)
+
+The termui package aliases these types, so buffers built here can be
+rendered by termui and the other way around.
+*/
+package cell
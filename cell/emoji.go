@@ -0,0 +1,111 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+import (
+	"regexp"
+	"sync"
+)
+
+// emoji is an emoji with the ASCII text standing for it on terminals
+// without emoji fonts.
+type emoji struct {
+	r     rune
+	ascii string
+}
+
+// emojis maps shortcodes, without colons, to emoji. Only emoji of a single
+// rune drawn as emoji by default are kept, so that they fit in one cell
+// 2 columns wide: e.g. ⚠ needs a variation selector not to be drawn as text.
+var emojis = map[string]emoji{
+	"rocket":            {'🚀', "^"},
+	"white_check_mark":  {'✅', "[ok]"},
+	"x":                 {'❌', "[x]"},
+	"fire":              {'🔥', "*"},
+	"bug":               {'🐛', "[bug]"},
+	"hourglass":         {'⌛', "..."},
+	"zap":               {'⚡', "!"},
+	"lock":              {'🔒', "[#]"},
+	"unlock":            {'🔓', "[ ]"},
+	"bell":              {'🔔', "(!)"},
+	"star":              {'⭐', "*"},
+	"tada":              {'🎉', "\\o/"},
+	"construction":      {'🚧', "[wip]"},
+	"red_circle":        {'🔴', "(R)"},
+	"yellow_circle":     {'🟡', "(Y)"},
+	"green_circle":      {'🟢', "(G)"},
+	"large_blue_circle": {'🔵', "(B)"},
+	"arrow_up_small":    {'🔼', "^"},
+	"arrow_down_small":  {'🔽', "v"},
+	"rotating_light":    {'🚨', "/!\\"},
+	"blue_heart":        {'💙', "<3"},
+	"question":          {'❓', "?"},
+	"thumbsup":          {'👍', "+1"},
+	"thumbsdown":        {'👎', "-1"},
+	"eyes":              {'👀', "oo"},
+	"package":           {'📦', "[=]"},
+	"memo":              {'📝', "[~]"},
+	"clock":             {'🕐', "(@)"},
+	"skull":             {'💀', "x_x"},
+}
+
+// wideEmoji are the runes of emojis, which terminals draw 2 columns wide
+// whatever the width tables of go-runewidth say.
+var wideEmoji = map[rune]bool{}
+
+// emojiMu guards emojis and wideEmoji, which AddEmoji changes while
+// widgets may be drawn.
+var emojiMu sync.RWMutex
+
+func init() {
+	for _, e := range emojis {
+		wideEmoji[e.r] = true
+	}
+}
+
+// EmojiShortcodes makes MarkdownTxBuilder expand the shortcodes of the text
+// it builds, see ExpandEmoji. It is off by default: text such as ":x:" is
+// then shown as it is and markup is parsed without scanning for them.
+var EmojiShortcodes = false
+
+// EmojiFallback makes ExpandEmoji write the ASCII text of shortcodes rather
+// than emoji, for terminals or fonts lacking them.
+var EmojiFallback = false
+
+// AddEmoji adds or overrides the shortcode name, without colons, standing
+// for the emoji r, or for ascii when EmojiFallback is set.
+func AddEmoji(name string, r rune, ascii string) {
+	emojiMu.Lock()
+	defer emojiMu.Unlock()
+	emojis[name] = emoji{r, ascii}
+	wideEmoji[r] = true
+}
+
+// isWideEmoji tells if r is the rune of a known emoji.
+func isWideEmoji(r rune) bool {
+	emojiMu.RLock()
+	defer emojiMu.RUnlock()
+	return wideEmoji[r]
+}
+
+var shortcode = regexp.MustCompile(`:[a-z0-9_+-]+:`)
+
+// ExpandEmoji replaces the known shortcodes of s, such as ":rocket:", with
+// their emoji, leaving the others as they are. MarkdownTxBuilder expands
+// shortcodes before parsing the markup when EmojiShortcodes is set.
+func ExpandEmoji(s string) string {
+	emojiMu.RLock()
+	defer emojiMu.RUnlock()
+	return shortcode.ReplaceAllStringFunc(s, func(m string) string {
+		e, ok := emojis[m[1:len(m)-1]]
+		switch {
+		case !ok:
+			return m
+		case EmojiFallback:
+			return e.ascii
+		}
+		return string(e.r)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestExpandEmoji(t *testing.T) {
+	if s := ExpandEmoji(":rocket: up at 12:30:45 :nope:"); s != "🚀 up at 12:30:45 :nope:" {
+		t.Errorf("got %q", s)
+	}
+	if w := StringWidth(ExpandEmoji(":rocket::fire:")); w != 4 {
+		t.Errorf("two emoji are %d columns wide, want 4", w)
+	}
+
+	EmojiFallback = true
+	defer func() { EmojiFallback = false }()
+	if s := ExpandEmoji(":white_check_mark: done"); s != "[ok] done" {
+		t.Errorf("fallback got %q", s)
+	}
+}
+
+func TestBuildEmoji(t *testing.T) {
+	AddEmoji("ship", '🚢', "=>")
+	cs := NewMarkdownTxBuilder().Build("[:ship: shipped](fg-green)", ColorDefault, ColorDefault)
+	if s := CellsToStr(cs); s != ":ship: shipped" {
+		t.Fatalf("shortcodes are off by default, got %q", s)
+	}
+
+	EmojiShortcodes = true
+	defer func() { EmojiShortcodes = false }()
+	cs = NewMarkdownTxBuilder().Build("[:ship: shipped](fg-green)", ColorDefault, ColorDefault)
+	if s := CellsToStr(cs); s != "🚢 shipped" {
+		t.Fatalf("got %q", s)
+	}
+	if cs[0].Fg != ColorGreen || cs[0].Width() != 2 {
+		t.Errorf("emoji cell %+v, width %d", cs[0], cs[0].Width())
+	}
+}
+
+func TestAddEmojiConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			AddEmoji("boat", '⛵', "~")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			StringWidth(ExpandEmoji(":boat: :rocket:"))
+		}
+	}()
+	wg.Wait()
+	if s := ExpandEmoji(":boat:"); s != "⛵" {
+		t.Errorf("got %q", s)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+import (
+	"bufio"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Text returns the cells of b's area as plain text, one line per row with
+// trailing blanks trimmed.
+func Text(b Buffer) string {
+	lines := make([]string, 0, b.Area.Dy())
+	for y := b.Area.Min.Y; y < b.Area.Max.Y; y++ {
+		var sb strings.Builder
+		for x := b.Area.Min.X; x < b.Area.Max.X; x++ {
+			c, ok := b.CellMap[image.Pt(x, y)]
+			if !ok || c.Ch == 0 {
+				sb.WriteRune(' ')
+				continue
+			}
+			sb.WriteRune(c.Ch)
+			x += c.Width() - 1
+		}
+		lines = append(lines, strings.TrimRight(sb.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SGR returns the escape sequence selecting the colors and attributes of
+// fg and bg.
+func SGR(fg, bg Attribute) string {
+	s := "\033[0"
+	if fg&AttrBold != 0 {
+		s += ";1"
+	}
+	if fg&AttrUnderline != 0 {
+		s += ";4"
+	}
+	if (fg|bg)&AttrReverse != 0 {
+		s += ";7"
+	}
+	color := func(a Attribute, base int) {
+		switch v := int(a & 0x1ff); {
+		case v == 0:
+		case v <= NumberofColors:
+			s += ";" + strconv.Itoa(base+v-1)
+		default:
+			s += ";" + strconv.Itoa(base+8) + ";5;" + strconv.Itoa(v-1)
+		}
+	}
+	color(fg, 30)
+	color(bg, 40)
+	return s + "m"
+}
+
+// WriteANSI writes the cells of b's area to w as text colored with ANSI
+// escape sequences, one line per row, for output to a terminal that is not
+// managed by termui, e.g. a CLI printing a chart and exiting.
+func WriteANSI(w io.Writer, b Buffer) error {
+	bw := bufio.NewWriter(w)
+	for y := b.Area.Min.Y; y < b.Area.Max.Y; y++ {
+		var fg, bg Attribute
+		for x := b.Area.Min.X; x < b.Area.Max.X; x++ {
+			c, ok := b.CellMap[image.Pt(x, y)]
+			if !ok || c.Ch == 0 {
+				c = Cell{Ch: ' '}
+			}
+			if c.Fg != fg || c.Bg != bg {
+				fg, bg = c.Fg, c.Bg
+				bw.WriteString(SGR(fg, bg))
+			}
+			bw.WriteRune(c.Ch)
+			x += c.Width() - 1
+		}
+		if fg != ColorDefault || bg != ColorDefault {
+			bw.WriteString("\033[0m")
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+func TestWriteANSI(t *testing.T) {
+	b := NewBuffer()
+	b.SetArea(image.Rect(0, 0, 4, 2))
+	for i, c := range NewMarkdownTxBuilder().Build("[ok](fg-green,fg-bold) x", ColorDefault, ColorDefault) {
+		b.Set(i, 0, c)
+	}
+	b.Set(0, 1, Cell{Ch: '█', Fg: Attribute(10), Bg: ColorDefault})
+
+	if s := Text(b); s != "ok x\n█" {
+		t.Errorf("unexpected text %q", s)
+	}
+
+	var out bytes.Buffer
+	if err := WriteANSI(&out, b); err != nil {
+		t.Fatal(err)
+	}
+	want := "\033[0;1;32mok\033[0m x\n\033[0;38;5;9m█\033[0m   \n"
+	if out.String() != want {
+		t.Errorf("unexpected output\nwant %q\ngot  %q", want, out.String())
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+// Ellipsis marks where text was cut by the Truncate functions and the
+// string trimming helpers, e.g. "..." for terminals lacking "…".
+var Ellipsis = "…"
+
+func cellsWidth(cs []Cell) int {
+	w := 0
+	for _, c := range cs {
+		w += c.Width()
+	}
+	return w
+}
+
+// ellipsis returns the cells of Ellipsis styled like c, trimmed to w
+// columns.
+func ellipsis(c Cell, w int) []Cell {
+	var cs []Cell
+	for _, r := range Ellipsis {
+		if RuneWidth(r) > w {
+			break
+		}
+		w -= RuneWidth(r)
+		cs = append(cs, Cell{Ch: r, Fg: c.Fg, Bg: c.Bg})
+	}
+	return cs
+}
+
+// head returns the first cells of cs fitting in w columns, never splitting
+// a wide rune.
+func head(cs []Cell, w int) []Cell {
+	n := 0
+	for i, c := range cs {
+		if n+c.Width() > w {
+			return cs[:i]
+		}
+		n += c.Width()
+	}
+	return cs
+}
+
+// tail returns the last cells of cs fitting in w columns.
+func tail(cs []Cell, w int) []Cell {
+	n := 0
+	for i := len(cs) - 1; i >= 0; i-- {
+		if n+cs[i].Width() > w {
+			return cs[i+1:]
+		}
+		n += cs[i].Width()
+	}
+	return cs
+}
+
+// TruncateRight returns cs if it fits in w columns, or its beginning
+// followed by Ellipsis otherwise. The cells keep their colors, so text is
+// built from markup first, and wide runes are never split.
+func TruncateRight(cs []Cell, w int) []Cell {
+	if w <= 0 {
+		return []Cell{}
+	}
+	if cellsWidth(cs) <= w {
+		return cs
+	}
+	e := ellipsis(cs[len(cs)-1], w)
+	h := head(cs, w-cellsWidth(e))
+	if len(h) > 0 {
+		e = ellipsis(h[len(h)-1], w)
+	}
+	return append(append([]Cell{}, h...), e...)
+}
+
+// TruncateLeft returns cs if it fits in w columns, or Ellipsis followed by
+// its end otherwise, e.g. for paths whose last elements matter most.
+func TruncateLeft(cs []Cell, w int) []Cell {
+	if w <= 0 {
+		return []Cell{}
+	}
+	if cellsWidth(cs) <= w {
+		return cs
+	}
+	e := ellipsis(cs[0], w)
+	t := tail(cs, w-cellsWidth(e))
+	if len(t) > 0 {
+		e = ellipsis(t[0], w)
+	}
+	return append(e, t...)
+}
+
+// TruncateMiddle returns cs if it fits in w columns, or its beginning and
+// end around Ellipsis otherwise, e.g. for ids or hashes.
+func TruncateMiddle(cs []Cell, w int) []Cell {
+	if w <= 0 {
+		return []Cell{}
+	}
+	if cellsWidth(cs) <= w {
+		return cs
+	}
+	e := ellipsis(cs[len(cs)/2], w)
+	left := w - cellsWidth(e)
+	h := head(cs, left-left/2)
+	t := tail(cs[len(h):], left/2)
+	rt := append(append([]Cell{}, h...), e...)
+	return append(rt, t...)
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package cell
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	cs := func(s string) []Cell { return TextCells(s, ColorRed, ColorDefault) }
+	cases := []struct {
+		f    func([]Cell, int) []Cell
+		s    string
+		w    int
+		want string
+	}{
+		{TruncateRight, "hello", 5, "hello"},
+		{TruncateRight, "hello", 4, "hel…"},
+		{TruncateRight, "你好世界", 6, "你好…"},
+		{TruncateRight, "你好世界", 4, "你…"},
+		{TruncateRight, "hello", 1, "…"},
+		{TruncateRight, "hello", 0, ""},
+		{TruncateLeft, "/usr/local/bin", 8, "…cal/bin"},
+		{TruncateLeft, "你好世界", 5, "…世界"},
+		{TruncateMiddle, "0123456789", 7, "012…789"},
+		{TruncateMiddle, "0123456789", 6, "012…89"},
+		{TruncateMiddle, "你好世界", 5, "你…界"},
+	}
+	for _, c := range cases {
+		got := c.f(cs(c.s), c.w)
+		if s := CellsToStr(got); s != c.want {
+			t.Errorf("truncating %q to %d: got %q, want %q", c.s, c.w, s, c.want)
+		}
+		if w := cellsWidth(got); w > c.w {
+			t.Errorf("truncating %q to %d: %d columns wide", c.s, c.w, w)
+		}
+		for _, x := range got {
+			if x.Fg != ColorRed {
+				t.Errorf("truncating %q: lost the colors of the text", c.s)
+			}
+		}
+	}
+
+	defer func(e string) { Ellipsis = e }(Ellipsis)
+	Ellipsis = "..."
+	if s := CellsToStr(TruncateRight(cs("hello world"), 8)); s != "hello..." {
+		t.Errorf("expected a custom ellipsis, got %q", s)
+	}
+	if s := CellsToStr(TruncateRight(cs("hello world"), 2)); s != ".." {
+		t.Errorf("expected the ellipsis cut to the width, got %q", s)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/base64"
+	"io"
+
+	"github.com/gizak/termui/cell"
+)
+
+// BufferText returns the cells of buf's area as plain text, one line per
+// row with trailing blanks trimmed. Braille canvases come out as braille
+// art, so the text can be pasted where screenshots cannot.
+func BufferText(buf Buffer) string {
+	return cell.Text(buf)
+}
+
+// ExportText returns the plain text rendering of b.
+func ExportText(b Bufferer) string {
+	return BufferText(b.Buffer())
+}
+
+// CopyText puts s on the system clipboard with an OSC 52 escape sequence.
+// The terminal (or tmux with set-clipboard on) must allow clipboard writes;
+// over ssh the text ends up on the local machine's clipboard.
+func CopyText(s string) error {
+	renderLock.Lock()
+	defer renderLock.Unlock()
+	_, err := io.WriteString(rawOut, "\033]52;c;"+base64.StdEncoding.EncodeToString([]byte(s))+"\a")
+	return err
+}
+
+// Copy puts the plain text rendering of b on the system clipboard.
+func Copy(b Bufferer) error {
+	return CopyText(ExportText(b))
+}
+
+// SetCopyKey registers key to copy the given widget, e.g. a chart, to the
+// system clipboard as text art.
+func SetCopyKey(key string, b Bufferer) {
+	Handle("/sys/kbd/"+key, func(Event) {
+		Copy(b)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCopyText(t *testing.T) {
+	p := NewPar("ab")
+	p.Border = false
+	p.Width = 4
+	p.Height = 2
+	if s := ExportText(p); s != "ab\n" {
+		t.Errorf("unexpected text %q", s)
+	}
+
+	var out bytes.Buffer
+	old := rawOut
+	rawOut = &out
+	defer func() { rawOut = old }()
+	CopyText("ab")
+	if out.String() != "\033]52;c;YWI=\a" {
+		t.Errorf("unexpected sequence %q", out.String())
+	}
+
+	// the sequence must not land in the middle of a frame
+	out.Reset()
+	renderLock.Lock()
+	done := make(chan struct{})
+	go func() {
+		CopyText("ab")
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Error("CopyText should wait for the frame being written")
+	case <-time.After(20 * time.Millisecond):
+	}
+	renderLock.Unlock()
+	<-done
+	if out.String() != "\033]52;c;YWI=\a" {
+		t.Errorf("unexpected sequence %q", out.String())
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "sync"
+
+// Clone methods copy a widget's style and configuration into a new widget
+// with its own id and no data, so similar panels can be stamped out cheaply.
+/*
+  proto := termui.NewGauge()
+  proto.Height = 3
+  proto.BarColor = termui.ColorRed
+
+  for _, host := range hosts {
+      g := proto.Clone()
+      g.BorderLabel = host
+      ...
+  }
+*/
+
+// Clone returns a copy of b with a new id.
+func (b *Block) Clone() *Block {
+	nb := *b
+	nb.id = GenId()
+	nb.TitleSegments = append([]TitleSegment(nil), b.TitleSegments...)
+	nb.loading = false
+	nb.err = nil
+	nb.retry = nil
+	nb.alert = false
+	return &nb
+}
+
+// Clone returns a copy of p without text.
+func (p *Par) Clone() *Par {
+	np := *p
+	np.Block = *p.Block.Clone()
+	np.Text = ""
+	return &np
+}
+
+// Clone returns a copy of g at zero progress.
+func (g *Gauge) Clone() *Gauge {
+	ng := *g
+	ng.Block = *g.Block.Clone()
+	ng.Percent = 0
+	ng.Current = 0
+	ng.Total = 0
+	return &ng
+}
+
+// Clone returns a copy of l without items, scrolled to the top, the first
+// item selected if l has a selection.
+func (l *List) Clone() *List {
+	nl := *l
+	nl.Block = *l.Block.Clone()
+	nl.Items = nil
+	nl.Keys = nil
+	if nl.SelectedRow > 0 {
+		nl.SelectedRow = 0
+	}
+	nl.offset = 0
+	nl.sel = selection{}
+	nl.Flash = l.Flash.clone()
+	return &nl
+}
+
+// Clone returns a copy of lc without data, keeping per series colors.
+func (lc *LineChart) Clone() *LineChart {
+	nlc := NewLineChart()
+	nlc.Block = *lc.Block.Clone()
+	nlc.AxesColor = lc.AxesColor
+	nlc.DotStyle = lc.DotStyle
+	nlc.Mode = lc.Mode
+	nlc.YCeil = lc.YCeil
+	nlc.YFloor = lc.YFloor
+	nlc.YPadding = lc.YPadding
+	nlc.YScale = lc.YScale
+	nlc.Y2Ceil = lc.Y2Ceil
+	nlc.Y2Floor = lc.Y2Floor
+	nlc.Y2Padding = lc.Y2Padding
+	nlc.Y2Scale = lc.Y2Scale
+	nlc.BandColors = append([]Attribute(nil), lc.BandColors...)
+	nlc.SnapshotColor = lc.SnapshotColor
+	nlc.SnapshotDashed = lc.SnapshotDashed
+	nlc.MaxPoints = lc.MaxPoints
+	nlc.YAxisSide = lc.YAxisSide
+	nlc.XAxisSide = lc.XAxisSide
+	nlc.EndLabels = lc.EndLabels
+	nlc.Fill = lc.Fill
+	nlc.Zoom = lc.Zoom
+	nlc.Downsample = lc.Downsample
+	nlc.LabelCount = lc.LabelCount
+	nlc.AutoScale = lc.AutoScale
+	nlc.ScaleHalfLife = lc.ScaleHalfLife
+	nlc.hlines = append([]hLine(nil), lc.hlines...)
+	nlc.defaultLineColor = lc.defaultLineColor
+	for k, v := range lc.LineColor {
+		nlc.LineColor[k] = v
+	}
+	for k, v := range lc.FillColor {
+		nlc.FillColor[k] = v
+	}
+	for k, v := range lc.SeriesMode {
+		nlc.SeriesMode[k] = v
+	}
+	for k, v := range lc.LineStyle {
+		nlc.LineStyle[k] = v
+	}
+	for k, v := range lc.SeriesAxis {
+		nlc.SeriesAxis[k] = v
+	}
+	return nlc
+}
+
+// Clone returns a copy of bc without data.
+func (bc *BarChart) Clone() *BarChart {
+	nbc := NewBarChart()
+	nbc.Block = *bc.Block.Clone()
+	nbc.BarColor = bc.BarColor
+	nbc.TextColor = bc.TextColor
+	nbc.NumColor = bc.NumColor
+	nbc.BarWidth = bc.BarWidth
+	nbc.BarGap = bc.BarGap
+	nbc.CellChar = bc.CellChar
+	nbc.StackColors = append([]Attribute(nil), bc.StackColors...)
+	nbc.ShowTotal = bc.ShowTotal
+	return nbc
+}
+
+// Clone returns a copy of s whose sparklines keep their titles and colors
+// but have no data.
+func (s *Sparklines) Clone() *Sparklines {
+	ns := NewSparklines()
+	ns.Block = *s.Block.Clone()
+	for _, l := range s.Lines {
+		nl := l
+		nl.Data = nil
+		ns.Add(nl)
+	}
+	return ns
+}
+
+// Clone returns a copy of t without rows, scrolled to the top, the first
+// row selected if t has a selection.
+func (t *Table) Clone() *Table {
+	nt := *t
+	nt.Block = *t.Block.Clone()
+	nt.Rows = nil
+	nt.Keys = nil
+	nt.CellWidth = nil
+	nt.FgColors = nil
+	nt.BgColors = nil
+	nt.Footer = append([]Aggregator(nil), t.Footer...)
+	nt.ColumnAlign = append([]Align(nil), t.ColumnAlign...)
+	nt.ColumnWidth = append([]int(nil), t.ColumnWidth...)
+	if nt.SelectedRow > 1 {
+		nt.SelectedRow = 1
+	}
+	nt.offset = 0
+	nt.sel = selection{}
+	nt.Flash = t.Flash.clone()
+	nt.Filters = nil
+	nt.filterInput = nil
+	nt.History = t.History.clone()
+	return &nt
+}
+
+// Clone returns a copy of st without values.
+func (st *StatTile) Clone() *StatTile {
+	nst := *st
+	nst.Block = *st.Block.Clone()
+	nst.Value = 0
+	nst.Previous = 0
+	nst.Trend = nil
+	nst.Flash = st.Flash.clone()
+	return &nst
+}
+
+// Template creates a new widget, typically by cloning a prototype.
+type Template func() Bufferer
+
+var templates struct {
+	sync.RWMutex
+	m map[string]Template
+}
+
+// RegisterTemplate registers t under name, replacing any previous one.
+/*
+  termui.RegisterTemplate("host", func() termui.Bufferer {
+      return proto.Clone()
+  })
+  g := termui.FromTemplate("host").(*termui.Gauge)
+*/
+func RegisterTemplate(name string, t Template) {
+	templates.Lock()
+	defer templates.Unlock()
+	if templates.m == nil {
+		templates.m = make(map[string]Template)
+	}
+	templates.m[name] = t
+}
+
+// FromTemplate creates a widget from the template registered under name.
+// It returns nil if there is no such template.
+func FromTemplate(name string) Bufferer {
+	templates.RLock()
+	t, ok := templates.m[name]
+	templates.RUnlock()
+	if !ok {
+		return nil
+	}
+	return t()
+}
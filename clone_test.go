@@ -0,0 +1,110 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLineChartClone(t *testing.T) {
+	lc := NewLineChart()
+	lc.Height = 7
+	lc.Data["cpu"] = []float64{1, 2, 3}
+	lc.LineColor["cpu"] = ColorRed
+	lc.BandColors = []Attribute{ColorBlack, ColorBlue}
+
+	c := lc.Clone()
+	if c.Id() == lc.Id() {
+		t.Error("clone should have a new id")
+	}
+	if c.Height != 7 || c.LineColor["cpu"] != ColorRed {
+		t.Error("clone should keep style and config")
+	}
+	if len(c.Data) != 0 {
+		t.Error("clone should have no data")
+	}
+
+	c.LineColor["cpu"] = ColorBlue
+	if lc.LineColor["cpu"] != ColorRed {
+		t.Error("clone should not share maps with the prototype")
+	}
+	if len(c.BandColors) != 2 || c.BandColors[1] != ColorBlue {
+		t.Errorf("clone should keep the bands, got %v", c.BandColors)
+	}
+	c.BandColors[0] = ColorRed
+	if lc.BandColors[0] != ColorBlack {
+		t.Error("clone should not share the bands with the prototype")
+	}
+}
+
+func TestBlockClone(t *testing.T) {
+	b := NewBlock()
+	b.TitleSegments = []TitleSegment{{Text: "a"}}
+
+	c := b.Clone()
+	c.TitleSegments[0].Text = "b"
+	if b.TitleSegments[0].Text != "a" {
+		t.Error("clone should not share title segments with the prototype")
+	}
+}
+
+func TestListClone(t *testing.T) {
+	l := NewList()
+	l.Items = []string{"a", "b", "c"}
+	l.Keys = []string{"a", "b", "c"}
+	l.SelectedRow, l.offset = 2, 1
+
+	c := l.Clone()
+	if c.Items != nil || c.Keys != nil {
+		t.Errorf("clone should have no items or keys, got %v %v", c.Items, c.Keys)
+	}
+	if c.SelectedRow != 0 || c.offset != 0 {
+		t.Errorf("clone should open at the top, got row %d, offset %d", c.SelectedRow, c.offset)
+	}
+
+	l.SelectedRow = -1
+	if c = l.Clone(); c.SelectedRow != -1 {
+		t.Errorf("clone should keep no selection, got row %d", c.SelectedRow)
+	}
+}
+
+func TestTableClone(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"name"}, {"api"}}
+	table.Keys = []string{"", "api"}
+	table.Footer = []Aggregator{AggCount}
+
+	c := table.Clone()
+	if c.Keys != nil || len(c.Footer) != 1 {
+		t.Errorf("clone should keep the footer but no keys, got %v", c.Keys)
+	}
+	c.Footer[0] = AggSum
+	if table.Footer[0]([]string{"1", "2"}) != "2" {
+		t.Error("clone should not share the footer with the prototype")
+	}
+
+	table.SelectedRow, table.offset = 5, 3
+	table.ColumnWidth = []int{8}
+	c = table.Clone()
+	if c.SelectedRow != 1 || c.offset != 0 {
+		t.Errorf("clone should open at the top, got row %d, offset %d", c.SelectedRow, c.offset)
+	}
+	c.ColumnWidth[0] = 4
+	if table.ColumnWidth[0] != 8 {
+		t.Error("clone should not share column widths with the prototype")
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	proto := NewPar("x")
+	proto.Height = 3
+	RegisterTemplate("par", func() Bufferer { return proto.Clone() })
+
+	p, ok := FromTemplate("par").(*Par)
+	if !ok || p.Height != 3 || p.Text != "" {
+		t.Errorf("unexpected widget from template: %+v", p)
+	}
+	if FromTemplate("missing") != nil {
+		t.Error("unknown template should yield nil")
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Command termui-gallery shows every widget of termui with settings to
+// change live, as living documentation of the widgets and as a test bed
+// for the quirks of terminals: colors, wide characters, cursors.
+//
+//	go run ./cmd/termui-gallery
+//
+// <up> and <down> pick a widget, <tab> picks one of its settings and
+// <left> and <right> change it. <enter> hands the keyboard to widgets
+// taking keys, until <escape>. q quits.
+//
+// -still stops the live data, so that the pages look the same every run.
+// Like any termui app it runs headless under termui-snap, e.g. to check
+// that the widgets still look the same, from this directory:
+//
+//	termui-snap -dir testdata/gallery testdata/gallery.snap -- go run . -still
+//
+// With -update the captures of the script replace the baselines, after a
+// change to a widget or a page.
+package main
+
+import (
+	"flag"
+	"strings"
+
+	ui "github.com/gizak/termui"
+)
+
+// setting is a setting of a widget, one of values, applied by set.
+type setting struct {
+	name   string
+	values []string
+	cur    int
+	set    func(v string)
+}
+
+// showcase is the page of a widget: the widget, its block to lay it out,
+// the settings to change and, for widgets taking keys, the handler of
+// those keys.
+type showcase struct {
+	name     string
+	widget   ui.Bufferer
+	block    *ui.Block
+	place    func(x, y, w, h int) // lays out pages of several blocks, instead of block
+	settings []*setting
+	keys     func(k ui.EvtKbd) bool
+	tick     func(n int) // called every second to animate live data
+}
+
+var still = flag.Bool("still", false, "stop the live data of the widgets")
+
+// gallery is the state of the app: the widget shown, the setting picked
+// and whether the widget has the keyboard.
+type gallery struct {
+	pages   []*showcase
+	menu    *ui.List
+	bar     *ui.Par
+	setting int
+	focused bool
+}
+
+// page returns the showcase shown.
+func (g *gallery) page() *showcase {
+	return g.pages[g.menu.SelectedRow]
+}
+
+// layout places the menu left, the widget right of it and the bar of
+// settings below them, for a terminal of w by h cells.
+func (g *gallery) layout(w, h int) {
+	menuW := 20
+	g.menu.X, g.menu.Y = 0, 0
+	g.menu.Width, g.menu.Height = menuW, h-3
+	for _, p := range g.pages {
+		if p.place != nil {
+			p.place(menuW, 0, w-menuW, h-3)
+			continue
+		}
+		p.block.X, p.block.Y = menuW, 0
+		p.block.Width, p.block.Height = w-menuW, h-3
+	}
+	g.bar.X, g.bar.Y = 0, h-3
+	g.bar.Width, g.bar.Height = w, 3
+}
+
+// step changes the setting picked by n values.
+func (g *gallery) step(n int) {
+	ss := g.page().settings
+	if len(ss) == 0 {
+		return
+	}
+	s := ss[g.setting]
+	s.cur = (s.cur + n + len(s.values)) % len(s.values)
+	s.set(s.values[s.cur])
+}
+
+// describe fills the bar with the settings of the page, the one picked
+// highlighted, and the keys changing them; or with the keys of the widget
+// while it has the keyboard.
+func (g *gallery) describe() {
+	p := g.page()
+	if g.focused {
+		g.bar.BorderLabel = p.name + ": <escape> to leave"
+		g.bar.Text = "the keys go to the " + p.name
+		return
+	}
+	g.bar.BorderLabel = p.name + ": <tab> setting, <left>/<right> change"
+	if p.keys != nil {
+		g.bar.BorderLabel += ", <enter> try it"
+	}
+	var parts []string
+	for i, s := range p.settings {
+		t := s.name + ": " + s.values[s.cur]
+		if i == g.setting {
+			t = "[" + t + "](fg-black,bg-green)"
+		}
+		parts = append(parts, t)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "no settings")
+	}
+	g.bar.Text = strings.Join(parts, "  ")
+}
+
+// render draws the menu, the page shown and the bar.
+func (g *gallery) render() {
+	g.describe()
+	ui.Render(g.menu, g.page().widget, g.bar)
+}
+
+// handleKey applies a keyboard event and tells if the app should go on.
+func (g *gallery) handleKey(k ui.EvtKbd) bool {
+	p := g.page()
+	if g.focused {
+		if k.KeyStr == "<escape>" {
+			g.focused = false
+		} else {
+			p.keys(k)
+		}
+		return true
+	}
+	switch k.KeyStr {
+	case "q", "C-c":
+		return false
+	case "<tab>":
+		if len(p.settings) > 0 {
+			g.setting = (g.setting + 1) % len(p.settings)
+		}
+	case "<left>", "h":
+		g.step(-1)
+	case "<right>", "l":
+		g.step(1)
+	case "<enter>":
+		g.focused = p.keys != nil
+	default:
+		// one page per key, held or not, not to skip widgets
+		k.Repeat = 0
+		row := g.menu.SelectedRow
+		if !g.menu.HandleKey(k) {
+			return true
+		}
+		if g.menu.SelectedRow != row {
+			g.setting = 0
+			ui.Clear()
+		}
+	}
+	return true
+}
+
+func main() {
+	flag.Parse()
+	if err := ui.Init(); err != nil {
+		panic(err)
+	}
+	defer ui.Close()
+
+	g := &gallery{pages: showcases()}
+	g.menu = ui.NewList()
+	g.menu.BorderLabel = "termui"
+	g.menu.SelectedRow = 0
+	for _, p := range g.pages {
+		g.menu.Items = append(g.menu.Items, p.name)
+	}
+	g.bar = ui.NewPar("")
+	for _, p := range g.pages {
+		for _, s := range p.settings {
+			s.set(s.values[s.cur])
+		}
+	}
+	g.layout(ui.TermWidth(), ui.TermHeight())
+	g.render()
+
+	ui.Handle("/sys/kbd", func(e ui.Event) {
+		if !g.handleKey(e.Data.(ui.EvtKbd)) {
+			ui.StopLoop()
+			return
+		}
+		g.render()
+	})
+	ui.Handle("/sys/wnd/resize", func(e ui.Event) {
+		w := e.Data.(ui.EvtWnd)
+		g.layout(w.Width, w.Height)
+		ui.Clear()
+		g.render()
+	})
+	ui.Handle("/timer/1s", func(e ui.Event) {
+		if *still {
+			return
+		}
+		n := int(e.Data.(ui.EvtTimer).Count)
+		for _, p := range g.pages {
+			if p.tick != nil {
+				p.tick(n)
+			}
+		}
+		g.render()
+	})
+	ui.Loop()
+}
+
+// colors are the values of the color settings, by name.
+var colors = map[string]ui.Attribute{
+	"green":   ui.ColorGreen,
+	"red":     ui.ColorRed,
+	"yellow":  ui.ColorYellow,
+	"blue":    ui.ColorBlue,
+	"magenta": ui.ColorMagenta,
+	"cyan":    ui.ColorCyan,
+	"white":   ui.ColorWhite,
+}
+
+// colorSetting returns a setting of name calling set with the color
+// picked, the first one being first.
+func colorSetting(name, first string, set func(ui.Attribute)) *setting {
+	values := []string{first}
+	for _, c := range []string{"green", "red", "yellow", "blue", "magenta", "cyan", "white"} {
+		if c != first {
+			values = append(values, c)
+		}
+	}
+	return &setting{name: name, values: values, set: func(v string) { set(colors[v]) }}
+}
+
+// onOff returns a setting of name switching on with set, on first if on.
+func onOff(name string, on bool, set func(bool)) *setting {
+	values := []string{"off", "on"}
+	if on {
+		values = []string{"on", "off"}
+	}
+	return &setting{name: name, values: values, set: func(v string) { set(v == "on") }}
+}
+
+// choice returns a setting of name among values, the first one first.
+func choice(name string, values []string, set func(string)) *setting {
+	return &setting{name: name, values: values, set: set}
+}
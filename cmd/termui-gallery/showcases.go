@@ -0,0 +1,654 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	ui "github.com/gizak/termui"
+	"github.com/gizak/termui/extra"
+)
+
+// showcases returns the pages of the gallery, one per widget.
+func showcases() []*showcase {
+	return []*showcase{
+		parPage(),
+		listPage(),
+		gaugePage(),
+		barChartPage(),
+		sparklinesPage(),
+		lineChartPage(),
+		tablePage(),
+		treePage(),
+		textAreaPage(),
+		textInputPage(),
+		consolePage(),
+		logViewerPage(),
+		detailViewPage(),
+		statTilePage(),
+		heatmapPage(),
+		heatstripPage(),
+		mBarChartPage(),
+		multiProgressPage(),
+		colorPickerPage(),
+		timeRangePage(),
+		tabpanePage(),
+		modalPage(),
+		alertsPage(),
+		terminalPage(),
+	}
+}
+
+func parPage() *showcase {
+	p := ui.NewPar("termui is a cross-platform, easy-to-compile and fully-customizable " +
+		"terminal dashboard. Text is [colored](fg-red) with markup, and wrapped to " +
+		"the width of the widget, aligned the way TextAlign says.")
+	p.BorderLabel = "Par"
+	p.WrapLength = -1
+	aligns := map[string]ui.Align{"left": ui.AlignLeft, "center": ui.AlignCenter, "right": ui.AlignRight, "justify": ui.AlignJustify}
+	return &showcase{
+		name:   "Par",
+		widget: p,
+		block:  &p.Block,
+		settings: []*setting{
+			choice("align", []string{"left", "center", "right", "justify"}, func(v string) { p.TextAlign = aligns[v] }),
+			colorSetting("color", "white", func(c ui.Attribute) { p.TextFgColor = c }),
+			onOff("border", true, func(on bool) { p.Border = on }),
+		},
+	}
+}
+
+func listPage() *showcase {
+	l := ui.NewList()
+	l.BorderLabel = "List"
+	for i, s := range []string{"github.com/gizak/termui", "editbox.go", "interrupt.go", "keyboard.go",
+		"output.go", "random_out.go", "dashboard.go", "nsf/termbox-go", "a long item, cut or wrapped at the width of the list"} {
+		l.Items = append(l.Items, "["+strconv.Itoa(i)+"] "+s)
+	}
+	return &showcase{
+		name:   "List",
+		widget: l,
+		block:  &l.Block,
+		settings: []*setting{
+			onOff("cursor", true, func(on bool) {
+				l.SelectedRow = -1
+				if on {
+					l.SelectedRow = 0
+				}
+			}),
+			choice("overflow", []string{"hidden", "wrap"}, func(v string) { l.Overflow = v }),
+			colorSetting("color", "yellow", func(c ui.Attribute) { l.ItemFgColor = c }),
+		},
+		keys: l.HandleKey,
+	}
+}
+
+func gaugePage() *showcase {
+	g := ui.NewGauge()
+	g.BorderLabel = "Gauge"
+	aligns := map[string]ui.Align{"center": ui.AlignCenter, "left": ui.AlignLeft, "right": ui.AlignRight}
+	return &showcase{
+		name:   "Gauge",
+		widget: g,
+		block:  &g.Block,
+		settings: []*setting{
+			colorSetting("color", "red", func(c ui.Attribute) { g.BarColor = c }),
+			choice("label", []string{"center", "left", "right"}, func(v string) { g.LabelAlign = aligns[v] }),
+		},
+		tick: func(n int) { g.Percent = n * 7 % 101 },
+	}
+}
+
+func barChartPage() *showcase {
+	bc := ui.NewBarChart()
+	bc.BorderLabel = "BarChart"
+	bc.Data = []int{3, 2, 5, 3, 9, 5, 3, 2, 5, 8}
+	bc.DataLabels = []string{"S0", "S1", "S2", "S3", "S4", "S5", "S6", "S7", "S8", "S9"}
+	stacks := [][]int{{1, 2}, {2, 0}, {2, 3}, {1, 2}, {4, 5}, {3, 2}, {1, 2}, {1, 1}, {2, 3}, {5, 3}}
+	return &showcase{
+		name:   "BarChart",
+		widget: bc,
+		block:  &bc.Block,
+		settings: []*setting{
+			onOff("stacked", false, func(on bool) {
+				bc.Stacks = nil
+				if on {
+					bc.Stacks = stacks
+				}
+			}),
+			onOff("totals", false, func(on bool) { bc.ShowTotal = on }),
+			choice("width", []string{"3", "5", "8"}, func(v string) { bc.BarWidth, _ = strconv.Atoi(v) }),
+			colorSetting("color", "red", func(c ui.Attribute) { bc.BarColor = c }),
+		},
+	}
+}
+
+func sparklinesPage() *showcase {
+	s0, s1 := ui.NewSparkline(), ui.NewSparkline()
+	s0.Title, s0.Height = "srv 0", 4
+	s1.Title, s1.Height = "srv 1", 4
+	sp := ui.NewSparklines(s0, s1)
+	sp.BorderLabel = "Sparklines"
+	for i := 0; i < 200; i++ {
+		sp.Lines[0].Data = append(sp.Lines[0].Data, 8+int(7*math.Sin(float64(i)/4)))
+		sp.Lines[1].Data = append(sp.Lines[1].Data, i*37%16)
+	}
+	return &showcase{
+		name:   "Sparklines",
+		widget: sp,
+		block:  &sp.Block,
+		settings: []*setting{
+			choice("mode", []string{"block", "braille"}, func(v string) {
+				for i := range sp.Lines {
+					sp.Lines[i].Mode = v
+				}
+			}),
+			colorSetting("color", "cyan", func(c ui.Attribute) { sp.Lines[0].LineColor = c }),
+		},
+		tick: func(n int) {
+			for i := range sp.Lines {
+				d := sp.Lines[i].Data
+				sp.Lines[i].Data = append(d[1:], d[0])
+			}
+		},
+	}
+}
+
+func lineChartPage() *showcase {
+	lc := ui.NewLineChart()
+	lc.BorderLabel = "LineChart"
+	lc.LineColor["sin"] = ui.ColorGreen | ui.AttrBold
+	lc.LineColor["cos"] = ui.ColorYellow | ui.AttrBold
+	add := func(i int) {
+		lc.Append("sin", 1+math.Sin(float64(i)/5))
+		lc.Append("cos", 1+math.Cos(float64(i)/7)/2)
+	}
+	for i := 0; i < 300; i++ {
+		add(i)
+	}
+	return &showcase{
+		name:   "LineChart",
+		widget: lc,
+		block:  &lc.Block,
+		settings: []*setting{
+			choice("mode", []string{"braille", "dot"}, func(v string) { lc.Mode = v }),
+			choice("cos style", []string{"solid", "dashed", "dotted", "thick"}, func(v string) { lc.LineStyle["cos"] = v }),
+			onOff("fill", false, func(on bool) { lc.Fill = on }),
+			onOff("end labels", false, func(on bool) { lc.EndLabels = on }),
+			choice("y axis", []string{"left", "right"}, func(v string) { lc.YAxisSide = v }),
+			choice("x axis", []string{"bottom", "top"}, func(v string) { lc.XAxisSide = v }),
+			choice("autoscale", []string{"expand", "fit", "decay"}, func(v string) { lc.AutoScale = v }),
+		},
+		tick: func(n int) { add(300 + 4*n) },
+	}
+}
+
+func tablePage() *showcase {
+	t := ui.NewTable()
+	t.BorderLabel = "Table"
+	t.Rows = [][]string{
+		{"NAME", "STATUS", "RESTARTS", "AGE"},
+		{"api-7d9f", "Running", "0", "3d"},
+		{"db-0", "Running", "2", "12d"},
+		{"worker-5c1a", "CrashLoopBackOff", "41", "2h"},
+		{"cache-1", "Pending", "0", "5m"},
+		{"proxy-88e2", "Running", "1", "7d"},
+	}
+	t.SelectedRow = 1
+	return &showcase{
+		name:   "Table",
+		widget: t,
+		block:  &t.Block,
+		settings: []*setting{
+			onOff("separator", true, func(on bool) { t.Separator = on }),
+			choice("sort", []string{"none", "NAME", "STATUS", "RESTARTS", "AGE"}, func(v string) {
+				x := -1
+				for i, h := range t.Rows[0] {
+					if h == v {
+						x = i
+					}
+				}
+				t.SortBy(x, false)
+			}),
+			colorSetting("color", "white", func(c ui.Attribute) { t.FgColor = c }),
+		},
+		keys: t.HandleKey,
+	}
+}
+
+func treePage() *showcase {
+	t := ui.NewTree()
+	t.BorderLabel = "Tree"
+	t.Nodes = []*ui.TreeNode{{
+		Text:     "1 init",
+		Expanded: true,
+		Children: []*ui.TreeNode{
+			{Text: "212 sshd", Children: []*ui.TreeNode{{Text: "1007 bash", Children: []*ui.TreeNode{{Text: "1290 [vim](fg-green)"}}}}},
+			{Text: "380 [nginx](fg-green)", Children: []*ui.TreeNode{{Text: "381 worker"}, {Text: "382 worker"}}},
+			{Text: "455 cron"},
+		},
+	}, {Text: "2 kthreadd"}}
+	return &showcase{
+		name:   "Tree",
+		widget: t,
+		block:  &t.Block,
+		settings: []*setting{
+			onOff("guides", true, func(on bool) { t.ShowGuides = on }),
+			onOff("expanded", false, func(on bool) { t.SetExpanded(on) }),
+		},
+		keys: t.HandleKey,
+	}
+}
+
+func textAreaPage() *showcase {
+	ta := ui.NewTextArea()
+	ta.BorderLabel = "TextArea"
+	ta.Placeholder = "Type here, <enter> for a new line"
+	shapes := map[string]ui.CursorShape{"block": ui.CursorBlock, "bar": ui.CursorBar, "underline": ui.CursorUnderline}
+	return &showcase{
+		name:   "TextArea",
+		widget: ta,
+		block:  &ta.Block,
+		settings: []*setting{
+			onOff("wrap", true, func(on bool) { ta.Wrap = on }),
+			choice("cursor", []string{"block", "bar", "underline"}, func(v string) { ta.CursorStyle.Shape = shapes[v] }),
+			onOff("hardware cursor", false, func(on bool) { ta.HardwareCursor = on }),
+		},
+		keys: ta.HandleKey,
+	}
+}
+
+func textInputPage() *showcase {
+	ti := ui.NewTextInput()
+	ti.BorderLabel = "TextInput"
+	ti.Placeholder = "Type here, C-z to undo"
+	return &showcase{
+		name:   "TextInput",
+		widget: ti,
+		block:  &ti.Block,
+		settings: []*setting{
+			onOff("mask", false, func(on bool) {
+				ti.Mask = 0
+				if on {
+					ti.Mask = '*'
+				}
+			}),
+			onOff("strength", false, func(on bool) { ti.ShowStrength = on }),
+			onOff("hardware cursor", false, func(on bool) { ti.HardwareCursor = on }),
+		},
+		keys: ti.HandleKey,
+	}
+}
+
+func consolePage() *showcase {
+	c := ui.NewConsole()
+	c.BorderLabel = "Console"
+	c.Eval = func(line string, out io.Writer) {
+		fs := strings.Fields(line)
+		if len(fs) == 0 {
+			return
+		}
+		n := 0
+		for _, f := range fs {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				fmt.Fprintf(out, "\033[31mnot a number: %s\033[0m\n", f)
+				return
+			}
+			n += v
+		}
+		fmt.Fprintf(out, "\033[32m%d\033[0m\n", n)
+	}
+	fmt.Fprintln(c, "Type numbers to add them up, <up> recalls a line.")
+	return &showcase{
+		name:   "Console",
+		widget: c,
+		block:  &c.Block,
+		settings: []*setting{
+			onOff("echo", true, func(on bool) { c.Echo = on }),
+			choice("prompt", []string{"> ", "$ ", "λ "}, func(v string) { c.Prompt = v }),
+		},
+		keys: c.HandleKey,
+	}
+}
+
+func logViewerPage() *showcase {
+	lv := ui.NewLogViewer()
+	lv.BorderLabel = "LogViewer"
+	lv.Parser = ui.ParseLog
+	start := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	levels := []string{"info", "debug", "info", "warn", "info", "error", "debug", "info"}
+	msgs := []string{"listening", "accepted conn", "GET /api/pods", "slow query", "GET /healthz", "upstream timeout", "closed conn", "POST /api/jobs"}
+	add := func(i int) {
+		lv.Append(fmt.Sprintf("time=%s level=%s msg=%q conn=%d",
+			start.Add(time.Duration(i)*time.Second).Format(time.RFC3339), levels[i%len(levels)], msgs[i%len(msgs)], i%5))
+	}
+	for i := 0; i < 40; i++ {
+		add(i)
+	}
+	return &showcase{
+		name:   "LogViewer",
+		widget: lv,
+		block:  &lv.Block,
+		settings: []*setting{
+			choice("min level", []string{"trace", "debug", "info", "warn", "error"}, func(v string) { lv.MinLevel = ui.ParseLevel(v) }),
+			onOff("parser", true, func(on bool) {
+				lv.Parser = nil
+				if on {
+					lv.Parser = ui.ParseLog
+				}
+			}),
+		},
+		keys: lv.HandleKey,
+		tick: func(n int) { add(40 + n) },
+	}
+}
+
+func detailViewPage() *showcase {
+	dv := ui.NewDetailView()
+	dv.BorderLabel = "DetailView"
+	dv.Data = map[string]interface{}{
+		"name":      "worker-5c1a",
+		"namespace": "default",
+		"labels":    map[string]string{"app": "worker", "tier": "batch"},
+		"status": map[string]interface{}{
+			"phase":    "CrashLoopBackOff",
+			"restarts": 41,
+			"ready":    false,
+		},
+		"ports": []int{8080, 9090},
+	}
+	dv.ValueColors["status.phase"] = ui.ColorRed
+	return &showcase{
+		name:   "DetailView",
+		widget: dv,
+		block:  &dv.Block,
+		settings: []*setting{
+			choice("indent", []string{"2", "4"}, func(v string) { dv.Indent, _ = strconv.Atoi(v) }),
+			colorSetting("keys", "cyan", func(c ui.Attribute) { dv.KeyColor = c }),
+		},
+		keys: dv.HandleKey,
+	}
+}
+
+func statTilePage() *showcase {
+	st := ui.NewStatTile()
+	st.BorderLabel = "StatTile"
+	st.Unit = "ms"
+	for i := 0; i < 30; i++ {
+		st.Push(float64(40 + i*13%20))
+	}
+	return &showcase{
+		name:   "StatTile",
+		widget: st,
+		block:  &st.Block,
+		settings: []*setting{
+			onOff("invert delta", true, func(on bool) { st.InvertDelta = on }),
+			choice("unit", []string{"ms", "%", "req/s"}, func(v string) { st.Unit = v }),
+		},
+		tick: func(n int) { st.Push(float64(40 + (30+n)*13%20)) },
+	}
+}
+
+func heatmapPage() *showcase {
+	hm := ui.NewHeatmap()
+	hm.BorderLabel = "Heatmap"
+	hm.RowLabels = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	for d := range hm.RowLabels {
+		var row []float64
+		for h := 0; h < 24; h++ {
+			v := 50 + 45*math.Sin(float64(h-6)*math.Pi/12)
+			if d >= 5 {
+				v /= 3
+			}
+			row = append(row, math.Floor(v))
+		}
+		hm.Data = append(hm.Data, row)
+	}
+	for h := 0; h < 24; h += 6 {
+		hm.ColLabels = append(hm.ColLabels, strconv.Itoa(h), "", "", "", "", "")
+	}
+	return &showcase{
+		name:   "Heatmap",
+		widget: hm,
+		block:  &hm.Block,
+		settings: []*setting{
+			choice("cell width", []string{"2", "3", "1"}, func(v string) { hm.CellWidth, _ = strconv.Atoi(v) }),
+			onOff("values", false, func(on bool) { hm.ShowValues = on }),
+		},
+	}
+}
+
+func heatstripPage() *showcase {
+	hs := ui.NewHeatstrip()
+	hs.BorderLabel = "Heatstrip"
+	hs.BucketLabels = []string{"1ms", "10ms", "100ms", "1s"}
+	hs.Cap = 200
+	push := func(i int) {
+		hs.Push([]int{20 + i*7%10, 10 + i*3%15, i * 5 % 8, i * 11 % 3})
+	}
+	for i := 0; i < 200; i++ {
+		push(i)
+	}
+	return &showcase{
+		name:   "Heatstrip",
+		widget: hs,
+		block:  &hs.Block,
+		settings: []*setting{
+			choice("max", []string{"auto", "10", "50"}, func(v string) { hs.Max, _ = strconv.Atoi(v) }),
+		},
+		tick: func(n int) { push(200 + n) },
+	}
+}
+
+func mBarChartPage() *showcase {
+	bc := ui.NewMBarChart()
+	bc.BorderLabel = "MBarChart"
+	bc.Data[0] = []int{3, 2, 5, 7, 9, 5, 3, 2}
+	bc.Data[1] = []int{2, 4, 1, 3, 2, 6, 5, 1}
+	bc.Data[2] = []int{1, 1, 3, 2, 4, 1, 2, 6}
+	bc.DataLabels = []string{"S0", "S1", "S2", "S3", "S4", "S5", "S6", "S7"}
+	bc.BarColor[1] = ui.ColorGreen
+	bc.BarColor[2] = ui.ColorYellow
+	return &showcase{
+		name:   "MBarChart",
+		widget: bc,
+		block:  &bc.Block,
+		settings: []*setting{
+			onOff("scale", false, func(on bool) { bc.ShowScale = on }),
+			choice("width", []string{"3", "5", "8"}, func(v string) { bc.BarWidth, _ = strconv.Atoi(v) }),
+			colorSetting("color", "red", func(c ui.Attribute) { bc.BarColor[0] = c }),
+		},
+	}
+}
+
+func multiProgressPage() *showcase {
+	mp := ui.NewMultiProgress()
+	mp.BorderLabel = "MultiProgress"
+	var jobs []*ui.ProgressEntry
+	for _, l := range []string{"download", "unpack", "index", "upload"} {
+		jobs = append(jobs, mp.Add(l))
+	}
+	set := func(n int) {
+		for i, e := range jobs {
+			mp.Set(e, (n+1)*(i+1)*9%101)
+		}
+	}
+	set(0)
+	return &showcase{
+		name:   "MultiProgress",
+		widget: mp,
+		block:  &mp.Block,
+		settings: []*setting{
+			colorSetting("color", "red", func(c ui.Attribute) { mp.BarColor = c }),
+		},
+		tick: set,
+	}
+}
+
+func colorPickerPage() *showcase {
+	cp := ui.NewColorPicker()
+	cp.BorderLabel = "ColorPicker"
+	return &showcase{
+		name:   "ColorPicker",
+		widget: cp,
+		block:  &cp.Block,
+		settings: []*setting{
+			choice("colors", []string{"8", "256"}, func(v string) { cp.Colors, _ = strconv.Atoi(v) }),
+			choice("mode", []string{"palette", "rgb"}, func(v string) { cp.Mode = v }),
+		},
+		keys: cp.HandleKey,
+	}
+}
+
+func timeRangePage() *showcase {
+	tr := ui.NewTimeRange()
+	tr.BorderLabel = "TimeRange"
+	return &showcase{
+		name:   "TimeRange",
+		widget: tr,
+		block:  &tr.Block,
+		settings: []*setting{
+			choice("last", []string{"1h", "5m", "15m", "6h", "24h"}, func(v string) {
+				d, _ := time.ParseDuration(v)
+				tr.SetLast(d)
+			}),
+		},
+		keys: tr.HandleKey,
+	}
+}
+
+// tabpanePage shows the Tabpane of package extra, its tabs holding pars
+// drawn below it.
+func tabpanePage() *showcase {
+	tp := extra.NewTabpane()
+	tp.BorderLabel = "Tabpane"
+	var pars []*ui.Par
+	var tabs []extra.Tab
+	for _, l := range []string{"overview", "pods", "events", "żółw"} {
+		p := ui.NewPar("The " + l + " tab, <left> and <right> switch tabs.")
+		p.BorderLabel = l
+		p.WrapLength = -1
+		pars = append(pars, p)
+		t := extra.NewTab(l)
+		t.AddBlocks(p)
+		tabs = append(tabs, *t)
+	}
+	tp.SetTabs(tabs...)
+	return &showcase{
+		name:   "Tabpane",
+		widget: tp,
+		place: func(x, y, w, h int) {
+			tp.X, tp.Y, tp.Width = x, y, w
+			for _, p := range pars {
+				p.X, p.Y = x, 0
+				p.Width, p.Height = w, h-3
+			}
+		},
+		settings: []*setting{
+			colorSetting("active", "blue", func(c ui.Attribute) { tp.ActiveTabBg = c }),
+		},
+		keys: func(k ui.EvtKbd) bool {
+			switch k.KeyStr {
+			case "<left>":
+				tp.SetActiveLeft()
+			case "<right>":
+				tp.SetActiveRight()
+			default:
+				return false
+			}
+			ui.Clear()
+			return true
+		},
+	}
+}
+
+// modalPage shows a Modal over a par, dimming the gallery beneath.
+func modalPage() *showcase {
+	p := ui.NewPar("The modal is centered on the terminal, over every widget drawn before it.")
+	p.BorderLabel = "Modal"
+	p.WrapLength = -1
+	m := ui.NewModal()
+	dims := map[string]ui.Backdrop{"dim": ui.BackdropDim, "blend": ui.BackdropBlend, "none": ui.BackdropNone}
+	return &showcase{
+		name:   "Modal",
+		widget: ui.NewGroup(p, m),
+		block:  &p.Block,
+		settings: []*setting{
+			onOff("shown", true, func(on bool) {
+				m.Hide()
+				if on {
+					m.Show("Delete pod", "Delete worker-5c1a from default?")
+				}
+			}),
+			choice("backdrop", []string{"dim", "blend", "none"}, func(v string) { m.Dim = dims[v] }),
+		},
+		keys: m.HandleKey,
+	}
+}
+
+// alertsPage shows a Gauge watched by an alert, its border turning to the
+// alert style while above the threshold.
+func alertsPage() *showcase {
+	g := ui.NewGauge()
+	g.BorderLabel = "cpu"
+	as := ui.NewAlerts()
+	threshold := 50
+	as.Add(&ui.Alert{
+		Name:     "cpu",
+		Severity: ui.SeverityWarning,
+		Widget:   g,
+		Cond:     func() bool { return ui.GaugeAbove(g, threshold)() },
+	})
+	return &showcase{
+		name:   "Alerts",
+		widget: g,
+		block:  &g.Block,
+		settings: []*setting{
+			choice("above", []string{"50", "75", "90"}, func(v string) {
+				threshold, _ = strconv.Atoi(v)
+				as.Eval()
+			}),
+		},
+		tick: func(n int) {
+			g.Percent = n * 7 % 101
+			as.Eval()
+		},
+	}
+}
+
+// terminalPage shows what terminals tend to get wrong: wide and combining
+// characters, box drawing, braille and the styles of text.
+func terminalPage() *showcase {
+	p := ui.NewPar("")
+	p.BorderLabel = "Terminal"
+	p.WrapLength = -1
+	text := func(bg string) string {
+		s := "wide:      日本語テキスト 한국어 漢字\n" +
+			"combining: café naïve é ñ\n" +
+			"emoji:     ✔ ✘ ★ ☂\n" +
+			"boxes:     ┌─┬─┐ ╔═╦═╗ ╭─╮ ▁▂▃▄▅▆▇█\n" +
+			"braille:   ⠁⠃⠇⡇⣇⣧⣷⣿\n" +
+			"styles:    [bold](fg-bold) [underline](fg-underline) [reverse](fg-reverse)\n" +
+			"colors:   "
+		for _, c := range []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"} {
+			s += " [" + c + "](fg-" + c + ",bg-" + bg + ")"
+		}
+		return s
+	}
+	return &showcase{
+		name:   "Terminal",
+		widget: p,
+		block:  &p.Block,
+		settings: []*setting{
+			choice("background", []string{"default", "black", "white"}, func(v string) { p.Text = text(v) }),
+		},
+	}
+}
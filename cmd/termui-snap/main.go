@@ -0,0 +1,212 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Command termui-snap is a visual regression tool for termui apps. It runs
+// an app on a headless screen, drives it with a script of events, captures
+// the screen at the script's snap commands and diffs the captures against
+// baselines.
+//
+//	termui-snap -dir testdata/snap gauge.snap -- go run _example/gauge.go
+//
+// The script format is documented on termui.Headless.RunScript, e.g.:
+//
+//	snap start
+//	key <down> <down>
+//	snap scrolled
+//	key q
+//
+// Missing baselines, snaps of the script left without a capture and
+// differences are reported and make the command exit with status 1, errors
+// of the script with status 2. With -update the captures replace the
+// baselines.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	ui "github.com/gizak/termui"
+)
+
+var (
+	dir     = flag.String("dir", "testdata/snap", "directory of the baselines")
+	size    = flag.String("size", "80x24", "screen size, WIDTHxHEIGHT")
+	update  = flag.Bool("update", false, "replace the baselines with the captures")
+	timeout = flag.Duration("timeout", 30*time.Second, "time the app is given to run the script")
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: termui-snap [flags] script -- command [args...]")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	os.Exit(run(flag.Args()))
+}
+
+// run runs the app and the script of args, diffs the captures and returns
+// the exit status, once the captures are removed.
+func run(args []string) int {
+	if len(args) < 3 || args[1] != "--" {
+		usage()
+	}
+	script, err := filepath.Abs(args[0])
+	if err != nil {
+		return fail(err)
+	}
+
+	out, err := os.MkdirTemp("", "termui-snap")
+	if err != nil {
+		return fail(err)
+	}
+	defer os.RemoveAll(out)
+
+	if err := runApp(script, out, args[2:]); err != nil {
+		return fail(err)
+	}
+	if msg, err := os.ReadFile(filepath.Join(out, ui.SnapErrFile)); err == nil {
+		return fail(fmt.Errorf("%s: %s", args[0], strings.TrimSpace(string(msg))))
+	}
+	snaps, err := snapNames(script)
+	if err != nil {
+		return fail(err)
+	}
+	if *update {
+		if err := os.MkdirAll(*dir, 0755); err != nil {
+			return fail(err)
+		}
+	}
+
+	names, err := filepath.Glob(filepath.Join(out, "*.txt"))
+	if err != nil {
+		return fail(err)
+	}
+	sort.Strings(names)
+	failed := false
+	for _, name := range snaps {
+		if _, err := os.Stat(filepath.Join(out, name+".txt")); os.IsNotExist(err) {
+			fmt.Printf("FAIL %s: no capture, the app quit before the snap\n", name)
+			failed = true
+		}
+	}
+	for _, name := range names {
+		ok, err := compare(name)
+		if err != nil {
+			return fail(err)
+		}
+		if !ok {
+			failed = true
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// snapNames returns the names of the snap commands of the script at path.
+func snapNames(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, l := range strings.Split(string(b), "\n") {
+		if fs := strings.Fields(l); len(fs) == 2 && fs[0] == "snap" {
+			names = append(names, fs[1])
+		}
+	}
+	return names, nil
+}
+
+// runApp runs cmd on a headless screen driven by script, writing the
+// captures into out.
+func runApp(script, out string, cmd []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Env = append(os.Environ(),
+		"TERMUI_HEADLESS="+*size,
+		"TERMUI_SNAP_SCRIPT="+script,
+		"TERMUI_SNAP_DIR="+out,
+	)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	err := c.Run()
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s did not finish the script within %v", cmd[0], *timeout)
+	}
+	return err
+}
+
+// compare diffs the capture at path against its baseline, or replaces the
+// baseline with -update, and tells if they matched.
+func compare(path string) (bool, error) {
+	name := strings.TrimSuffix(filepath.Base(path), ".txt")
+	base := filepath.Join(*dir, filepath.Base(path))
+	got, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	if *update {
+		if err := os.WriteFile(base, got, 0644); err != nil {
+			return false, err
+		}
+		fmt.Println("updated", name)
+		return true, nil
+	}
+
+	want, err := os.ReadFile(base)
+	if os.IsNotExist(err) {
+		fmt.Printf("FAIL %s: no baseline %s, run with -update to create it\n", name, base)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if d := diff(string(want), string(got)); d != "" {
+		fmt.Printf("FAIL %s\n%s", name, d)
+		return false, nil
+	}
+	fmt.Println("ok", name)
+	return true, nil
+}
+
+// diff returns the lines that differ between want and got.
+func diff(want, got string) string {
+	ws := strings.Split(want, "\n")
+	gs := strings.Split(got, "\n")
+	var sb strings.Builder
+	for i := 0; i < len(ws) || i < len(gs); i++ {
+		var w, g string
+		if i < len(ws) {
+			w = ws[i]
+		}
+		if i < len(gs) {
+			g = gs[i]
+		}
+		if w != g {
+			fmt.Fprintf(&sb, "  line %d:\n  - %s\n  + %s\n", i+1, w, g)
+		}
+	}
+	return sb.String()
+}
+
+// fail reports err and returns the exit status of errors.
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, "termui-snap:", err)
+	return 2
+}
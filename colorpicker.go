@@ -0,0 +1,203 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "strconv"
+
+// ColorPicker lets the user pick a color Attribute, either from a palette
+// grid or with RGB sliders over the 6x6x6 color cube of 256 color terminals
+// (see ColorRGB). The 256 color palette and the sliders require
+// SetOutputMode(Output256).
+/*
+  cp := termui.NewColorPicker()
+  cp.Colors = 256
+  cp.OnSelect = func(a termui.Attribute) {
+      lc.LineColor["cpu"] = a
+  }
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      cp.HandleKey(e.Data.(termui.EvtKbd))
+      termui.Render(cp)
+  })
+*/
+type ColorPicker struct {
+	Block
+	Colors   int    // palette size, 8 or 256
+	Mode     string // palette | rgb
+	Cursor   int    // palette index of the highlighted color
+	RGB      [3]int // slider values, each 0..5
+	Channel  int    // focused slider: 0 red, 1 green, 2 blue
+	OnSelect func(Attribute)
+	offset   int // first visible palette row
+}
+
+// NewColorPicker returns a new *ColorPicker with current theme.
+func NewColorPicker() *ColorPicker {
+	cp := &ColorPicker{Block: *NewBlock()}
+	cp.Colors = 8
+	cp.Mode = "palette"
+	cp.Width = 36
+	cp.Height = 10
+	return cp
+}
+
+// paletteColor returns the Attribute of palette index i.
+func paletteColor(i int) Attribute {
+	return Attribute(i + 1)
+}
+
+func (cp *ColorPicker) cols() int {
+	if cp.Colors > 16 {
+		return 16
+	}
+	return 8
+}
+
+// Selected returns the currently highlighted color.
+func (cp *ColorPicker) Selected() Attribute {
+	if cp.Mode == "rgb" {
+		return ColorRGB(cp.RGB[0], cp.RGB[1], cp.RGB[2])
+	}
+	return paletteColor(cp.Cursor)
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed.
+// Arrows move the cursor or adjust sliders, <tab> switches modes and
+// <enter> calls OnSelect.
+func (cp *ColorPicker) HandleKey(k EvtKbd) bool {
+	if k.KeyStr == "<tab>" {
+		if cp.Mode == "rgb" {
+			cp.Mode = "palette"
+		} else {
+			cp.Mode = "rgb"
+		}
+		return true
+	}
+	if k.KeyStr == "<enter>" {
+		if cp.OnSelect != nil {
+			cp.OnSelect(cp.Selected())
+		}
+		return true
+	}
+
+	if cp.Mode == "rgb" {
+		switch k.KeyStr {
+		case "<up>":
+			cp.Channel = clamp(cp.Channel-1, 0, 2)
+		case "<down>":
+			cp.Channel = clamp(cp.Channel+1, 0, 2)
+		case "<left>":
+			cp.RGB[cp.Channel] = clamp(cp.RGB[cp.Channel]-1, 0, 5)
+		case "<right>":
+			cp.RGB[cp.Channel] = clamp(cp.RGB[cp.Channel]+1, 0, 5)
+		default:
+			return false
+		}
+		return true
+	}
+
+	d := map[string]int{"<left>": -1, "<right>": 1, "<up>": -cp.cols(), "<down>": cp.cols()}
+	n, ok := d[k.KeyStr]
+	if !ok {
+		return false
+	}
+	cp.Cursor = clamp(cp.Cursor+n, 0, cp.Colors-1)
+	return true
+}
+
+func (cp *ColorPicker) bufferPalette(buf Buffer) {
+	cols := cp.cols()
+	rows := (cp.Colors + cols - 1) / cols
+	visible := cp.innerArea.Dy()
+
+	// scroll to keep the cursor row visible
+	row := cp.Cursor / cols
+	if row < cp.offset {
+		cp.offset = row
+	}
+	if row >= cp.offset+visible {
+		cp.offset = row - visible + 1
+	}
+
+	for r := cp.offset; r < rows && r-cp.offset < visible; r++ {
+		y := cp.innerArea.Min.Y + r - cp.offset
+		for c := 0; c < cols && r*cols+c < cp.Colors; c++ {
+			i := r*cols + c
+			x := cp.innerArea.Min.X + c*2
+			if x+1 >= cp.innerArea.Max.X {
+				break
+			}
+			l, rr := ' ', ' '
+			if i == cp.Cursor {
+				l, rr = '[', ']'
+			}
+			fg := ColorWhite | AttrBold
+			if i == 7 || i == 15 {
+				fg = ColorBlack
+			}
+			buf.Set(x, y, Cell{Ch: l, Fg: fg, Bg: paletteColor(i)})
+			buf.Set(x+1, y, Cell{Ch: rr, Fg: fg, Bg: paletteColor(i)})
+		}
+	}
+}
+
+func (cp *ColorPicker) bufferRGB(buf Buffer) {
+	names := []string{"R", "G", "B"}
+	for ch := 0; ch < 3 && ch < cp.innerArea.Dy(); ch++ {
+		y := cp.innerArea.Min.Y + ch
+		fg := ThemeAttr("colorpicker.fg")
+		if ch == cp.Channel {
+			fg |= AttrBold
+		}
+		s := names[ch] + " "
+		for v := 0; v <= 5; v++ {
+			if v == cp.RGB[ch] {
+				s += "●"
+			} else {
+				s += "─"
+			}
+		}
+		s += " " + strconv.Itoa(cp.RGB[ch])
+
+		x := cp.innerArea.Min.X
+		for _, c := range TextCells(s, fg, cp.Bg) {
+			if x >= cp.innerArea.Max.X {
+				break
+			}
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+	}
+
+	// preview swatch
+	for y := cp.innerArea.Min.Y + 4; y < cp.innerArea.Max.Y; y++ {
+		for x := cp.innerArea.Min.X; x < cp.innerArea.Max.X; x++ {
+			buf.Set(x, y, Cell{Ch: ' ', Fg: ColorDefault, Bg: cp.Selected()})
+		}
+	}
+}
+
+// Buffer implements Bufferer interface.
+func (cp *ColorPicker) Buffer() Buffer {
+	buf := cp.Block.Buffer()
+	if cp.innerArea.Dx() <= 0 || cp.innerArea.Dy() <= 0 {
+		return buf
+	}
+	if cp.Mode == "rgb" {
+		cp.bufferRGB(buf)
+	} else {
+		cp.bufferPalette(buf)
+	}
+	return buf
+}
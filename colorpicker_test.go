@@ -0,0 +1,28 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestColorPicker(t *testing.T) {
+	cp := NewColorPicker()
+	cp.Colors = 256
+
+	var picked Attribute
+	cp.OnSelect = func(a Attribute) { picked = a }
+	for _, k := range []string{"<down>", "<right>", "<right>", "<enter>"} {
+		cp.HandleKey(EvtKbd{KeyStr: k})
+	}
+	if picked != paletteColor(18) {
+		t.Errorf("expected palette color 18, got %d", picked)
+	}
+
+	for _, k := range []string{"<tab>", "<right>", "<down>", "<down>", "<right>", "<right>", "<enter>"} {
+		cp.HandleKey(EvtKbd{KeyStr: k})
+	}
+	if picked != ColorRGB(1, 0, 2) {
+		t.Errorf("expected rgb(1,0,2), got %d", picked)
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Candidate is a completion of the word at the cursor, with an optional
+// description shown next to it.
+type Candidate struct {
+	Text string
+	Desc string
+}
+
+// Completer completes the text of a TextInput: given the text and the rune
+// index of the cursor, it returns the rune index where the word being
+// completed starts, which the candidate chosen replaces up to the cursor.
+type Completer interface {
+	Complete(text string, cursor int) (start int, cands []Candidate)
+}
+
+// CompleterFunc is a func used as a Completer.
+type CompleterFunc func(text string, cursor int) (int, []Candidate)
+
+// Complete implements Completer interface.
+func (f CompleterFunc) Complete(text string, cursor int) (int, []Candidate) {
+	return f(text, cursor)
+}
+
+// wordAt returns the start and the text of the word ending at the cursor,
+// words being separated by spaces.
+func wordAt(text string, cursor int) (int, string) {
+	rs := []rune(text)
+	cursor = clamp(cursor, 0, len(rs))
+	start := cursor
+	for start > 0 && !unicode.IsSpace(rs[start-1]) {
+		start--
+	}
+	return start, string(rs[start:cursor])
+}
+
+// Words completes the word at the cursor with the words starting with it,
+// in their order.
+/*
+  input.Completer = termui.Words{
+      {Text: "help", Desc: "list the commands"},
+      {Text: "quit", Desc: "exit the app"},
+  }
+*/
+type Words []Candidate
+
+// Complete implements Completer interface.
+func (ws Words) Complete(text string, cursor int) (int, []Candidate) {
+	start, word := wordAt(text, cursor)
+	var cs []Candidate
+	for _, w := range ws {
+		if strings.HasPrefix(w.Text, word) {
+			cs = append(cs, w)
+		}
+	}
+	return start, cs
+}
+
+// PathCompleter completes the word at the cursor with the paths of files
+// it is the start of, relative to Dir or the working directory. Directories
+// end with a separator, so that completing goes on inside them.
+type PathCompleter struct {
+	Dir    string
+	Hidden bool // complete dot files without a leading dot typed
+}
+
+// Complete implements Completer interface.
+func (pc PathCompleter) Complete(text string, cursor int) (int, []Candidate) {
+	start, word := wordAt(text, cursor)
+	dir, base := filepath.Split(word)
+	path := dir
+	if strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) && pc.Dir != "" {
+		path = filepath.Join(pc.Dir, path)
+	}
+	if path == "" {
+		path = "."
+	}
+	fis, err := ioutil.ReadDir(path)
+	if err != nil {
+		return start, nil
+	}
+	var cs []Candidate
+	for _, fi := range fis {
+		name := fi.Name()
+		if !strings.HasPrefix(name, base) || strings.HasPrefix(name, ".") && !pc.Hidden && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		c := Candidate{Text: dir + name, Desc: "file"}
+		if fi.IsDir() {
+			c.Text += string(filepath.Separator)
+			c.Desc = "dir"
+		}
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Text < cs[j].Text })
+	return start, cs
+}
+
+// commonPrefix returns the longest prefix shared by the texts of cs.
+func commonPrefix(cs []Candidate) string {
+	if len(cs) == 0 {
+		return ""
+	}
+	p := []rune(cs[0].Text)
+	for _, c := range cs[1:] {
+		rs := []rune(c.Text)
+		n := 0
+		for n < len(p) && n < len(rs) && p[n] == rs[n] {
+			n++
+		}
+		p = p[:n]
+	}
+	return string(p)
+}
+
+// completion is the state of the completion running in a TextInput.
+type completion struct {
+	start    int
+	cands    []Candidate
+	selected int
+}
+
+// Completing tells if completion candidates are offered, see Complete.
+func (ti *TextInput) Completing() bool {
+	return ti.completion != nil
+}
+
+// Complete completes the word at the cursor with Completer: a single
+// candidate, or the prefix shared by all of them, is inserted right away;
+// several candidates are offered in the CompletionList of ti, to choose
+// from with <tab>, <up> and <down> and accept with <enter>.
+func (ti *TextInput) Complete() bool {
+	if ti.Completer == nil {
+		return false
+	}
+	ti.clampCursor([]rune(ti.Text))
+	start, cs := ti.Completer.Complete(ti.Text, ti.Cursor)
+	ti.completion = nil
+	switch len(cs) {
+	case 0:
+		return true
+	case 1:
+		ti.replace(start, cs[0].Text)
+		return true
+	}
+	if p := commonPrefix(cs); len([]rune(p)) > ti.Cursor-start {
+		ti.replace(start, p)
+	}
+	ti.completion = &completion{start: start, cands: cs, selected: -1}
+	return true
+}
+
+// replace replaces the text from start to the cursor with s.
+func (ti *TextInput) replace(start int, s string) {
+	rs := []rune(ti.Text)
+	start = clamp(start, 0, ti.Cursor)
+	ti.record("")
+	ti.Text = string(rs[:start]) + s + string(rs[ti.Cursor:])
+	ti.Cursor = start + len([]rune(s))
+}
+
+// refreshCompletion offers the candidates of the text edited while the
+// completion runs, and ends it when none are left.
+func (ti *TextInput) refreshCompletion() {
+	start, cs := ti.Completer.Complete(ti.Text, ti.Cursor)
+	if len(cs) == 0 {
+		ti.completion = nil
+		return
+	}
+	ti.completion = &completion{start: start, cands: cs, selected: -1}
+}
+
+// followCompletion refreshes the running completion after a key edited
+// the text, which was before, or ends it.
+func (ti *TextInput) followCompletion(before string) {
+	if ti.completion == nil {
+		return
+	}
+	if ti.Text == before {
+		ti.completion = nil
+		return
+	}
+	ti.refreshCompletion()
+}
+
+// completionKey applies k to the running completion and tells if it was
+// consumed. Other keys apply to the text, and end the completion unless
+// they edit it.
+func (ti *TextInput) completionKey(k EvtKbd) bool {
+	c := ti.completion
+	switch k.KeyStr {
+	case "<tab>", "<down>", "C-n":
+		c.selected = (c.selected + 1) % len(c.cands)
+	case "<up>", "C-p":
+		c.selected = (c.selected + len(c.cands) - 1) % len(c.cands)
+	case "<enter>":
+		if c.selected < 0 {
+			ti.completion = nil
+			return false
+		}
+		ti.completion = nil
+		ti.replace(c.start, c.cands[c.selected].Text)
+	case "<escape>":
+		ti.completion = nil
+	default:
+		return false
+	}
+	return true
+}
+
+// CompletionList is the popup of the completion candidates of a
+// TextInput, drawn below the word completed, or above it when the input
+// is at the bottom of the terminal. It draws nothing while no completion
+// runs, so it can always be rendered after the widgets it covers.
+/*
+  input.Completer = termui.PathCompleter{}
+  popup := termui.NewCompletionList(input)
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      input.HandleKey(e.Data.(termui.EvtKbd))
+      termui.Render(termui.Body, popup)
+  })
+*/
+type CompletionList struct {
+	Block
+	Input       *TextInput
+	MaxItems    int
+	TextFgColor Attribute
+	DescFgColor Attribute
+	SelectedBg  Attribute
+}
+
+// NewCompletionList returns a new *CompletionList of the candidates of ti
+// with current theme.
+func NewCompletionList(ti *TextInput) *CompletionList {
+	cl := &CompletionList{Block: *NewBlock(), Input: ti}
+	cl.MaxItems = 8
+	cl.TextFgColor = ThemeAttr("completion.text.fg")
+	cl.DescFgColor = ThemeAttr("completion.desc.fg")
+	cl.SelectedBg = ThemeAttr("completion.selected.bg")
+	return cl
+}
+
+// Backdrop implements Overlay interface.
+func (cl *CompletionList) Backdrop() Backdrop {
+	return BackdropNone
+}
+
+// Buffer implements Bufferer interface.
+func (cl *CompletionList) Buffer() Buffer {
+	ti := cl.Input
+	if ti == nil || ti.completion == nil {
+		return NewBuffer()
+	}
+	c := ti.completion
+	n := len(c.cands)
+	if cl.MaxItems > 0 && n > cl.MaxItems {
+		n = cl.MaxItems
+	}
+	top := 0
+	if c.selected >= n {
+		top = c.selected - n + 1
+	}
+	cands := c.cands[top : top+n]
+
+	textW, descW := 0, 0
+	for _, cd := range cands {
+		if w := strWidth(cd.Text); w > textW {
+			textW = w
+		}
+		if w := strWidth(cd.Desc); w > descW {
+			descW = w
+		}
+	}
+	if descW > 0 {
+		descW += 2
+	}
+	cl.Width = textW + descW + 2
+	cl.Height = n + 2
+	if !cl.Border {
+		cl.Width -= 2
+		cl.Height -= 2
+	}
+
+	// under the start of the word completed
+	cs, _ := ti.cells()
+	x := ti.innerArea.Min.X
+	if c.start > ti.offset && c.start <= len(cs) {
+		x += cellsWidth(cs[ti.offset:c.start])
+	}
+	cl.X, cl.Y = x-1, ti.innerArea.Max.Y
+	if r := TermRect(); !r.Empty() {
+		if cl.Y+cl.Height > r.Max.Y && ti.innerArea.Min.Y-cl.Height >= 0 {
+			cl.Y = ti.innerArea.Min.Y - cl.Height
+		}
+		if cl.X+cl.Width > r.Max.X {
+			cl.X = r.Max.X - cl.Width
+		}
+	}
+	if cl.X < 0 {
+		cl.X = 0
+	}
+	buf := cl.Block.Buffer()
+
+	for i, cd := range cands {
+		y := cl.innerArea.Min.Y + i
+		bg := cl.Bg
+		if top+i == c.selected {
+			bg = cl.SelectedBg
+		}
+		row := TextCells(cd.Text, cl.TextFgColor, bg)
+		for w := strWidth(cd.Text); w < textW+2; w++ {
+			row = append(row, Cell{Ch: ' ', Fg: cl.TextFgColor, Bg: bg})
+		}
+		row = append(row, TextCells(cd.Desc, cl.DescFgColor, bg)...)
+		for cellsWidth(row) < cl.innerArea.Dx() {
+			row = append(row, Cell{Ch: ' ', Fg: cl.TextFgColor, Bg: bg})
+		}
+		xx := cl.innerArea.Min.X
+		for _, cell := range TruncateRight(row, cl.innerArea.Dx()) {
+			buf.Set(xx, y, cell)
+			xx += cell.Width()
+		}
+	}
+	return buf
+}
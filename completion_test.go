@@ -0,0 +1,76 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTextInputComplete(t *testing.T) {
+	ti := NewTextInput()
+	ti.Completer = Words{{Text: "status"}, {Text: "start", Desc: "run it"}, {Text: "stop"}}
+	keys(ti, "g", "i", "t", "<space>", "s", "t", "a", "<tab>")
+	if ti.Text != "git sta" || !ti.Completing() {
+		t.Fatalf("expected candidates offered, got %q", ti.Text)
+	}
+	keys(ti, "r")
+	if !ti.Completing() || len(ti.completion.cands) != 1 {
+		t.Fatal("expected the candidates narrowed by typing")
+	}
+	keys(ti, "<tab>", "<enter>")
+	if ti.Text != "git start" || ti.Completing() {
+		t.Errorf("unexpected completed text %q", ti.Text)
+	}
+
+	ti.Text, ti.Cursor = "sto", 3
+	keys(ti, "<tab>")
+	if ti.Text != "stop" || ti.Completing() {
+		t.Errorf("expected the single candidate inserted, got %q", ti.Text)
+	}
+}
+
+func TestPathCompleter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "termui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Mkdir(filepath.Join(dir, "docs"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "doc.go"), nil, 0644)
+	ioutil.WriteFile(filepath.Join(dir, ".dotfile"), nil, 0644)
+
+	start, cs := PathCompleter{Dir: dir}.Complete("cat do", 6)
+	if start != 4 || len(cs) != 2 || cs[0].Text != "doc.go" || cs[1].Text != "docs/" || cs[1].Desc != "dir" {
+		t.Errorf("unexpected candidates %v from %d", cs, start)
+	}
+	if _, cs := (PathCompleter{Dir: dir}).Complete("", 0); len(cs) != 2 {
+		t.Errorf("expected dot files hidden, got %v", cs)
+	}
+}
+
+func TestCompletionList(t *testing.T) {
+	ti := NewTextInput()
+	ti.Width = 20
+	ti.Completer = Words{{Text: "ab", Desc: "x"}, {Text: "ac"}}
+	ti.Text, ti.Cursor = "a", 1
+	ti.Buffer()
+	ti.Complete()
+	keys(ti, "<down>")
+
+	cl := NewCompletionList(ti)
+	buf := cl.Buffer()
+	if cl.Y != 2 || cl.X != 0 {
+		t.Errorf("unexpected position %d,%d", cl.X, cl.Y)
+	}
+	if c := buf.At(1, 3); c.Ch != 'a' || c.Bg != cl.SelectedBg {
+		t.Errorf("expected the selected candidate, got %q in %v", c.Ch, c.Bg)
+	}
+	if c := buf.At(5, 3); c.Ch != 'x' {
+		t.Errorf("expected the description, got %q", c.Ch)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "image"
+
+// Backdrop is how an overlay widget, e.g. a Modal, composites the cells
+// drawn before it in the same frame and left uncovered by its own cells.
+type Backdrop int
+
+// Backdrops.
+const (
+	// BackdropNone leaves the cells beneath as they are.
+	BackdropNone Backdrop = iota
+	// BackdropDim redraws the cells beneath with the "backdrop.fg" and
+	// "backdrop.bg" theme colors, which reads on any terminal.
+	BackdropDim
+	// BackdropBlend redraws the cells beneath with darker shades of their
+	// own colors, from the 256 color palette, see SetOutputMode.
+	BackdropBlend
+)
+
+// Overlay is implemented by Bufferers drawn over other widgets, to tell the
+// compositor how to render what they cover.
+type Overlay interface {
+	Bufferer
+	Backdrop() Backdrop
+}
+
+// composite applies b to the cells drawn so far in the frame.
+func composite(cells map[image.Point]Cell, b Backdrop) {
+	for p, c := range cells {
+		c = b.apply(c)
+		cells[p] = c
+		screen.setCell(p.X, p.Y, c)
+	}
+}
+
+func (b Backdrop) apply(c Cell) Cell {
+	switch b {
+	case BackdropDim:
+		c.Fg = ThemeAttr("backdrop.fg") | c.Fg&AttrUnderline
+		c.Bg = ThemeAttr("backdrop.bg")
+	case BackdropBlend:
+		c.Fg = darken(c.Fg, ColorGrayscale(8))
+		c.Bg = darken(c.Bg, ColorDefault)
+	}
+	return c
+}
+
+// darken returns a darker shade of a, without bold, or def for the default
+// color, whose shade is not known.
+func darken(a Attribute, def Attribute) Attribute {
+	style := a & (AttrUnderline | AttrReverse)
+	c := a & 0x1ff
+	switch {
+	case c == ColorDefault:
+		return def | style
+	case c <= ColorWhite:
+		// the basic colors, at half intensity
+		rgb := [...][3]int{
+			ColorBlack:   {0, 0, 0},
+			ColorRed:     {2, 0, 0},
+			ColorGreen:   {0, 2, 0},
+			ColorYellow:  {2, 2, 0},
+			ColorBlue:    {0, 0, 2},
+			ColorMagenta: {2, 0, 2},
+			ColorCyan:    {0, 2, 2},
+			ColorWhite:   {2, 2, 2},
+		}[c]
+		return ColorRGB(rgb[0], rgb[1], rgb[2]) | style
+	case c < ColorRGB(0, 0, 0):
+		return c | style
+	case c <= ColorRGB(5, 5, 5):
+		v := int(c - ColorRGB(0, 0, 0))
+		return ColorRGB(v/36/2, v/6%6/2, v%6/2) | style
+	default:
+		return ColorGrayscale(int(c-ColorGrayscale(0))/2) | style
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestDarken(t *testing.T) {
+	cases := []struct{ in, def, want Attribute }{
+		{ColorDefault, ColorDefault, ColorDefault},
+		{ColorRed | AttrBold, ColorDefault, ColorRGB(2, 0, 0)},
+		{ColorGreen | AttrUnderline, ColorDefault, ColorRGB(0, 2, 0) | AttrUnderline},
+		{ColorRGB(5, 3, 1), ColorDefault, ColorRGB(2, 1, 0)},
+		{ColorGrayscale(20), ColorDefault, ColorGrayscale(10)},
+		{ColorDefault, ColorGrayscale(8), ColorGrayscale(8)},
+	}
+	for _, c := range cases {
+		if got := darken(c.in, c.def); got != c.want {
+			t.Errorf("darken(%v, %v) = %v, want %v", c.in, c.def, got, c.want)
+		}
+	}
+}
+
+func TestModalBackdrop(t *testing.T) {
+	h := NewHeadless(20, 9)
+	old, w, ht := screen, termWidth, termHeight
+	screen = h
+	termSync()
+	defer func() { screen, termWidth, termHeight = old, w, ht }()
+
+	p := NewPar("under")
+	p.Width = 20
+	p.Height = 9
+	p.TextFgColor = ColorGreen
+	m := NewModal()
+	m.Width = 10
+	m.Height = 5
+	m.Dim = BackdropDim
+	m.Show("x", "")
+
+	render(p, m)
+	scr := h.Screen()
+	if c := scr.At(1, 1); c.Ch != 'u' || c.Fg != ThemeAttr("backdrop.fg") {
+		t.Errorf("expected the covered text dimmed, got %q %v", c.Ch, c.Fg)
+	}
+	if c := scr.At(5, 2); c.Fg != m.BorderFg {
+		t.Errorf("expected the modal drawn over the backdrop, got %q %v", c.Ch, c.Fg)
+	}
+
+	m.Hide()
+	render(p, m)
+	if c := h.Screen().At(1, 1); c.Fg != ColorGreen {
+		t.Errorf("a hidden modal should not dim, got %v", c.Fg)
+	}
+}
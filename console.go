@@ -0,0 +1,200 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// Console is an interactive shell: a prompt taking lines, with the history
+// and completion of TextInput, above which the lines submitted and their
+// output scroll by. Eval runs every line submitted, writing its output to
+// the console, which draws the colors of ANSI escape sequences. Console is
+// an io.Writer, so that output can also come from other goroutines.
+/*
+  c := termui.NewConsole()
+  c.BorderLabel = "sql"
+  c.Prompt = "> "
+  c.Input.Completer = termui.Words{{Text: "SELECT"}, {Text: "FROM"}}
+  c.Eval = func(line string, out io.Writer) {
+      rows, err := db.Query(line)
+      if err != nil {
+          fmt.Fprintf(out, "\033[31m%v\033[0m\n", err)
+          return
+      }
+      printRows(out, rows)
+  }
+  popup := termui.NewCompletionList(c.Input)
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      c.HandleKey(e.Data.(termui.EvtKbd))
+      termui.Render(c, popup)
+  })
+*/
+type Console struct {
+	Block
+	sync.Mutex
+	Input         *TextInput
+	Prompt        string
+	PromptFgColor Attribute
+	TextFgColor   Attribute
+	TextBgColor   Attribute
+	Eval          func(line string, out io.Writer)
+	Echo          bool // write the lines submitted to the output, after the Prompt
+	Cap           int  // number of output lines kept, 0 keeps all
+	lines         [][]Cell
+	partial       string // output after the last newline
+	offset        int    // rows scrolled back from the newest
+}
+
+// NewConsole returns a new *Console with current theme, keeping the history
+// of 1000 lines.
+func NewConsole() *Console {
+	c := &Console{Block: *NewBlock()}
+	c.Prompt = "> "
+	c.PromptFgColor = ThemeAttr("console.prompt.fg") | AttrBold
+	c.TextFgColor = ThemeAttr("console.text.fg")
+	c.TextBgColor = ThemeAttr("console.text.bg")
+	c.Echo = true
+	c.Cap = 5000
+	c.Input = NewTextInput()
+	c.Input.Border = false
+	c.Input.Height = 1
+	c.Input.Recall = NewInputHistory(1000)
+	c.Input.OnSubmit = c.submit
+	return c
+}
+
+// Write implements io.Writer interface, appending p to the output. Lines
+// are shown as they are completed by a newline, colored by the ANSI escape
+// sequences they hold.
+func (c *Console) Write(p []byte) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+	s := c.partial + string(p)
+	i := strings.LastIndexByte(s, '\n')
+	if i < 0 {
+		c.partial = s
+		return len(p), nil
+	}
+	c.partial = s[i+1:]
+	for _, l := range strings.Split(s[:i], "\n") {
+		c.lines = append(c.lines, ANSITxBuilder{}.Build(strings.TrimSuffix(l, "\r"), c.TextFgColor, c.TextBgColor))
+	}
+	if c.Cap > 0 && len(c.lines) > c.Cap {
+		c.lines = c.lines[len(c.lines)-c.Cap:]
+	}
+	return len(p), nil
+}
+
+// Clear drops the output.
+func (c *Console) Clear() {
+	c.Lock()
+	c.lines, c.partial, c.offset = nil, "", 0
+	c.Unlock()
+}
+
+// submit echoes line, clears the prompt and evaluates line.
+func (c *Console) submit(line string) {
+	if c.Echo {
+		c.Lock()
+		cs := TextCells(c.Prompt, c.PromptFgColor, c.TextBgColor)
+		cs = append(cs, TextCells(line, c.TextFgColor, c.TextBgColor)...)
+		c.lines = append(c.lines, cs)
+		c.offset = 0
+		c.Unlock()
+	}
+	c.Input.Text, c.Input.Cursor = "", 0
+	if c.Input.History != nil {
+		c.Input.History.Clear()
+	}
+	if c.Eval != nil {
+		c.Eval(line, c)
+	}
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed:
+// <pageup> and <pagedown> scroll the output, C-l clears it, other keys
+// edit the prompt.
+func (c *Console) HandleKey(k EvtKbd) bool {
+	switch k.KeyStr {
+	case "<pageup>", "<previous>":
+		c.scrollBy(c.innerArea.Dy() - 1)
+	case "<pagedown>", "<next>":
+		c.scrollBy(1 - c.innerArea.Dy())
+	case "C-l":
+		c.Clear()
+	default:
+		return c.Input.HandleKey(k)
+	}
+	return true
+}
+
+func (c *Console) scrollBy(n int) {
+	c.Lock()
+	c.offset += n
+	if c.offset < 0 {
+		c.offset = 0
+	}
+	c.Unlock()
+}
+
+// rows returns the output wrapped to w columns.
+func (c *Console) rows(w int) [][]Cell {
+	lines := c.lines
+	if c.partial != "" {
+		lines = append(lines[:len(lines):len(lines)], ANSITxBuilder{}.Build(c.partial, c.TextFgColor, c.TextBgColor))
+	}
+	var rows [][]Cell
+	for _, l := range lines {
+		for _, pl := range wrapLines(l, w, 0) {
+			rows = append(rows, pl.cells)
+		}
+	}
+	return rows
+}
+
+// Buffer implements Bufferer interface.
+func (c *Console) Buffer() Buffer {
+	buf := c.Block.Buffer()
+	if c.drawState(buf) {
+		return buf
+	}
+	w, h := c.innerArea.Dx(), c.innerArea.Dy()
+	if w <= 0 || h <= 0 {
+		return buf
+	}
+
+	c.Lock()
+	rows := c.rows(w)
+	c.offset = clamp(c.offset, 0, clamp(len(rows)-(h-1), 0, len(rows)))
+	end := len(rows) - c.offset
+	start := clamp(end-(h-1), 0, end)
+	c.Unlock()
+	for i, row := range rows[start:end] {
+		x := c.innerArea.Min.X
+		for _, cell := range row {
+			buf.Set(x, c.innerArea.Min.Y+i, cell)
+			x += cell.Width()
+		}
+	}
+
+	// the prompt, on the last row
+	y := c.innerArea.Max.Y - 1
+	x := c.innerArea.Min.X
+	for _, cell := range TruncateRight(TextCells(c.Prompt, c.PromptFgColor, c.TextBgColor), w) {
+		buf.Set(x, y, cell)
+		x += cell.Width()
+	}
+	if x < c.innerArea.Max.X {
+		c.Input.SetX(x)
+		c.Input.SetY(y)
+		c.Input.Width = c.innerArea.Max.X - x
+		c.Input.Bg = c.Bg
+		buf.Merge(c.Input.Buffer())
+	}
+	return buf
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// consoleRows returns the text of the rows of the inner area of c.
+func consoleRows(c *Console, buf Buffer) []string {
+	var rows []string
+	for y := c.innerArea.Min.Y; y < c.innerArea.Max.Y; y++ {
+		var b strings.Builder
+		for x := c.innerArea.Min.X; x < c.innerArea.Max.X; x++ {
+			b.WriteRune(buf.At(x, y).Ch)
+		}
+		rows = append(rows, strings.TrimRight(b.String(), " "))
+	}
+	return rows
+}
+
+func TestConsole(t *testing.T) {
+	c := NewConsole()
+	c.Border = false
+	c.Width = 12
+	c.Height = 4
+	c.Eval = func(line string, out io.Writer) {
+		fmt.Fprintf(out, "\033[32m%d\033[0m chars\n", len(line))
+	}
+	keys(c.Input, "h", "i")
+	c.HandleKey(EvtKbd{KeyStr: "<enter>"})
+	fmt.Fprint(c, "part")
+
+	buf := c.Buffer()
+	want := []string{"> hi", "2 chars", "part", ">"}
+	if rows := consoleRows(c, buf); strings.Join(rows, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected rows %q", rows)
+	}
+	if c := buf.At(0, 1); c.Fg != ColorGreen {
+		t.Errorf("expected the output colored, got %v", c.Fg)
+	}
+
+	c.HandleKey(EvtKbd{KeyStr: "<up>"})
+	if c.Input.Text != "hi" {
+		t.Errorf("expected the line recalled, got %q", c.Input.Text)
+	}
+
+	fmt.Fprint(c, "\nmore\n")
+	c.HandleKey(EvtKbd{KeyStr: "<pageup>"})
+	if rows := consoleRows(c, c.Buffer()); rows[0] != "> hi" {
+		t.Errorf("expected the output scrolled back, got %q", rows)
+	}
+}
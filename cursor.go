@@ -0,0 +1,228 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+)
+
+// CursorShape is the shape of a text cursor.
+type CursorShape int
+
+// Cursor shapes. CursorDefault is the one the terminal is set up with.
+const (
+	CursorDefault CursorShape = iota
+	CursorBlock
+	CursorUnderline
+	CursorBar
+)
+
+// CursorStyle is how a text cursor is drawn.
+type CursorStyle struct {
+	Shape CursorShape
+	Blink bool
+}
+
+// decscusr returns the escape sequence setting the cursor style s.
+func (s CursorStyle) decscusr() string {
+	n := 0
+	if s.Shape != CursorDefault {
+		n = 2 * int(s.Shape)
+		if s.Blink {
+			n--
+		}
+	}
+	return fmt.Sprintf("\033[%d q", n)
+}
+
+// cursorPlace is a cursor requested by PlaceCursor.
+type cursorPlace struct {
+	p     image.Point
+	style CursorStyle
+}
+
+// cursor is the cursor requested in the frame being drawn, and the one on
+// the screen.
+var cursor = struct {
+	sync.Mutex
+	building *cursorPlace
+	shown    *cursorPlace
+}{}
+
+// PlaceCursor shows the terminal cursor at x, y once the frame being drawn
+// is flushed. Text widgets call it from their Buffer, the last one to call
+// it in a frame gets the cursor, which is hidden in frames where none does
+// but for those leaving its widget on screen, not drawing over it.
+/*
+  func (e *Editor) Buffer() termui.Buffer {
+      buf := e.Block.Buffer()
+      // draw the text...
+      termui.PlaceCursor(x, y, termui.CursorStyle{Shape: termui.CursorBar})
+      return buf
+  }
+*/
+func PlaceCursor(x, y int, s CursorStyle) {
+	cursor.Lock()
+	defer cursor.Unlock()
+	cursor.building = &cursorPlace{p: image.Pt(x, y), style: s}
+}
+
+// beginCursor starts the cursor of a new frame.
+func beginCursor() {
+	cursor.Lock()
+	cursor.building = nil
+	cursor.Unlock()
+}
+
+// cursorRequest returns the cursor requested so far in this frame.
+func cursorRequest() *cursorPlace {
+	cursor.Lock()
+	defer cursor.Unlock()
+	return cursor.building
+}
+
+// restoreCursor requests again the cursor of a buffer reused from the last
+// frame.
+func restoreCursor(c *cursorPlace) {
+	cursor.Lock()
+	defer cursor.Unlock()
+	cursor.building = c
+}
+
+// placeCursor moves, styles, shows or hides the cursor of the screen as
+// requested in the frame just flushed, drawn over the areas drawn; the
+// cursor shown is kept if none was requested outside of them. It is moved
+// back in place after every flush, which may have moved it while drawing.
+// It is called under renderLock.
+func placeCursor(drawn []image.Rectangle) {
+	cursor.Lock()
+	defer cursor.Unlock()
+	c, shown := cursor.building, cursor.shown
+	if c == nil && shown != nil && !within(image.Rect(shown.p.X, shown.p.Y, shown.p.X+1, shown.p.Y+1), drawn) {
+		c = shown
+	}
+	switch {
+	case c == nil && shown != nil:
+		screen.hideCursor()
+	case c != nil:
+		screen.showCursor(c.p.X, c.p.Y, c.style)
+	}
+	cursor.shown = c
+}
+
+// resetCursor forgets the cursor, which the closing screen hides.
+func resetCursor() {
+	cursor.Lock()
+	cursor.building, cursor.shown = nil, nil
+	cursor.Unlock()
+}
+
+// Blinker times the blinking of soft cursors: they are shown for a Period,
+// hidden for the next one, and so on from the last Reset. Editing widgets
+// share CursorBlinker, so that their cursors blink together and are shown
+// as soon as one is moved.
+/*
+  termui.CursorBlinker.Redraw = func() { termui.Render(termui.Body) }
+  input.CursorStyle = termui.CursorStyle{Shape: termui.CursorBar, Blink: true}
+*/
+type Blinker struct {
+	sync.Mutex
+	Period  time.Duration
+	Redraw  func() // nil leaves redrawing to the app's own timers
+	reset   time.Time
+	drawn   time.Time
+	running bool
+}
+
+// NewBlinker returns a *Blinker of the usual terminal period.
+func NewBlinker() *Blinker {
+	return &Blinker{Period: 530 * time.Millisecond}
+}
+
+// CursorBlinker is the Blinker of the soft cursors of editing widgets.
+var CursorBlinker = NewBlinker()
+
+// On tells if a blinking cursor is shown. Widgets drawing one call it from
+// their Buffer, which keeps Redraw running until they stop.
+func (b *Blinker) On() bool {
+	return b.on(time.Now())
+}
+
+func (b *Blinker) on(now time.Time) bool {
+	b.Lock()
+	defer b.Unlock()
+	b.drawn = now
+	if b.Redraw != nil && !b.running {
+		b.running = true
+		go b.animate()
+	}
+	if b.Period <= 0 {
+		return true
+	}
+	return now.Sub(b.reset)/b.Period%2 == 0
+}
+
+// Reset shows the cursors and starts their blinking over, e.g. when one
+// was moved.
+func (b *Blinker) Reset() {
+	b.Lock()
+	b.reset = time.Now()
+	b.Unlock()
+}
+
+// animate calls Redraw every Period until no cursor was drawn for one.
+func (b *Blinker) animate() {
+	for {
+		b.Lock()
+		p := b.Period
+		b.Unlock()
+		if p <= 0 {
+			p = NewBlinker().Period
+		}
+		time.Sleep(p)
+
+		b.Lock()
+		active := time.Since(b.drawn) < 2*p
+		if !active {
+			b.running = false
+		}
+		redraw := b.Redraw
+		b.Unlock()
+
+		if !active {
+			return
+		}
+		if redraw != nil {
+			redraw()
+		}
+	}
+}
+
+// SoftCursorCell returns c drawn as a cursor of style s, for terminals
+// where the hardware cursor is not wanted: in reverse video for blocks and
+// the default shape, underlined for underlines, as a bar on blank cells;
+// bars over text are underlined since a cell cannot hold both. Blinking
+// cursors follow CursorBlinker.
+func SoftCursorCell(c Cell, s CursorStyle) Cell {
+	if s.Blink && !CursorBlinker.On() {
+		return c
+	}
+	switch s.Shape {
+	case CursorUnderline:
+		c.Fg |= AttrUnderline
+	case CursorBar:
+		if c.Ch == ' ' {
+			c.Ch = '▏'
+		} else {
+			c.Fg |= AttrUnderline
+		}
+	default:
+		c.Fg |= AttrReverse
+	}
+	return c
+}
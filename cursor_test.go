@@ -0,0 +1,119 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlaceCursor(t *testing.T) {
+	h := NewHeadless(10, 3)
+	old := screen
+	screen = h
+	defer func() { screen = old }()
+	defer resetCursor()
+
+	ti := NewTextInput()
+	ti.Width = 10
+	ti.Text, ti.Cursor = "abc", 2
+	ti.HardwareCursor = true
+	ti.CursorStyle = CursorStyle{Shape: CursorBar, Blink: true}
+	render(ti)
+	x, y, s, ok := h.Cursor()
+	if !ok || x != 3 || y != 1 || s != ti.CursorStyle {
+		t.Errorf("unexpected cursor %v at %d,%d, shown %v", s, x, y, ok)
+	}
+	if c := h.Screen().At(3, 1); c.Fg&AttrReverse != 0 {
+		t.Error("unexpected reverse video cursor cell")
+	}
+
+	ti.ShowCursor = false
+	render(ti)
+	if _, _, _, ok := h.Cursor(); ok {
+		t.Error("expected a hidden cursor")
+	}
+}
+
+// cursorScreen counts the cursors shown on a Headless screen.
+type cursorScreen struct {
+	*Headless
+	shows int
+}
+
+func (s *cursorScreen) showCursor(x, y int, st CursorStyle) {
+	s.shows++
+	s.Headless.showCursor(x, y, st)
+}
+
+func TestPlaceCursorPartialRender(t *testing.T) {
+	h := &cursorScreen{Headless: NewHeadless(20, 6)}
+	old := screen
+	screen = h
+	defer func() { screen = old }()
+	defer resetCursor()
+
+	ti := NewTextInput()
+	ti.Width = 10
+	ti.Text, ti.Cursor = "abc", 2
+	ti.HardwareCursor = true
+	p := NewPar("other")
+	p.Y, p.Width, p.Height = 3, 10, 3
+	render(ti, p)
+	render(p)
+	if x, y, _, ok := h.Cursor(); !ok || x != 3 || y != 1 {
+		t.Errorf("cursor should stay on the input left on screen, got %d,%d shown %v", x, y, ok)
+	}
+	if h.shows != 2 {
+		t.Errorf("cursor should be moved back after every flush, shown %d times", h.shows)
+	}
+
+	// drawn over, the input loses it
+	p.Y = 0
+	render(p)
+	if _, _, _, ok := h.Cursor(); ok {
+		t.Error("expected the cursor hidden under the widget drawn over it")
+	}
+}
+
+func TestCursorStyleDECSCUSR(t *testing.T) {
+	for s, want := range map[CursorStyle]string{
+		{}:                                "\033[0 q",
+		{Shape: CursorBlock, Blink: true}: "\033[1 q",
+		{Shape: CursorUnderline}:          "\033[4 q",
+		{Shape: CursorBar}:                "\033[6 q",
+	} {
+		if got := s.decscusr(); got != want {
+			t.Errorf("%v: expected %q, got %q", s, want, got)
+		}
+	}
+}
+
+func TestBlinker(t *testing.T) {
+	b := NewBlinker()
+	b.Period = time.Second
+	b.Reset()
+	now := b.reset
+	for d, want := range map[time.Duration]bool{
+		0: true, 999 * time.Millisecond: true, time.Second: false, 2500 * time.Millisecond: true,
+	} {
+		if on := b.on(now.Add(d)); on != want {
+			t.Errorf("at %v: expected %v, got %v", d, want, on)
+		}
+	}
+}
+
+func TestSoftCursorCell(t *testing.T) {
+	c := Cell{Ch: 'a'}
+	if got := SoftCursorCell(c, CursorStyle{}); got.Fg != AttrReverse {
+		t.Errorf("expected a reverse block, got %v", got)
+	}
+	if got := SoftCursorCell(c, CursorStyle{Shape: CursorUnderline}); got.Fg != AttrUnderline {
+		t.Errorf("expected an underline, got %v", got)
+	}
+	if got := SoftCursorCell(Cell{Ch: ' '}, CursorStyle{Shape: CursorBar}); got.Ch != '▏' {
+		t.Errorf("expected a bar, got %v", got)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "time"
+
+// decimCache keeps the series of a LineChart as zoomed and downsampled on
+// the last frame, so that redrawing or panning a long history does not go
+// over all of its points again. Entries are told apart by the first point
+// and the length of the slices they were made of, and by the generation of
+// the series: appending to a series, or replacing it, makes a new entry,
+// and so does a change to its points through AddPoint or SetPoint. Other
+// changes to the values of a slice in place are not seen; ClearCache
+// forgets the entries. The zoom of a series grown by whole groups is
+// extended with the new ones only.
+type decimCache struct {
+	zoom  map[string]decimEntry
+	down  map[string]decimEntry
+	zoomT timesEntry
+	downT timesEntry
+}
+
+// decimEntry is a series decimated with the parameters param and mode:
+// the number of points per group and whether they give pairs for zooms,
+// the number of points and the policy for downsamples.
+type decimEntry struct {
+	base  *float64
+	n     int
+	gen   int // of the series when made, see seriesGen
+	param int
+	mode  string
+	out   []float64
+}
+
+// seriesGen counts the changes to a series made by the methods of
+// LineChart: gen counts them all, rewrite is gen as of the last one
+// changing points already there, which makes the entries of the series
+// made before it stale; Append only adds points.
+type seriesGen struct {
+	gen, rewrite int
+}
+
+// stale tells if e was made of the points of a series before they changed.
+func (g seriesGen) stale(e decimEntry) bool {
+	return e.gen < g.rewrite
+}
+
+// changed counts a change to series, rewrite if it changed points already
+// there rather than adding new ones.
+func (lc *LineChart) changed(series string, rewrite bool) {
+	if lc.gens == nil {
+		lc.gens = make(map[string]seriesGen)
+	}
+	g := lc.gens[series]
+	g.gen++
+	if rewrite {
+		g.rewrite = g.gen
+	}
+	lc.gens[series] = g
+}
+
+// timesEntry is to Times what decimEntry is to series.
+type timesEntry struct {
+	base  *time.Time
+	n     int
+	param int
+	mode  string
+	out   []time.Time
+}
+
+// ClearCache forgets the series zoomed and downsampled on the last frame,
+// to be called after changing their points in place.
+func (lc *LineChart) ClearCache() {
+	lc.decim = decimCache{}
+}
+
+func pairMode(pair bool) string {
+	if pair {
+		return "pair"
+	}
+	return "max"
+}
+
+// zoomPrefix returns how much of out, zoomed by groups of z from a series
+// of n points starting at base, is the zoom of the series of m points
+// starting at base too: groups being counted from the last point, a series
+// shorter by a number of groups, as panning leaves it, has the same groups
+// but the last ones.
+func zoomPrefix(n, m, z, per, out int) (int, bool) {
+	if m > n || (n-m)%z != 0 {
+		return 0, false
+	}
+	return out - (n-m)/z*per, true
+}
+
+// cachedZoom returns lc.zoomSeries(d, z), reusing the zoom of key on the
+// last frame if it holds it and g says it is not stale, x being the
+// minMaxIndex of d if any.
+func (lc *LineChart) cachedZoom(prev map[string]decimEntry, key string, d []float64, g seriesGen, z int, x *minMaxIndex) []float64 {
+	if len(d) == 0 {
+		return lc.zoomSeries(d, z)
+	}
+	pair := lc.pointsPerCell() == 2
+	per := 1
+	if pair {
+		per = 2
+	}
+	if e, ok := prev[key]; ok && !g.stale(e) && e.base == &d[0] && e.param == z && e.mode == pairMode(pair) {
+		if k, ok := zoomPrefix(e.n, len(d), z, per, len(e.out)); ok {
+			lc.decim.zoom[key] = e
+			return e.out[:k:k]
+		}
+		if len(d) > e.n && (len(d)-e.n)%z == 0 {
+			// grown by whole groups
+			e.out = append(e.out[:len(e.out):len(e.out)], lc.zoomIndexed(d[e.n:], z, nil)...)
+			e.n = len(d)
+			lc.decim.zoom[key] = e
+			return e.out
+		}
+	}
+	out := lc.zoomIndexed(d, z, x)
+	lc.decim.zoom[key] = decimEntry{base: &d[0], n: len(d), gen: g.gen, param: z, mode: pairMode(pair), out: out}
+	return out
+}
+
+// cachedZoomTimes returns lc.zoomTimes(ts, z), cached like cachedZoom.
+func (lc *LineChart) cachedZoomTimes(ts []time.Time, z int) []time.Time {
+	if len(ts) == 0 {
+		return lc.zoomTimes(ts, z)
+	}
+	pair := lc.pointsPerCell() == 2
+	per := 1
+	if pair {
+		per = 2
+	}
+	e := lc.decim.zoomT
+	if e.base == &ts[0] && e.param == z && e.mode == pairMode(pair) {
+		if k, ok := zoomPrefix(e.n, len(ts), z, per, len(e.out)); ok {
+			return e.out[:k:k]
+		}
+		if len(ts) > e.n && (len(ts)-e.n)%z == 0 {
+			e.out = append(e.out[:len(e.out):len(e.out)], lc.zoomTimes(ts[e.n:], z)...)
+			e.n = len(ts)
+			lc.decim.zoomT = e
+			return e.out
+		}
+	}
+	out := lc.zoomTimes(ts, z)
+	lc.decim.zoomT = timesEntry{base: &ts[0], n: len(ts), param: z, mode: pairMode(pair), out: out}
+	return out
+}
+
+// cachedDownsample returns downsample(d, n, lc.Downsample), reusing that
+// of key on the last frame if it was made of the same points, g telling
+// if they changed since.
+func (lc *LineChart) cachedDownsample(prev map[string]decimEntry, key string, d []float64, g seriesGen, n int) []float64 {
+	if len(d) <= n || n <= 0 {
+		return d
+	}
+	if e, ok := prev[key]; ok && !g.stale(e) && e.base == &d[0] && e.n == len(d) && e.param == n && e.mode == lc.Downsample {
+		lc.decim.down[key] = e
+		return e.out
+	}
+	out := downsample(d, n, lc.Downsample)
+	lc.decim.down[key] = decimEntry{base: &d[0], n: len(d), gen: g.gen, param: n, mode: lc.Downsample, out: out}
+	return out
+}
+
+// cachedDownsampleTimes returns downsampleTimes(ts, n), cached like
+// cachedDownsample.
+func (lc *LineChart) cachedDownsampleTimes(ts []time.Time, n int) []time.Time {
+	if len(ts) <= n || n <= 0 {
+		return ts
+	}
+	e := lc.decim.downT
+	if e.base == &ts[0] && e.n == len(ts) && e.param == n {
+		return e.out
+	}
+	out := downsampleTimes(ts, n)
+	lc.decim.downT = timesEntry{base: &ts[0], n: len(ts), param: n, out: out}
+	return out
+}
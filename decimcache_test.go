@@ -0,0 +1,114 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineChartCachedZoom(t *testing.T) {
+	lc := NewLineChart()
+	d := make([]float64, 103)
+	for i := range d {
+		d[i] = float64(i * 7 % 13)
+	}
+	lc.decim.zoom = make(map[string]decimEntry)
+	lc.cachedZoom(nil, "s", d, seriesGen{}, 4, nil)
+	for off := 0; off < len(d); off += 4 {
+		prev := lc.decim.zoom
+		lc.decim.zoom = make(map[string]decimEntry)
+		got := lc.cachedZoom(prev, "s", d[:len(d)-off], seriesGen{}, 4, nil)
+		if want := lc.zoomSeries(d[:len(d)-off], 4); !reflect.DeepEqual(got, want) {
+			t.Fatalf("offset %d: %v, want %v", off, got, want)
+		}
+		if e := lc.decim.zoom["s"]; e.n != len(d) {
+			t.Fatalf("offset %d: the zoom of %d points was dropped for %d", off, len(d), e.n)
+		}
+	}
+
+	// an offset splitting a group is zoomed again
+	prev := lc.decim.zoom
+	lc.decim.zoom = make(map[string]decimEntry)
+	lc.cachedZoom(prev, "s", d[:len(d)-3], seriesGen{}, 4, nil)
+	if e := lc.decim.zoom["s"]; e.n != len(d)-3 {
+		t.Errorf("expected a new entry of %d points, got %d", len(d)-3, e.n)
+	}
+}
+
+func TestLineChartDecimCache(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 30
+	lc.Height = 10
+	lc.Zoom = 4
+	lc.Downsample = "max"
+	for i := 0; i < 1000; i++ {
+		lc.Data["up"] = append(lc.Data["up"], float64(i%10))
+	}
+	lc.Buffer()
+	z := lc.decim.zoom["data/up"]
+	down := lc.decim.down["data/up"]
+	if z.n != 1000 || down.out == nil {
+		t.Fatalf("expected the zoom and downsample to be cached, got %d points and %v", z.n, down.out)
+	}
+
+	// redrawing reuses both
+	lc.Buffer()
+	if e := lc.decim.down["data/up"]; &e.out[0] != &down.out[0] {
+		t.Error("expected the downsample to be reused")
+	}
+	// panning reuses the zoom
+	lc.ScrollLeft()
+	lc.Buffer()
+	if e := lc.decim.zoom["data/up"]; &e.out[0] != &z.out[0] {
+		t.Error("expected the zoom to be reused when panning")
+	}
+	// appending makes a new one
+	lc.ScrollToEnd()
+	lc.Data["up"] = append(lc.Data["up"], 5)
+	lc.Buffer()
+	if e := lc.decim.zoom["data/up"]; e.n != 1001 {
+		t.Errorf("expected a zoom of the 1001 points, got %d", e.n)
+	}
+	delete(lc.Data, "up")
+	lc.Data["down"] = []float64{1, 2}
+	lc.Buffer()
+	if _, ok := lc.decim.zoom["data/up"]; ok {
+		t.Error("expected the zoom of a removed series to be dropped")
+	}
+	lc.ClearCache()
+	if lc.decim.zoom != nil {
+		t.Error("expected ClearCache to drop the entries")
+	}
+}
+
+func TestLineChartDecimCacheChanges(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 30
+	lc.Height = 10
+	lc.Zoom = 2
+	lc.MaxPoints = 8
+	z := lc.zoom() * lc.pointsPerCell()
+	check := func(when string) {
+		lc.Buffer()
+		got := lc.decim.zoom["data/s"].out
+		if want := lc.zoomSeries(lc.Data["s"], z); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: drew %v, want %v", when, got, want)
+		}
+	}
+
+	for i := 0; i < 9; i++ {
+		lc.AddPoint("s", float64(i))
+	}
+	check("first frame")
+	// the ring wraps, its window back on the same points of the buffer
+	for i := 0; i < 8; i++ {
+		lc.AddPoint("s", float64(100+i))
+	}
+	check("after the ring wrapped")
+
+	lc.SetPoint("s", 0, -50)
+	check("after a point changed in place")
+}
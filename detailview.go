@@ -0,0 +1,263 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DetailView describes a value as "key: value" rows, the values of sibling
+// keys aligned and wrapped to the width of the view. Maps, structs and
+// slices in Data are shown indented under their key; structs show their
+// exported fields, named by their json tag if any.
+// ValueColors styles the values of some keys, given by name or by dotted
+// path from Data, e.g. "status.phase".
+/*
+  dv := termui.NewDetailView()
+  dv.BorderLabel = "pod"
+  dv.Data = map[string]interface{}{
+      "name": "web-7f9c",
+      "status": map[string]string{"phase": "Running", "ip": "10.0.3.7"},
+      "ports": []int{80, 443},
+  }
+  dv.ValueColors["status.phase"] = termui.ColorGreen
+*/
+type DetailView struct {
+	Block
+	Data        interface{}
+	KeyColor    Attribute
+	ValueColor  Attribute
+	ValueColors map[string]Attribute
+	Indent      int // columns per nesting level
+	offset      int // first row shown
+}
+
+// detailRow is a key of a DetailView with its value, or heading the rows
+// of its nested value.
+type detailRow struct {
+	depth  int
+	key    string
+	path   string
+	value  string
+	nested bool
+	keyW   int // width of the widest sibling key
+}
+
+// NewDetailView returns a new *DetailView with current theme.
+func NewDetailView() *DetailView {
+	dv := &DetailView{Block: *NewBlock()}
+	dv.KeyColor = ThemeAttr("detailview.key.fg")
+	dv.ValueColor = ThemeAttr("detailview.value.fg")
+	dv.ValueColors = make(map[string]Attribute)
+	dv.Indent = 2
+	return dv
+}
+
+// indirect follows the pointers and interfaces of v down to a non nil one.
+func indirect(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// detailKeys returns the keys and values of v, sorted for maps, or false
+// if v is shown as a single value.
+func detailKeys(v reflect.Value) ([]string, []reflect.Value, bool) {
+	if !v.IsValid() || isNil(v) {
+		return nil, nil, false
+	}
+	if _, ok := v.Interface().(fmt.Stringer); ok && v.Kind() != reflect.Map && v.Kind() != reflect.Slice {
+		return nil, nil, false
+	}
+	var ks []string
+	var vs []reflect.Value
+	switch v.Kind() {
+	case reflect.Map:
+		mk := v.MapKeys()
+		sort.Slice(mk, func(i, j int) bool { return fmt.Sprint(mk[i]) < fmt.Sprint(mk[j]) })
+		for _, k := range mk {
+			ks = append(ks, fmt.Sprint(k))
+			vs = append(vs, v.MapIndex(k))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			name := f.Name
+			if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			ks = append(ks, name)
+			vs = append(vs, v.Field(i))
+		}
+		if len(ks) == 0 {
+			return nil, nil, false
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, nil, false
+		}
+		for i := 0; i < v.Len(); i++ {
+			ks = append(ks, "-")
+			vs = append(vs, v.Index(i))
+		}
+	default:
+		return nil, nil, false
+	}
+	return ks, vs, true
+}
+
+// detailRows appends the rows describing v, at the nesting depth and under
+// the dotted path.
+func detailRows(rows []detailRow, v reflect.Value, depth int, path string) []detailRow {
+	ks, vs, ok := detailKeys(v)
+	if !ok {
+		return rows
+	}
+	keyW := 0
+	for _, k := range ks {
+		if w := strWidth(k); w > keyW {
+			keyW = w
+		}
+	}
+	for i, k := range ks {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		r := detailRow{depth: depth, key: k, path: p, keyW: keyW}
+		e := indirect(vs[i])
+		switch _, _, nested := detailKeys(e); {
+		case !e.IsValid() || isNil(e):
+			r.value = "<nil>"
+		case nested:
+			r.nested = true
+			rows = append(rows, r)
+			rows = detailRows(rows, e, depth+1, p)
+			continue
+		default:
+			r.value = fmt.Sprint(e.Interface())
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+// valueColor returns the color of the value of row r.
+func (dv *DetailView) valueColor(r detailRow) Attribute {
+	if c, ok := dv.ValueColors[r.path]; ok {
+		return c
+	}
+	if c, ok := dv.ValueColors[r.key]; ok {
+		return c
+	}
+	return dv.ValueColor
+}
+
+// lines returns the lines of the view, values wrapped to its width.
+func (dv *DetailView) lines() [][]Cell {
+	if dv.Data == nil {
+		return nil
+	}
+	var ls [][]Cell
+	for _, r := range detailRows(nil, indirect(reflect.ValueOf(dv.Data)), 0, "") {
+		indent := r.depth * dv.Indent
+		key := r.key + ":"
+		if r.key == "-" {
+			key = "-"
+		}
+		head := TextCells(strings.Repeat(" ", indent)+key, dv.KeyColor, dv.Bg)
+		if r.nested {
+			ls = append(ls, head)
+			continue
+		}
+		col := indent + r.keyW + 2
+		if r.key == "-" {
+			col = indent + 2
+		}
+		head = append(head, TextCells(strings.Repeat(" ", col-cellsWidth(head)), dv.ValueColor, dv.Bg)...)
+		w := dv.innerArea.Dx() - col
+		if w < 1 {
+			w = 1
+		}
+		for i, l := range wrapLines(TextCells(r.value, dv.valueColor(r), dv.Bg), w, 0) {
+			pre := head
+			if i > 0 {
+				pre = TextCells(strings.Repeat(" ", col), dv.ValueColor, dv.Bg)
+			}
+			ls = append(ls, append(append([]Cell(nil), pre...), l.cells...))
+		}
+	}
+	return ls
+}
+
+// HandleKey scrolls the view on <up>, <down>, <pageup> and <pagedown>, and
+// tells if the key was consumed.
+func (dv *DetailView) HandleKey(k EvtKbd) bool {
+	n := len(dv.lines()) - dv.innerArea.Dy()
+	if n < 0 {
+		n = 0
+	}
+	o := dv.offset
+	switch k.KeyStr {
+	case "<up>":
+		o--
+	case "<down>":
+		o++
+	case "<pageup>":
+		o -= dv.innerArea.Dy()
+	case "<pagedown>":
+		o += dv.innerArea.Dy()
+	default:
+		return false
+	}
+	dv.offset = clamp(o, 0, n)
+	return true
+}
+
+// Buffer implements Bufferer interface.
+func (dv *DetailView) Buffer() Buffer {
+	buf := dv.Block.Buffer()
+	if dv.drawState(buf) {
+		return buf
+	}
+	if dv.Skeleton && dv.Data == nil {
+		dv.drawSkeleton(buf, false)
+		return buf
+	}
+
+	ls := dv.lines()
+	if dv.offset > len(ls) {
+		dv.offset = len(ls)
+	}
+	for i, l := range ls[dv.offset:] {
+		if i >= dv.innerArea.Dy() {
+			break
+		}
+		x := dv.innerArea.Min.X
+		for _, c := range TruncateRight(l, dv.innerArea.Dx()) {
+			buf.Set(x, dv.innerArea.Min.Y+i, c)
+			x += c.Width()
+		}
+	}
+	return buf
+}
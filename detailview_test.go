@@ -0,0 +1,73 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetailView(t *testing.T) {
+	type port struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+		note string
+	}
+	dv := NewDetailView()
+	dv.Border = false
+	dv.Width = 30
+	dv.Height = 20
+	dv.Data = &map[string]interface{}{
+		"name":    "web-7f9c",
+		"status":  map[string]string{"phase": "Running", "ip": "10.0.3.7"},
+		"ports":   []port{{Name: "http", Port: 80}},
+		"owner":   nil,
+		"message": "back-off restarting the failed container web",
+	}
+	dv.ValueColors["status.phase"] = ColorGreen
+	buf := dv.Buffer()
+
+	var got []string
+	for y := 0; y < dv.innerArea.Dy(); y++ {
+		var b strings.Builder
+		for x := 0; x < dv.innerArea.Dx(); x++ {
+			b.WriteRune(buf.At(x, y).Ch)
+		}
+		if s := strings.TrimRight(b.String(), " \x00"); s != "" {
+			got = append(got, s)
+		}
+	}
+	want := []string{
+		"message: back-off restarting",
+		"         the failed container",
+		"         web",
+		"name:    web-7f9c",
+		"owner:   <nil>",
+		"ports:",
+		"  -",
+		"    name: http",
+		"    port: 80",
+		"status:",
+		"  ip:    10.0.3.7",
+		"  phase: Running",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if c := buf.At(9, 11); c.Fg != ColorGreen {
+		t.Errorf("status.phase drawn in %v, want green", c.Fg)
+	}
+
+	dv.Height = 5
+	dv.Buffer()
+	if !dv.HandleKey(EvtKbd{KeyStr: "<pagedown>"}) || dv.offset != 5 {
+		t.Errorf("offset %d after a page down, want 5", dv.offset)
+	}
+	dv.HandleKey(EvtKbd{KeyStr: "<pagedown>"})
+	dv.HandleKey(EvtKbd{KeyStr: "<pagedown>"})
+	if dv.offset != 7 {
+		t.Errorf("offset %d at the end, want 7", dv.offset)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"math"
+	"time"
+)
+
+// bucket returns the bounds of the i-th of n buckets splitting m points.
+func bucket(i, n, m int) (lo, hi int) {
+	return i * m / n, (i + 1) * m / n
+}
+
+// downsample returns d squeezed into n points by policy: the "mean", "min"
+// or "max" of n buckets of points, or the points of "lttb", the Largest
+// Triangle Three Buckets algorithm, which keeps the shape of the series.
+// Series of n points or less are returned as is.
+func downsample(d []float64, n int, policy string) []float64 {
+	m := len(d)
+	if m <= n || n <= 0 {
+		return d
+	}
+	if policy == "lttb" {
+		return lttb(d, n)
+	}
+	out := make([]float64, n)
+	for i := range out {
+		lo, hi := bucket(i, n, m)
+		v := d[lo]
+		for _, x := range d[lo+1 : hi] {
+			switch policy {
+			case "min":
+				v = math.Min(v, x)
+			case "max":
+				v = math.Max(v, x)
+			default:
+				v += x
+			}
+		}
+		if policy != "min" && policy != "max" {
+			v /= float64(hi - lo)
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// lttb picks n points of d: the first and last ones, and one of each of
+// n-2 buckets of the others, the one making the largest triangle with the
+// point picked before and the mean of the next bucket.
+func lttb(d []float64, n int) []float64 {
+	m := len(d)
+	out := make([]float64, n)
+	out[0] = d[0]
+	out[n-1] = d[m-1]
+	// buckets of the points between the first and the last
+	inner := func(i int) (lo, hi int) {
+		lo, hi = bucket(i, n-2, m-2)
+		return lo + 1, hi + 1
+	}
+	prev := 0
+	for i := 0; i < n-2; i++ {
+		lo, hi := inner(i)
+		nlo, nhi := m-1, m
+		if i+1 < n-2 {
+			nlo, nhi = inner(i + 1)
+		}
+		var nx, ny float64
+		for j := nlo; j < nhi; j++ {
+			nx += float64(j)
+			ny += d[j]
+		}
+		nx /= float64(nhi - nlo)
+		ny /= float64(nhi - nlo)
+
+		best, area := lo, -1.0
+		for j := lo; j < hi; j++ {
+			a := math.Abs((float64(prev)-nx)*(d[j]-d[prev]) - (float64(prev)-float64(j))*(ny-d[prev]))
+			if a > area {
+				best, area = j, a
+			}
+		}
+		out[i+1] = d[best]
+		prev = best
+	}
+	return out
+}
+
+// downsampleTimes returns the times of the points of downsample: the last
+// time of every bucket.
+func downsampleTimes(ts []time.Time, n int) []time.Time {
+	m := len(ts)
+	if m <= n || n <= 0 {
+		return ts
+	}
+	out := make([]time.Time, n)
+	for i := range out {
+		_, hi := bucket(i, n, m)
+		out[i] = ts[hi-1]
+	}
+	return out
+}
+
+// downsampled returns the series, snapshot and Times of lc squeezed into
+// the columns of the plot with the Downsample policy, reusing those of the
+// last frame, see decimCache.
+func (lc *LineChart) downsampled() (data, snapshot map[string][]float64, times []time.Time) {
+	n := (lc.innerArea.Max.X - 1 - lc.innerArea.Min.X - lc.labelYSpace) * lc.pointsPerCell()
+	prev := lc.decim.down
+	lc.decim.down = make(map[string]decimEntry, len(lc.Data))
+	data = make(map[string][]float64, len(lc.Data))
+	for name, d := range lc.Data {
+		data[name] = lc.cachedDownsample(prev, "data/"+name, d, lc.gens[name], n)
+	}
+	if lc.snapshot != nil {
+		snapshot = make(map[string][]float64, len(lc.snapshot))
+		for name, d := range lc.snapshot {
+			snapshot[name] = lc.cachedDownsample(prev, "snapshot/"+name, d, seriesGen{}, n)
+		}
+	}
+	return data, snapshot, lc.cachedDownsampleTimes(lc.Times, n)
+}
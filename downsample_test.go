@@ -0,0 +1,53 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDownsample(t *testing.T) {
+	d := []float64{1, 3, 2, 8, 4, 4, 0, 6}
+	for _, c := range []struct {
+		policy string
+		want   []float64
+	}{
+		{"mean", []float64{2, 5, 4, 3}},
+		{"min", []float64{1, 2, 4, 0}},
+		{"max", []float64{3, 8, 4, 6}},
+		{"lttb", []float64{1, 8, 0, 6}},
+	} {
+		if got := downsample(d, 4, c.policy); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: %v, want %v", c.policy, got, c.want)
+		}
+	}
+	if got := downsample(d, 10, "mean"); len(got) != len(d) {
+		t.Errorf("short series changed: %v", got)
+	}
+}
+
+func TestLineChartDownsample(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 30
+	lc.Height = 10
+	lc.YPadding = 0
+	lc.Downsample = "max"
+	for i := 0; i < 1000; i++ {
+		v := 1.0
+		if i == 10 {
+			v = 100 // an old spike, dropped without downsampling
+		}
+		lc.Data["cpu"] = append(lc.Data["cpu"], v)
+	}
+	lc.Buffer()
+	if lc.topValue != 100 {
+		t.Errorf("axis tops at %v, want the old spike", lc.topValue)
+	}
+	if len(lc.Data["cpu"]) != 1000 {
+		t.Errorf("Buffer left %d points, want 1000", len(lc.Data["cpu"]))
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"math"
+	"sort"
+)
+
+// endLabel is the label of a series right of the plot, see EndLabels.
+type endLabel struct {
+	text  string
+	color Attribute
+	y     int
+}
+
+// endLabelText returns the label of the series name, of points d.
+func endLabelText(name string, d []float64) string {
+	return name + " " + shortenFloatVal(d[len(d)-1])
+}
+
+// endLabelWidth returns the number of columns the labels of EndLabels take
+// right of the plot, a third of it at most.
+func (lc *LineChart) endLabelWidth() int {
+	if !lc.EndLabels {
+		return 0
+	}
+	w := 0
+	for name, d := range lc.Data {
+		if len(d) > 0 {
+			w = max(w, strWidth(endLabelText(name, d)))
+		}
+	}
+	if w == 0 {
+		return 0
+	}
+	return clamp(w+1, 0, lc.innerArea.Dx()/3)
+}
+
+// endRow returns the row of the last point of d, on the y axis in place.
+func (lc *LineChart) endRow(d []float64) int {
+	base := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3
+	p := lc.yPos(d[len(d)-1]) - lc.bottomValue
+	if lc.pointsPerCell() == 1 {
+		return base - int(p/lc.scale+0.5)
+	}
+	l := int(math.Floor(p/(lc.scale/4) + 0.5))
+	return base - int(math.Floor(float64(l)/4))
+}
+
+// spreadLabels moves the labels ls, sorted by row, to rows of their own
+// from top to bottom, as close to theirs as they fit, leaving out those
+// that do not.
+func spreadLabels(ls []endLabel, top, bottom int) []endLabel {
+	if n := bottom - top + 1; len(ls) > n {
+		ls = ls[:clamp(n, 0, len(ls))]
+	}
+	for i := range ls {
+		ls[i].y = clamp(ls[i].y, top, bottom)
+		if i > 0 && ls[i].y <= ls[i-1].y {
+			ls[i].y = ls[i-1].y + 1
+		}
+	}
+	for i := len(ls) - 1; i >= 0; i-- {
+		if ls[i].y > bottom {
+			ls[i].y = bottom
+		}
+		if i < len(ls)-1 && ls[i].y >= ls[i+1].y {
+			ls[i].y = ls[i+1].y - 1
+		}
+	}
+	return ls
+}
+
+// plotEndLabels draws the labels of EndLabels from column x, for the
+// series of left and right on their axes.
+func (lc *LineChart) plotEndLabels(x int, left, right map[string][]float64) Buffer {
+	buf := NewBuffer()
+	var ls []endLabel
+	add := func(data map[string][]float64) {
+		for name, d := range data {
+			if len(d) > 0 {
+				ls = append(ls, endLabel{text: endLabelText(name, d), color: lc.lineColor(name), y: lc.endRow(d)})
+			}
+		}
+	}
+	add(left)
+	lc.onRight(func() { add(right) })
+	sort.Slice(ls, func(i, j int) bool {
+		if ls[i].y != ls[j].y {
+			return ls[i].y < ls[j].y
+		}
+		return ls[i].text < ls[j].text
+	})
+
+	w := lc.endLabelSpace - 1
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	for _, l := range spreadLabels(ls, lc.innerArea.Min.Y, origY-1) {
+		cx := x
+		for _, c := range TruncateRight(TextCells(l.text, l.color, lc.Bg), w) {
+			buf.Set(cx, l.y, c)
+			cx += c.Width()
+		}
+	}
+	return buf
+}
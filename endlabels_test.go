@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpreadLabels(t *testing.T) {
+	ls := []endLabel{{y: 0}, {y: 3}, {y: 3}, {y: 5}, {y: 5}}
+	var got []int
+	for _, l := range spreadLabels(ls, 1, 5) {
+		got = append(got, l.y)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("spread to rows %v, want %v", got, want)
+	}
+	if n := len(spreadLabels(make([]endLabel, 4), 0, 1)); n != 2 {
+		t.Errorf("kept %d labels on 2 rows", n)
+	}
+}
+
+func TestLineChartEndLabels(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 40
+	lc.Height = 10
+	lc.EndLabels = true
+	lc.LineColor["rx"] = ColorGreen
+	lc.Data["rx"] = []float64{1, 4, 2, 8}
+	lc.Data["tx"] = []float64{2, 3, 5, 8}
+	buf := lc.Buffer()
+
+	rows := map[string]int{}
+	for y := 0; y < 10; y++ {
+		var sb strings.Builder
+		for x := 40 - lc.endLabelSpace + 1; x < 40; x++ {
+			sb.WriteRune(buf.At(x, y).Ch)
+		}
+		if s := strings.TrimRight(sb.String(), " \x00"); s != "" {
+			rows[s] = y
+		}
+	}
+	rx, ok1 := rows["rx 8.00"]
+	tx, ok2 := rows["tx 8.00"]
+	if !ok1 || !ok2 || rx == tx {
+		t.Fatalf("expected the labels on rows of their own, got %v", rows)
+	}
+	if c := buf.At(40-lc.endLabelSpace+1, rx); c.Fg != ColorGreen {
+		t.Errorf("expected the label in the color of its line, got %v", c.Fg)
+	}
+	if x := lc.innerArea.Max.X; x != 40 {
+		t.Errorf("inner area not restored, ends at %d", x)
+	}
+}
@@ -5,6 +5,8 @@
 package termui
 
 import (
+	"context"
+	"image"
 	"path"
 	"strconv"
 	"sync"
@@ -26,6 +28,7 @@ var sysEvtChs []chan Event
 
 type EvtKbd struct {
 	KeyStr string
+	Repeat int // consecutive repeats of a held key, 0 for a fresh press
 }
 
 func evtKbd(e termbox.Event) EvtKbd {
@@ -102,6 +105,7 @@ func crtTermboxEvt(e termbox.Event) Event {
 		m := EvtMouse{}
 		m.X = e.MouseX
 		m.Y = e.MouseY
+		m.Press = mousePress[e.Key]
 		ne.Path = "/sys/mouse"
 		ne.Data = m
 	}
@@ -121,14 +125,66 @@ type EvtMouse struct {
 
 type EvtErr error
 
-func hookTermboxEvt() {
+func sendSysEvt(e Event) {
+	for _, c := range sysEvtChs {
+		func(ch chan Event) {
+			ch <- e
+		}(c)
+	}
+}
+
+// hookTermboxEvt feeds the events read by poll, termbox.PollEvent but in
+// tests, to the event stream.
+func hookTermboxEvt(poll func() termbox.Event) {
 	for {
-		e := termbox.PollEvent()
+		feedSysEvt(crtTermboxEvt(poll()))
+	}
+}
+
+// feedSysEvt delivers a system event read from the terminal or injected
+// into a Headless screen.
+func feedSysEvt(ne Event) {
+	if _, ok := ne.Data.(EvtWnd); ok {
+		postResize(ne)
+		return
+	}
+	defaultFocus.feed(ne)
+}
+
+// deliverSysEvt sends ne to the event stream, once focus reports were
+// picked out of the keys.
+func deliverSysEvt(ne Event) {
+	if k, ok := ne.Data.(EvtKbd); ok {
+		ne.Data = defaultRepeats.feed(k, time.Now())
+	}
+	sendSysEvt(ne)
+	if m, ok := ne.Data.(EvtMouse); ok {
+		for _, ge := range defaultGestures.feed(m, time.Now()) {
+			sendSysEvt(ge)
+		}
+	}
+}
+
+// resizeCh holds the latest pending resize event. Resizes never block the
+// termbox poller: a resize not yet delivered is replaced by a newer one.
+var resizeCh = make(chan Event, 1)
 
-		for _, c := range sysEvtChs {
-			func(ch chan Event) {
-				ch <- crtTermboxEvt(e)
-			}(c)
+func postResize(e Event) {
+	if w, ok := e.Data.(EvtWnd); ok {
+		renderLock.Lock()
+		termWidth, termHeight = w.Width, w.Height
+		renderLock.Unlock()
+	}
+	for {
+		select {
+		case resizeCh <- e:
+			return
+		default:
+		}
+		select {
+		case <-resizeCh:
+			count("termui.events.dropped", 1)
+		default:
 		}
 	}
 }
@@ -149,6 +205,12 @@ type EvtStream struct {
 	sigStopLoop chan Event
 	Handlers    map[string]func(Event)
 	hook        func(Event)
+	pause       struct {
+		sync.Mutex
+		on   bool
+		held []Event
+	}
+	idle idleState
 }
 
 func NewEvtStream() *EvtStream {
@@ -203,9 +265,18 @@ func (es *EvtStream) Merge(name string, ec chan Event) {
 }
 
 func (es *EvtStream) Handle(path string, handler func(Event)) {
+	es.Lock()
+	defer es.Unlock()
 	es.Handlers[cleanPath(path)] = handler
 }
 
+// Handler returns the handler registered for path, nil if none is.
+func (es *EvtStream) Handler(path string) func(Event) {
+	es.RLock()
+	defer es.RUnlock()
+	return es.Handlers[cleanPath(path)]
+}
+
 func findMatch(mux map[string]func(Event), path string) string {
 	n := -1
 	pattern := ""
@@ -224,6 +295,8 @@ func findMatch(mux map[string]func(Event), path string) string {
 
 // Remove all existing defined Handlers from the map
 func (es *EvtStream) ResetHandlers() {
+	es.Lock()
+	defer es.Unlock()
 	for Path, _ := range es.Handlers {
 		delete(es.Handlers, Path)
 	}
@@ -238,23 +311,135 @@ func (es *EvtStream) Hook(f func(Event)) {
 	es.hook = f
 }
 
+func (es *EvtStream) dispatch(e Event) {
+	_, end := span(context.Background(), "termui.event", "path", e.Path, "type", e.Type)
+	defer end()
+	// bound widgets are updated before the handlers render them
+	if o, ok := e.Data.(*Observable); ok && e.Path == "/usr/observable" {
+		o.deliver()
+	}
+	// a Headless waiting for the events before this one, and their frames
+	if c, ok := e.Data.(headlessSync); ok {
+		if renderJobs != nil {
+			renderJobs <- []Bufferer{renderBarrier{}}
+		}
+		close(c)
+		return
+	}
+	// handlers run unlocked, free to register others
+	es.RLock()
+	var h func(Event)
+	if pattern := es.match(e.Path); pattern != "" {
+		h = es.Handlers[pattern]
+	}
+	es.RUnlock()
+	if h != nil {
+		h(e)
+	}
+	if es.hook != nil {
+		es.hook(e)
+	}
+}
+
 func (es *EvtStream) Loop() {
 	for e := range es.stream {
 		switch e.Path {
 		case "/sig/stoploop":
 			return
+		case "/sig/resume":
+			es.release()
+			continue
+		}
+		if es.throttle(e) || es.hold(e) {
+			continue
 		}
-		func(a Event) {
-			es.RLock()
-			defer es.RUnlock()
-			if pattern := es.match(a.Path); pattern != "" {
-				es.Handlers[pattern](a)
+		// events held before a Resume go first, those e resumes after it
+		es.release()
+		es.dispatch(e)
+		es.release()
+	}
+}
+
+// isLive tells if e is a tick/data event, which is held back while paused.
+// System, internal and replayed macro events are always delivered.
+func isLive(e Event) bool {
+	return e.From != "termbox" && e.From != "internal" && e.From != "macro" && e.From != "resize"
+}
+
+// hold queues e if the stream is paused. Timer events are coalesced so only
+// the latest tick of each timer is kept.
+func (es *EvtStream) hold(e Event) bool {
+	es.pause.Lock()
+	defer es.pause.Unlock()
+
+	if !es.pause.on || !isLive(e) {
+		return false
+	}
+	if e.Type == "timer" {
+		for i, h := range es.pause.held {
+			if h.Path == e.Path {
+				es.pause.held[i] = e
+				count("termui.events.dropped", 1)
+				return true
 			}
-		}(e)
-		if es.hook != nil {
-			es.hook(e)
 		}
 	}
+	es.pause.held = append(es.pause.held, e)
+	return true
+}
+
+// release delivers the events held during a pause once it is resumed.
+func (es *EvtStream) release() {
+	es.pause.Lock()
+	if es.pause.on || len(es.pause.held) == 0 {
+		es.pause.Unlock()
+		return
+	}
+	held := es.pause.held
+	es.pause.held = nil
+	es.pause.Unlock()
+
+	for _, e := range held {
+		es.dispatch(e)
+	}
+}
+
+// Pause holds back tick and data events until Resume is called.
+// Keyboard, mouse and window events are still delivered.
+func (es *EvtStream) Pause() {
+	es.pause.Lock()
+	es.pause.on = true
+	es.pause.Unlock()
+}
+
+// Resume delivers the events held since Pause and stops holding new ones.
+// Called outside of a handler, it wakes the loop to deliver them.
+func (es *EvtStream) Resume() {
+	es.pause.Lock()
+	wake := es.pause.on && len(es.pause.held) > 0
+	es.pause.on = false
+	es.pause.Unlock()
+	if wake {
+		go func() {
+			es.sigStopLoop <- Event{Path: "/sig/resume"}
+		}()
+	}
+}
+
+// Paused tells if es is currently paused.
+func (es *EvtStream) Paused() bool {
+	es.pause.Lock()
+	defer es.pause.Unlock()
+	return es.pause.on
+}
+
+// TogglePause pauses a running stream or resumes a paused one.
+func (es *EvtStream) TogglePause() {
+	if es.Paused() {
+		es.Resume()
+	} else {
+		es.Pause()
+	}
 }
 
 func (es *EvtStream) StopLoop() {
@@ -286,6 +471,44 @@ func StopLoop() {
 	DefaultEvtStream.StopLoop()
 }
 
+// pausedBadge marks the top right corner of the terminal while paused.
+type pausedBadge struct{}
+
+func (pausedBadge) text() string {
+	return " " + Msg("paused") + " "
+}
+
+func (pb pausedBadge) area() image.Rectangle {
+	w := strWidth(pb.text())
+	return image.Rect(termWidth-w, 0, termWidth, 1)
+}
+
+// Buffer implements Bufferer interface.
+func (pb pausedBadge) Buffer() Buffer {
+	buf := NewBuffer()
+	buf.SetArea(pb.area())
+	x := buf.Area.Min.X
+	for _, c := range TextCells(pb.text(), ThemeAttr("paused.fg")|AttrReverse, ThemeAttr("paused.bg")) {
+		buf.Set(x, 0, c)
+		x += c.Width()
+	}
+	return buf
+}
+
+// SetPauseKey registers key (e.g. "p" or "<space>") to toggle pausing of the
+// default event stream. While paused, timer and custom events are held and
+// a "PAUSED" badge is drawn; resuming delivers the held events.
+func SetPauseKey(key string) {
+	Handle("/sys/kbd/"+key, func(Event) {
+		DefaultEvtStream.TogglePause()
+		if DefaultEvtStream.Paused() {
+			Render(pausedBadge{})
+		} else {
+			ClearArea(pausedBadge{}.area(), ThemeAttr("bg"))
+		}
+	})
+}
+
 type EvtTimer struct {
 	Duration time.Duration
 	Count    uint64
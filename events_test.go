@@ -4,7 +4,12 @@
 
 package termui
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/nsf/termbox-go"
+)
 
 var ps = []string{
 	"",
@@ -35,3 +40,180 @@ func TestMatchScore(t *testing.T) {
 func TestCrtEvt(t *testing.T) {
 
 }
+
+func TestEvtStreamPause(t *testing.T) {
+	es := NewEvtStream()
+	got := []string{}
+	es.Handle("/", func(e Event) {
+		got = append(got, e.Path)
+	})
+
+	es.Pause()
+	for _, e := range []Event{
+		{From: "timer", Type: "timer", Path: "/timer/1s", Data: 1},
+		{From: "custom", Path: "/usr/a"},
+		{From: "timer", Type: "timer", Path: "/timer/1s", Data: 2},
+		{From: "termbox", Path: "/sys/kbd/q"},
+	} {
+		if !es.hold(e) {
+			es.dispatch(e)
+		}
+	}
+	if len(got) != 1 || got[0] != "/sys/kbd/q" {
+		t.Errorf("only system events should pass while paused, got %v", got)
+	}
+	if len(es.pause.held) != 2 || es.pause.held[0].Data != 2 {
+		t.Errorf("timer events should be coalesced, held %v", es.pause.held)
+	}
+
+	es.Resume()
+	es.release()
+	if len(got) != 3 || got[1] != "/timer/1s" || got[2] != "/usr/a" {
+		t.Errorf("held events should be released in order, got %v", got)
+	}
+}
+
+func TestEvtStreamResume(t *testing.T) {
+	es := NewEvtStream()
+	got := make(chan string, 4)
+	es.Handle("/", func(e Event) { got <- e.Path })
+	src := make(chan Event)
+	es.Merge("custom", src)
+	es.Init()
+	go es.Loop()
+	defer es.StopLoop()
+
+	es.Pause()
+	src <- Event{Path: "/usr/a"}
+	src <- Event{Path: "/usr/b"}
+	select {
+	case p := <-got:
+		t.Fatalf("%s delivered while paused", p)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// resumed outside of a handler, with no event following
+	es.Resume()
+	for _, want := range []string{"/usr/a", "/usr/b"} {
+		select {
+		case p := <-got:
+			if p != want {
+				t.Errorf("expected %s, got %s", want, p)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s still held after Resume", want)
+		}
+	}
+}
+
+func TestGestures(t *testing.T) {
+	gd := &gestureDetector{}
+	now := time.Now()
+	click := EvtMouse{X: 3, Y: 4, Press: "left"}
+
+	if es := gd.feed(click, now); len(es) != 0 {
+		t.Errorf("single click should not yield a gesture, got %v", es)
+	}
+	gd.feed(EvtMouse{X: 3, Y: 4, Press: "release"}, now)
+	if es := gd.feed(click, now.Add(100*time.Millisecond)); len(es) != 1 || es[0].Path != "/sys/gesture/dblclick" {
+		t.Errorf("expected a double click, got %v", es)
+	}
+	if es := gd.feed(click, now.Add(200*time.Millisecond)); len(es) != 1 || es[0].Path != "/sys/gesture/tripleclick" {
+		t.Errorf("expected a triple click, got %v", es)
+	}
+	if es := gd.feed(click, now.Add(time.Second)); len(es) != 0 {
+		t.Errorf("slow clicks should not yield a gesture, got %v", es)
+	}
+}
+
+func TestHookTermboxMouse(t *testing.T) {
+	old, oldGestures := sysEvtChs, defaultGestures
+	defer func() { sysEvtChs, defaultGestures = old, oldGestures }()
+	sysEvtChs = nil
+	defaultGestures = &gestureDetector{emit: sendSysEvt}
+	ch := NewSysEvtCh()
+
+	var evs []termbox.Event
+	for _, k := range []termbox.Key{termbox.MouseLeft, termbox.MouseRelease, termbox.MouseLeft, termbox.MouseRelease} {
+		evs = append(evs, termbox.Event{Type: termbox.EventMouse, Key: k, MouseX: 17, MouseY: 9})
+	}
+	// done is closed once the last event went through
+	done := make(chan bool)
+	go hookTermboxEvt(func() termbox.Event {
+		if len(evs) == 0 {
+			close(done)
+			select {}
+		}
+		e := evs[0]
+		evs = evs[1:]
+		return e
+	})
+
+	want := []string{"/sys/mouse", "/sys/mouse", "/sys/mouse", "/sys/gesture/dblclick", "/sys/mouse"}
+	for i, p := range want {
+		select {
+		case e := <-ch:
+			if e.Path != p {
+				t.Fatalf("event %d: expected %s, got %s", i, p, e.Path)
+			}
+			if m := e.Data.(EvtMouse); m.X != 17 || m.Y != 9 {
+				t.Errorf("event %d: unexpected position %d,%d", i, m.X, m.Y)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %d: expected %s, got none", i, p)
+		}
+	}
+	<-done
+}
+
+func TestKeyRepeat(t *testing.T) {
+	rt := &repeatTracker{}
+	now := time.Now()
+	down := EvtKbd{KeyStr: "<down>"}
+
+	k := down
+	for i := 0; i < 25; i++ {
+		k = rt.feed(down, now.Add(time.Duration(i)*30*time.Millisecond))
+	}
+	if k.Repeat != 24 || k.Step() != 4 {
+		t.Errorf("expected 24 repeats at step 4, got %d at step %d", k.Repeat, k.Step())
+	}
+
+	if k = rt.feed(down, now.Add(5*time.Second)); k.Repeat != 0 || k.Step() != 1 {
+		t.Error("a late key press should reset the repeat count")
+	}
+}
+
+func TestMacros(t *testing.T) {
+	es := NewEvtStream()
+	m := NewMacros(es)
+	m.Bind("q", "@")
+
+	feed := func(k string) {
+		e := Event{From: "termbox", Type: "keyboard", Path: "/sys/kbd/" + k}
+		es.dispatch(e)
+	}
+	feed("q")
+	feed("j")
+	feed("j")
+	feed("q")
+
+	if got := m.macros["@"]; len(got) != 2 || got[0].Path != "/sys/kbd/j" {
+		t.Errorf("expected the two j presses to be recorded, got %v", got)
+	}
+}
+
+func TestPostResizeCoalesces(t *testing.T) {
+	for _, w := range []int{80, 100, 120} {
+		postResize(Event{Path: "/sys/wnd/resize", Data: EvtWnd{Width: w, Height: 40}})
+	}
+	e := <-resizeCh
+	if w := e.Data.(EvtWnd).Width; w != 120 || termWidth != 120 {
+		t.Errorf("expected the latest resize to win, got %d", w)
+	}
+	select {
+	case e := <-resizeCh:
+		t.Errorf("unexpected pending resize %v", e)
+	default:
+	}
+}
@@ -88,6 +88,22 @@ func (tp *Tabpane) SetActiveRight() {
 	}
 }
 
+// SaveState implements termui.Stater, saving the active tab and tab scroll.
+func (tp *Tabpane) SaveState() ViewState {
+	return ViewState{Selected: tp.activeTabIndex, ScrollX: tp.offTabText}
+}
+
+// RestoreState implements termui.Stater.
+func (tp *Tabpane) RestoreState(s ViewState) {
+	if s.Selected < 0 || s.Selected >= len(tp.Tabs) {
+		return
+	}
+	tp.activeTabIndex = s.Selected
+	if s.ScrollX >= 0 && s.ScrollX <= tp.posTabText[tp.activeTabIndex] {
+		tp.offTabText = s.ScrollX
+	}
+}
+
 // Checks if left and right tabs are fully visible
 // if only left tabs are not visible return -1
 // if only right tabs are not visible return 1
@@ -0,0 +1,309 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"errors"
+	"image"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Font is a FIGlet font: every glyph is Height lines of text. Glyphs are
+// laid out at full width, FIGlet kerning and smushing rules are not applied.
+type Font struct {
+	Name   string
+	Height int
+	glyphs map[rune][]string
+}
+
+// Fonts holds the bundled fonts by name: "block", drawn with full blocks
+// five rows high, and "half", the same glyphs squeezed into three rows of
+// half blocks.
+var Fonts = map[string]*Font{
+	"block": blockFont(),
+	"half":  halfFont(),
+}
+
+// ParseFont reads a FIGlet font (.flf) from r.
+func ParseFont(r io.Reader) (*Font, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		return nil, errors.New("termui: empty font")
+	}
+	hdr := sc.Text()
+	if !strings.HasPrefix(hdr, "flf2a") || len(hdr) < 6 {
+		return nil, errors.New("termui: not a FIGlet font")
+	}
+	hardblank := string(hdr[5])
+	fs := strings.Fields(hdr[6:])
+	if len(fs) < 5 {
+		return nil, errors.New("termui: malformed FIGlet header")
+	}
+	h, err := strconv.Atoi(fs[0])
+	if err != nil || h <= 0 {
+		return nil, errors.New("termui: malformed FIGlet height")
+	}
+	comments, err := strconv.Atoi(fs[4])
+	if err != nil {
+		return nil, errors.New("termui: malformed FIGlet comment count")
+	}
+	for i := 0; i < comments && sc.Scan(); i++ {
+	}
+
+	f := &Font{Height: h, glyphs: make(map[rune][]string)}
+	glyph := func() ([]string, bool) {
+		ls := make([]string, h)
+		for i := range ls {
+			if !sc.Scan() {
+				return nil, false
+			}
+			l := strings.TrimRight(sc.Text(), " ")
+			if len(l) > 0 {
+				l = strings.TrimRight(l, l[len(l)-1:])
+			}
+			ls[i] = strings.Replace(l, hardblank, " ", -1)
+		}
+		return ls, true
+	}
+
+	// required ASCII and German glyphs, then code tagged ones
+	var required []rune
+	for c := rune(' '); c <= '~'; c++ {
+		required = append(required, c)
+	}
+	required = append(required, 'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß')
+	for _, c := range required {
+		ls, ok := glyph()
+		if !ok {
+			return f, nil
+		}
+		f.glyphs[c] = ls
+	}
+	for sc.Scan() {
+		tag := strings.Fields(sc.Text())
+		if len(tag) == 0 {
+			continue
+		}
+		c, err := strconv.ParseInt(tag[0], 0, 32)
+		if err != nil {
+			return f, nil
+		}
+		ls, ok := glyph()
+		if !ok {
+			break
+		}
+		f.glyphs[rune(c)] = ls
+	}
+	return f, sc.Err()
+}
+
+// LoadFont reads the FIGlet font file at path, named after the file.
+func LoadFont(path string) (*Font, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	f, err := ParseFont(r)
+	if f != nil {
+		f.Name = strings.TrimSuffix(path[strings.LastIndex(path, "/")+1:], ".flf")
+	}
+	return f, err
+}
+
+func (f *Font) glyph(c rune) []string {
+	if g, ok := f.glyphs[c]; ok {
+		return g
+	}
+	return f.glyphs[unicode.ToUpper(c)]
+}
+
+// Render returns the Height lines of s drawn in f. Characters missing from
+// the font are skipped.
+func (f *Font) Render(s string) []string {
+	ls := make([]string, f.Height)
+	for _, c := range s {
+		g := f.glyph(c)
+		if g == nil {
+			continue
+		}
+		w := 0
+		for _, l := range g {
+			if n := strWidth(l); n > w {
+				w = n
+			}
+		}
+		for i := range ls {
+			l := ""
+			if i < len(g) {
+				l = g[i]
+			}
+			ls[i] += l + strings.Repeat(" ", w-strWidth(l))
+		}
+	}
+	return ls
+}
+
+// Banner draws s in font f into buf with its top left corner at (x, y).
+// Blank font cells are left untouched.
+func Banner(buf Buffer, x, y int, s string, f *Font, fg, bg Attribute) {
+	for i, l := range f.Render(s) {
+		cx := x
+		for _, c := range TextCells(l, fg, bg) {
+			if c.Ch != ' ' {
+				buf.Set(cx, y+i, c)
+			}
+			cx += c.Width()
+		}
+	}
+}
+
+// glyphs3x5 is the bitmap the bundled fonts are built from.
+var glyphs3x5 = map[rune]string{
+	'0': "### #.# #.# #.# ###", '1': ".#. ##. .#. .#. ###",
+	'2': "### ..# ### #.. ###", '3': "### ..# ### ..# ###",
+	'4': "#.# #.# ### ..# ..#", '5': "### #.. ### ..# ###",
+	'6': "### #.. ### #.# ###", '7': "### ..# ..# ..# ..#",
+	'8': "### #.# ### #.# ###", '9': "### #.# ### ..# ###",
+	'A': ".#. #.# ### #.# #.#", 'B': "##. #.# ##. #.# ##.",
+	'C': ".## #.. #.. #.. .##", 'D': "##. #.# #.# #.# ##.",
+	'E': "### #.. ##. #.. ###", 'F': "### #.. ##. #.. #..",
+	'G': ".## #.. #.# #.# .##", 'H': "#.# #.# ### #.# #.#",
+	'I': "### .#. .#. .#. ###", 'J': "..# ..# ..# #.# .#.",
+	'K': "#.# #.# ##. #.# #.#", 'L': "#.. #.. #.. #.. ###",
+	'M': "#.# ### ### #.# #.#", 'N': "##. #.# #.# #.# #.#",
+	'O': ".#. #.# #.# #.# .#.", 'P': "##. #.# ##. #.. #..",
+	'Q': ".#. #.# #.# ##. .##", 'R': "##. #.# ##. #.# #.#",
+	'S': ".## #.. .#. ..# ##.", 'T': "### .#. .#. .#. .#.",
+	'U': "#.# #.# #.# #.# ###", 'V': "#.# #.# #.# #.# .#.",
+	'W': "#.# #.# ### ### #.#", 'X': "#.# #.# .#. #.# #.#",
+	'Y': "#.# #.# .#. .#. .#.", 'Z': "### ..# .#. #.. ###",
+	' ': "... ... ... ... ...", '!': ".#. .#. .#. ... .#.",
+	'.': "... ... ... ... .#.", ',': "... ... ... .#. #..",
+	':': "... .#. ... .#. ...", '-': "... ... ### ... ...",
+	'+': "... .#. ### .#. ...", '/': "..# ..# .#. #.. #..",
+	'?': "##. ..# .#. ... .#.", '\'': ".#. .#. ... ... ...",
+	'(': "..# .#. .#. .#. ..#", ')': "#.. .#. .#. .#. #..",
+	'%': "#.# ..# .#. #.. #.#", '_': "... ... ... ... ###",
+	'=': "... ### ... ### ...", '#': "#.# ### #.# ### #.#",
+}
+
+// bitmap returns the rows of the 3x5 glyph c.
+func bitmap(c rune) [][]bool {
+	rows := strings.Fields(glyphs3x5[c])
+	px := make([][]bool, len(rows))
+	for i, r := range rows {
+		for _, b := range r {
+			px[i] = append(px[i], b == '#')
+		}
+	}
+	return px
+}
+
+func blockFont() *Font {
+	f := &Font{Name: "block", Height: 5, glyphs: make(map[rune][]string)}
+	for c := range glyphs3x5 {
+		g := make([]string, 5)
+		for i, row := range bitmap(c) {
+			for _, on := range row {
+				if on {
+					g[i] += "█"
+				} else {
+					g[i] += " "
+				}
+			}
+			g[i] += " "
+		}
+		f.glyphs[c] = g
+	}
+	return f
+}
+
+func halfFont() *Font {
+	f := &Font{Name: "half", Height: 3, glyphs: make(map[rune][]string)}
+	for c := range glyphs3x5 {
+		px := append(bitmap(c), make([]bool, 3))
+		g := make([]string, 3)
+		for i := range g {
+			for x := 0; x < 3; x++ {
+				top, bottom := px[2*i][x], px[2*i+1][x]
+				switch {
+				case top && bottom:
+					g[i] += "█"
+				case top:
+					g[i] += "▀"
+				case bottom:
+					g[i] += "▄"
+				default:
+					g[i] += " "
+				}
+			}
+			g[i] += " "
+		}
+		f.glyphs[c] = g
+	}
+	return f
+}
+
+// BigText shows a line of text in a FIGlet font, e.g. a splash screen title
+// or a section header.
+/*
+  bt := termui.NewBigText("termui")
+  bt.Font = termui.Fonts["half"]
+  bt.Align = termui.AlignCenter
+  bt.Width = 40
+  bt.Height = 5
+*/
+type BigText struct {
+	Block
+	Text        string
+	Font        *Font
+	TextFgColor Attribute
+	TextBgColor Attribute
+	Align       Align
+}
+
+// NewBigText returns a new *BigText with given text and current theme.
+func NewBigText(s string) *BigText {
+	bt := &BigText{Block: *NewBlock(), Text: s}
+	bt.Font = Fonts["block"]
+	bt.TextFgColor = ThemeAttr("bigtext.fg")
+	bt.TextBgColor = ThemeAttr("bigtext.bg")
+	bt.Align = AlignLeft | AlignTop
+	bt.Height = 7
+	return bt
+}
+
+// Buffer implements Bufferer interface.
+func (bt *BigText) Buffer() Buffer {
+	buf := bt.Block.Buffer()
+	if bt.Font == nil {
+		return buf
+	}
+
+	w := 0
+	for _, l := range bt.Font.Render(bt.Text) {
+		if n := strWidth(l); n > w {
+			w = n
+		}
+	}
+	r := AlignArea(bt.innerArea, image.Rect(0, 0, w, bt.Font.Height).Add(bt.innerArea.Min), bt.Align)
+
+	inner := NewBuffer()
+	inner.SetArea(bt.innerArea)
+	Banner(inner, r.Min.X, r.Min.Y, bt.Text, bt.Font, bt.TextFgColor, bt.TextBgColor)
+	for p, c := range inner.CellMap {
+		if p.In(bt.innerArea) {
+			buf.Set(p.X, p.Y, c)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+const testFlf = `flf2a$ 2 2 4 0 1
+tiny test font
+$$@
+$$@@
+`
+
+func TestParseFont(t *testing.T) {
+	src := testFlf
+	for c := '!'; c <= '~'; c++ {
+		src += string(c) + "$@\n" + string(c) + "$@@\n"
+	}
+	f, err := ParseFont(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls := f.Render("a b")
+	if ls[0] != "a   b " || ls[1] != "a   b " {
+		t.Errorf("unexpected render %q", ls)
+	}
+}
+
+func TestBundledFonts(t *testing.T) {
+	ls := Fonts["half"].Render("Hi")
+	want := []string{"█ █ ▀█▀ ", "█▀█  █  ", "▀ ▀ ▀▀▀ "}
+	for i := range want {
+		if ls[i] != want[i] {
+			t.Errorf("line %d: expected %q, got %q", i, want[i], ls[i])
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+// Flasher remembers the values a widget drew and highlights the ones that
+// changed since the previous frame with a background fading through Colors.
+// Table, List and StatTile use it when their Flash field is set.
+/*
+  f := termui.NewFlasher()
+  f.Colors = []termui.Attribute{
+      termui.ColorRGB(5, 5, 0), termui.ColorRGB(4, 4, 0),
+      termui.ColorRGB(3, 3, 0), termui.ColorRGB(2, 2, 0),
+  }
+  f.Redraw = func() { termui.Render(table) }
+  table.Flash = f
+*/
+type Flasher struct {
+	sync.Mutex
+	Duration time.Duration // how long a change stays highlighted
+	Colors   []Attribute   // background steps, from the change to the end
+	Step     time.Duration // interval Redraw is called at while fading
+	Redraw   func()        // nil leaves redrawing to the app's own timers
+	values   map[string]string
+	changed  map[string]time.Time
+	running  bool
+}
+
+// NewFlasher returns a *Flasher highlighting changes in yellow for a second.
+func NewFlasher() *Flasher {
+	return &Flasher{
+		Duration: time.Second,
+		Colors:   []Attribute{ColorYellow},
+		Step:     100 * time.Millisecond,
+		values:   make(map[string]string),
+		changed:  make(map[string]time.Time),
+	}
+}
+
+// Observe records the value drawn under key and tells if it changed. The
+// first value seen for a key is not a change.
+func (f *Flasher) Observe(key, value string) bool {
+	return f.observe(key, value, time.Now())
+}
+
+func (f *Flasher) observe(key, value string, now time.Time) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	old, seen := f.values[key]
+	f.values[key] = value
+	if !seen || old == value {
+		return false
+	}
+	f.changed[key] = now
+	if f.Redraw != nil && !f.running {
+		f.running = true
+		go f.animate()
+	}
+	return true
+}
+
+// Color returns the background key is highlighted with, if any.
+func (f *Flasher) Color(key string) (Attribute, bool) {
+	return f.color(key, time.Now())
+}
+
+func (f *Flasher) color(key string, now time.Time) (Attribute, bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	t, ok := f.changed[key]
+	if !ok || len(f.Colors) == 0 {
+		return 0, false
+	}
+	d := now.Sub(t)
+	if d >= f.Duration {
+		delete(f.changed, key)
+		return 0, false
+	}
+	return f.Colors[int(d)*len(f.Colors)/int(f.Duration)], true
+}
+
+// Reset forgets all recorded values and running highlights.
+func (f *Flasher) Reset() {
+	f.Lock()
+	f.values = make(map[string]string)
+	f.changed = make(map[string]time.Time)
+	f.Unlock()
+}
+
+// clone returns a *Flasher with the settings of f and no recorded values,
+// or nil if f is nil.
+func (f *Flasher) clone() *Flasher {
+	if f == nil {
+		return nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	nf := NewFlasher()
+	nf.Duration = f.Duration
+	nf.Colors = f.Colors
+	nf.Step = f.Step
+	nf.Redraw = f.Redraw
+	return nf
+}
+
+// animate calls Redraw every Step until all highlights are over.
+func (f *Flasher) animate() {
+	for {
+		time.Sleep(f.Step)
+
+		f.Lock()
+		active := false
+		now := time.Now()
+		for _, t := range f.changed {
+			if now.Sub(t) < f.Duration+f.Step {
+				active = true
+				break
+			}
+		}
+		if !active {
+			f.running = false
+		}
+		redraw := f.Redraw
+		f.Unlock()
+
+		if redraw != nil {
+			redraw()
+		}
+		if !active {
+			return
+		}
+	}
+}
+
+// flashBg returns the background of the value drawn under key by f, or bg
+// when f is nil or the value is not highlighted.
+func flashBg(f *Flasher, key, value string, bg Attribute) Attribute {
+	if f == nil {
+		return bg
+	}
+	f.Observe(key, value)
+	if c, ok := f.Color(key); ok {
+		return c
+	}
+	return bg
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlasherFade(t *testing.T) {
+	f := NewFlasher()
+	f.Colors = []Attribute{ColorRed, ColorYellow}
+	now := time.Now()
+
+	if f.observe("a", "1", now) {
+		t.Error("first value must not flash")
+	}
+	if !f.observe("a", "2", now) {
+		t.Error("expected a change")
+	}
+	if c, ok := f.color("a", now.Add(100*time.Millisecond)); !ok || c != ColorRed {
+		t.Errorf("expected red, got %v %v", c, ok)
+	}
+	if c, ok := f.color("a", now.Add(600*time.Millisecond)); !ok || c != ColorYellow {
+		t.Errorf("expected yellow, got %v %v", c, ok)
+	}
+	if _, ok := f.color("a", now.Add(time.Second)); ok {
+		t.Error("expected the highlight to be over")
+	}
+}
+
+func TestTableFlash(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"a", "1"}}
+	table.Flash = NewFlasher()
+	table.Analysis()
+	table.SetSize()
+	table.Buffer()
+
+	table.Rows[0][1] = "2"
+	buf := table.Buffer()
+	if c := buf.At(7, 1); c.Ch != '2' || c.Bg != ColorYellow {
+		t.Errorf("expected a highlighted 2, got %q %v", c.Ch, c.Bg)
+	}
+	if c := buf.At(3, 1); c.Bg == ColorYellow {
+		t.Error("unchanged cell must not be highlighted")
+	}
+}
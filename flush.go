@@ -0,0 +1,317 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"hash/fnv"
+	"image"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/gizak/termui/cell"
+)
+
+// FlushStrategy is how frames are written to the terminal.
+type FlushStrategy int
+
+// Flush strategies.
+const (
+	// FlushDiff writes the cells changed since the last frame, the default.
+	FlushDiff FlushStrategy = iota
+	// FlushFull repaints the whole screen every frame, for terminals or
+	// multiplexers that get out of sync with the diff.
+	FlushFull
+	// FlushScroll writes the changed cells like FlushDiff, but first moves
+	// rows that shifted up or down since the last frame with the terminal's
+	// scroll commands, so that a scrolling log only draws its new lines.
+	// It pays off when the scrolling widget spans the full terminal width,
+	// since terminals scroll whole rows.
+	FlushScroll
+)
+
+// SetFlushStrategy selects how frames are written to the terminal. It has
+// no effect on a Headless screen.
+func SetFlushStrategy(s FlushStrategy) {
+	renderLock.Lock()
+	defer renderLock.Unlock()
+	if scrollScreen.setStrategy(s) {
+		// termbox's view of the terminal went stale while we drew it
+		screen.sync()
+	}
+}
+
+// ScrollMargins tells FlushScroll that the terminal supports left and right
+// margins (DECLRMM), like xterm or kitty do, so that panes narrower than the
+// screen can be scrolled too. Other terminals would scroll the whole rows.
+var ScrollMargins = false
+
+// scrollHint tells FlushScroll that the cells of r moved up by n rows, or
+// down if n is negative, in the frame being drawn. It is only acted upon
+// if the cells on the terminal do match.
+func scrollHint(r image.Rectangle, n int) {
+	scrollScreen.Lock()
+	if scrollScreen.strategy == FlushScroll {
+		scrollScreen.hints = append(scrollScreen.hints, scrollRect{r, n})
+	}
+	scrollScreen.Unlock()
+}
+
+type scrollRect struct {
+	r image.Rectangle
+	n int
+}
+
+// scrollScreen keeps the frames drawn with FlushScroll.
+var scrollScreen diffScreen
+
+// minScrollRows is the fewest rows a scroll must save from being drawn.
+const minScrollRows = 2
+
+// diffScreen draws frames itself by diffing them against the last one
+// written, as termbox only knows how to diff cell by cell.
+type diffScreen struct {
+	sync.Mutex
+	strategy FlushStrategy
+	width    int
+	height   int
+	back     []Cell // frame being drawn
+	front    []Cell // frame on the terminal, nil to repaint all
+	hints    []scrollRect
+}
+
+// setStrategy tells if s leaves FlushScroll. The frames kept and the scroll
+// hints of the last strategy are dropped.
+func (d *diffScreen) setStrategy(s FlushStrategy) bool {
+	d.Lock()
+	defer d.Unlock()
+	left := d.strategy == FlushScroll && s != FlushScroll
+	d.strategy = s
+	d.back, d.front, d.hints = nil, nil, nil
+	return left
+}
+
+func (d *diffScreen) current() FlushStrategy {
+	d.Lock()
+	defer d.Unlock()
+	return d.strategy
+}
+
+// resize must be called with d locked.
+func (d *diffScreen) resize(w, h int) {
+	if w == d.width && h == d.height && d.back != nil {
+		return
+	}
+	d.width, d.height = w, h
+	d.back = make([]Cell, w*h)
+	for i := range d.back {
+		d.back[i] = Cell{Ch: ' '}
+	}
+	d.front = nil
+}
+
+func (d *diffScreen) set(w, h, x, y int, c Cell) {
+	d.Lock()
+	defer d.Unlock()
+	if d.strategy != FlushScroll {
+		return
+	}
+	d.resize(w, h)
+	if x >= 0 && y >= 0 && x < w && y < h {
+		if c.Ch == 0 {
+			c.Ch = ' '
+		}
+		d.back[y*w+x] = c
+	}
+}
+
+func (d *diffScreen) clear(w, h int, bg Attribute) {
+	d.Lock()
+	defer d.Unlock()
+	if d.strategy != FlushScroll {
+		return
+	}
+	d.resize(w, h)
+	for i := range d.back {
+		d.back[i] = Cell{Ch: ' ', Bg: bg}
+	}
+}
+
+// invalidate makes the next flush repaint all, after the terminal was
+// redrawn behind d's back.
+func (d *diffScreen) invalidate() {
+	d.Lock()
+	d.front = nil
+	d.Unlock()
+}
+
+func (d *diffScreen) flush(w, h int, out io.Writer) {
+	d.Lock()
+	defer d.Unlock()
+	d.resize(w, h)
+
+	bw := bufio.NewWriter(out)
+	if d.front == nil {
+		d.front = make([]Cell, len(d.back))
+		for i := range d.front {
+			d.front[i] = Cell{Ch: ' '}
+		}
+		bw.WriteString("\033[0m\033[2J")
+	} else {
+		for _, h := range d.hints {
+			d.shift(bw, h.r, h.n)
+		}
+		d.scroll(bw)
+	}
+	d.hints = nil
+	d.draw(bw)
+	bw.WriteString("\033[0m")
+	bw.Flush()
+}
+
+func (d *diffScreen) rowHashes(cs []Cell) []uint64 {
+	hs := make([]uint64, d.height)
+	buf := make([]byte, 12)
+	for y := range hs {
+		f := fnv.New64a()
+		for _, c := range cs[y*d.width : (y+1)*d.width] {
+			for i, v := range []uint32{uint32(c.Ch), uint32(c.Fg), uint32(c.Bg)} {
+				buf[4*i], buf[4*i+1], buf[4*i+2], buf[4*i+3] = byte(v), byte(v>>8), byte(v>>16), byte(v>>24)
+			}
+			f.Write(buf)
+		}
+		hs[y] = f.Sum64()
+	}
+	return hs
+}
+
+// scroll finds the band of full rows that moved by the same number of rows
+// since the last frame and saves the most drawing, and shifts it on the
+// terminal within a scroll region.
+func (d *diffScreen) scroll(bw *bufio.Writer) {
+	cur, prev := d.rowHashes(d.back), d.rowHashes(d.front)
+	h := d.height
+
+	best, top, bot, shift := minScrollRows-1, 0, 0, 0
+	for n := 1 - h/2; n < h/2; n++ {
+		if n == 0 {
+			continue
+		}
+		// runs of rows y with cur[y] == prev[y+n]
+		for a := 0; a < h; {
+			if a+n < 0 || a+n >= h || cur[a] != prev[a+n] {
+				a++
+				continue
+			}
+			b, saved := a, 0
+			for ; b < h && b+n >= 0 && b+n < h && cur[b] == prev[b+n]; b++ {
+				if cur[b] != prev[b] {
+					saved++
+				}
+			}
+			// rows scrolled in are blank and must be drawn again
+			rt, rb := min(a, a+n), max(b, b+n)-1
+			in, lost := b, rb
+			if n < 0 {
+				in, lost = rt, a-1
+			}
+			for y := in; y <= lost; y++ {
+				if cur[y] == prev[y] {
+					saved--
+				}
+			}
+			if saved > best {
+				best, top, bot, shift = saved, rt, rb, n
+			}
+			a = b
+		}
+	}
+	if shift != 0 {
+		d.shift(bw, image.Rect(0, top, d.width, bot+1), shift)
+	}
+}
+
+// shift scrolls the cells of r on the terminal by n rows, up if n is
+// positive, if they moved so in the frame being drawn.
+func (d *diffScreen) shift(bw *bufio.Writer, r image.Rectangle, n int) bool {
+	r = r.Intersect(image.Rect(0, 0, d.width, d.height))
+	full := r.Min.X == 0 && r.Max.X == d.width
+	if n == 0 || abs(n) >= r.Dy() || !full && !ScrollMargins {
+		return false
+	}
+
+	// moved rows must match, not only their hashes
+	ys, ye := r.Min.Y, r.Max.Y-n
+	if n < 0 {
+		ys, ye = r.Min.Y-n, r.Max.Y
+	}
+	w := d.width
+	for y := ys; y < ye; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if d.back[y*w+x] != d.front[(y+n)*w+x] {
+				return false
+			}
+		}
+	}
+
+	// rows and columns are 1-based and the margins inclusive
+	bw.WriteString("\033[0m\033[" + strconv.Itoa(r.Min.Y+1) + ";" + strconv.Itoa(r.Max.Y) + "r")
+	if !full {
+		bw.WriteString("\033[?69h\033[" + strconv.Itoa(r.Min.X+1) + ";" + strconv.Itoa(r.Max.X) + "s")
+	}
+	if n > 0 {
+		bw.WriteString("\033[" + strconv.Itoa(n) + "S")
+	} else {
+		bw.WriteString("\033[" + strconv.Itoa(-n) + "T")
+	}
+	if !full {
+		bw.WriteString("\033[s\033[?69l")
+	}
+	bw.WriteString("\033[r")
+
+	moved := make([]Cell, r.Dx()*r.Dy())
+	for i := range moved {
+		moved[i] = Cell{Ch: ' '}
+	}
+	for y := ys; y < ye; y++ {
+		copy(moved[(y-r.Min.Y)*r.Dx():], d.front[(y+n)*w+r.Min.X:(y+n)*w+r.Max.X])
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		copy(d.front[y*w+r.Min.X:y*w+r.Max.X], moved[(y-r.Min.Y)*r.Dx():])
+	}
+	return true
+}
+
+func (d *diffScreen) draw(bw *bufio.Writer) {
+	cx, cy := -1, -1
+	var fg, bg Attribute
+	sgr := false
+	for y := 0; y < d.height; y++ {
+		for x := 0; x < d.width; x++ {
+			i := y*d.width + x
+			c := d.back[i]
+			if c == d.front[i] {
+				continue
+			}
+			d.front[i] = c
+			if x != cx || y != cy {
+				bw.WriteString("\033[" + strconv.Itoa(y+1) + ";" + strconv.Itoa(x+1) + "H")
+			}
+			if !sgr || c.Fg != fg || c.Bg != bg {
+				fg, bg, sgr = c.Fg, c.Bg, true
+				bw.WriteString(cell.SGR(fg, bg))
+			}
+			bw.WriteRune(c.Ch)
+			cw := c.Width()
+			// the cells covered by a wide rune are not drawn
+			for j := 1; j < cw && x+j < d.width; j++ {
+				d.front[i+j] = d.back[i+j]
+			}
+			x += cw - 1
+			cx, cy = x+1, y
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+	"testing"
+)
+
+func drawLog(d *diffScreen, w, h int, lines []string) {
+	d.clear(w, h, ColorDefault)
+	for y, l := range lines {
+		for x, r := range l {
+			d.set(w, h, x, y, Cell{Ch: r})
+		}
+	}
+}
+
+func TestDiffScreenScroll(t *testing.T) {
+	d := &diffScreen{}
+	d.setStrategy(FlushScroll)
+	w, h := 20, 10
+
+	var lines []string
+	for i := 0; i < h; i++ {
+		lines = append(lines, fmt.Sprintf("line-%d", i))
+	}
+	var out bytes.Buffer
+	drawLog(d, w, h, lines)
+	d.flush(w, h, &out)
+	if !strings.Contains(out.String(), "line-0") {
+		t.Fatalf("first frame not repainted: %q", out.String())
+	}
+
+	out.Reset()
+	lines = append(lines[1:], "line-10")
+	drawLog(d, w, h, lines)
+	d.flush(w, h, &out)
+	s := out.String()
+	if !strings.Contains(s, "\033[1;10r\033[1S\033[r") {
+		t.Errorf("log not scrolled: %q", s)
+	}
+	if !strings.Contains(s, "line-10") || strings.Contains(s, "line-5") {
+		t.Errorf("want only the new line drawn: %q", s)
+	}
+	for i := range d.back {
+		if d.back[i] != d.front[i] {
+			t.Fatalf("front differs from back at %d: %v, %v", i, d.front[i], d.back[i])
+		}
+	}
+
+	out.Reset()
+	d.flush(w, h, &out)
+	if out.String() != "\033[0m" {
+		t.Errorf("unchanged frame wrote %q", out.String())
+	}
+}
+
+func TestDiffScreenScrollBack(t *testing.T) {
+	d := &diffScreen{}
+	d.setStrategy(FlushScroll)
+	w, h := 20, 10
+
+	lines := make([]string, h)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line-%d", i+2)
+	}
+	var out bytes.Buffer
+	drawLog(d, w, h, lines)
+	d.flush(w, h, &out)
+
+	// header row stays, the log below it moves down by two rows
+	out.Reset()
+	lines = append([]string{lines[0], "line-0", "line-1"}, lines[1:h-2]...)
+	drawLog(d, w, h, lines)
+	d.flush(w, h, &out)
+	s := out.String()
+	if !strings.Contains(s, "\033[2;10r\033[2T\033[r") {
+		t.Errorf("log not scrolled back: %q", s)
+	}
+	if strings.Contains(s, "line-5") {
+		t.Errorf("moved rows drawn again: %q", s)
+	}
+	for i := range d.back {
+		if d.back[i] != d.front[i] {
+			t.Fatalf("front differs from back at %d: %v, %v", i, d.front[i], d.back[i])
+		}
+	}
+}
+
+func TestDiffScreenStrategy(t *testing.T) {
+	d := &diffScreen{}
+	d.set(10, 10, 0, 0, Cell{Ch: 'x'})
+	if d.back != nil {
+		t.Error("cells kept while not scrolling")
+	}
+	d.setStrategy(FlushScroll)
+	if !d.setStrategy(FlushDiff) {
+		t.Error("leaving FlushScroll must resync termbox")
+	}
+	if d.setStrategy(FlushFull) {
+		t.Error("FlushDiff to FlushFull needs no resync")
+	}
+}
+
+func TestDiffScreenScrollPane(t *testing.T) {
+	defer func(m bool) { ScrollMargins = m }(ScrollMargins)
+	d := &diffScreen{}
+	d.setStrategy(FlushScroll)
+	w, h := 20, 6
+
+	draw := func(lines []string) {
+		d.clear(w, h, ColorDefault)
+		for y := 0; y < h; y++ {
+			d.set(w, h, 0, y, Cell{Ch: '|'})
+		}
+		for y, l := range lines {
+			for x, r := range l {
+				d.set(w, h, x+2, y, Cell{Ch: r})
+			}
+		}
+	}
+	var out bytes.Buffer
+	draw([]string{"a-0", "a-1", "a-2", "a-3", "a-4", "a-5"})
+	d.flush(w, h, &out)
+
+	ScrollMargins = true
+	out.Reset()
+	draw([]string{"a-1", "a-2", "a-3", "a-4", "a-5", "a-6"})
+	d.hints = append(d.hints, scrollRect{image.Rect(2, 0, 20, 6), 1})
+	d.flush(w, h, &out)
+	s := out.String()
+	if !strings.Contains(s, "\033[1;6r\033[?69h\033[3;20s\033[1S") {
+		t.Errorf("pane not scrolled: %q", s)
+	}
+	if strings.Contains(s, "a-3") || !strings.Contains(s, "a-6") {
+		t.Errorf("want only the new line drawn: %q", s)
+	}
+	for i := range d.back {
+		if d.back[i] != d.front[i] {
+			t.Fatalf("front differs from back at %d: %v, %v", i, d.front[i], d.back[i])
+		}
+	}
+}
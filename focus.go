@@ -0,0 +1,148 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// EvtFocus is the Data of the "/sys/focus/in" and "/sys/focus/out" events,
+// sent when the terminal window gains or loses the focus.
+type EvtFocus struct {
+	Focused bool
+}
+
+// FocusSeqTimeout is how long an <escape> key is held back, waiting for the
+// rest of a focus report, once EnableFocusEvents was called.
+var FocusSeqTimeout = 25 * time.Millisecond
+
+// EnableFocusEvents asks the terminal to report when its window gains or
+// loses the focus, which most terminals (xterm, iTerm2, kitty, VTE, tmux
+// with focus-events on) do. Apps can then pause animations and expensive
+// refreshes while nobody looks.
+/*
+  termui.EnableFocusEvents()
+  termui.Handle("/sys/focus/out", func(termui.Event) {
+      termui.DefaultEvtStream.Pause()
+  })
+  termui.Handle("/sys/focus/in", func(termui.Event) {
+      termui.DefaultEvtStream.Resume()
+  })
+*/
+func EnableFocusEvents() {
+	defaultFocus.Lock()
+	defaultFocus.on = true
+	defaultFocus.Unlock()
+	renderLock.Lock()
+	io.WriteString(rawOut, "\033[?1004h")
+	renderLock.Unlock()
+}
+
+// disableFocusEvents stops focus reports, if enabled, before termui exits.
+func disableFocusEvents() {
+	defaultFocus.Lock()
+	on := defaultFocus.on
+	defaultFocus.on = false
+	defaultFocus.Unlock()
+	if on {
+		renderLock.Lock()
+		io.WriteString(rawOut, "\033[?1004l")
+		renderLock.Unlock()
+	}
+}
+
+// Focused tells if the terminal window has the focus. It is assumed to
+// until a focus report says otherwise.
+func Focused() bool {
+	defaultFocus.Lock()
+	defer defaultFocus.Unlock()
+	return !defaultFocus.blurred
+}
+
+// focusParser picks focus reports, "\033[I" and "\033[O", out of the key
+// events termbox makes of them: <escape>, [ then I or O, or M-[ then I or O
+// in alt input mode.
+type focusParser struct {
+	sync.Mutex
+	on      bool
+	blurred bool
+	pending []Event
+	gen     int
+	send    func(Event)
+}
+
+var defaultFocus = &focusParser{send: deliverSysEvt}
+
+func keyOf(e Event) string {
+	if k, ok := e.Data.(EvtKbd); ok {
+		return k.KeyStr
+	}
+	return ""
+}
+
+func (fp *focusParser) feed(ne Event) {
+	fp.Lock()
+	defer fp.Unlock()
+	if !fp.on {
+		fp.send(ne)
+		return
+	}
+
+	k := keyOf(ne)
+	seq := make([]string, len(fp.pending))
+	for i, e := range fp.pending {
+		seq[i] = keyOf(e)
+	}
+	switch {
+	case len(seq) == 0 && (k == "<escape>" || k == "M-["),
+		len(seq) == 1 && seq[0] == "<escape>" && k == "[":
+		fp.hold(ne)
+		return
+	case (k == "I" || k == "O") &&
+		(len(seq) == 1 && seq[0] == "M-[" || len(seq) == 2 && seq[1] == "["):
+		fp.pending = nil
+		fp.gen++
+		fp.blurred = k == "O"
+		path := "/sys/focus/in"
+		if fp.blurred {
+			path = "/sys/focus/out"
+		}
+		fp.send(Event{Type: "focus", Path: path, From: "/sys", Data: EvtFocus{Focused: !fp.blurred}, Time: ne.Time})
+		return
+	}
+
+	// not a focus report: deliver what was held back, ne may start another
+	fp.flush()
+	if k == "<escape>" || k == "M-[" {
+		fp.hold(ne)
+		return
+	}
+	fp.send(ne)
+}
+
+// hold keeps e back until the sequence is complete or FocusSeqTimeout.
+func (fp *focusParser) hold(e Event) {
+	fp.pending = append(fp.pending, e)
+	fp.gen++
+	gen := fp.gen
+	time.AfterFunc(FocusSeqTimeout, func() {
+		fp.Lock()
+		defer fp.Unlock()
+		if fp.gen == gen {
+			fp.flush()
+		}
+	})
+}
+
+func (fp *focusParser) flush() {
+	pending := fp.pending
+	fp.pending = nil
+	fp.gen++
+	for _, e := range pending {
+		fp.send(e)
+	}
+}
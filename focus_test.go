@@ -0,0 +1,47 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFocusParser(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	fp := &focusParser{on: true, send: func(e Event) {
+		mu.Lock()
+		got = append(got, e.Path)
+		mu.Unlock()
+	}}
+	key := func(k string) Event {
+		return Event{Type: "keyboard", Path: "/sys/kbd/" + k, Data: EvtKbd{KeyStr: k}}
+	}
+	for _, k := range []string{"a", "<escape>", "[", "O", "M-[", "I", "<escape>", "<escape>", "[", "x"} {
+		fp.feed(key(k))
+	}
+
+	want := "/sys/kbd/a /sys/focus/out /sys/focus/in /sys/kbd/<escape> /sys/kbd/<escape> /sys/kbd/[ /sys/kbd/x"
+	mu.Lock()
+	if s := strings.Join(got, " "); s != want {
+		t.Errorf("got %s\nwant %s", s, want)
+	}
+	got = nil
+	mu.Unlock()
+
+	fp.feed(key("<escape>"))
+	time.Sleep(3 * FocusSeqTimeout)
+	mu.Lock()
+	if len(got) != 1 || got[0] != "/sys/kbd/<escape>" {
+		t.Errorf("a lone <escape> should be delivered after the timeout, got %v", got)
+	}
+	mu.Unlock()
+	if fp.blurred {
+		t.Error("expected the focus back")
+	}
+}
@@ -5,8 +5,10 @@
 package termui
 
 import (
+	"bytes"
 	"strconv"
 	"strings"
+	"text/template"
 )
 
 // Gauge is a progress bar like widget.
@@ -19,6 +21,16 @@ import (
   g.BorderLabel = "Slim Gauge"
   g.BarColor = termui.ColorRed
   g.PercentColor = termui.ColorBlue
+
+Gauges can also track absolute values. When Total is set, Percent is derived
+from Current/Total and Label may use a text/template with the fields
+.Current, .Total and .Percent:
+
+  g := termui.NewGauge()
+  g.Current = 3.2 * 1024 * 1024 * 1024
+  g.Total = 8 * 1024 * 1024 * 1024
+  g.FormatValue = termui.FormatBytes
+  g.Label = "{{.Current}}/{{.Total}} ({{.Percent}}%)"
 */
 
 const ColorUndef Attribute = Attribute(^uint16(0))
@@ -31,6 +43,16 @@ type Gauge struct {
 	PercentColorHighlighted Attribute
 	Label                   string
 	LabelAlign              Align
+	Current                 float64
+	Total                   float64
+	FormatValue             func(float64) string // formats Current and Total in Label, defaults to FormatFloat
+}
+
+// gaugeLabel is the data passed to a templated Gauge Label.
+type gaugeLabel struct {
+	Current string
+	Total   string
+	Percent int
 }
 
 // NewGauge return a new gauge with current theme.
@@ -42,6 +64,7 @@ func NewGauge() *Gauge {
 		Label:                   "{{percent}}%",
 		LabelAlign:              AlignCenter,
 		PercentColorHighlighted: ColorUndef,
+		FormatValue:             FormatFloat,
 	}
 
 	g.Width = 12
@@ -49,10 +72,66 @@ func NewGauge() *Gauge {
 	return g
 }
 
+// FormatFloat formats v with at most two decimals and no trailing zeros.
+func FormatFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// FormatBytes formats v as a binary byte size, e.g. 1.5GiB.
+func FormatBytes(v float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	return FormatFloat(v) + units[i]
+}
+
+// label renders g.Label, substituting the legacy {{percent}} marker and
+// executing it as a template when it refers to any gauge field.
+func (g *Gauge) label() string {
+	s := strings.Replace(g.Label, "{{percent}}", strconv.Itoa(g.Percent), -1)
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+
+	format := g.FormatValue
+	if format == nil {
+		format = FormatFloat
+	}
+	tpl, err := template.New("gauge").Parse(s)
+	if err != nil {
+		return s
+	}
+	var b bytes.Buffer
+	err = tpl.Execute(&b, gaugeLabel{
+		Current: format(g.Current),
+		Total:   format(g.Total),
+		Percent: g.Percent,
+	})
+	if err != nil {
+		return s
+	}
+	return b.String()
+}
+
 // Buffer implements Bufferer interface.
 func (g *Gauge) Buffer() Buffer {
 	buf := g.Block.Buffer()
 
+	if g.Total > 0 {
+		g.Percent = int(g.Current / g.Total * 100)
+		if g.Percent > 100 {
+			g.Percent = 100
+		}
+		if g.Percent < 0 {
+			g.Percent = 0
+		}
+	}
+
 	// plot bar
 	w := g.Percent * g.innerArea.Dx() / 100
 	for i := 0; i < g.innerArea.Dy(); i++ {
@@ -68,7 +147,7 @@ func (g *Gauge) Buffer() Buffer {
 	}
 
 	// plot percentage
-	s := strings.Replace(g.Label, "{{percent}}", strconv.Itoa(g.Percent), -1)
+	s := g.label()
 	pry := g.innerArea.Min.Y + g.innerArea.Dy()/2
 	rs := str2runes(s)
 	var pos int
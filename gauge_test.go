@@ -0,0 +1,24 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestGaugeLabel(t *testing.T) {
+	g := NewGauge()
+	g.Percent = 42
+	if s := g.label(); s != "42%" {
+		t.Errorf("expected legacy label 42%%, got %q", s)
+	}
+
+	g.Current = 1536
+	g.Total = 4096
+	g.FormatValue = FormatBytes
+	g.Label = "{{.Current}}/{{.Total}} ({{.Percent}}%)"
+	g.Buffer()
+	if s := g.label(); s != "1.5KiB/4KiB (37%)" {
+		t.Errorf("unexpected templated label %q", s)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nsf/termbox-go"
+)
+
+// GestureConfig holds the timings used to synthesize mouse gestures.
+type GestureConfig struct {
+	MultiClick time.Duration // max delay between clicks of a double/triple click
+	LongPress  time.Duration // min time a button is held for a long press
+}
+
+// Gestures configures detection of the "/sys/gesture/dblclick",
+// "/sys/gesture/tripleclick" and "/sys/gesture/longpress" events, which are
+// sent in addition to the plain "/sys/mouse" events. Their Data is EvtMouse.
+var Gestures = GestureConfig{
+	MultiClick: 400 * time.Millisecond,
+	LongPress:  600 * time.Millisecond,
+}
+
+var mousePress = map[termbox.Key]string{
+	termbox.MouseLeft:      "left",
+	termbox.MouseMiddle:    "middle",
+	termbox.MouseRight:     "right",
+	termbox.MouseRelease:   "release",
+	termbox.MouseWheelUp:   "wheelup",
+	termbox.MouseWheelDown: "wheeldown",
+}
+
+func isButton(press string) bool {
+	return press == "left" || press == "middle" || press == "right"
+}
+
+// gestureDetector turns plain mouse events into gesture events.
+type gestureDetector struct {
+	sync.Mutex
+	last   EvtMouse
+	lastAt time.Time
+	clicks int
+	timer  *time.Timer
+	emit   func(Event)
+}
+
+func gestureEvt(name string, m EvtMouse) Event {
+	return Event{
+		Type: "mouse",
+		Path: "/sys/gesture/" + name,
+		From: "/sys",
+		Data: m,
+		Time: time.Now().Unix(),
+	}
+}
+
+// feed processes mouse event m received at now and returns the gesture
+// events it completes. Long presses are sent later through emit.
+func (gd *gestureDetector) feed(m EvtMouse, now time.Time) []Event {
+	gd.Lock()
+	defer gd.Unlock()
+
+	if gd.timer != nil {
+		gd.timer.Stop()
+		gd.timer = nil
+	}
+	if !isButton(m.Press) {
+		return nil
+	}
+
+	same := m.Press == gd.last.Press && abs(m.X-gd.last.X) <= 1 && abs(m.Y-gd.last.Y) <= 1
+	if same && now.Sub(gd.lastAt) <= Gestures.MultiClick {
+		gd.clicks++
+	} else {
+		gd.clicks = 1
+	}
+	gd.last = m
+	gd.lastAt = now
+
+	if gd.emit != nil && Gestures.LongPress > 0 {
+		gd.timer = time.AfterFunc(Gestures.LongPress, func() {
+			gd.emit(gestureEvt("longpress", m))
+		})
+	}
+
+	switch gd.clicks {
+	case 2:
+		return []Event{gestureEvt("dblclick", m)}
+	case 3:
+		gd.clicks = 0
+		return []Event{gestureEvt("tripleclick", m)}
+	}
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+var defaultGestures = &gestureDetector{
+	emit: sendSysEvt,
+}
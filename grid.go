@@ -4,6 +4,8 @@
 
 package termui
 
+import "context"
+
 // GridBufferer introduces a Bufferer that can be manipulated by Grid.
 type GridBufferer interface {
 	Bufferer
@@ -23,6 +25,7 @@ type Row struct {
 	Height int
 	Span   int
 	Offset int
+	shared bool // overlap the borders of adjacent cols and rows, see Grid
 }
 
 // calculate and set the underlying layout tree's x, y, height and width.
@@ -33,6 +36,21 @@ func (r *Row) calcLayout() {
 	r.assignY(r.Y)
 }
 
+// overlaps tells if col i of r reaches over the right border of the col
+// before it, see Grid.SharedBorders.
+func (r *Row) overlaps(i int) bool {
+	return r.shared && i >= 1 && r.Cols[i].Offset == 0
+}
+
+// stacked returns the height the widget of r takes above its cols, its
+// bottom border shared with them with Grid.SharedBorders.
+func (r *Row) stacked() int {
+	if r.shared {
+		return r.Widget.GetHeight() - 1
+	}
+	return r.Widget.GetHeight()
+}
+
 // tell if the node is leaf in the tree.
 func (r *Row) isLeaf() bool {
 	return r.Cols == nil || len(r.Cols) == 0
@@ -65,6 +83,9 @@ func (r *Row) assignWidth(w int) {
 			cw = r.Width - calcOftX[i]
 		}
 		calcW[i] = cw
+		if r.overlaps(i) {
+			cw++
+		}
 		r.Cols[i].assignWidth(cw)
 	}
 }
@@ -83,7 +104,7 @@ func (r *Row) solveHeight() int {
 			nh := c.solveHeight()
 			// when embed rows in Cols, row widgets stack up
 			if r.Widget != nil {
-				nh += r.Widget.GetHeight()
+				nh += r.stacked()
 			}
 			if nh > maxh {
 				maxh = nh
@@ -105,6 +126,9 @@ func (r *Row) assignX(x int) {
 			if c.Offset != 0 {
 				acc += int(float64(c.Offset*r.Width) / 12.0)
 			}
+			if r.overlaps(i) {
+				acc--
+			}
 			r.Cols[i].assignX(x + acc)
 			acc += c.Width
 		}
@@ -122,7 +146,7 @@ func (r *Row) assignY(y int) {
 	for i := range r.Cols {
 		acc := 0
 		if r.Widget != nil {
-			acc = r.Widget.GetHeight()
+			acc = r.stacked()
 		}
 		r.Cols[i].assignY(y + acc)
 	}
@@ -159,23 +183,34 @@ func (r *Row) SetWidth(w int) {
 }
 
 // Buffer implements Bufferer interface,
-// recursively merge all widgets buffer
+// recursively merge all widgets buffer, joining the borders they share
 func (r *Row) Buffer() Buffer {
 	merged := NewBuffer()
 
 	if r.isRenderableLeaf() {
-		return r.Widget.Buffer()
+		buf := r.Widget.Buffer()
+		// content of collapsed widgets must not leak into rows below
+		if c, ok := r.Widget.(interface {
+			IsCollapsed() bool
+		}); ok && c.IsCollapsed() {
+			for p := range buf.CellMap {
+				if !p.In(buf.Area) {
+					delete(buf.CellMap, p)
+				}
+			}
+		}
+		return buf
 	}
 
 	// for those are not leaves but have a renderable widget
 	if r.Widget != nil {
-		merged.Merge(r.Widget.Buffer())
+		merged.Compose(r.Widget.Buffer())
 	}
 
 	// collect buffer from children
 	if !r.isLeaf() {
 		for _, c := range r.Cols {
-			merged.Merge(c.Buffer())
+			merged.Compose(c.Buffer())
 		}
 	}
 
@@ -209,6 +244,10 @@ type Grid struct {
 	X       int
 	Y       int
 	BgColor Attribute
+	// SharedBorders lays adjacent widgets out over the border between them,
+	// joined where borders meet (├, ┬, ┼) rather than drawn twice. Meant
+	// for widgets which all have borders.
+	SharedBorders bool
 }
 
 // NewGrid returns *Grid with given rows.
@@ -254,15 +293,57 @@ func NewCol(span, offset int, widgets ...GridBufferer) *Row {
 	return r
 }
 
+// walk visits r and its descendants in depth-first order.
+func (r *Row) walk(f func(*Row)) {
+	f(r)
+	for _, c := range r.Cols {
+		c.walk(f)
+	}
+}
+
+// Spans returns the span of every row and col in g, in depth-first order.
+func (g *Grid) Spans() []int {
+	spans := []int{}
+	for _, r := range g.Rows {
+		r.walk(func(n *Row) {
+			spans = append(spans, n.Span)
+		})
+	}
+	return spans
+}
+
+// SetSpans assigns spans previously returned by Spans. It does nothing if
+// the number of spans does not match the current layout tree.
+func (g *Grid) SetSpans(spans []int) {
+	if len(spans) != len(g.Spans()) {
+		return
+	}
+	i := 0
+	for _, r := range g.Rows {
+		r.walk(func(n *Row) {
+			n.Span = spans[i]
+			i++
+		})
+	}
+}
+
 // Align calculate each rows' layout.
 func (g *Grid) Align() {
+	_, end := span(context.Background(), "termui.layout")
+	defer end()
 	h := 0
 	for _, r := range g.Rows {
+		r.walk(func(n *Row) {
+			n.shared = g.SharedBorders
+		})
 		r.SetWidth(g.Width)
 		r.SetX(g.X)
 		r.SetY(g.Y + h)
 		r.calcLayout()
 		h += r.GetHeight()
+		if g.SharedBorders {
+			h--
+		}
 	}
 }
 
@@ -271,7 +352,7 @@ func (g Grid) Buffer() Buffer {
 	buf := NewBuffer()
 
 	for _, r := range g.Rows {
-		buf.Merge(r.Buffer())
+		buf.Compose(r.Buffer())
 	}
 	return buf
 }
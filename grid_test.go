@@ -78,3 +78,45 @@ func TestAssignXY(t *testing.T) {
 		t.Error("assignXY fails")
 	}
 }
+
+func TestGridSpans(t *testing.T) {
+	g := NewGrid(NewRow(
+		NewCol(4, 0, NewBlock()),
+		NewCol(8, 0, NewBlock())))
+
+	if spans := g.Spans(); len(spans) != 3 || spans[1] != 4 || spans[2] != 8 {
+		t.Errorf("unexpected spans %v", spans)
+	}
+
+	g.SetSpans([]int{12, 6, 6})
+	if g.Rows[0].Cols[0].Span != 6 || g.Rows[0].Cols[1].Span != 6 {
+		t.Error("SetSpans failed")
+	}
+
+	g.SetSpans([]int{1})
+	if g.Rows[0].Cols[0].Span != 6 {
+		t.Error("SetSpans should ignore mismatched trees")
+	}
+}
+
+func TestGridSharedBorders(t *testing.T) {
+	bs := make([]*Block, 4)
+	for i := range bs {
+		bs[i] = NewBlock()
+		bs[i].Height = 3
+	}
+	g := NewGrid(
+		NewRow(NewCol(6, 0, bs[0]), NewCol(6, 0, bs[1])),
+		NewRow(NewCol(6, 0, bs[2]), NewCol(6, 0, bs[3])))
+	g.Width = 11
+	g.SharedBorders = true
+	g.Align()
+
+	if bs[1].X != 4 || bs[1].Width != 7 || bs[2].Y != 2 {
+		t.Errorf("borders not shared: x %d, width %d, y %d", bs[1].X, bs[1].Width, bs[2].Y)
+	}
+	want := "┌───┬─────┐\n│   │     │\n├───┼─────┤\n│   │     │\n└───┴─────┘"
+	if s := BufferText(g.Buffer()); s != want {
+		t.Errorf("unexpected junctions\n%s", s)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "sync"
+
+// frameLock is held for reading while a frame is computed, so batch updates
+// holding it for writing never show up half applied.
+var frameLock sync.RWMutex
+
+// Group is a set of widgets which are styled, shown/hidden and updated
+// together. Changes made through Update, Style and SetDisplay are applied
+// between two frames, never in the middle of one.
+/*
+  hosts := termui.NewGroup(cpu, mem, disk)
+  hosts.Style(func(b *termui.Block) {
+      b.BorderFg = termui.ColorRed
+  })
+  hosts.Update(func(w termui.Bufferer) {
+      // refresh data of every widget
+  })
+  termui.Render(hosts)
+*/
+type Group struct {
+	Widgets []Bufferer
+}
+
+// NewGroup returns a *Group of ws.
+func NewGroup(ws ...Bufferer) *Group {
+	return &Group{Widgets: ws}
+}
+
+// Add appends ws to the group.
+func (g *Group) Add(ws ...Bufferer) {
+	Batch(func() {
+		g.Widgets = append(g.Widgets, ws...)
+	})
+}
+
+// Batch runs f while no frame is being rendered.
+func Batch(f func()) {
+	frameLock.Lock()
+	defer frameLock.Unlock()
+	f()
+}
+
+// Update calls f on every widget of the group within one batch.
+func (g *Group) Update(f func(w Bufferer)) {
+	Batch(func() {
+		for _, w := range g.Widgets {
+			f(w)
+		}
+	})
+}
+
+// Style calls f with the Block of every Block based widget of the group.
+func (g *Group) Style(f func(b *Block)) {
+	g.Update(func(w Bufferer) {
+		if b, ok := w.(interface {
+			GetBlock() *Block
+		}); ok {
+			f(b.GetBlock())
+		}
+	})
+}
+
+// SetDisplay shows or hides all Block based widgets of the group.
+func (g *Group) SetDisplay(on bool) {
+	g.Style(func(b *Block) {
+		b.Display = on
+	})
+}
+
+// Buffer implements Bufferer interface, merging the buffers of all
+// displayed widgets in order.
+func (g *Group) Buffer() Buffer {
+	buf := NewBuffer()
+	for _, w := range g.Widgets {
+		if b, ok := w.(interface {
+			GetBlock() *Block
+		}); ok && !b.GetBlock().Display {
+			continue
+		}
+		buf.Merge(w.Buffer())
+	}
+	return buf
+}
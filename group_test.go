@@ -0,0 +1,34 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	p := NewPar("a")
+	p.Width, p.Height = 3, 3
+	g := NewGauge()
+	g.Y = 5
+	grp := NewGroup(p, g)
+
+	grp.Style(func(b *Block) {
+		b.BorderFg = ColorRed
+	})
+	if p.BorderFg != ColorRed || g.BorderFg != ColorRed {
+		t.Error("style should be broadcast to all widgets")
+	}
+
+	g.Display = false
+	buf := grp.Buffer()
+	if _, ok := buf.CellMap[image.Pt(0, 5)]; ok {
+		t.Error("hidden widgets should not be drawn")
+	}
+	if buf.At(1, 1).Ch != 'a' {
+		t.Error("displayed widgets should be drawn")
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"sync"
+	"time"
+
+	"github.com/gizak/termui/cell"
+)
+
+// Headless is an in-memory screen frames are drawn to instead of the
+// terminal, to drive an app without a tty in tests or tools. Events are
+// injected with Key, Mouse and Resize.
+/*
+  h := termui.NewHeadless(80, 24)
+  termui.InitHeadless(h)
+  go app()
+
+  h.Settle(50*time.Millisecond, time.Second)
+  h.Key("<down>")
+  h.Settle(50*time.Millisecond, time.Second)
+  fmt.Println(h.Text())
+*/
+type Headless struct {
+	sync.Mutex
+	cond   *sync.Cond
+	width  int
+	height int
+	back   map[image.Point]Cell // cells set since the last clear
+	front  Buffer               // last flushed frame
+	frames int
+	last   time.Time
+	cursor *cursorPlace // shown cursor, nil when hidden
+	fed    bool         // events were injected since the last handled
+}
+
+// headlessSync is the data of the event a Headless injects after others to
+// learn when they were handled: the event loop closes it, see handled.
+type headlessSync chan struct{}
+
+// renderBarrier is queued as a frame by the event loop on a headlessSync.
+// Frames being drawn in turn, its turn comes once those queued before it
+// are drawn; it is not drawn itself.
+type renderBarrier struct{}
+
+func (renderBarrier) Buffer() Buffer { return NewBuffer() }
+
+// NewHeadless returns a w by h *Headless screen.
+func NewHeadless(w, h int) *Headless {
+	hl := &Headless{width: w, height: h, back: make(map[image.Point]Cell)}
+	hl.cond = sync.NewCond(&hl.Mutex)
+	hl.front = NewBuffer()
+	hl.front.SetArea(image.Rect(0, 0, w, h))
+	return hl
+}
+
+// InitHeadless initializes termui like Init, drawing to h instead of the
+// terminal.
+func InitHeadless(h *Headless) error {
+	screen = h
+	start(false)
+	return nil
+}
+
+func (h *Headless) setCell(x, y int, c Cell) {
+	h.Lock()
+	if x >= 0 && y >= 0 && x < h.width && y < h.height {
+		h.back[image.Pt(x, y)] = c
+	}
+	h.Unlock()
+}
+
+func (h *Headless) flush() {
+	h.Lock()
+	buf := NewBuffer()
+	buf.SetArea(image.Rect(0, 0, h.width, h.height))
+	for p, c := range h.back {
+		buf.Set(p.X, p.Y, c)
+	}
+	h.front = buf
+	h.frames++
+	h.last = time.Now()
+	h.cond.Broadcast()
+	h.Unlock()
+}
+
+func (h *Headless) clear(bg Attribute) {
+	h.Lock()
+	h.back = make(map[image.Point]Cell)
+	if bg != ColorDefault {
+		for x := 0; x < h.width; x++ {
+			for y := 0; y < h.height; y++ {
+				h.back[image.Pt(x, y)] = Cell{Ch: ' ', Bg: bg}
+			}
+		}
+	}
+	h.Unlock()
+}
+
+func (h *Headless) sync() {}
+
+func (h *Headless) size() (int, int) {
+	h.Lock()
+	defer h.Unlock()
+	return h.width, h.height
+}
+
+func (h *Headless) close() {}
+
+func (h *Headless) showCursor(x, y int, s CursorStyle) {
+	h.Lock()
+	h.cursor = &cursorPlace{p: image.Pt(x, y), style: s}
+	h.Unlock()
+}
+
+func (h *Headless) hideCursor() {
+	h.Lock()
+	h.cursor = nil
+	h.Unlock()
+}
+
+// Cursor returns the position and style of the cursor, ok being false when
+// it is hidden.
+func (h *Headless) Cursor() (x, y int, s CursorStyle, ok bool) {
+	h.Lock()
+	defer h.Unlock()
+	if h.cursor == nil {
+		return 0, 0, CursorStyle{}, false
+	}
+	return h.cursor.p.X, h.cursor.p.Y, h.cursor.style, true
+}
+
+// Screen returns a copy of the last flushed frame.
+func (h *Headless) Screen() Buffer {
+	h.Lock()
+	defer h.Unlock()
+	buf := NewBuffer()
+	buf.Merge(h.front)
+	return buf
+}
+
+// Text returns the last flushed frame as plain text.
+func (h *Headless) Text() string {
+	return cell.Text(h.Screen())
+}
+
+// Frames returns the number of frames flushed so far.
+func (h *Headless) Frames() int {
+	h.Lock()
+	defer h.Unlock()
+	return h.frames
+}
+
+// Settle waits until no frame was flushed for quiet, e.g. after injecting
+// an event, and tells if that happened within timeout.
+func (h *Headless) Settle(quiet, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		h.Lock()
+		idle := time.Since(h.last)
+		h.Unlock()
+		if idle >= quiet {
+			return true
+		}
+		if time.Now().Add(quiet - idle).After(deadline) {
+			return false
+		}
+		time.Sleep(quiet - idle)
+	}
+}
+
+// handled waits until the event loop handled the events injected since the
+// last call and the frames their handlers asked for were drawn, and tells
+// if that happened within timeout.
+func (h *Headless) handled(timeout time.Duration) bool {
+	h.Lock()
+	fed := h.fed
+	h.fed = false
+	h.Unlock()
+	if !fed {
+		return true
+	}
+	done := make(headlessSync)
+	// injected in turn with the events, after them
+	go feedSysEvt(Event{
+		Type: "headless",
+		Path: "/sys/headless/sync",
+		From: "/sys",
+		Data: done,
+		Time: time.Now().Unix(),
+	})
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// feed notes that an event is being injected, see handled.
+func (h *Headless) feed() {
+	h.Lock()
+	h.fed = true
+	h.Unlock()
+}
+
+// WaitFrame waits until at least n frames were flushed and tells if that
+// happened within timeout.
+func (h *Headless) WaitFrame(n int, timeout time.Duration) bool {
+	t := time.AfterFunc(timeout, func() {
+		h.Lock()
+		h.cond.Broadcast()
+		h.Unlock()
+	})
+	defer t.Stop()
+
+	deadline := time.Now().Add(timeout)
+	h.Lock()
+	defer h.Unlock()
+	for h.frames < n {
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		h.cond.Wait()
+	}
+	return true
+}
+
+// Key injects a key press, named like the paths of keyboard events, e.g.
+// "q", "C-c" or "<enter>".
+func (h *Headless) Key(key string) {
+	h.feed()
+	feedSysEvt(Event{
+		Type: "keyboard",
+		Path: "/sys/kbd/" + key,
+		From: "/sys",
+		Data: EvtKbd{KeyStr: key},
+		Time: time.Now().Unix(),
+	})
+}
+
+// Mouse injects a mouse event at (x, y); press is "left", "middle",
+// "right", "release", "wheelup" or "wheeldown".
+func (h *Headless) Mouse(x, y int, press string) {
+	h.feed()
+	feedSysEvt(Event{
+		Type: "mouse",
+		Path: "/sys/mouse",
+		From: "/sys",
+		Data: EvtMouse{X: x, Y: y, Press: press},
+		Time: time.Now().Unix(),
+	})
+}
+
+// Resize resizes the screen and injects the resize event.
+func (h *Headless) Resize(w, ht int) {
+	h.Lock()
+	h.width, h.height = w, ht
+	h.fed = true
+	h.Unlock()
+	feedSysEvt(Event{
+		Type: "window",
+		Path: "/sys/wnd/resize",
+		From: "/sys",
+		Data: EvtWnd{Width: w, Height: ht},
+		Time: time.Now().Unix(),
+	})
+}
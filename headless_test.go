@@ -0,0 +1,123 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeadless(t *testing.T) {
+	h := NewHeadless(6, 3)
+	old := screen
+	screen = h
+	defer func() { screen = old }()
+
+	p := NewPar("hi")
+	p.Width = 6
+	p.Height = 3
+	render(p)
+	if !h.WaitFrame(1, time.Second) {
+		t.Fatal("expected a frame")
+	}
+	want := "┌────┐\n│hi  │\n└────┘"
+	if s := h.Text(); s != want {
+		t.Errorf("unexpected screen\n%s", s)
+	}
+
+	var snaps []string
+	err := h.RunScript(strings.NewReader("# comment\n\nsnap one\n"), func(name string, buf Buffer) error {
+		snaps = append(snaps, name+":"+BufferText(buf))
+		return nil
+	})
+	if err != nil || len(snaps) != 1 || snaps[0] != "one:"+want {
+		t.Errorf("unexpected snaps %q, error %v", snaps, err)
+	}
+
+	err = h.RunScript(strings.NewReader("jump 3\n"), nil)
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected an error on line 1, got %v", err)
+	}
+}
+
+func TestHeadlessNoJoinAcrossWidgets(t *testing.T) {
+	h := NewHeadless(6, 3)
+	old := screen
+	screen = h
+	defer func() { screen = old }()
+
+	under, over := NewBlock(), NewBlock()
+	under.Width, under.Height = 6, 3
+	over.X, over.Width, over.Height = 2, 4, 3
+	render(under, over)
+	if !h.WaitFrame(1, time.Second) {
+		t.Fatal("expected a frame")
+	}
+	want := "┌─┌──┐\n│ │  │\n└─└──┘"
+	if s := h.Text(); s != want {
+		t.Errorf("borders of widgets drawn over others should not be joined\n%s", s)
+	}
+}
+
+func TestHeadlessSharedBorders(t *testing.T) {
+	h := NewHeadless(11, 5)
+	old := screen
+	screen = h
+	defer func() { screen = old }()
+
+	bs := make([]*Block, 4)
+	for i := range bs {
+		bs[i] = NewBlock()
+		bs[i].Height = 3
+	}
+	g := NewGrid(
+		NewRow(NewCol(6, 0, bs[0]), NewCol(6, 0, bs[1])),
+		NewRow(NewCol(6, 0, bs[2]), NewCol(6, 0, bs[3])))
+	g.Width = 11
+	g.SharedBorders = true
+	render(g)
+	if !h.WaitFrame(1, time.Second) {
+		t.Fatal("expected a frame")
+	}
+	want := "┌───┬─────┐\n│   │     │\n├───┼─────┤\n│   │     │\n└───┴─────┘"
+	if s := h.Text(); s != want {
+		t.Errorf("borders shared in a grid should be joined on screen\n%s", s)
+	}
+}
+
+func TestHeadlessSnapAfterKey(t *testing.T) {
+	oldScreen, oldStream := screen, DefaultEvtStream
+	DefaultEvtStream = NewEvtStream()
+	defer func() { screen, DefaultEvtStream = oldScreen, oldStream }()
+
+	h := NewHeadless(8, 3)
+	InitHeadless(h)
+	p := NewPar("before")
+	p.Width, p.Height = 8, 3
+	Handle("/sys/kbd/x", func(Event) {
+		// slower than the screen takes to settle
+		time.Sleep(2 * snapQuiet)
+		p.Text = "after"
+		Render(p)
+	})
+	Render(p)
+	done := make(chan struct{})
+	go func() {
+		Loop()
+		close(done)
+	}()
+
+	var got string
+	err := h.RunScript(strings.NewReader("key x\nsnap after\n"), func(name string, buf Buffer) error {
+		got = BufferText(buf)
+		return nil
+	})
+	StopLoop()
+	<-done
+	if want := "┌──────┐\n│after │\n└──────┘"; err != nil || got != want {
+		t.Errorf("snap should show the frame drawn for the key, got\n%s\nerror %v", got, err)
+	}
+}
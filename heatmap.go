@@ -0,0 +1,171 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"math"
+)
+
+// Heatmap shows a matrix of values as cells colored along a ramp, with the
+// labels of its rows on the left and of its columns on top, e.g. latency by
+// hour of the day or metrics by host. NaN values are left blank.
+/*
+  hm := termui.NewHeatmap()
+  hm.BorderLabel = "p99 latency (ms)"
+  hm.RowLabels = []string{"web-1", "web-2", "db-1"}
+  hm.ColLabels = []string{"00", "06", "12", "18"}
+  hm.Data = [][]float64{
+      {12, 15, 40, 22},
+      {11, 18, 55, 25},
+      {3, 4, 9, 5},
+  }
+  hm.ShowValues = true
+  hm.CellWidth = 4
+  hm.Width = 30
+  hm.Height = 6
+*/
+type Heatmap struct {
+	Block
+	Data        [][]float64 // rows of values
+	RowLabels   []string
+	ColLabels   []string
+	Colors      []Attribute // ramp from the lowest to the highest value
+	LabelColor  Attribute
+	ValueColor  Attribute
+	ShowValues  bool
+	ValueFormat string  // fmt verb of the values shown, e.g. "%.1f"
+	CellWidth   int     // columns of a cell, at least 1
+	Min         float64 // value of the first color, NaN for the lowest value
+	Max         float64 // value of the last color, NaN for the highest value
+}
+
+// NewHeatmap returns a new *Heatmap with current theme.
+func NewHeatmap() *Heatmap {
+	hm := &Heatmap{Block: *NewBlock()}
+	hm.Colors = []Attribute{ColorBlue, ColorCyan, ColorGreen, ColorYellow, ColorRed}
+	hm.LabelColor = ThemeAttr("heatmap.label.fg")
+	hm.ValueColor = ThemeAttr("heatmap.value.fg")
+	hm.ValueFormat = "%.0f"
+	hm.CellWidth = 3
+	hm.Min = math.NaN()
+	hm.Max = math.NaN()
+	return hm
+}
+
+// bounds returns the values of the first and last colors.
+func (hm *Heatmap) bounds() (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, row := range hm.Data {
+		for _, v := range row {
+			if !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if !math.IsNaN(hm.Min) {
+		lo = hm.Min
+	}
+	if !math.IsNaN(hm.Max) {
+		hi = hm.Max
+	}
+	return lo, hi
+}
+
+// color returns the color of v on the ramp from lo to hi.
+func (hm *Heatmap) color(v, lo, hi float64) (Attribute, bool) {
+	if math.IsNaN(v) || len(hm.Colors) == 0 {
+		return 0, false
+	}
+	n := len(hm.Colors)
+	if hi <= lo {
+		return hm.Colors[n-1], true
+	}
+	i := int((v - lo) / (hi - lo) * float64(n))
+	return hm.Colors[clamp(i, 0, n-1)], true
+}
+
+// Buffer implements Bufferer interface.
+func (hm *Heatmap) Buffer() Buffer {
+	buf := hm.Block.Buffer()
+	if hm.drawState(buf) {
+		return buf
+	}
+	if hm.Skeleton && len(hm.Data) == 0 {
+		hm.drawSkeleton(buf, false)
+		return buf
+	}
+
+	labelW := 0
+	for _, l := range hm.RowLabels {
+		if w := strWidth(l); w > labelW {
+			labelW = w
+		}
+	}
+	if labelW > 0 {
+		labelW++
+	}
+	if labelW >= hm.innerArea.Dx() {
+		labelW = 0
+	}
+	top := hm.innerArea.Min.Y
+	if len(hm.ColLabels) > 0 {
+		top++
+	}
+	cw := hm.CellWidth
+	if cw < 1 {
+		cw = 1
+	}
+	x0 := hm.innerArea.Min.X + labelW
+
+	text := func(s string, x, y, w int, fg, bg Attribute) {
+		for _, c := range TruncateRight(TextCells(s, fg, bg), w) {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+	}
+
+	for i, l := range hm.ColLabels {
+		x := x0 + i*cw
+		if x >= hm.innerArea.Max.X {
+			break
+		}
+		text(l, x, hm.innerArea.Min.Y, clamp(cw, 0, hm.innerArea.Max.X-x), hm.LabelColor, hm.Bg)
+	}
+
+	lo, hi := hm.bounds()
+	for r, row := range hm.Data {
+		y := top + r
+		if y >= hm.innerArea.Max.Y {
+			break
+		}
+		if labelW > 0 && r < len(hm.RowLabels) {
+			text(hm.RowLabels[r], hm.innerArea.Min.X, y, labelW-1, hm.LabelColor, hm.Bg)
+		}
+		for i, v := range row {
+			x := x0 + i*cw
+			w := clamp(cw, 0, hm.innerArea.Max.X-x)
+			if w <= 0 {
+				break
+			}
+			bg, ok := hm.color(v, lo, hi)
+			if !ok {
+				continue
+			}
+			for dx := 0; dx < w; dx++ {
+				buf.Set(x+dx, y, Cell{Ch: ' ', Bg: bg})
+			}
+			if hm.ShowValues {
+				s := fmt.Sprintf(hm.ValueFormat, v)
+				pad := (w - strWidth(s)) / 2
+				if pad < 0 {
+					pad = 0
+				}
+				text(s, x+pad, y, w-pad, hm.ValueColor, bg)
+			}
+		}
+	}
+	return buf
+}
@@ -0,0 +1,43 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeatmap(t *testing.T) {
+	hm := NewHeatmap()
+	hm.Border = false
+	hm.Width = 9
+	hm.Height = 3
+	hm.Colors = []Attribute{ColorBlue, ColorRed}
+	hm.RowLabels = []string{"a", "bb"}
+	hm.ColLabels = []string{"x", "y"}
+	hm.Data = [][]float64{{1, 9}, {math.NaN(), 5}}
+	hm.ShowValues = true
+
+	buf := hm.Buffer()
+	// labels are 3 cells wide, cells 3 cells wide
+	if c := buf.At(3, 0); c.Ch != 'x' {
+		t.Errorf("expected the column label, got %q", c.Ch)
+	}
+	if c := buf.At(0, 2); c.Ch != 'b' {
+		t.Errorf("expected the row label, got %q", c.Ch)
+	}
+	expect := map[[2]int]Attribute{
+		{3, 1}: ColorBlue, {6, 1}: ColorRed,
+		{3, 2}: ColorDefault, {6, 2}: ColorRed,
+	}
+	for p, bg := range expect {
+		if c := buf.At(p[0], p[1]); c.Bg != bg {
+			t.Errorf("cell %v: expected bg %v, got %v", p, bg, c.Bg)
+		}
+	}
+	if c := buf.At(7, 1); c.Ch != '9' {
+		t.Errorf("expected the value centered, got %q", c.Ch)
+	}
+}
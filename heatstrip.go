@@ -0,0 +1,154 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// Heatstrip shows a distribution evolving over time: every column is a time
+// step and every row a bucket, colored by its count, like a latency heatmap.
+// The newest columns are drawn on the right.
+/*
+  hs := termui.NewHeatstrip()
+  hs.BorderLabel = "latency"
+  hs.BucketLabels = []string{"1ms", "10ms", "100ms", "1s"}
+  hs.Cap = 200
+  hs.Width = 60
+  hs.Height = 6
+
+  termui.Handle("/timer/1s", func(termui.Event) {
+      hs.Push(histogram.Counts()) // counts per bucket, lowest bucket first
+      termui.Render(hs)
+  })
+
+  // smoother shades on 256 color terminals
+  termui.SetOutputMode(termui.Output256)
+  hs.Colors = []termui.Attribute{
+      termui.ColorRGB(0, 0, 2), termui.ColorRGB(0, 1, 4), termui.ColorRGB(0, 3, 3),
+      termui.ColorRGB(2, 4, 0), termui.ColorRGB(5, 4, 0), termui.ColorRGB(5, 1, 0),
+  }
+*/
+type Heatstrip struct {
+	Block
+	Data         [][]int     // one column per time step, counts per bucket from the lowest up
+	BucketLabels []string    // drawn left of the strip, lowest bucket first
+	Colors       []Attribute // from the fewest to the most counts
+	LabelColor   Attribute
+	Max          int // count of the hottest color, 0 scales to the largest count shown
+	Cap          int // number of columns kept by Push, 0 keeps all
+}
+
+// NewHeatstrip returns a new *Heatstrip with current theme.
+func NewHeatstrip() *Heatstrip {
+	hs := &Heatstrip{Block: *NewBlock()}
+	hs.Colors = []Attribute{ColorBlue, ColorCyan, ColorGreen, ColorYellow, ColorRed}
+	hs.LabelColor = ThemeAttr("heatstrip.label.fg")
+	return hs
+}
+
+// Push appends a column of bucket counts, dropping the oldest ones beyond Cap.
+func (hs *Heatstrip) Push(counts []int) {
+	hs.Data = append(hs.Data, counts)
+	if hs.Cap > 0 && len(hs.Data) > hs.Cap {
+		hs.Data = hs.Data[len(hs.Data)-hs.Cap:]
+	}
+}
+
+// buckets returns the range of buckets shown on row r, counted from the
+// bottom, when n buckets are spread over rows rows.
+func (hs *Heatstrip) buckets(r, rows, n int) (lo, hi int) {
+	lo, hi = r*n/rows, (r+1)*n/rows
+	if hi == lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+func (hs *Heatstrip) color(v, max int) (Attribute, bool) {
+	if v <= 0 || max <= 0 || len(hs.Colors) == 0 {
+		return 0, false
+	}
+	if v > max {
+		v = max
+	}
+	return hs.Colors[(v-1)*len(hs.Colors)/max], true
+}
+
+// Buffer implements Bufferer interface.
+func (hs *Heatstrip) Buffer() Buffer {
+	buf := hs.Block.Buffer()
+	if hs.drawState(buf) {
+		return buf
+	}
+	if hs.Skeleton && len(hs.Data) == 0 {
+		hs.drawSkeleton(buf, true)
+		return buf
+	}
+
+	rows := hs.innerArea.Dy()
+	n := 0
+	for _, col := range hs.Data {
+		if len(col) > n {
+			n = len(col)
+		}
+	}
+	if rows <= 0 || n == 0 {
+		return buf
+	}
+
+	labelW := 0
+	for _, l := range hs.BucketLabels {
+		if w := strWidth(l); w > labelW {
+			labelW = w
+		}
+	}
+	if labelW > 0 {
+		labelW++
+	}
+	if labelW >= hs.innerArea.Dx() {
+		labelW = 0
+	}
+
+	cols := hs.innerArea.Dx() - labelW
+	data := hs.Data
+	if len(data) > cols {
+		data = data[len(data)-cols:]
+	}
+
+	// sum buckets sharing a row
+	cells := make([][]int, len(data))
+	max := hs.Max
+	for i, col := range data {
+		cells[i] = make([]int, rows)
+		for r := 0; r < rows; r++ {
+			lo, hi := hs.buckets(r, rows, n)
+			for b := lo; b < hi && b < len(col); b++ {
+				cells[i][r] += col[b]
+			}
+			if hs.Max <= 0 && cells[i][r] > max {
+				max = cells[i][r]
+			}
+		}
+	}
+
+	bottom := hs.innerArea.Max.Y - 1
+	for r := 0; r < rows; r++ {
+		// label the row where a bucket starts
+		lo, _ := hs.buckets(r, rows, n)
+		if labelW > 0 && lo < len(hs.BucketLabels) && (r == 0 || (r-1)*n/rows != lo) {
+			x := hs.innerArea.Min.X
+			for _, c := range DTrimTxCls(DefaultTxBuilder.Build(hs.BucketLabels[lo], hs.LabelColor, hs.Bg), labelW) {
+				buf.Set(x, bottom-r, c)
+				x += c.Width()
+			}
+		}
+
+		x := hs.innerArea.Max.X - len(cells)
+		for i := range cells {
+			if bg, ok := hs.color(cells[i][r], max); ok {
+				buf.Set(x+i, bottom-r, Cell{Ch: ' ', Bg: bg})
+			}
+		}
+	}
+
+	return buf
+}
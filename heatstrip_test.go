@@ -0,0 +1,58 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestHeatstrip(t *testing.T) {
+	hs := NewHeatstrip()
+	hs.Border = false
+	hs.Width = 6
+	hs.Height = 2
+	hs.Colors = []Attribute{ColorBlue, ColorRed}
+	hs.BucketLabels = []string{"a", "b"}
+	hs.Cap = 3
+	for _, col := range [][]int{{9, 9}, {1, 0}, {4, 2}, {0, 4}} {
+		hs.Push(col)
+	}
+	if len(hs.Data) != 3 {
+		t.Fatalf("Push should keep Cap columns, got %d", len(hs.Data))
+	}
+
+	buf := hs.Buffer()
+	if c := buf.At(0, 1); c.Ch != 'a' {
+		t.Errorf("expected the lowest label at the bottom, got %q", c.Ch)
+	}
+	if c := buf.At(0, 0); c.Ch != 'b' {
+		t.Errorf("expected the highest label at the top, got %q", c.Ch)
+	}
+	// columns {1,0}, {4,2}, {0,4} are drawn right aligned, max is 4
+	expect := map[[2]int]Attribute{
+		{3, 1}: ColorBlue, {4, 1}: ColorRed, {5, 1}: ColorDefault,
+		{3, 0}: ColorDefault, {4, 0}: ColorBlue, {5, 0}: ColorRed,
+	}
+	for p, bg := range expect {
+		if c := buf.At(p[0], p[1]); c.Bg != bg {
+			t.Errorf("cell %v: expected bg %v, got %v", p, bg, c.Bg)
+		}
+	}
+}
+
+func TestHeatstripMergesBuckets(t *testing.T) {
+	hs := NewHeatstrip()
+	hs.Border = false
+	hs.Width = 1
+	hs.Height = 2
+	hs.Colors = []Attribute{ColorBlue, ColorRed}
+	hs.Push([]int{1, 0, 0, 3})
+
+	buf := hs.Buffer()
+	if c := buf.At(0, 1); c.Bg != ColorBlue {
+		t.Errorf("buckets 0 and 1 should sum to 1, got bg %v", c.Bg)
+	}
+	if c := buf.At(0, 0); c.Bg != ColorRed {
+		t.Errorf("buckets 2 and 3 should sum to 3, got bg %v", c.Bg)
+	}
+}
@@ -5,45 +5,39 @@
 package termui
 
 import (
-	"regexp"
-	"strings"
-
+	"github.com/gizak/termui/cell"
 	tm "github.com/nsf/termbox-go"
 )
-import rw "github.com/mattn/go-runewidth"
 
 /* ---------------Port from termbox-go --------------------- */
 
 // Attribute is printable cell's color and style.
-type Attribute uint16
+type Attribute = cell.Attribute
 
 // 8 basic clolrs
 const (
-	ColorDefault Attribute = iota
-	ColorBlack
-	ColorRed
-	ColorGreen
-	ColorYellow
-	ColorBlue
-	ColorMagenta
-	ColorCyan
-	ColorWhite
+	ColorDefault = cell.ColorDefault
+	ColorBlack   = cell.ColorBlack
+	ColorRed     = cell.ColorRed
+	ColorGreen   = cell.ColorGreen
+	ColorYellow  = cell.ColorYellow
+	ColorBlue    = cell.ColorBlue
+	ColorMagenta = cell.ColorMagenta
+	ColorCyan    = cell.ColorCyan
+	ColorWhite   = cell.ColorWhite
 )
 
 // Have a constant that defines number of colors
-const NumberofColors = 8
+const NumberofColors = cell.NumberofColors
 
 // Text style
 const (
-	AttrBold Attribute = 1 << (iota + 9)
-	AttrUnderline
-	AttrReverse
+	AttrBold      = cell.AttrBold
+	AttrUnderline = cell.AttrUnderline
+	AttrReverse   = cell.AttrReverse
 )
 
-var (
-	dot  = "…"
-	dotw = rw.StringWidth(dot)
-)
+var dot = "…"
 
 // termbox passthrough
 type OutputMode int
@@ -76,161 +70,62 @@ func trimStr2Runes(s string, w int) []rune {
 // of that string if string is grather then n. If string is small then w,
 // return the runes.
 func TrimStr2Runes(s string, w int) []rune {
-	if w <= 0 {
-		return []rune{}
-	}
-
-	sw := rw.StringWidth(s)
-	if sw > w {
-		return []rune(rw.Truncate(s, w, dot))
-	}
-	return str2runes(s)
+	return cell.TrimStr2Runes(s, w)
 }
 
 // TrimStrIfAppropriate trim string to "s[:-1] + …"
 // if string > width otherwise return string
 func TrimStrIfAppropriate(s string, w int) string {
-	if w <= 0 {
-		return ""
-	}
-
-	sw := rw.StringWidth(s)
-	if sw > w {
-		return rw.Truncate(s, w, dot)
-	}
-
-	return s
+	return cell.TrimStrIfAppropriate(s, w)
 }
 
 func strWidth(s string) int {
-	return rw.StringWidth(s)
+	return cell.StringWidth(s)
 }
 
 func charWidth(ch rune) int {
-	return rw.RuneWidth(ch)
+	return cell.RuneWidth(ch)
 }
 
-var whiteSpaceRegex = regexp.MustCompile(`\s`)
-
 // StringToAttribute converts text to a termui attribute. You may specify more
 // then one attribute like that: "BLACK, BOLD, ...". All whitespaces
 // are ignored.
 func StringToAttribute(text string) Attribute {
-	text = whiteSpaceRegex.ReplaceAllString(strings.ToLower(text), "")
-	attributes := strings.Split(text, ",")
-	result := Attribute(0)
-
-	for _, theAttribute := range attributes {
-		var match Attribute
-		switch theAttribute {
-		case "reset", "default":
-			match = ColorDefault
-
-		case "black":
-			match = ColorBlack
-
-		case "red":
-			match = ColorRed
-
-		case "green":
-			match = ColorGreen
-
-		case "yellow":
-			match = ColorYellow
-
-		case "blue":
-			match = ColorBlue
-
-		case "magenta":
-			match = ColorMagenta
-
-		case "cyan":
-			match = ColorCyan
-
-		case "white":
-			match = ColorWhite
-
-		case "bold":
-			match = AttrBold
-
-		case "underline":
-			match = AttrUnderline
-
-		case "reverse":
-			match = AttrReverse
-		}
-
-		result |= match
-	}
-
-	return result
+	return cell.StringToAttribute(text)
 }
 
 // TextCells returns a coloured text cells []Cell
 func TextCells(s string, fg, bg Attribute) []Cell {
-	cs := make([]Cell, 0, len(s))
-
-	// sequence := MarkdownTextRendererFactory{}.TextRenderer(s).Render(fg, bg)
-	// runes := []rune(sequence.NormalizedText)
-	runes := str2runes(s)
-
-	for n := range runes {
-		// point, _ := sequence.PointAt(n, 0, 0)
-		// cs = append(cs, Cell{point.Ch, point.Fg, point.Bg})
-		cs = append(cs, Cell{runes[n], fg, bg})
-	}
-	return cs
-}
-
-// Width returns the actual screen space the cell takes (usually 1 or 2).
-func (c Cell) Width() int {
-	return charWidth(c.Ch)
-}
-
-// Copy return a copy of c
-func (c Cell) Copy() Cell {
-	return c
+	return cell.TextCells(s, fg, bg)
 }
 
 // TrimTxCells trims the overflowed text cells sequence.
 func TrimTxCells(cs []Cell, w int) []Cell {
-	if len(cs) <= w {
-		return cs
-	}
-	return cs[:w]
+	return cell.TrimTxCells(cs, w)
 }
 
 // DTrimTxCls trims the overflowed text cells sequence and append dots at the end.
 func DTrimTxCls(cs []Cell, w int) []Cell {
-	l := len(cs)
-	if l <= 0 {
-		return []Cell{}
-	}
+	return cell.DTrimTxCls(cs, w)
+}
 
-	rt := make([]Cell, 0, w)
-	csw := 0
-	for i := 0; i < l && csw <= w; i++ {
-		c := cs[i]
-		cw := c.Width()
-
-		if cw+csw < w {
-			rt = append(rt, c)
-			csw += cw
-		} else {
-			rt = append(rt, Cell{'…', c.Fg, c.Bg})
-			break
-		}
-	}
+// TruncateRight cuts the end of cs beyond w columns, see cell.Ellipsis.
+func TruncateRight(cs []Cell, w int) []Cell {
+	return cell.TruncateRight(cs, w)
+}
 
-	return rt
+// TruncateLeft cuts the beginning of cs beyond w columns.
+func TruncateLeft(cs []Cell, w int) []Cell {
+	return cell.TruncateLeft(cs, w)
+}
+
+// TruncateMiddle cuts the middle of cs beyond w columns.
+func TruncateMiddle(cs []Cell, w int) []Cell {
+	return cell.TruncateMiddle(cs, w)
 }
 
 func CellsToStr(cs []Cell) string {
-	str := ""
-	for _, c := range cs {
-		str += string(c.Ch)
-	}
-	return str
+	return cell.CellsToStr(cs)
 }
 
 // Passthrough to termbox using termbox constants above
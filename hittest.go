@@ -0,0 +1,186 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"sync"
+	"time"
+)
+
+// EvtHit is the Data of the events of HandleHit: the mouse event and the
+// region it hit, X and Y being relative to the top left cell of the region.
+type EvtHit struct {
+	ID    string
+	X     int
+	Y     int
+	Mouse EvtMouse
+}
+
+// hitRegion is a region registered by RegisterHit.
+type hitRegion struct {
+	r  image.Rectangle
+	id string
+}
+
+// hits are the regions of the frame being drawn and of those on screen,
+// with their handlers.
+var hits = struct {
+	sync.Mutex
+	building []hitRegion
+	regions  []hitRegion
+	handlers map[string]func(Event)
+	focused  string
+}{handlers: make(map[string]func(Event))}
+
+// RegisterHit makes the mouse events over r go to the handler of id, see
+// HandleHit. Widgets call it from their Buffer, for the frame being drawn;
+// regions registered later in the frame are above the earlier ones, as
+// widgets drawn later are.
+/*
+  func (b *Buttons) Buffer() termui.Buffer {
+      buf := b.Block.Buffer()
+      for i, l := range b.Labels {
+          r := image.Rect(x, y, x+len(l), y+1)
+          // draw the button in r...
+          termui.RegisterHit(r, b.Id()+"/"+strconv.Itoa(i))
+      }
+      return buf
+  }
+  termui.HandleHit(b.Id()+"/0", func(e termui.Event) {
+      if e.Data.(termui.EvtHit).Mouse.Press == "left" {
+          save()
+      }
+  })
+*/
+func RegisterHit(r image.Rectangle, id string) {
+	hits.Lock()
+	defer hits.Unlock()
+	hits.building = append(hits.building, hitRegion{r: r.Canon(), id: id})
+}
+
+// HandleHit calls f with the mouse events hitting the regions of id, at
+// path "/sys/hit/<id>". A nil f removes the handler.
+func HandleHit(id string, f func(Event)) {
+	hits.Lock()
+	defer hits.Unlock()
+	if f == nil {
+		delete(hits.handlers, id)
+		return
+	}
+	hits.handlers[id] = f
+}
+
+// HitTest returns the id of the topmost region on screen at x, y.
+func HitTest(x, y int) (string, bool) {
+	hits.Lock()
+	defer hits.Unlock()
+	r, ok := hitAt(image.Pt(x, y))
+	return r.id, ok
+}
+
+// HitFocus returns the id of the region last clicked, "" if none.
+func HitFocus() string {
+	hits.Lock()
+	defer hits.Unlock()
+	return hits.focused
+}
+
+func hitAt(p image.Point) (hitRegion, bool) {
+	for i := len(hits.regions) - 1; i >= 0; i-- {
+		if p.In(hits.regions[i].r) {
+			return hits.regions[i], true
+		}
+	}
+	return hitRegion{}, false
+}
+
+// beginHits starts the regions of a new frame.
+func beginHits() {
+	hits.Lock()
+	hits.building = nil
+	hits.Unlock()
+}
+
+// hitsSince returns the regions registered since there were n of them.
+func hitsSince(n int) []hitRegion {
+	hits.Lock()
+	defer hits.Unlock()
+	return append([]hitRegion(nil), hits.building[n:]...)
+}
+
+// hitCount returns the number of regions registered in this frame.
+func hitCount() int {
+	hits.Lock()
+	defer hits.Unlock()
+	return len(hits.building)
+}
+
+// addHits registers again the regions of a buffer reused from the last
+// frame.
+func addHits(rs []hitRegion) {
+	hits.Lock()
+	defer hits.Unlock()
+	hits.building = append(hits.building, rs...)
+}
+
+// endHits adds the regions of the frame drawn, over the areas drawn, to
+// those hit by the mouse. The regions of the frames before are kept but for
+// those within the areas, their widgets being drawn again or drawn over, so
+// that drawing some widgets leaves the others on screen clickable.
+func endHits(drawn []image.Rectangle) {
+	hits.Lock()
+	var kept []hitRegion
+	for _, r := range hits.regions {
+		if !within(r.r, drawn) {
+			kept = append(kept, r)
+		}
+	}
+	hits.regions, hits.building = append(kept, hits.building...), nil
+	hits.Unlock()
+}
+
+// within tells if r is within one of areas.
+func within(r image.Rectangle, areas []image.Rectangle) bool {
+	for _, a := range areas {
+		if r.In(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// clearHits forgets the regions on screen, once it is cleared.
+func clearHits() {
+	hits.Lock()
+	hits.regions = nil
+	hits.Unlock()
+}
+
+// dispatchHit sends a mouse event to the handler of the region it hits,
+// which gets the focus on clicks.
+func dispatchHit(e Event) {
+	m, ok := e.Data.(EvtMouse)
+	if !ok || e.Path != "/sys/mouse" {
+		return
+	}
+	hits.Lock()
+	r, ok := hitAt(image.Pt(m.X, m.Y))
+	if ok && isButton(m.Press) {
+		hits.focused = r.id
+	}
+	f := hits.handlers[r.id]
+	hits.Unlock()
+	if !ok || f == nil {
+		return
+	}
+	f(Event{
+		Type: "mouse",
+		Path: "/sys/hit/" + r.id,
+		From: "/sys",
+		Data: EvtHit{ID: r.id, X: m.X - r.r.Min.X, Y: m.Y - r.r.Min.Y, Mouse: m},
+		Time: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+// hitPar registers its inner area as a hit region.
+type hitPar struct {
+	*Par
+	id    string
+	dirty bool
+}
+
+func (p *hitPar) Buffer() Buffer {
+	RegisterHit(p.InnerBounds(), p.id)
+	return p.Par.Buffer()
+}
+
+func (p *hitPar) Dirty() bool { return p.dirty }
+
+func TestHitTest(t *testing.T) {
+	back := &hitPar{Par: NewPar("back"), id: "back"}
+	back.Width, back.Height = 20, 10
+	front := &hitPar{Par: NewPar("front"), id: "front"}
+	front.X, front.Y, front.Width, front.Height = 5, 5, 10, 3
+
+	frame := func() {
+		beginHits()
+		cache := bufCache{}
+		var drawn []image.Rectangle
+		for _, b := range []Bufferer{back, front} {
+			drawn = append(drawn, lastBufs.buffer(b, cache).Area)
+		}
+		lastBufs = cache
+		endHits(drawn)
+	}
+	frame()
+	frame() // regions of reused buffers are kept
+
+	if id, _ := HitTest(2, 2); id != "back" {
+		t.Errorf("hit %q at 2,2, want back", id)
+	}
+	if id, _ := HitTest(7, 6); id != "front" {
+		t.Errorf("hit %q at 7,6, want front on top", id)
+	}
+	if _, ok := HitTest(30, 30); ok {
+		t.Error("hit outside of the regions")
+	}
+
+	var got EvtHit
+	HandleHit("front", func(e Event) { got = e.Data.(EvtHit) })
+	defer HandleHit("front", nil)
+	dispatchHit(Event{Path: "/sys/mouse", Data: EvtMouse{X: 7, Y: 6, Press: "left"}})
+	if got.ID != "front" || got.X != 1 || got.Y != 0 {
+		t.Errorf("handler got %+v, want front at 1,0", got)
+	}
+	if HitFocus() != "front" {
+		t.Errorf("focus on %q after a click, want front", HitFocus())
+	}
+}
+
+func TestHitTestPartialRender(t *testing.T) {
+	h := NewHeadless(30, 10)
+	old := screen
+	screen = h
+	defer func() { screen = old }()
+	clearHits()
+	defer clearHits()
+
+	left := &hitPar{Par: NewPar("left"), id: "left"}
+	left.Width, left.Height = 10, 5
+	right := &hitPar{Par: NewPar("right"), id: "right"}
+	right.X, right.Width, right.Height = 10, 10, 5
+	render(left, right)
+	render(right)
+	if !h.WaitFrame(2, time.Second) {
+		t.Fatal("expected two frames")
+	}
+	if id, _ := HitTest(2, 2); id != "left" {
+		t.Errorf("hit %q at 2,2, want left, drawn in the frame before", id)
+	}
+	if id, _ := HitTest(12, 2); id != "right" {
+		t.Errorf("hit %q at 12,2, want right", id)
+	}
+	hits.Lock()
+	n := len(hits.regions)
+	hits.Unlock()
+	if n != 2 {
+		t.Errorf("expected the regions of right to be replaced, got %d regions", n)
+	}
+
+	Clear()
+	if _, ok := HitTest(2, 2); ok {
+		t.Error("a cleared screen should have no regions")
+	}
+}
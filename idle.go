@@ -0,0 +1,124 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+// idleState slows an EvtStream down while the user is away.
+type idleState struct {
+	sync.Mutex
+	after  time.Duration // 0 disables idle detection
+	factor int
+	last   time.Time // last keyboard or mouse event
+	on     bool
+	wake   chan struct{} // closed when the user comes back
+	woken  bool          // made active by SetIdle, "/sys/active" due
+}
+
+// SetIdle makes es idle once no keyboard or mouse event was received for
+// after: only one timer tick in factor is delivered, e.g. every 5s instead
+// of every 1s with a factor of 5, and Pollers wait factor times longer
+// between fetches. Input restores the full rate right away. Becoming idle
+// and active are delivered as "/sys/idle" and "/sys/active" events, e.g.
+// to stop animations. An after of 0 disables idle detection. Calling
+// SetIdle makes es active, "/sys/active" being delivered with the next
+// event if it was idle.
+func (es *EvtStream) SetIdle(after time.Duration, factor int) {
+	if factor < 1 {
+		factor = 1
+	}
+	es.idle.Lock()
+	es.idle.after = after
+	es.idle.factor = factor
+	es.idle.last = time.Now()
+	if es.idle.on {
+		es.idle.on = false
+		es.idle.woken = true
+		close(es.idle.wake)
+	}
+	es.idle.Unlock()
+}
+
+// Idle tells if es is idle, see SetIdle.
+func (es *EvtStream) Idle() bool {
+	es.idle.Lock()
+	defer es.idle.Unlock()
+	return es.idle.on
+}
+
+// idleWait returns the factor delays are multiplied by and a channel closed
+// when es stops being idle, nil while active.
+func (es *EvtStream) idleWait() (int, <-chan struct{}) {
+	es.idle.Lock()
+	defer es.idle.Unlock()
+	if !es.idle.on {
+		return 1, nil
+	}
+	return es.idle.factor, es.idle.wake
+}
+
+// throttle tracks input and tells if e is a tick dropped while idle.
+func (es *EvtStream) throttle(e Event) bool {
+	es.idle.Lock()
+	if es.idle.woken {
+		es.idle.woken = false
+		es.idle.Unlock()
+		es.dispatch(Event{Type: "idle", Path: "/sys/active", From: "internal", Time: time.Now().Unix()})
+		es.idle.Lock()
+	}
+	if es.idle.after <= 0 {
+		es.idle.Unlock()
+		return false
+	}
+
+	switch {
+	case e.Type == "keyboard" || e.Type == "mouse":
+		es.idle.last = time.Now()
+		if !es.idle.on {
+			es.idle.Unlock()
+			return false
+		}
+		es.idle.on = false
+		close(es.idle.wake)
+		es.idle.Unlock()
+		es.dispatch(Event{Type: "idle", Path: "/sys/active", From: "internal", Time: time.Now().Unix()})
+		return false
+
+	case e.Type == "timer":
+		became := !es.idle.on && time.Since(es.idle.last) >= es.idle.after
+		if became {
+			es.idle.on = true
+			es.idle.wake = make(chan struct{})
+		}
+		drop := false
+		if t, ok := e.Data.(EvtTimer); ok && es.idle.on {
+			drop = t.Count%uint64(es.idle.factor) != 0
+		}
+		es.idle.Unlock()
+		if became {
+			es.dispatch(Event{Type: "idle", Path: "/sys/idle", From: "internal", Time: time.Now().Unix()})
+		}
+		if drop {
+			count("termui.events.dropped", 1)
+		}
+		return drop
+	}
+	es.idle.Unlock()
+	return false
+}
+
+// SetIdle sets idle detection of the default event stream, see
+// EvtStream.SetIdle.
+func SetIdle(after time.Duration, factor int) {
+	DefaultEvtStream.SetIdle(after, factor)
+}
+
+// Idle tells if the default event stream is idle.
+func Idle() bool {
+	return DefaultEvtStream.Idle()
+}
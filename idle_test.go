@@ -0,0 +1,89 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvtStreamIdle(t *testing.T) {
+	es := NewEvtStream()
+	var got []string
+	es.Handle("/sys/idle", func(e Event) { got = append(got, e.Path) })
+	es.Handle("/sys/active", func(e Event) { got = append(got, e.Path) })
+
+	tick := func(n uint64) Event {
+		return Event{Type: "timer", Path: "/timer/1s", Data: EvtTimer{Duration: time.Second, Count: n}}
+	}
+	if es.throttle(tick(1)) {
+		t.Fatal("ticks should not be dropped without idle detection")
+	}
+
+	es.SetIdle(time.Minute, 3)
+	if es.throttle(tick(1)) || es.Idle() {
+		t.Fatal("should not be idle before a minute without input")
+	}
+
+	es.idle.last = time.Now().Add(-2 * time.Minute)
+	if !es.throttle(tick(2)) || !es.Idle() {
+		t.Fatal("expected idle and the tick dropped")
+	}
+	if es.throttle(tick(3)) {
+		t.Error("expected one tick in 3 delivered while idle")
+	}
+	factor, wake := es.idleWait()
+	if factor != 3 || wake == nil {
+		t.Fatalf("expected polls slowed down by 3, got %d", factor)
+	}
+
+	if es.throttle(Event{Type: "keyboard", Path: "/sys/kbd/q"}) || es.Idle() {
+		t.Fatal("input should make the stream active")
+	}
+	select {
+	case <-wake:
+	default:
+		t.Error("expected waiting polls to be woken")
+	}
+	if es.throttle(tick(4)) {
+		t.Error("ticks should not be dropped once active")
+	}
+	if len(got) != 2 || got[0] != "/sys/idle" || got[1] != "/sys/active" {
+		t.Errorf("unexpected events %v", got)
+	}
+}
+
+func TestEvtStreamIdleOff(t *testing.T) {
+	es := NewEvtStream()
+	var got []string
+	es.Handle("/sys/active", func(e Event) { got = append(got, e.Path) })
+	tick := Event{Type: "timer", Path: "/timer/1s", Data: EvtTimer{Duration: time.Second, Count: 2}}
+
+	es.SetIdle(time.Minute, 3)
+	es.idle.last = time.Now().Add(-2 * time.Minute)
+	if !es.throttle(tick) || !es.Idle() {
+		t.Fatal("expected idle and the tick dropped")
+	}
+	_, wake := es.idleWait()
+
+	es.SetIdle(0, 1)
+	if es.Idle() {
+		t.Error("disabling idle detection should make the stream active")
+	}
+	select {
+	case <-wake:
+	default:
+		t.Error("expected waiting polls to be woken")
+	}
+	if factor, wake := es.idleWait(); factor != 1 || wake != nil {
+		t.Errorf("expected polls at full rate, got a factor of %d", factor)
+	}
+	if es.throttle(tick) {
+		t.Error("ticks should not be dropped once idle detection is off")
+	}
+	if len(got) != 1 {
+		t.Errorf("expected /sys/active with the next event, got %v", got)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// Spacing dead keys as sent by terminals for accent keys.
+const (
+	deadAcute      = '´'
+	deadGrave      = '`'
+	deadCircumflex = '^'
+	deadTilde      = '~'
+	deadDiaeresis  = '¨'
+	deadCedilla    = '¸'
+)
+
+// combining diacritical marks and the dead key they correspond to
+var combiningDead = map[rune]rune{
+	'\u0300': deadGrave,
+	'\u0301': deadAcute,
+	'\u0302': deadCircumflex,
+	'\u0303': deadTilde,
+	'\u0308': deadDiaeresis,
+	'\u0327': deadCedilla,
+}
+
+var composeTable = func() map[[2]rune]rune {
+	m := make(map[[2]rune]rune)
+	add := func(dead rune, bases, composed string) {
+		cs := []rune(composed)
+		for i, b := range []rune(bases) {
+			m[[2]rune{dead, b}] = cs[i]
+		}
+	}
+	add(deadAcute, "aeiouyAEIOUYcnsz", "áéíóúýÁÉÍÓÚÝćńśź")
+	add(deadGrave, "aeiouAEIOU", "àèìòùÀÈÌÒÙ")
+	add(deadCircumflex, "aeiouAEIOU", "âêîôûÂÊÎÔÛ")
+	add(deadTilde, "anoANO", "ãñõÃÑÕ")
+	add(deadDiaeresis, "aeiouyAEIOU", "äëïöüÿÄËÏÖÜ")
+	add(deadCedilla, "cC", "çÇ")
+	return m
+}()
+
+// Compose returns the precomposed rune for base with the accent of a dead
+// key or combining mark, e.g. Compose('´', 'e') == 'é'.
+func Compose(accent, base rune) (rune, bool) {
+	if d, ok := combiningDead[accent]; ok {
+		accent = d
+	}
+	r, ok := composeTable[[2]rune{accent, base}]
+	return r, ok
+}
+
+// composer handles input method composition for text editing widgets:
+// dead key sequences typed on the keyboard and preedit text set by an
+// external input method, shown but not yet part of the text.
+type composer struct {
+	// DeadKeys composes dead key sequences, e.g. ´ then e gives é. Off by
+	// default, terminals compose them before the app sees the keys.
+	DeadKeys bool
+	preedit  []rune
+	dead     bool // preedit holds a pending dead key
+}
+
+// isDeadKey tells if r is taken for a dead key, only the spacing accents
+// outside ASCII: ` ^ ~ are typed for themselves, e.g. in ~/src or 2^8.
+func isDeadKey(r rune) bool {
+	switch r {
+	case deadAcute, deadDiaeresis, deadCedilla:
+		return true
+	}
+	return false
+}
+
+// feed processes one typed rune and returns the runes to commit to the text.
+func (c *composer) feed(r rune) []rune {
+	if c.dead {
+		d := c.preedit[0]
+		c.preedit, c.dead = nil, false
+		if cr, ok := Compose(d, r); ok {
+			return []rune{cr}
+		}
+		if r == ' ' || r == d {
+			return []rune{d}
+		}
+		return []rune{d, r}
+	}
+	if c.DeadKeys && isDeadKey(r) && len(c.preedit) == 0 {
+		c.preedit, c.dead = []rune{r}, true
+		return nil
+	}
+	return []rune{r}
+}
+
+// SetPreedit sets the uncommitted composition text of an input method.
+func (c *composer) SetPreedit(s string) {
+	c.preedit = []rune(s)
+	c.dead = false
+}
+
+// Preedit returns the uncommitted composition text.
+func (c *composer) Preedit() string {
+	return string(c.preedit)
+}
+
+// commit returns and clears the preedit text.
+func (c *composer) commit() []rune {
+	rs := c.preedit
+	c.preedit, c.dead = nil, false
+	return rs
+}
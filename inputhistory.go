@@ -0,0 +1,98 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// InputHistory is the ring of lines submitted to a TextInput, recalled
+// with <up> and <down> and searched with C-r. OnAdd lets apps persist the
+// lines as they are entered, Load and Save the whole ring.
+/*
+  h := termui.NewInputHistory(500)
+  if f, err := os.Open(path); err == nil {
+      h.Load(f)
+      f.Close()
+  }
+  h.OnAdd = func(line string) {
+      f, _ := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+      fmt.Fprintln(f, line)
+      f.Close()
+  }
+  input.Recall = h
+*/
+type InputHistory struct {
+	Max     int               // lines kept, 0 for no limit
+	OnAdd   func(line string) // called with every line added
+	entries []string
+}
+
+// NewInputHistory returns an *InputHistory keeping max lines.
+func NewInputHistory(max int) *InputHistory {
+	return &InputHistory{Max: max}
+}
+
+// Add appends line, unless it is blank or repeats the last one.
+func (h *InputHistory) Add(line string) {
+	if strings.TrimSpace(line) == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return
+	}
+	h.add(line)
+	if h.OnAdd != nil {
+		h.OnAdd(line)
+	}
+}
+
+func (h *InputHistory) add(line string) {
+	h.entries = append(h.entries, line)
+	if h.Max > 0 && len(h.entries) > h.Max {
+		h.entries = h.entries[len(h.entries)-h.Max:]
+	}
+}
+
+// Entries returns the lines, the oldest first.
+func (h *InputHistory) Entries() []string {
+	return append([]string(nil), h.entries...)
+}
+
+// Len returns the number of lines.
+func (h *InputHistory) Len() int {
+	return len(h.entries)
+}
+
+// Load appends the lines read from r, one per line, without calling OnAdd.
+func (h *InputHistory) Load(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if s.Text() != "" {
+			h.add(s.Text())
+		}
+	}
+	return s.Err()
+}
+
+// Save writes the lines to w, one per line, for Load to read back.
+func (h *InputHistory) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range h.entries {
+		bw.WriteString(e)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// search returns the index of the newest line before the index before
+// containing q.
+func (h *InputHistory) search(q string, before int) (int, bool) {
+	for i := clamp(before, 0, len(h.entries)) - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], q) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,336 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// KeyAction is a named, rebindable keyboard command.
+type KeyAction struct {
+	Name    string
+	Desc    string
+	Default string // key the action was registered with
+	Key     string // key currently bound, e.g. "q" or "C-s"
+	handler func(Event)
+}
+
+// Keymap registers keyboard actions on an event stream by name, so they can
+// be listed, checked for conflicts and rebound at runtime. When several
+// actions share a key, the one registered first receives the event.
+/*
+  km := termui.NewKeymap(termui.DefaultEvtStream)
+  km.Bind("quit", "q", "Quit the app", func(termui.Event) { termui.StopLoop() })
+  km.Bind("refresh", "r", "Refresh now", refresh)
+
+  s, _ := termui.LoadSession("myapp")
+  s.RestoreKeys(km)
+*/
+type Keymap struct {
+	sync.RWMutex
+	es      *EvtStream
+	actions []*KeyAction
+}
+
+// NewKeymap returns a *Keymap registering its actions on es.
+func NewKeymap(es *EvtStream) *Keymap {
+	return &Keymap{es: es}
+}
+
+func keyPath(key string) string {
+	return cleanPath("/sys/kbd/" + key)
+}
+
+// Bind registers the action name on key. Binding an existing name replaces
+// its handler and description but keeps a key it was rebound to.
+func (km *Keymap) Bind(name, key, desc string, f func(Event)) {
+	km.Lock()
+	a := km.find(name)
+	if a == nil {
+		a = &KeyAction{Name: name, Key: key}
+		km.actions = append(km.actions, a)
+	}
+	a.Desc = desc
+	a.Default = key
+	a.handler = f
+	k := a.Key
+	km.Unlock()
+
+	km.register(k)
+}
+
+func (km *Keymap) find(name string) *KeyAction {
+	for _, a := range km.actions {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// register routes key to the first action bound to it.
+func (km *Keymap) register(key string) {
+	km.es.Handle(keyPath(key), func(e Event) {
+		km.RLock()
+		var f func(Event)
+		for _, a := range km.actions {
+			if a.Key == key {
+				f = a.handler
+				break
+			}
+		}
+		km.RUnlock()
+		if f != nil {
+			f(e)
+		}
+	})
+}
+
+// Rebind moves the action name to key. The old key is unregistered when no
+// other action uses it anymore.
+func (km *Keymap) Rebind(name, key string) error {
+	km.Lock()
+	a := km.find(name)
+	if a == nil {
+		km.Unlock()
+		return fmt.Errorf("termui: unknown key action %q", name)
+	}
+	old := a.Key
+	a.Key = key
+	used := false
+	for _, b := range km.actions {
+		if b.Key == old {
+			used = true
+		}
+	}
+	km.Unlock()
+
+	if !used && old != key {
+		km.es.Lock()
+		delete(km.es.Handlers, keyPath(old))
+		km.es.Unlock()
+	}
+	km.register(key)
+	return nil
+}
+
+// Reset rebinds every action to its default key.
+func (km *Keymap) Reset() {
+	for _, a := range km.Actions() {
+		km.Rebind(a.Name, a.Default)
+	}
+}
+
+// Actions returns a copy of the registered actions in registration order.
+func (km *Keymap) Actions() []KeyAction {
+	km.RLock()
+	defer km.RUnlock()
+	as := make([]KeyAction, len(km.actions))
+	for i, a := range km.actions {
+		as[i] = *a
+	}
+	return as
+}
+
+// Conflicts returns the keys bound to more than one action, each with the
+// names of the actions sharing it.
+func (km *Keymap) Conflicts() map[string][]string {
+	km.RLock()
+	defer km.RUnlock()
+	byKey := make(map[string][]string)
+	for _, a := range km.actions {
+		byKey[a.Key] = append(byKey[a.Key], a.Name)
+	}
+	for k, ns := range byKey {
+		if len(ns) < 2 {
+			delete(byKey, k)
+		}
+	}
+	return byKey
+}
+
+// Bindings returns the keys of actions that differ from their defaults,
+// by action name.
+func (km *Keymap) Bindings() map[string]string {
+	km.RLock()
+	defer km.RUnlock()
+	m := make(map[string]string)
+	for _, a := range km.actions {
+		if a.Key != a.Default {
+			m[a.Name] = a.Key
+		}
+	}
+	return m
+}
+
+// SaveKeys records the rebound keys of km.
+func (s *Session) SaveKeys(km *Keymap) {
+	s.Keys = km.Bindings()
+}
+
+// RestoreKeys applies the recorded keys to the actions of km. Unknown
+// action names are ignored.
+func (s *Session) RestoreKeys(km *Keymap) {
+	names := make([]string, 0, len(s.Keys))
+	for n := range s.Keys {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		km.Rebind(n, s.Keys[n])
+	}
+}
+
+// KeymapEditor lists the actions of a Keymap with their keys, highlights
+// conflicting bindings and rebinds the selected action to the next key
+// pressed after <enter>.
+/*
+  ed := termui.NewKeymapEditor(km)
+  ed.OnChange = func() {
+      s.SaveKeys(km)
+      s.Write("myapp")
+  }
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      ed.HandleKey(e.Data.(termui.EvtKbd))
+      termui.Render(ed)
+  })
+*/
+type KeymapEditor struct {
+	Block
+	Keymap        *Keymap
+	Selected      int
+	TextFgColor   Attribute
+	KeyFgColor    Attribute
+	ConflictColor Attribute
+	CursorColor   Attribute
+	OnChange      func()
+	capturing     bool
+	offset        int
+}
+
+// NewKeymapEditor returns a new *KeymapEditor for km with current theme.
+func NewKeymapEditor(km *Keymap) *KeymapEditor {
+	ed := &KeymapEditor{Block: *NewBlock(), Keymap: km}
+	ed.TextFgColor = ThemeAttr("keymap.text.fg")
+	ed.KeyFgColor = ThemeAttr("keymap.key.fg") | AttrBold
+	ed.ConflictColor = ColorRed
+	ed.CursorColor = ColorBlue
+	ed.BorderLabel = Msg("keys")
+	ed.Height = 12
+	return ed
+}
+
+// Capturing tells if the editor waits for the new key of the selected action.
+func (ed *KeymapEditor) Capturing() bool {
+	return ed.capturing
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed.
+// <up>/<down> select an action, <enter> starts capturing its new key,
+// <escape> cancels the capture and "C-d" restores the default key.
+func (ed *KeymapEditor) HandleKey(k EvtKbd) bool {
+	as := ed.Keymap.Actions()
+	if len(as) == 0 {
+		return false
+	}
+	ed.Selected = clamp(ed.Selected, 0, len(as)-1)
+
+	if ed.capturing {
+		ed.capturing = false
+		if k.KeyStr != "<escape>" {
+			ed.rebind(as[ed.Selected].Name, k.KeyStr)
+		}
+		return true
+	}
+
+	switch k.KeyStr {
+	case "<up>":
+		ed.Selected = clamp(ed.Selected-1, 0, len(as)-1)
+	case "<down>":
+		ed.Selected = clamp(ed.Selected+1, 0, len(as)-1)
+	case "<enter>":
+		ed.capturing = true
+	case "C-d":
+		ed.rebind(as[ed.Selected].Name, as[ed.Selected].Default)
+	default:
+		return false
+	}
+	return true
+}
+
+func (ed *KeymapEditor) rebind(name, key string) {
+	ed.Keymap.Rebind(name, key)
+	if ed.OnChange != nil {
+		ed.OnChange()
+	}
+}
+
+// Buffer implements Bufferer interface.
+func (ed *KeymapEditor) Buffer() Buffer {
+	buf := ed.Block.Buffer()
+	if ed.innerArea.Dx() <= 0 || ed.innerArea.Dy() <= 0 {
+		return buf
+	}
+
+	as := ed.Keymap.Actions()
+	conflicts := ed.Keymap.Conflicts()
+	keyW := 0
+	for _, a := range as {
+		if w := strWidth(a.Key); w > keyW {
+			keyW = w
+		}
+	}
+
+	rows := ed.innerArea.Dy()
+	if ed.Selected < ed.offset {
+		ed.offset = ed.Selected
+	}
+	if ed.Selected >= ed.offset+rows {
+		ed.offset = ed.Selected - rows + 1
+	}
+
+	for i := ed.offset; i < len(as) && i-ed.offset < rows; i++ {
+		a := as[i]
+		y := ed.innerArea.Min.Y + i - ed.offset
+
+		key := a.Key
+		if ed.capturing && i == ed.Selected {
+			key = "…"
+		}
+		keyFg, textFg := ed.KeyFgColor, ed.TextFgColor
+		desc := a.Desc
+		if ns, ok := conflicts[a.Key]; ok {
+			keyFg = ed.ConflictColor | AttrBold
+			for _, n := range ns {
+				if n != a.Name {
+					desc += " [" + n + "]"
+				}
+			}
+		}
+		bg := ed.Bg
+		if i == ed.Selected {
+			bg = ed.CursorColor
+		}
+
+		cs := TextCells(key, keyFg, bg)
+		for w := strWidth(key); w < keyW+2; w++ {
+			cs = append(cs, Cell{Ch: ' ', Fg: keyFg, Bg: bg})
+		}
+		cs = append(cs, TextCells(desc, textFg, bg)...)
+		cs = TruncateRight(cs, ed.innerArea.Dx())
+
+		x := ed.innerArea.Min.X
+		for _, c := range cs {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+		for ; i == ed.Selected && x < ed.innerArea.Max.X; x++ {
+			buf.Set(x, y, Cell{Ch: ' ', Fg: textFg, Bg: bg})
+		}
+	}
+	return buf
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestKeymapRebind(t *testing.T) {
+	es := NewEvtStream()
+	km := NewKeymap(es)
+
+	var got []string
+	km.Bind("quit", "q", "Quit", func(Event) { got = append(got, "quit") })
+	km.Bind("query", "q", "Query", func(Event) { got = append(got, "query") })
+
+	if c := km.Conflicts(); len(c["q"]) != 2 {
+		t.Fatalf("expected conflict on q, got %v", c)
+	}
+	es.dispatch(Event{Path: "/sys/kbd/q"})
+
+	ed := NewKeymapEditor(km)
+	for _, k := range []string{"<down>", "<enter>", "x"} {
+		ed.HandleKey(EvtKbd{KeyStr: k})
+	}
+	if len(km.Conflicts()) != 0 {
+		t.Errorf("expected no conflicts, got %v", km.Conflicts())
+	}
+	es.dispatch(Event{Path: "/sys/kbd/x"})
+	es.dispatch(Event{Path: "/sys/kbd/q"})
+	if len(got) != 3 || got[0] != "quit" || got[1] != "query" || got[2] != "quit" {
+		t.Errorf("unexpected dispatch %v", got)
+	}
+
+	s := NewSession()
+	s.SaveKeys(km)
+	if s.Keys["query"] != "x" || len(s.Keys) != 1 {
+		t.Errorf("unexpected saved keys %v", s.Keys)
+	}
+	km.Reset()
+	s.RestoreKeys(km)
+	if km.Actions()[1].Key != "x" {
+		t.Errorf("expected restored key x, got %q", km.Actions()[1].Key)
+	}
+}
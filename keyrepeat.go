@@ -0,0 +1,73 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+// KeyRepeatConfig controls how held keys are detected and accelerated.
+// A key event is a repeat when the same key arrived less than Interval ago.
+// Step starts at 1 and doubles every Doubling repeats, up to MaxStep.
+type KeyRepeatConfig struct {
+	Interval time.Duration
+	Doubling int
+	MaxStep  int
+}
+
+// KeyRepeat is the configuration used for all keyboard events.
+var KeyRepeat = KeyRepeatConfig{
+	Interval: 120 * time.Millisecond,
+	Doubling: 10,
+	MaxStep:  32,
+}
+
+// Step returns how many rows/cells a scrolling or panning widget should
+// move for k, growing the longer the key is held.
+/*
+  termui.Handle("/sys/kbd/<down>", func(e termui.Event) {
+      k := e.Data.(termui.EvtKbd)
+      table.ScrollDown(k.Step())
+  })
+*/
+func (k EvtKbd) Step() int {
+	step := 1
+	if KeyRepeat.Doubling <= 0 {
+		return step
+	}
+	for i := KeyRepeat.Doubling; i <= k.Repeat && step < KeyRepeat.MaxStep; i += KeyRepeat.Doubling {
+		step *= 2
+	}
+	if KeyRepeat.MaxStep > 0 && step > KeyRepeat.MaxStep {
+		step = KeyRepeat.MaxStep
+	}
+	return step
+}
+
+// repeatTracker counts consecutive repeats of the same key.
+type repeatTracker struct {
+	sync.Mutex
+	key    string
+	at     time.Time
+	repeat int
+}
+
+func (rt *repeatTracker) feed(k EvtKbd, now time.Time) EvtKbd {
+	rt.Lock()
+	defer rt.Unlock()
+
+	if k.KeyStr == rt.key && now.Sub(rt.at) <= KeyRepeat.Interval {
+		rt.repeat++
+	} else {
+		rt.repeat = 0
+	}
+	rt.key = k.KeyStr
+	rt.at = now
+	k.Repeat = rt.repeat
+	return k
+}
+
+var defaultRepeats = &repeatTracker{}
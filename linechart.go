@@ -6,39 +6,47 @@ package termui
 
 import (
 	"fmt"
+	"image"
 	"math"
 	"sort"
+	"strings"
+	"time"
 )
 
-// only 16 possible combinations, why bother
-var braillePatterns = map[[2]int]rune{
-	[2]int{0, 0}: '⣀',
-	[2]int{0, 1}: '⡠',
-	[2]int{0, 2}: '⡐',
-	[2]int{0, 3}: '⡈',
-
-	[2]int{1, 0}: '⢄',
-	[2]int{1, 1}: '⠤',
-	[2]int{1, 2}: '⠔',
-	[2]int{1, 3}: '⠌',
-
-	[2]int{2, 0}: '⢂',
-	[2]int{2, 1}: '⠢',
-	[2]int{2, 2}: '⠒',
-	[2]int{2, 3}: '⠊',
-
-	[2]int{3, 0}: '⢁',
-	[2]int{3, 1}: '⠡',
-	[2]int{3, 2}: '⠑',
-	[2]int{3, 3}: '⠉',
-}
-
-var lSingleBraille = [4]rune{'\u2840', '⠄', '⠂', '⠁'}
-var rSingleBraille = [4]rune{'\u2880', '⠠', '⠐', '⠈'}
+// brailleDots are the dots of a braille cell, by height from the bottom
+// of the cell and column.
+var brailleDots = [4][2]rune{{0x40, 0x80}, {0x04, 0x20}, {0x02, 0x10}, {0x01, 0x08}}
 
 // LineChart has two modes: braille(default) and dot.
 // A single braille character is a 2x4 grid of dots, so Using braille
 // gives 2x X resolution and 4x Y resolution over dot mode.
+// Series are plotted against the left y axis unless SeriesAxis puts them on
+// the "right" one, which has its own range and labels: Y2Ceil, Y2Floor,
+// Y2Padding and Y2Scale are to it what YCeil, YFloor, YPadding and YScale
+// are to the left one.
+// Times, if set, are the times of the data points, the last one being the
+// time of the last point of every series; the x axis then shows time ticks
+// evenly spaced over the visible span instead of DataLabels.
+// Fill shades the area below every line, making an area chart, in a dimmer
+// color than the line; FillColor sets the shade of some series, and fills
+// them even without Fill.
+// DataOffset pans the chart back through history: the newest DataOffset
+// points of every series are left out, and an indicator in the top right
+// corner tells how far back the chart is.
+// Zoom draws that many times more points per column, each column showing
+// the minimum and maximum of its points, to look over long histories.
+// Series longer than the plot is wide lose their oldest points, unless
+// Downsample squeezes them into its columns: "mean", "min" or "max" of
+// evenly sized buckets, or "lttb" to keep the shape of the series.
+// Both are kept from frame to frame until points are appended, so that
+// panning over long histories stays fast; change points in place with
+// SetPoint, or see ClearCache.
+// YAxisSide "right" moves the y axis and its labels right of the plot, and
+// the right axis of SeriesAxis left of it, e.g. for the second chart of a
+// pair facing each other; XAxisSide "top" moves the x labels above it.
+// EndLabels writes the name and last value of every series right of the
+// plot, at the height of its last point, in place of a legend; labels
+// sharing a row are moved apart.
 /*
   lc := termui.NewLineChart()
   lc.Border.Label = "braille-mode Line Chart"
@@ -48,22 +56,80 @@ var rSingleBraille = [4]rune{'\u2880', '⠠', '⠐', '⠈'}
   lc.AxesColor = termui.ColorWhite
   lc.LineColor = termui.ColorGreen | termui.AttrBold
   // termui.Render(lc)...
+
+  // time axis: one timestamp per point, labels like "15:04" or "Jan 2"
+  lc.Times = []time.Time{...}
+
+  // values spanning orders of magnitude, those <= 0 drawn at the bottom
+  lc.YScale = "log"
+
+  // latency in ms on the left, request rate on the right
+  lc.SeriesAxis["rps"] = "right"
+  lc.Y2Floor = 0
+
+  // area chart of the total, with a line on top of it
+  lc.Fill = true
+
+  termui.Handle("/sys/kbd/<left>", func(termui.Event) { lc.ScrollLeft(); termui.Render(lc) })
+  termui.Handle("/sys/kbd/<right>", func(termui.Event) { lc.ScrollRight(); termui.Render(lc) })
+  termui.Handle("/sys/kbd/<end>", func(termui.Event) { lc.ScrollToEnd(); termui.Render(lc) })
+  termui.Handle("/sys/kbd/-", func(termui.Event) { lc.ZoomOut(); termui.Render(lc) })
+  termui.Handle("/sys/kbd/+", func(termui.Event) { lc.ZoomIn(); termui.Render(lc) })
+
+  // SLO of 200ms, against the left axis
+  lc.AddHLine(200, termui.ColorRed, "p99 SLO")
+
+  // error markers as dots over the braille lines
+  lc.SeriesMode["errors"] = "dot"
+
+  // told apart without colors
+  lc.LineStyle["p50"] = "dotted"
+  lc.LineStyle["p99"] = "thick"
+
+  // the right chart of a pair, its y axis on the outer side
+  lc.YAxisSide = "right"
+
+  // "p50 12.30" and "p99 48.10" at the end of their lines
+  lc.EndLabels = true
 */
 type LineChart struct {
 	Block
 	AxesColor        Attribute
 	Data             map[string][]float64
-	DataLabels       []string // if unset, the data indices will be used
+	DataLabels       []string    // if unset, the data indices will be used
+	Times            []time.Time // if set, time ticks label the x axis
+	Window           TimeWindow  // span of Times shown, see TimeRange
+	DataOffset       int         // newest points not shown
+	Zoom             int         // points per column, as a multiple of the Mode's
+	Downsample       string      // none | mean | min | max | lttb
 	DotStyle         rune
 	LineColor        map[string]Attribute
-	Mode             string // braille | dot
+	Mode             string            // braille | dot
+	SeriesMode       map[string]string // Mode of single series, e.g. dots over lines
+	LineStyle        map[string]string // solid | dashed | dotted | thick, per series
 	YCeil            float64
 	YFloor           float64
 	YPadding         float64
+	YScale           string // linear | log
+	SeriesAxis       map[string]string
+	Y2Ceil           float64
+	Y2Floor          float64
+	Y2Padding        float64
+	Y2Scale          string
+	BandColors       []Attribute // background colors cycled per y label interval
+	SnapshotColor    Attribute   // color of frozen series, see Snapshot
+	SnapshotDashed   bool
+	Fill             bool
+	FillColor        map[string]Attribute
+	MaxPoints        int    // points per series kept by AddPoint
+	YAxisSide        string // left | right
+	XAxisSide        string // bottom | top
+	EndLabels        bool
 	autoLabels       bool
 	axisXLabelGap    int
 	axisXLebelGap    int
 	axisXWidth       int
+	labelXGap        int // cells between index labels
 	axisYHeight      int
 	axisYLabelGap    int
 	axisYLebelGap    int
@@ -74,10 +140,53 @@ type LineChart struct {
 	labelX           [][]rune
 	labelY           [][]rune
 	labelYSpace      int
+	endLabelSpace    int // columns of EndLabels, right of the right axis
 	maxY             float64
 	minY             float64
 	scale            float64 // data span per cell on y-axis
 	topValue         float64
+	snapshot         map[string][]float64
+	timeLabels       []timeLabel
+	rings            map[string]*pointRing
+	right            rightAxis
+	hlines           []hLine
+	decim            decimCache
+	minMax           map[string]*minMaxIndex // of the series, see Append
+	gens             map[string]seriesGen    // of the series, see decimCache
+
+	// LabelCount returns the number of labels wanted on the axis "x" or
+	// "y", given its length and the width of its labels, in cells. It is
+	// asked on every frame, so that the labels follow the size of the
+	// chart; nil uses DefaultLabelCount. No more labels than fit are drawn.
+	LabelCount func(axis string, length, labelWidth int) int
+
+	// AutoScale sets how the y axes follow the points shown: "expand"
+	// widens them to new extremes and never narrows them back, "fit" fits
+	// them to the points on every frame, and "decay" widens them at once
+	// but narrows them back to the points gradually, by half the gap every
+	// ScaleHalfLife, so that a spike does not squash the chart for good.
+	AutoScale     string
+	ScaleHalfLife time.Duration
+	scaledAt      time.Time // frame the range of decay was last set at
+	yLock         yRange    // see SetYRange
+}
+
+// hLine is a horizontal line of a LineChart, see AddHLine.
+type hLine struct {
+	value float64
+	color Attribute
+	label string
+}
+
+// rightAxis is the state of the right y axis of a LineChart, see onRight.
+type rightAxis struct {
+	bottomValue float64
+	topValue    float64
+	scale       float64
+	labelY      [][]rune
+	labelYSpace int
+	scaledAt    time.Time
+	yLock       yRange
 }
 
 // NewLineChart returns a new LineChart with current theme.
@@ -89,6 +198,9 @@ func NewLineChart() *LineChart {
 	lc.DotStyle = '•'
 	lc.Data = make(map[string][]float64)
 	lc.LineColor = make(map[string]Attribute)
+	lc.FillColor = make(map[string]Attribute)
+	lc.SeriesMode = make(map[string]string)
+	lc.LineStyle = make(map[string]string)
 	lc.axisXLabelGap = 2
 	lc.axisYLabelGap = 1
 	lc.bottomValue = math.Inf(1)
@@ -96,26 +208,94 @@ func NewLineChart() *LineChart {
 	lc.YPadding = 0.2
 	lc.YFloor = math.Inf(-1)
 	lc.YCeil = math.Inf(1)
+	lc.SeriesAxis = make(map[string]string)
+	lc.Y2Padding = 0.2
+	lc.Y2Floor = math.Inf(-1)
+	lc.Y2Ceil = math.Inf(1)
+	lc.right.bottomValue = math.Inf(1)
+	lc.right.topValue = math.Inf(-1)
+	lc.SnapshotColor = ThemeAttr("linechart.snapshot.fg")
+	lc.SnapshotDashed = true
+	lc.MaxPoints = 1000
+	lc.YAxisSide = "left"
+	lc.XAxisSide = "bottom"
+	lc.AutoScale = "expand"
+	lc.ScaleHalfLife = 5 * time.Second
 	return lc
 }
 
-// one cell contains two data points, so capicity is 2x dot mode
-func (lc *LineChart) renderBraille() Buffer {
+// lineColor returns the color series name is drawn with.
+func (lc *LineChart) lineColor(name string) Attribute {
+	if c, ok := lc.LineColor[name]; ok {
+		return c
+	}
+	return lc.defaultLineColor
+}
+
+// fillColor returns the color the area below series name is shaded with,
+// and whether it is shaded.
+func (lc *LineChart) fillColor(name string) (Attribute, bool) {
+	if c, ok := lc.FillColor[name]; ok {
+		return c, true
+	}
+	return darken(lc.lineColor(name), ColorDefault), lc.Fill
+}
+
+// renderFill shades the cells below the points of the filled series of
+// data, down to the x axis.
+func (lc *LineChart) renderFill(data map[string][]float64) Buffer {
 	buf := NewBuffer()
+	// points per cell, and dots per cell height as in the renderers
+	perCell, levels, shade := 2, 4, '⣿'
+	if lc.Mode == "dot" {
+		perCell, levels, shade = 1, 1, '█'
+	}
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	// return: b -> which cell should the point be in
-	//         m -> in the cell, divided into 4 equal height levels, which subcell?
-	getPos := func(d float64) (b, m int) {
-		cnt4 := int((d-lc.bottomValue)/(lc.scale/4) + 0.5)
-		b = cnt4 / 4
-		m = cnt4 % 4
-		return
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	minCell := lc.innerArea.Min.X + lc.labelYSpace
+	for _, name := range names {
+		fg, ok := lc.fillColor(name)
+		if !ok {
+			continue
+		}
+		d := data[name]
+		cellPos := lc.innerArea.Max.X - 1
+		for dataPos := len(d) - 1; dataPos >= 0 && cellPos > minCell; dataPos -= perCell {
+			top := lc.yPos(d[dataPos])
+			if dataPos > 0 && perCell == 2 {
+				top = math.Max(top, lc.yPos(d[dataPos-1]))
+			}
+			b := int((top-lc.bottomValue)/(lc.scale/float64(levels))+0.5) / levels
+			for y := origY - b; y < origY; y++ {
+				buf.Set(cellPos, y, Cell{Ch: shade, Fg: fg, Bg: lc.Bg})
+			}
+			cellPos--
+		}
+	}
+	return buf
+}
+
+// one cell contains two data points, so capicity is 2x dot mode. Points more
+// than a dot apart vertically are joined by a vertical run of dots, half in
+// the column of each, so that steep lines stay continuous. Dotted lines
+// keep every other dot, thick ones add the dot above every dot.
+func (lc *LineChart) renderBraille(data map[string][]float64, color func(string) Attribute, dashed bool) Buffer {
+	buf := NewBuffer()
+
+	// level returns the height of d in dots from the bottom of the plot
+	level := func(d float64) int {
+		return int(math.Floor((lc.yPos(d)-lc.bottomValue)/(lc.scale/4) + 0.5))
 	}
 
 	// Sort the series so that overlapping data will overlap the same way each time
-	seriesList := make([]string, len(lc.Data))
+	seriesList := make([]string, len(data))
 	i := 0
-	for seriesName := range lc.Data {
+	for seriesName := range data {
 		seriesList[i] = seriesName
 		i++
 	}
@@ -123,83 +303,99 @@ func (lc *LineChart) renderBraille() Buffer {
 
 	// plot points
 	for _, seriesName := range seriesList {
-		seriesData := lc.Data[seriesName]
+		seriesData := data[seriesName]
 		if len(seriesData) == 0 {
 			continue
 		}
-		thisLineColor, ok := lc.LineColor[seriesName]
-		if !ok {
-			thisLineColor = lc.defaultLineColor
+		thisLineColor := color(seriesName)
+		style := lc.lineStyle(seriesName)
+		if dashed {
+			style = "dashed"
+		}
+
+		// dots of the series by cell; x counts dot columns
+		dots := make(map[image.Point]rune)
+		set := func(x, lo, hi int) {
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if style == "thick" {
+				hi++
+			}
+			for l := lo; l <= hi; l++ {
+				if style == "dotted" && (x%2 == 1 || (l-lo)%2 == 1) {
+					continue
+				}
+				b := int(math.Floor(float64(l) / 4))
+				y := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - b
+				dots[image.Pt(x/2, y)] |= brailleDots[l-4*b][x%2]
+			}
 		}
 
 		minCell := lc.innerArea.Min.X + lc.labelYSpace
+		prevX, prevL := -1, 0 // the point drawn last, right of this one
 		cellPos := lc.innerArea.Max.X - 1
 		for dataPos := len(seriesData) - 1; dataPos >= 0 && cellPos > minCell; {
-			b0, m0 := getPos(seriesData[dataPos])
-			var b1, m1 int
-
-			if dataPos > 0 {
-				b1, m1 = getPos(seriesData[dataPos-1])
-
-				if b0 == b1 {
-					c := Cell{
-						Ch: braillePatterns[[2]int{m1, m0}],
-						Bg: lc.Bg,
-						Fg: thisLineColor,
-					}
-					y := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - b0
-					buf.Set(cellPos, y, c)
-				} else {
-					c0 := Cell{
-						Ch: rSingleBraille[m0],
-						Fg: thisLineColor,
-						Bg: lc.Bg,
-					}
-					y0 := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - b0
-					buf.Set(cellPos, y0, c0)
-
-					c1 := Cell{
-						Ch: lSingleBraille[m1],
-						Fg: thisLineColor,
-						Bg: lc.Bg,
+			if style == "dashed" && (lc.innerArea.Max.X-1-cellPos)/2%2 == 1 {
+				prevX = -1
+				dataPos -= 2
+				cellPos--
+				continue
+			}
+			for sub := 1; sub >= 0 && dataPos+sub-1 >= 0; sub-- {
+				x, l := 2*cellPos+sub, level(seriesData[dataPos+sub-1])
+				set(x, l, l)
+				if prevX == x+1 && (l > prevL+1 || prevL > l+1) {
+					mid := int(math.Floor(float64(l+prevL) / 2))
+					if l < prevL {
+						set(x, l, mid)
+						set(prevX, mid+1, prevL)
+					} else {
+						set(x, mid+1, l)
+						set(prevX, prevL, mid)
 					}
-					y1 := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - b1
-					buf.Set(cellPos, y1, c1)
-				}
-			} else {
-				c0 := Cell{
-					Ch: rSingleBraille[m0],
-					Fg: thisLineColor,
-					Bg: lc.Bg,
 				}
-				x0 := cellPos
-				y0 := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - b0
-				buf.Set(x0, y0, c0)
+				prevX, prevL = x, l
 			}
 			dataPos -= 2
 			cellPos--
 		}
+
+		for p, d := range dots {
+			buf.Set(p.X, p.Y, Cell{Ch: 0x2800 + d, Fg: thisLineColor, Bg: lc.Bg})
+		}
 	}
 	return buf
 }
 
-func (lc *LineChart) renderDot() Buffer {
+// renderDot draws a DotStyle rune per point. Dashed and dotted lines skip
+// cells, thick ones are bold.
+func (lc *LineChart) renderDot(data map[string][]float64, color func(string) Attribute, dashed bool) Buffer {
 	buf := NewBuffer()
-	for seriesName, seriesData := range lc.Data {
-		thisLineColor, ok := lc.LineColor[seriesName]
-		if !ok {
-			thisLineColor = lc.defaultLineColor
+	for seriesName, seriesData := range data {
+		thisLineColor := color(seriesName)
+		style := lc.lineStyle(seriesName)
+		if dashed {
+			style = "dashed"
+		}
+		if style == "thick" {
+			thisLineColor |= AttrBold
 		}
 		minCell := lc.innerArea.Min.X + lc.labelYSpace
 		cellPos := lc.innerArea.Max.X - 1
 		for dataPos := len(seriesData) - 1; dataPos >= 0 && cellPos > minCell; {
+			if style == "dashed" && (lc.innerArea.Max.X-1-cellPos)%2 == 1 || style == "dotted" && (lc.innerArea.Max.X-1-cellPos)%3 != 0 {
+				cellPos--
+				dataPos--
+				continue
+			}
 			c := Cell{
 				Ch: lc.DotStyle,
 				Fg: thisLineColor,
 				Bg: lc.Bg,
 			}
 			x := cellPos
-			y := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - int((seriesData[dataPos]-lc.bottomValue)/lc.scale+0.5)
+			y := lc.innerArea.Min.Y + lc.innerArea.Dy() - 3 - int((lc.yPos(seriesData[dataPos])-lc.bottomValue)/lc.scale+0.5)
 			buf.Set(x, y, c)
 
 			cellPos--
@@ -210,35 +406,122 @@ func (lc *LineChart) renderDot() Buffer {
 	return buf
 }
 
-func (lc *LineChart) calcLabelX() {
-	lc.labelX = [][]rune{}
+// lineStyle returns the LineStyle of series name.
+func (lc *LineChart) lineStyle(name string) string {
+	if s, ok := lc.LineStyle[name]; ok {
+		return s
+	}
+	return "solid"
+}
 
-	for i, l := 0, 0; i < len(lc.DataLabels) && l < lc.axisXWidth; i++ {
-		if lc.Mode == "dot" {
-			if l >= len(lc.DataLabels) {
-				break
-			}
+// seriesMode returns the mode series name is drawn in.
+func (lc *LineChart) seriesMode(name string) string {
+	if m, ok := lc.SeriesMode[name]; ok {
+		return m
+	}
+	return lc.Mode
+}
 
-			s := str2runes(lc.DataLabels[l])
-			w := strWidth(lc.DataLabels[l])
-			if l+w <= lc.axisXWidth {
-				lc.labelX = append(lc.labelX, s)
-			}
-			l += w + lc.axisXLabelGap
-		} else { // braille
-			if 2*l >= len(lc.DataLabels) {
-				break
+// modeSeries returns d as the points of a series drawn in mode, while the
+// columns of the chart hold the points of its Mode: the pairs of points of
+// a braille chart are drawn as the largest of the two by dots, and the
+// points of a dot chart are drawn twice by braille.
+func (lc *LineChart) modeSeries(d []float64, mode string) []float64 {
+	switch perCell := lc.pointsPerCell(); {
+	case mode == "dot" && perCell == 2:
+		out := make([]float64, (len(d)+1)/2)
+		for i, j := len(d)-1, len(out)-1; j >= 0; i, j = i-2, j-1 {
+			out[j] = d[i]
+			if i > 0 {
+				out[j] = math.Max(d[i-1], d[i])
 			}
+		}
+		return out
+	case mode != "dot" && perCell == 1:
+		out := make([]float64, 0, 2*len(d))
+		for _, v := range d {
+			out = append(out, v, v)
+		}
+		return out
+	}
+	return d
+}
 
-			s := str2runes(lc.DataLabels[2*l])
-			w := strWidth(lc.DataLabels[2*l])
-			if l+w <= lc.axisXWidth {
-				lc.labelX = append(lc.labelX, s)
-			}
-			l += w + lc.axisXLabelGap
+// renderSeries draws the series of data in their mode, dots over lines.
+func (lc *LineChart) renderSeries(data map[string][]float64, color func(string) Attribute, dashed bool) Buffer {
+	braille := make(map[string][]float64)
+	dot := make(map[string][]float64)
+	for name, d := range data {
+		if mode := lc.seriesMode(name); mode == "dot" {
+			dot[name] = lc.modeSeries(d, mode)
+		} else {
+			braille[name] = lc.modeSeries(d, mode)
+		}
+	}
+	buf := lc.renderBraille(braille, color, dashed)
+	buf.Merge(lc.renderDot(dot, color, dashed))
+	return buf
+}
+
+func (lc *LineChart) calcLabelX() {
+	lc.labelX = [][]rune{}
 
+	// labels of the points of the columns, one per column
+	step := lc.pointsPerCell()
+	maxW := 0
+	for i := 0; i < len(lc.DataLabels) && i/step < lc.axisXWidth; i += step {
+		if w := strWidth(lc.DataLabels[i]); w > maxW {
+			maxW = w
 		}
 	}
+	n := lc.labelCount("x", lc.axisXWidth, maxW)
+	if n == 0 {
+		return
+	}
+	lc.labelXGap = lc.axisXWidth/n - maxW
+	if lc.labelXGap < 1 {
+		lc.labelXGap = 1
+	}
+
+	for l := 0; l*step < len(lc.DataLabels) && l < lc.axisXWidth; {
+		s := str2runes(lc.DataLabels[l*step])
+		w := strWidth(lc.DataLabels[l*step])
+		if l+w <= lc.axisXWidth {
+			lc.labelX = append(lc.labelX, s)
+		}
+		l += w + lc.labelXGap
+	}
+}
+
+// DefaultLabelCount is the number of labels of a LineChart axis without
+// LabelCount: on the y axis one every other row, or every third or fourth
+// one on taller axes, and on the x axis as many as fit 2 cells apart.
+func DefaultLabelCount(axis string, length, labelWidth int) int {
+	if axis == "x" {
+		return length / (labelWidth + 2)
+	}
+	per := 2
+	switch {
+	case length >= 40:
+		per = 4
+	case length >= 16:
+		per = 3
+	}
+	return (length + 1) / per
+}
+
+// labelCount returns the number of labels of axis, of length cells with
+// labels labelWidth wide, at most as many as fit.
+func (lc *LineChart) labelCount(axis string, length, labelWidth int) int {
+	count := lc.LabelCount
+	if count == nil {
+		count = DefaultLabelCount
+	}
+	max := length + 1
+	if axis == "x" {
+		max = length / (labelWidth + 1)
+	}
+	return clamp(count(axis, length, labelWidth), 0, max)
 }
 
 func shortenFloatVal(x float64) string {
@@ -250,44 +533,81 @@ func shortenFloatVal(x float64) string {
 	if x < 0 {
 		s = fmt.Sprintf("%.2f", x)
 	}
+	// localize the decimal separator
+	if d := CurrentLocale().Decimal; d != "." {
+		s = strings.Replace(s, ".", d, 1)
+	}
 	return s
 }
 
-func (lc *LineChart) calcLabelY() {
+// yv returns v on the scale of the y axis: v itself, or its logarithm with
+// YScale "log", -Inf for values <= 0.
+func (lc *LineChart) yv(v float64) float64 {
+	if lc.YScale != "log" {
+		return v
+	}
+	if v <= 0 {
+		return math.Inf(-1)
+	}
+	return math.Log10(v)
+}
+
+// yPos returns the height of v on the y axis, values <= 0 on a log scale
+// being drawn at the bottom.
+func (lc *LineChart) yPos(v float64) float64 {
+	if p := lc.yv(v); !math.IsInf(p, -1) {
+		return p
+	}
+	return lc.bottomValue
+}
+
+// yLabel returns the value at the height p of the y axis.
+func (lc *LineChart) yLabel(p float64) float64 {
+	if lc.YScale == "log" {
+		return math.Pow(10, p)
+	}
+	return p
+}
+
+// calcLabelY sets the scale of the y axis and returns its labels, from the
+// bottom, with their width.
+func (lc *LineChart) calcLabelY() ([][]rune, int) {
 	span := lc.topValue - lc.bottomValue
 	// where does -2 come from? Without it, we might draw on the top border or past the block
 	lc.scale = span / float64(lc.axisYHeight-2)
 
-	n := (1 + lc.axisYHeight) / (lc.axisYLabelGap + 1)
-	lc.labelY = make([][]rune, n)
+	n := lc.labelCount("y", lc.axisYHeight, strWidth(shortenFloatVal(lc.yLabel(lc.topValue))))
+	if n == 0 {
+		return nil, 0
+	}
+	lc.axisYLabelGap = (1+lc.axisYHeight)/n - 1
+	if lc.axisYLabelGap < 0 {
+		lc.axisYLabelGap = 0
+	}
+	labelY := make([][]rune, n)
 	maxLen := 0
 	for i := 0; i < n; i++ {
-		s := str2runes(shortenFloatVal(lc.bottomValue + float64(i)*span/float64(n)))
+		s := str2runes(shortenFloatVal(lc.yLabel(lc.bottomValue + float64(i)*span/float64(n))))
 		if len(s) > maxLen {
 			maxLen = len(s)
 		}
-		lc.labelY[i] = s
+		labelY[i] = s
 	}
 
-	lc.labelYSpace = maxLen
+	return labelY, maxLen
 }
 
-func (lc *LineChart) calcLayout() {
-	for _, seriesData := range lc.Data {
-		if seriesData == nil || len(seriesData) == 0 {
+// fitY lazily widens the range of the y axis to the visible points of
+// series.
+func (lc *LineChart) fitY(series map[string][]float64) {
+	for _, seriesData := range series {
+		if len(seriesData) == 0 {
 			continue
 		}
-		// set datalabels if not provided
-		if lc.DataLabels == nil || len(lc.DataLabels) == 0 {
-			lc.DataLabels = make([]string, len(seriesData))
-			for i := range seriesData {
-				lc.DataLabels[i] = fmt.Sprint(i)
-			}
-		}
 
 		// lazy increase, to avoid y shaking frequently
-		lc.minY = seriesData[0]
-		lc.maxY = seriesData[0]
+		lc.minY = math.Inf(1)
+		lc.maxY = math.Inf(-1)
 
 		// valid visible range
 		vrange := lc.innerArea.Dx()
@@ -299,6 +619,10 @@ func (lc *LineChart) calcLayout() {
 		}
 
 		for _, v := range seriesData[:vrange] {
+			v = lc.yv(v)
+			if math.IsInf(v, -1) {
+				continue
+			}
 			if v > lc.maxY {
 				lc.maxY = v
 			}
@@ -306,30 +630,154 @@ func (lc *LineChart) calcLayout() {
 				lc.minY = v
 			}
 		}
+		if math.IsInf(lc.minY, 1) {
+			// nothing to plot on a log scale
+			lc.minY, lc.maxY = 0, 0
+		}
 
 		span := lc.maxY - lc.minY
 
 		// allow some padding unless we are beyond the flor/ceil
 		if lc.minY <= lc.bottomValue {
 			lc.bottomValue = lc.minY - lc.YPadding*span
-			if lc.bottomValue < lc.YFloor {
-				lc.bottomValue = lc.YFloor
+			if floor := lc.yv(lc.YFloor); lc.bottomValue < floor {
+				lc.bottomValue = floor
 			}
 		}
 
 		if lc.maxY >= lc.topValue {
 			lc.topValue = lc.maxY + lc.YPadding*span
-			if lc.topValue > lc.YCeil {
-				lc.topValue = lc.YCeil
+			if ceil := lc.yv(lc.YCeil); lc.topValue > ceil {
+				lc.topValue = ceil
 			}
 		}
 	}
+}
+
+// autoscale sets the range of the y axis by calling fit, which widens it
+// to the points shown, the way AutoScale says, at the frame drawn at now.
+func (lc *LineChart) autoscale(now time.Time, fit func()) {
+	if lc.yLock.locked {
+		lc.bottomValue, lc.topValue = lc.yLock.bottom, lc.yLock.top
+		return
+	}
+	switch lc.AutoScale {
+	case "fit":
+		lc.bottomValue, lc.topValue = math.Inf(1), math.Inf(-1)
+		fit()
+	case "decay":
+		bottom, top := lc.bottomValue, lc.topValue
+		lc.bottomValue, lc.topValue = math.Inf(1), math.Inf(-1)
+		fit()
+		if !lc.scaledAt.IsZero() && lc.ScaleHalfLife > 0 && bottom <= top {
+			// the part of the gap to the points closed since the last frame
+			k := 1 - math.Pow(0.5, float64(now.Sub(lc.scaledAt))/float64(lc.ScaleHalfLife))
+			if lc.bottomValue > bottom {
+				lc.bottomValue = bottom + (lc.bottomValue-bottom)*k
+			}
+			if lc.topValue < top {
+				lc.topValue = top + (lc.topValue-top)*k
+			}
+		}
+		lc.scaledAt = now
+	default:
+		fit()
+	}
+}
+
+// axisSeries splits data into the series of the left and right y axes,
+// right being nil without series on the right.
+func (lc *LineChart) axisSeries(data map[string][]float64) (left, right map[string][]float64) {
+	left = make(map[string][]float64, len(data))
+	for name, d := range data {
+		if lc.SeriesAxis[name] != "right" {
+			left[name] = d
+			continue
+		}
+		if right == nil {
+			right = make(map[string][]float64)
+		}
+		right[name] = d
+	}
+	return left, right
+}
+
+// onRight calls f with the range and settings of the right y axis in place
+// of those of the left one, so that the code of the left axis works on it.
+func (lc *LineChart) onRight(f func()) {
+	swap := func() {
+		lc.bottomValue, lc.right.bottomValue = lc.right.bottomValue, lc.bottomValue
+		lc.topValue, lc.right.topValue = lc.right.topValue, lc.topValue
+		lc.scaledAt, lc.right.scaledAt = lc.right.scaledAt, lc.scaledAt
+		lc.yLock, lc.right.yLock = lc.right.yLock, lc.yLock
+		lc.scale, lc.right.scale = lc.right.scale, lc.scale
+		lc.YCeil, lc.Y2Ceil = lc.Y2Ceil, lc.YCeil
+		lc.YFloor, lc.Y2Floor = lc.Y2Floor, lc.YFloor
+		lc.YPadding, lc.Y2Padding = lc.Y2Padding, lc.YPadding
+		lc.YScale, lc.Y2Scale = lc.Y2Scale, lc.YScale
+	}
+	swap()
+	defer swap()
+	f()
+}
+
+func (lc *LineChart) calcLayout() {
+	// set datalabels if not provided
+	for _, seriesData := range lc.Data {
+		if len(seriesData) > 0 && len(lc.DataLabels) == 0 {
+			lc.DataLabels = make([]string, len(seriesData))
+			for i := range seriesData {
+				lc.DataLabels[i] = fmt.Sprint(i)
+			}
+		}
+	}
+
+	left, right := lc.axisSeries(lc.Data)
+	snapLeft, snapRight := lc.axisSeries(lc.snapshot)
+	if len(left) == 0 {
+		// the left axis mirrors the right one
+		left, snapLeft = right, snapRight
+	}
+	now := time.Now()
+	lc.autoscale(now, func() {
+		lc.fitY(left)
+		lc.fitY(snapLeft)
+		if len(lc.hlines) > 0 {
+			vs := make([]float64, len(lc.hlines))
+			for i, l := range lc.hlines {
+				vs[i] = l.value
+			}
+			lc.fitY(map[string][]float64{"": vs})
+		}
+	})
 
 	lc.axisYHeight = lc.innerArea.Dy() - 1
-	lc.calcLabelY()
+	lc.labelY, lc.labelYSpace = lc.calcLabelY()
+
+	lc.endLabelSpace = lc.endLabelWidth()
+	lc.innerArea.Max.X -= lc.endLabelSpace
+
+	lc.right.labelY, lc.right.labelYSpace = nil, 0
+	if right != nil {
+		lc.onRight(func() {
+			lc.autoscale(now, func() {
+				lc.fitY(right)
+				lc.fitY(snapRight)
+			})
+			lc.right.labelY, lc.right.labelYSpace = lc.calcLabelY()
+		})
+		// room for the right axis and its labels
+		lc.innerArea.Max.X -= lc.right.labelYSpace + 1
+	}
 
 	lc.axisXWidth = lc.innerArea.Dx() - 1 - lc.labelYSpace
-	lc.calcLabelX()
+	if len(lc.Times) > 0 {
+		lc.labelX = nil
+		lc.calcTimeLabels()
+	} else {
+		lc.timeLabels = nil
+		lc.calcLabelX()
+	}
 
 	lc.drawingX = lc.innerArea.Min.X + 1 + lc.labelYSpace
 	lc.drawingY = lc.innerArea.Min.Y
@@ -352,6 +800,11 @@ func (lc *LineChart) plotAxes() Buffer {
 	}
 
 	// x label
+	for _, l := range lc.timeLabels {
+		for j, r := range l.s {
+			buf.Set(l.x+j, lc.innerArea.Min.Y+lc.innerArea.Dy()-1, Cell{Ch: r, Fg: lc.AxesColor, Bg: lc.Bg})
+		}
+	}
 	oft := 0
 	for _, rs := range lc.labelX {
 		if oft+len(rs) > lc.axisXWidth {
@@ -367,7 +820,7 @@ func (lc *LineChart) plotAxes() Buffer {
 			y := lc.innerArea.Min.Y + lc.innerArea.Dy() - 1
 			buf.Set(x, y, c)
 		}
-		oft += len(rs) + lc.axisXLabelGap
+		oft += len(rs) + lc.labelXGap
 	}
 
 	// y labels
@@ -383,9 +836,192 @@ func (lc *LineChart) plotAxes() Buffer {
 	return buf
 }
 
+// AddHLine draws a dashed line across the plot at value on the left y axis,
+// e.g. a threshold or an SLO, with label at its right end if not empty.
+// The y axis extends to show it.
+func (lc *LineChart) AddHLine(value float64, color Attribute, label string) {
+	lc.hlines = append(lc.hlines, hLine{value: value, color: color, label: label})
+}
+
+// ClearHLines removes the lines added by AddHLine.
+func (lc *LineChart) ClearHLines() {
+	lc.hlines = nil
+}
+
+// plotHLines draws the lines of AddHLine.
+func (lc *LineChart) plotHLines() Buffer {
+	buf := NewBuffer()
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	origX := lc.innerArea.Min.X + lc.labelYSpace
+	for _, l := range lc.hlines {
+		y := origY - 1 - int((lc.yPos(l.value)-lc.bottomValue)/lc.scale+0.5)
+		if y < lc.innerArea.Min.Y || y >= origY {
+			continue
+		}
+		for x := origX + 1; x < lc.innerArea.Max.X; x++ {
+			buf.Set(x, y, Cell{Ch: HDASH, Fg: l.color, Bg: lc.Bg})
+		}
+		if l.label == "" {
+			continue
+		}
+		cs := TruncateRight(TextCells(" "+l.label, l.color, lc.Bg), lc.innerArea.Max.X-origX-1)
+		x := lc.innerArea.Max.X - cellsWidth(cs)
+		for _, c := range cs {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+	}
+	return buf
+}
+
+// plotRightAxis draws the right y axis and its labels, right of the plot.
+func (lc *LineChart) plotRightAxis() Buffer {
+	buf := NewBuffer()
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	x := lc.innerArea.Max.X
+	for y := origY; y > origY-lc.axisYHeight; y-- {
+		buf.Set(x, y, Cell{Ch: VDASH, Fg: lc.AxesColor, Bg: lc.Bg})
+	}
+	for i, rs := range lc.right.labelY {
+		for j, r := range rs {
+			buf.Set(x+1+j, origY-i*(lc.axisYLabelGap+1), Cell{Ch: r, Fg: lc.AxesColor, Bg: lc.Bg})
+		}
+	}
+	return buf
+}
+
+// paintBands sets the background of the plot area to alternating bands, one
+// per y label interval, leaving cells with a custom background untouched.
+func (lc *LineChart) paintBands(buf Buffer) {
+	if len(lc.BandColors) == 0 {
+		return
+	}
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	origX := lc.innerArea.Min.X + lc.labelYSpace
+	for y := origY - 1; y >= lc.innerArea.Min.Y; y-- {
+		bg := lc.BandColors[((origY-1-y)/(lc.axisYLabelGap+1))%len(lc.BandColors)]
+		for x := origX + 1; x < lc.innerArea.Max.X; x++ {
+			c, ok := buf.CellMap[image.Pt(x, y)]
+			if !ok {
+				c = Cell{Ch: ' ', Bg: lc.Bg}
+			}
+			if c.Bg == lc.Bg {
+				c.Bg = bg
+				buf.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// pointsPerCell returns the number of points plotted per column.
+func (lc *LineChart) pointsPerCell() int {
+	if lc.Mode == "dot" {
+		return 1
+	}
+	return 2
+}
+
+// maxOffset returns the largest DataOffset leaving a point to show.
+func (lc *LineChart) maxOffset() int {
+	n := len(lc.Times)
+	for _, d := range lc.Data {
+		if len(d) > n {
+			n = len(d)
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return n - 1
+}
+
+func (lc *LineChart) scrollBy(n int) {
+	lc.DataOffset = clamp(lc.DataOffset+n, 0, lc.maxOffset())
+}
+
+// ScrollLeft pans back by one column.
+func (lc *LineChart) ScrollLeft() {
+	lc.scrollBy(lc.pointsPerCell() * lc.zoom())
+}
+
+// ScrollRight pans forward by one column.
+func (lc *LineChart) ScrollRight() {
+	lc.scrollBy(-lc.pointsPerCell() * lc.zoom())
+}
+
+// ScrollToEnd shows the newest points again.
+func (lc *LineChart) ScrollToEnd() {
+	lc.DataOffset = 0
+}
+
+// panned returns the series and Times of lc without their newest
+// DataOffset points.
+func (lc *LineChart) panned() (map[string][]float64, []time.Time) {
+	data := make(map[string][]float64, len(lc.Data))
+	for name, d := range lc.Data {
+		data[name] = d[:clamp(len(d)-lc.DataOffset, 0, len(d))]
+	}
+	return data, lc.Times[:clamp(len(lc.Times)-lc.DataOffset, 0, len(lc.Times))]
+}
+
+// SaveState implements Stater, saving DataOffset, Zoom and the range the
+// left y axis is locked to, see SetYRange.
+func (lc *LineChart) SaveState() ViewState {
+	s := ViewState{ScrollX: lc.DataOffset, Zoom: lc.Zoom}
+	if lc.yLock.locked {
+		s.ZoomMin, s.ZoomMax = lc.yLabel(lc.yLock.bottom), lc.yLabel(lc.yLock.top)
+	}
+	return s
+}
+
+// RestoreState implements Stater.
+func (lc *LineChart) RestoreState(s ViewState) {
+	lc.DataOffset = max(s.ScrollX, 0)
+	lc.Zoom = clamp(s.Zoom, 0, maxZoom)
+	if s.ZoomMin < s.ZoomMax {
+		lc.SetYRange(s.ZoomMin, s.ZoomMax)
+	} else {
+		lc.AutoY()
+	}
+}
+
+// plotOffset draws the indicator of a panned chart in the top right corner
+// of the plot.
+func (lc *LineChart) plotOffset(buf Buffer) {
+	cs := TextCells(fmt.Sprintf(" -%d ▸", lc.DataOffset), lc.AxesColor, lc.Bg)
+	x := lc.innerArea.Max.X - cellsWidth(cs)
+	for _, c := range cs {
+		buf.Set(x, lc.innerArea.Min.Y, c)
+		x += c.Width()
+	}
+}
+
 // Buffer implements Bufferer interface.
 func (lc *LineChart) Buffer() Buffer {
 	buf := lc.Block.Buffer()
+	if lc.drawState(buf) {
+		return buf
+	}
+	if lc.Skeleton && len(lc.Data) == 0 {
+		lc.drawSkeleton(buf, true)
+		return buf
+	}
+
+	if !lc.Window.IsZero() && len(lc.Times) > 0 {
+		data, times := lc.Data, lc.Times
+		lc.Data, lc.Times = lc.windowed(time.Now())
+		defer func() { lc.Data, lc.Times = data, times }()
+	}
+	if lc.DataOffset > 0 {
+		data, times := lc.Data, lc.Times
+		lc.Data, lc.Times = lc.panned()
+		defer func() { lc.Data, lc.Times = data, times }()
+	}
+	if lc.zoom() > 1 {
+		data, snapshot, times := lc.Data, lc.snapshot, lc.Times
+		lc.Data, lc.snapshot, lc.Times = lc.zoomed()
+		defer func() { lc.Data, lc.snapshot, lc.Times = data, snapshot, times }()
+	}
 
 	seriesCount := 0
 	for _, data := range lc.Data {
@@ -396,13 +1032,46 @@ func (lc *LineChart) Buffer() Buffer {
 	if seriesCount == 0 {
 		return buf
 	}
+	area := lc.innerArea
+	defer func() { lc.innerArea = area }()
 	lc.calcLayout()
+	if lc.Downsample != "" && lc.Downsample != "none" {
+		data, snapshot, times := lc.Data, lc.snapshot, lc.Times
+		lc.Data, lc.snapshot, lc.Times = lc.downsampled()
+		defer func() { lc.Data, lc.snapshot, lc.Times = data, snapshot, times }()
+		lc.innerArea = area
+		lc.calcLayout()
+	}
 	buf.Merge(lc.plotAxes())
+	buf.Merge(lc.plotHLines())
 
-	if lc.Mode == "dot" {
-		buf.Merge(lc.renderDot())
-	} else {
-		buf.Merge(lc.renderBraille())
+	render := lc.renderSeries
+	left, right := lc.axisSeries(lc.Data)
+	snapLeft, snapRight := lc.axisSeries(lc.snapshot)
+	if len(snapLeft) > 0 {
+		buf.Merge(render(snapLeft, lc.snapshotColor, lc.SnapshotDashed))
+	}
+	buf.Merge(lc.renderFill(left))
+	buf.Merge(render(left, lc.lineColor, false))
+	if right != nil {
+		buf.Merge(lc.plotRightAxis())
+		lc.onRight(func() {
+			buf.Merge(lc.renderFill(right))
+			if len(snapRight) > 0 {
+				buf.Merge(render(snapRight, lc.snapshotColor, lc.SnapshotDashed))
+			}
+			buf.Merge(render(right, lc.lineColor, false))
+		})
+	}
+	if lc.endLabelSpace > 0 {
+		buf.Merge(lc.plotEndLabels(area.Max.X-lc.endLabelSpace+1, left, right))
+	}
+	lc.paintBands(buf)
+	if lc.DataOffset > 0 {
+		lc.plotOffset(buf)
+	}
+	if lc.mirrored() {
+		buf = lc.mirror(buf, area)
 	}
 
 	return buf
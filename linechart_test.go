@@ -0,0 +1,364 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLineChartLogScale(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 40
+	lc.Height = 12
+	lc.YScale = "log"
+	lc.YPadding = 0
+	lc.Data["rps"] = []float64{1, 10, 100, 1000, 10000, 0, -5}
+	lc.Buffer()
+	if math.Abs(lc.bottomValue) > 1e-9 || math.Abs(lc.topValue-4) > 1e-9 {
+		t.Fatalf("axis spans %v to %v, want 0 to 4 decades", lc.bottomValue, lc.topValue)
+	}
+	if s := string(lc.labelY[0]); s != "1.00" {
+		t.Errorf("bottom label %q, want 1.00", s)
+	}
+	if a, b := lc.yLabel(lc.bottomValue+1), lc.yLabel(lc.bottomValue+2); math.Abs(b/a-10) > 1e-9 {
+		t.Errorf("labels should be log spaced, got %v and %v", a, b)
+	}
+	if p := lc.yPos(-5); p != lc.bottomValue {
+		t.Errorf("values <= 0 should be at the bottom, got %v", p)
+	}
+}
+
+func TestLineChartRightAxis(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 40
+	lc.Height = 12
+	lc.YPadding, lc.Y2Padding = 0, 0
+	lc.Data["latency"] = []float64{10, 20, 15, 30}
+	lc.Data["rps"] = []float64{1000, 4000, 2000, 3000}
+	lc.SeriesAxis["rps"] = "right"
+	buf := lc.Buffer()
+
+	if lc.bottomValue != 10 || lc.topValue != 30 {
+		t.Errorf("left axis spans %v to %v, want 10 to 30", lc.bottomValue, lc.topValue)
+	}
+	if lc.right.bottomValue != 1000 || lc.right.topValue != 4000 {
+		t.Errorf("right axis spans %v to %v, want 1000 to 4000", lc.right.bottomValue, lc.right.topValue)
+	}
+	if lc.innerArea.Dx() != 40 {
+		t.Error("Buffer should restore the inner area")
+	}
+
+	// the bottom label of the right axis ends at the right edge
+	label := string(lc.right.labelY[0])
+	var rs []rune
+	for x := 40 - len(label); x < 40; x++ {
+		rs = append(rs, buf.At(x, 12-2).Ch)
+	}
+	if string(rs) != label {
+		t.Errorf("got %q at the right of the x axis, want %q", string(rs), label)
+	}
+}
+
+func TestLineChartFill(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Mode = "dot"
+	lc.Width = 20
+	lc.Height = 10
+	lc.YPadding = 0
+	lc.Data["total"] = []float64{0, 4, 8}
+	lc.LineColor["total"] = ColorGreen
+
+	shaded := func(buf Buffer) int {
+		n := 0
+		for _, c := range buf.CellMap {
+			if c.Ch == '█' {
+				n++
+				if c.Fg != darken(ColorGreen, ColorDefault) {
+					t.Fatalf("fill color %v, want a dimmer green", c.Fg)
+				}
+			}
+		}
+		return n
+	}
+	if n := shaded(lc.Buffer()); n != 0 {
+		t.Fatalf("%d cells shaded without Fill", n)
+	}
+
+	lc.Fill = true
+	buf := lc.Buffer()
+	if shaded(buf) == 0 {
+		t.Fatal("no cells shaded with Fill")
+	}
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	x := lc.innerArea.Max.X - 1
+	for y := lc.innerArea.Min.Y; y < origY; y++ {
+		c := buf.At(x, y)
+		if c.Ch != '█' && c.Ch != lc.DotStyle && c.Ch != ' ' {
+			t.Errorf("unexpected %q at row %d", c.Ch, y)
+		}
+		if c.Ch == lc.DotStyle {
+			if below := buf.At(x, y+1); y+1 < origY && below.Ch != '█' {
+				t.Errorf("cell below the last point is %q, want shaded", below.Ch)
+			}
+		}
+	}
+	if c := buf.At(x-2, origY-1); c.Ch != lc.DotStyle {
+		t.Errorf("zero point at %q, want the dot on the axis", c.Ch)
+	}
+}
+
+func TestLineChartPan(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 30
+	lc.Height = 10
+	for i := 0; i < 100; i++ {
+		lc.Data["up"] = append(lc.Data["up"], float64(i))
+	}
+
+	lc.ScrollLeft()
+	lc.ScrollLeft()
+	if lc.DataOffset != 4 {
+		t.Fatalf("offset %d after panning two columns, want 4", lc.DataOffset)
+	}
+	buf := lc.Buffer()
+	if lc.topValue >= 99 {
+		t.Errorf("axis reaches %v, hidden points should not count", lc.topValue)
+	}
+	if len(lc.Data["up"]) != 100 {
+		t.Errorf("Buffer left %d points, want 100", len(lc.Data["up"]))
+	}
+	if c := buf.At(lc.innerArea.Max.X-1, 0); c.Ch != '▸' {
+		t.Errorf("no indicator while panned, got %q", c.Ch)
+	}
+
+	lc.ScrollRight()
+	lc.ScrollRight()
+	lc.ScrollRight()
+	if lc.DataOffset != 0 {
+		t.Errorf("offset %d past the end, want 0", lc.DataOffset)
+	}
+	lc.DataOffset = 50
+	for i := 0; i < 100; i++ {
+		lc.ScrollLeft()
+	}
+	if lc.DataOffset != 99 {
+		t.Errorf("offset %d past the start, want 99", lc.DataOffset)
+	}
+	lc.ScrollToEnd()
+	if buf := lc.Buffer(); buf.At(lc.innerArea.Max.X-1, 0).Ch == '▸' {
+		t.Error("indicator drawn on the newest points")
+	}
+}
+
+func TestLineChartBrailleJoinsPoints(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 20
+	lc.Height = 10
+	lc.YPadding = 0
+	lc.Data["step"] = []float64{0, 0, 10, 10}
+	buf := lc.Buffer()
+
+	x := lc.innerArea.Max.X - 1
+	origY := lc.innerArea.Min.Y + lc.innerArea.Dy() - 2
+	top, bottom := -1, -1
+	for y := lc.innerArea.Min.Y; y < origY; y++ {
+		l, r := buf.At(x-1, y).Ch, buf.At(x, y).Ch
+		if l < 0x2800 && r < 0x2800 {
+			if top >= 0 && bottom < 0 {
+				bottom = y
+			}
+			continue
+		}
+		if top < 0 {
+			top = y
+		}
+		if bottom >= 0 {
+			t.Fatalf("gap in the line at row %d", bottom)
+		}
+	}
+	if top != lc.innerArea.Min.Y || bottom != -1 && bottom != origY {
+		t.Errorf("line spans rows %d to %d, want %d to %d", top, bottom, lc.innerArea.Min.Y, origY)
+	}
+	if c := buf.At(x, origY-1); c.Ch >= 0x2800 {
+		t.Errorf("the run should be split between the columns, got %q", c.Ch)
+	}
+}
+
+func TestLineChartHLine(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 30
+	lc.Height = 12
+	lc.YPadding = 0
+	lc.Data["latency"] = []float64{50, 80, 120, 90}
+	lc.AddHLine(200, ColorRed, "SLO")
+	buf := lc.Buffer()
+
+	if lc.topValue < 200 {
+		t.Fatalf("axis tops at %v, want the line shown", lc.topValue)
+	}
+	y := -1
+	for yy := lc.innerArea.Min.Y; yy < lc.innerArea.Max.Y; yy++ {
+		if c := buf.At(lc.innerArea.Max.X-1, yy); c.Ch == 'O' && c.Fg == ColorRed {
+			y = yy
+		}
+	}
+	if y < 0 {
+		t.Fatal("label not drawn at the right end")
+	}
+	if c := buf.At(lc.labelYSpace+2, y); c.Ch != HDASH || c.Fg != ColorRed {
+		t.Errorf("line drawn with %q in %v", c.Ch, c.Fg)
+	}
+
+	lc.ClearHLines()
+	if len(lc.hlines) != 0 {
+		t.Error("ClearHLines kept lines")
+	}
+}
+
+func TestLineChartSeriesMode(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Width = 20
+	lc.Height = 8
+	lc.Data["load"] = []float64{1, 2, 3, 4, 5, 6}
+	lc.Data["errors"] = []float64{0, 0, 0, 6, 0, 0}
+	lc.SeriesMode["errors"] = "dot"
+	lc.LineColor["errors"] = ColorRed
+	buf := lc.Buffer()
+
+	// the pair of the spike is in the second column from the right
+	x := lc.innerArea.Max.X - 2
+	found := false
+	for y := lc.innerArea.Min.Y; y < lc.innerArea.Max.Y; y++ {
+		c := buf.At(x, y)
+		if c.Ch == lc.DotStyle && c.Fg == ColorRed {
+			found = true
+		} else if c.Fg == ColorRed {
+			t.Errorf("unexpected %q at %d,%d", c.Ch, x, y)
+		}
+	}
+	if !found {
+		t.Error("dot of the spike not drawn")
+	}
+	if got := lc.modeSeries([]float64{1, 5, 2, 3, 4}, "dot"); !reflect.DeepEqual(got, []float64{1, 5, 4}) {
+		t.Errorf("unexpected pairs %v", got)
+	}
+	lc.Mode = "dot"
+	if got := lc.modeSeries([]float64{1, 2}, "braille"); !reflect.DeepEqual(got, []float64{1, 1, 2, 2}) {
+		t.Errorf("unexpected points %v", got)
+	}
+}
+
+func TestLineChartLabelCount(t *testing.T) {
+	lc := NewLineChart()
+	lc.Border = false
+	lc.Mode = "dot"
+	for i := 0; i < 100; i++ {
+		lc.Data["s"] = append(lc.Data["s"], float64(i))
+	}
+	lc.Width, lc.Height = 80, 41
+	lc.Buffer()
+	tall, wide := len(lc.labelY), len(lc.labelX)
+
+	lc.Width, lc.Height = 20, 8
+	lc.Buffer()
+	if len(lc.labelY) >= tall || len(lc.labelX) >= wide {
+		t.Errorf("expected fewer labels once shrunk, got %d/%d then %d/%d", tall, wide, len(lc.labelY), len(lc.labelX))
+	}
+	// labels of a shrunk chart do not overlap
+	end := 0
+	for _, l := range lc.labelX {
+		end += len(l) + lc.labelXGap
+	}
+	if end-lc.labelXGap > lc.axisXWidth {
+		t.Errorf("labels span %d cells of %d", end-lc.labelXGap, lc.axisXWidth)
+	}
+
+	lc.LabelCount = func(axis string, length, w int) int {
+		if axis == "y" {
+			return 2
+		}
+		return 100
+	}
+	lc.Buffer()
+	if len(lc.labelY) != 2 || lc.labelXGap != 1 {
+		t.Errorf("expected the hook followed, got %d y labels, x gap %d", len(lc.labelY), lc.labelXGap)
+	}
+}
+
+func TestLineChartAutoScale(t *testing.T) {
+	spiked := func(mode string) *LineChart {
+		lc := NewLineChart()
+		lc.Width = 30
+		lc.Height = 10
+		lc.YPadding = 0
+		lc.AutoScale = mode
+		lc.Data["cpu"] = []float64{1, 100, 1, 2}
+		lc.Buffer()
+		lc.Data["cpu"] = []float64{1, 2, 1, 2}
+		return lc
+	}
+
+	lc := spiked("expand")
+	lc.Buffer()
+	if lc.topValue != 100 {
+		t.Errorf("expand: top %v, want the spike kept", lc.topValue)
+	}
+	lc = spiked("fit")
+	lc.Buffer()
+	if lc.topValue != 2 {
+		t.Errorf("fit: top %v, want the points fit", lc.topValue)
+	}
+	lc = spiked("decay")
+	lc.scaledAt = lc.scaledAt.Add(-lc.ScaleHalfLife)
+	lc.Buffer()
+	if lc.topValue > 51 || lc.topValue < 45 {
+		t.Errorf("decay: top %v, want about halfway back after a half life", lc.topValue)
+	}
+	lc.Data["cpu"] = []float64{1, 200}
+	lc.Buffer()
+	if lc.topValue != 200 {
+		t.Errorf("decay: top %v, want a new spike fit at once", lc.topValue)
+	}
+}
+
+func TestLineChartLineStyle(t *testing.T) {
+	dots := func(style string) int {
+		lc := NewLineChart()
+		lc.Border = false
+		lc.Width = 20
+		lc.Height = 10
+		lc.YFloor, lc.YCeil = 0, 10
+		lc.LineStyle["s"] = style
+		for i := 0; i < 40; i++ {
+			lc.Data["s"] = append(lc.Data["s"], 5)
+		}
+		lc.Buffer()
+		n := 0
+		for _, c := range lc.renderBraille(lc.Data, lc.lineColor, false).CellMap {
+			for d := c.Ch - 0x2800; d > 0; d &= d - 1 {
+				n++
+			}
+		}
+		return n
+	}
+	solid := dots("solid")
+	if got := dots("dotted"); got*2 != solid {
+		t.Errorf("dotted: %d dots, want half of %d", got, solid)
+	}
+	if got := dots("thick"); got != solid*2 {
+		t.Errorf("thick: %d dots, want twice %d", got, solid)
+	}
+	if got := dots("dashed"); got >= solid || got == 0 {
+		t.Errorf("dashed: %d dots, want fewer than %d", got, solid)
+	}
+}
@@ -4,7 +4,10 @@
 
 package termui
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 // List displays []string as its items,
 // it has a Overflow option (default is "hidden"), when set to "hidden",
@@ -28,6 +31,9 @@ import "strings"
   ls.Height = 7
   ls.Width = 25
   ls.Y = 0
+
+With SelectedRow set, the list is interactive: the selected item is
+highlighted and kept in view as HandleKey moves it.
 */
 type List struct {
 	Block
@@ -35,6 +41,22 @@ type List struct {
 	Overflow    string
 	ItemFgColor Attribute
 	ItemBgColor Attribute
+	// Flash highlights items whose text changed since the previous frame,
+	// only with the "hidden" overflow.
+	Flash *Flasher
+	// Keys identify the items, so that Flash follows them when they move;
+	// items without a key are identified by their index.
+	Keys []string
+	// SelectedRow is the index in Items of the item highlighted, -1 for
+	// none; only with the "hidden" overflow. With Keys, it follows its
+	// item when Items are replaced.
+	SelectedRow     int
+	SelectedFgColor Attribute
+	SelectedBgColor Attribute
+
+	OnSelect func(row int) // called on <enter> with the SelectedRow
+	offset   int           // items scrolled past
+	sel      selection
 }
 
 // NewList returns a new *List with current theme.
@@ -43,12 +65,38 @@ func NewList() *List {
 	l.Overflow = "hidden"
 	l.ItemFgColor = ThemeAttr("list.item.fg")
 	l.ItemBgColor = ThemeAttr("list.item.bg")
+	l.SelectedRow = -1
+	l.SelectedFgColor = ThemeAttr("list.selected.fg")
+	l.SelectedBgColor = ThemeAttr("list.selected.bg")
 	return l
 }
 
+// itemKey returns the key identifying item i.
+// key returns the key of item i in Keys, "" if it has none.
+func (l *List) key(i int) string {
+	if i < len(l.Keys) {
+		return l.Keys[i]
+	}
+	return ""
+}
+
+func (l *List) itemKey(i int) string {
+	if i < len(l.Keys) && l.Keys[i] != "" {
+		return l.Keys[i]
+	}
+	return strconv.Itoa(i)
+}
+
 // Buffer implements Bufferer interface.
 func (l *List) Buffer() Buffer {
 	buf := l.Block.Buffer()
+	if l.drawState(buf) {
+		return buf
+	}
+	if l.Skeleton && len(l.Items) == 0 {
+		l.drawSkeleton(buf, false)
+		return buf
+	}
 
 	switch l.Overflow {
 	case "wrap":
@@ -71,16 +119,22 @@ func (l *List) Buffer() Buffer {
 		}
 
 	case "hidden":
-		trimItems := l.Items
-		if len(trimItems) > l.innerArea.Dy() {
-			trimItems = trimItems[:l.innerArea.Dy()]
-		}
-		for i, v := range trimItems {
-			cs := DTrimTxCls(DefaultTxBuilder.Build(v, l.ItemFgColor, l.ItemBgColor), l.innerArea.Dx())
+		start, end := l.shown()
+		for i := start; i < end; i++ {
+			v := l.Items[i]
+			y := l.innerArea.Min.Y + i - start
+			fg, bg := l.ItemFgColor, flashBg(l.Flash, l.itemKey(i), v, l.ItemBgColor)
+			if i == l.SelectedRow {
+				fg, bg = l.SelectedFgColor, l.SelectedBgColor
+				for x := l.innerArea.Min.X; x < l.innerArea.Max.X; x++ {
+					buf.Set(x, y, Cell{Ch: ' ', Fg: fg, Bg: bg})
+				}
+			}
+			cs := TruncateRight(DefaultTxBuilder.Build(v, fg, bg), l.innerArea.Dx())
 			j := 0
 			for _, vv := range cs {
 				w := vv.Width()
-				buf.Set(l.innerArea.Min.X+j, l.innerArea.Min.Y+i, vv)
+				buf.Set(l.innerArea.Min.X+j, y, vv)
 				j += w
 			}
 		}
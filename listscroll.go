@@ -0,0 +1,117 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// shown returns the range of Items drawn, scrolled to keep the SelectedRow
+// in view.
+func (l *List) shown() (int, int) {
+	l.follow()
+	h := l.innerArea.Dy()
+	if h < 0 {
+		h = 0
+	}
+	if i := l.SelectedRow; i >= 0 && i < len(l.Items) {
+		if i < l.offset {
+			l.offset = i
+		}
+		if i >= l.offset+h {
+			l.offset = i - h + 1
+		}
+	}
+	l.offset = clamp(l.offset, 0, clamp(len(l.Items)-h, 0, len(l.Items)))
+	return l.offset, clamp(l.offset+h, 0, len(l.Items))
+}
+
+// follow moves the selection to the item of its key after Items changed,
+// scrolling by as many rows as the item moved, see selection.
+func (l *List) follow() {
+	row := l.sel.follow(l.SelectedRow, 0, len(l.Items), l.key)
+	if row >= 0 && l.SelectedRow >= 0 {
+		l.offset = max(l.offset+row-l.SelectedRow, 0)
+	}
+	l.SelectedRow = row
+}
+
+// moveSelection selects the item n rows below the selected one, above it
+// if n is negative. Without an item selected, the items scroll by n
+// instead.
+func (l *List) moveSelection(n int) {
+	l.follow()
+	if len(l.Items) == 0 {
+		return
+	}
+	if l.SelectedRow < 0 {
+		l.offset = clamp(l.offset+n, 0, len(l.Items)-1)
+		return
+	}
+	l.SelectedRow = clamp(l.SelectedRow+n, 0, len(l.Items)-1)
+}
+
+// ScrollDown selects the item n rows below the selected one.
+func (l *List) ScrollDown(n int) {
+	l.moveSelection(n)
+}
+
+// ScrollUp selects the item n rows above the selected one.
+func (l *List) ScrollUp(n int) {
+	l.moveSelection(-n)
+}
+
+// HandleKey applies a keyboard event to the selection and tells if it was
+// consumed: <up>, <down>, <pageup>, <pagedown>, <home> and <end> move the
+// selection, or scroll without one, and <enter> calls OnSelect.
+/*
+  ls.SelectedRow = 0
+  ls.OnSelect = func(row int) { open(files[row]) }
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      if ls.HandleKey(e.Data.(termui.EvtKbd)) {
+          termui.Render(ls)
+      }
+  })
+*/
+func (l *List) HandleKey(k EvtKbd) bool {
+	page := l.innerArea.Dy()
+	if page < 1 {
+		page = 1
+	}
+	switch k.KeyStr {
+	case "<up>", "k":
+		l.ScrollUp(k.Step())
+	case "<down>", "j":
+		l.ScrollDown(k.Step())
+	case "<pageup>", "<previous>":
+		l.ScrollUp(page)
+	case "<pagedown>", "<next>":
+		l.ScrollDown(page)
+	case "<home>":
+		l.ScrollUp(len(l.Items))
+	case "<end>":
+		l.ScrollDown(len(l.Items))
+	case "<enter>":
+		l.follow()
+		if l.SelectedRow < 0 || l.SelectedRow >= len(l.Items) {
+			return false
+		}
+		if l.OnSelect != nil {
+			l.OnSelect(l.SelectedRow)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// SaveState implements Stater, saving the SelectedRow and the scroll.
+func (l *List) SaveState() ViewState {
+	l.follow()
+	return ViewState{Selected: l.SelectedRow, ScrollY: l.offset}
+}
+
+// RestoreState implements Stater. The scroll is fitted to the Items when
+// they are drawn, which may be loaded after.
+func (l *List) RestoreState(s ViewState) {
+	l.SelectedRow = max(s.Selected, -1)
+	l.offset = max(s.ScrollY, 0)
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListSelection(t *testing.T) {
+	l := NewList()
+	l.Border = false
+	l.Width = 10
+	l.Height = 3
+	l.Items = []string{"a", "b", "c", "d", "e", "f"}
+	l.SelectedRow = 0
+	selected := -1
+	l.OnSelect = func(row int) { selected = row }
+	l.Buffer()
+
+	l.HandleKey(EvtKbd{KeyStr: "<pagedown>"})
+	l.HandleKey(EvtKbd{KeyStr: "<down>"})
+	if l.SelectedRow != 4 {
+		t.Fatalf("expected item 4 selected, got %d", l.SelectedRow)
+	}
+	buf := l.Buffer()
+	if got := strings.Join(treeLines(buf, 10, 3), ","); got != "c,d,e" {
+		t.Errorf("expected the list scrolled to c,d,e, got %s", got)
+	}
+	if c := buf.At(9, 2); c.Bg != l.SelectedBgColor {
+		t.Errorf("expected the selected row highlighted, got bg %v", c.Bg)
+	}
+
+	l.HandleKey(EvtKbd{KeyStr: "<home>"})
+	if got := strings.Join(treeLines(l.Buffer(), 10, 3), ","); got != "a,b,c" {
+		t.Errorf("expected <home> to scroll back to a,b,c, got %s", got)
+	}
+	l.HandleKey(EvtKbd{KeyStr: "<end>"})
+	if !l.HandleKey(EvtKbd{KeyStr: "<enter>"}) || selected != 5 {
+		t.Errorf("expected OnSelect called with 5, got %d", selected)
+	}
+}
+
+func TestListNoSelection(t *testing.T) {
+	l := NewList()
+	l.Border = false
+	l.Width = 10
+	l.Height = 2
+	l.Items = []string{"a", "b", "c"}
+	if l.HandleKey(EvtKbd{KeyStr: "<enter>"}) {
+		t.Error("expected <enter> ignored without a selection")
+	}
+	l.HandleKey(EvtKbd{KeyStr: "<down>"})
+	if got := strings.Join(treeLines(l.Buffer(), 10, 2), ","); got != "b,c" {
+		t.Errorf("expected the list scrolled to b,c, got %s", got)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale holds the built-in UI strings and number/date conventions of a
+// language. Messages missing from a locale fall back to English.
+type Locale struct {
+	Tag        string // e.g. "en", "de"
+	Decimal    string // decimal separator
+	Group      string // thousands separator
+	DateLayout string // time.Format layout for dates
+	TimeLayout string // time.Format layout for times of day
+	Messages   map[string]string
+}
+
+var locales = struct {
+	sync.RWMutex
+	m   map[string]*Locale
+	cur *Locale
+}{
+	m: map[string]*Locale{
+		"en": {
+			Tag: "en", Decimal: ".", Group: ",",
+			DateLayout: "2006-01-02", TimeLayout: "15:04:05",
+			Messages: map[string]string{
+				"paused":  "PAUSED",
+				"loading": "Loading…",
+				"error":   "Error",
+				"keys":    "Keys",
+				"dismiss": "<enter> to dismiss",
+				"retry":   "press r to retry",
+
+				"strength.weak":   "weak",
+				"strength.fair":   "fair",
+				"strength.good":   "good",
+				"strength.strong": "strong",
+			},
+		},
+		"de": {
+			Tag: "de", Decimal: ",", Group: ".",
+			DateLayout: "02.01.2006", TimeLayout: "15:04:05",
+			Messages: map[string]string{
+				"paused":  "PAUSIERT",
+				"loading": "Lädt…",
+				"error":   "Fehler",
+				"keys":    "Tasten",
+				"dismiss": "<Enter> zum Schließen",
+				"retry":   "r drücken zum Wiederholen",
+
+				"strength.weak":   "schwach",
+				"strength.fair":   "mäßig",
+				"strength.good":   "gut",
+				"strength.strong": "stark",
+			},
+		},
+		"fr": {
+			Tag: "fr", Decimal: ",", Group: " ",
+			DateLayout: "02/01/2006", TimeLayout: "15:04:05",
+			Messages: map[string]string{
+				"paused":  "EN PAUSE",
+				"loading": "Chargement…",
+				"error":   "Erreur",
+				"keys":    "Touches",
+				"dismiss": "<Entrée> pour fermer",
+				"retry":   "appuyez sur r pour réessayer",
+
+				"strength.weak":   "faible",
+				"strength.fair":   "moyen",
+				"strength.good":   "bon",
+				"strength.strong": "fort",
+			},
+		},
+	},
+}
+
+func init() {
+	locales.cur = locales.m["en"]
+}
+
+// RegisterLocale adds or replaces a locale.
+func RegisterLocale(l *Locale) {
+	locales.Lock()
+	locales.m[l.Tag] = l
+	locales.Unlock()
+}
+
+// SetLocale switches to the locale matching tag, which may be a full POSIX
+// locale like "de_DE.UTF-8". It returns false if no locale matches.
+func SetLocale(tag string) bool {
+	tag = strings.ToLower(tag)
+	if i := strings.IndexAny(tag, "_-."); i >= 0 {
+		tag = tag[:i]
+	}
+
+	locales.Lock()
+	defer locales.Unlock()
+	l, ok := locales.m[tag]
+	if ok {
+		locales.cur = l
+	}
+	return ok
+}
+
+// DetectLocale sets the locale from the LC_ALL, LC_MESSAGES or LANG
+// environment variables, keeping the current one if none matches.
+func DetectLocale() {
+	for _, v := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if s := os.Getenv(v); s != "" {
+			SetLocale(s)
+			return
+		}
+	}
+}
+
+// CurrentLocale returns the locale in use.
+func CurrentLocale() *Locale {
+	locales.RLock()
+	defer locales.RUnlock()
+	return locales.cur
+}
+
+// Msg returns the localized built-in string id, falling back to English
+// and finally to id itself.
+func Msg(id string) string {
+	if s, ok := CurrentLocale().Messages[id]; ok {
+		return s
+	}
+	locales.RLock()
+	defer locales.RUnlock()
+	if s, ok := locales.m["en"].Messages[id]; ok {
+		return s
+	}
+	return id
+}
+
+// FormatNumber formats v with prec decimals using the locale's separators.
+func (l *Locale) FormatNumber(v float64, prec int) string {
+	return l.format(strconv.FormatFloat(v, 'f', prec, 64))
+}
+
+// plainNumber matches the numbers localizeNumber rewrites.
+var plainNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// localizeNumber rewrites s with the locale's separators if it is a plain
+// number like "-1234.50", keeping its digits, and returns other text as is.
+func (l *Locale) localizeNumber(s string) string {
+	if !plainNumber.MatchString(s) {
+		return s
+	}
+	return l.format(s)
+}
+
+// format groups the digits of s, a number formatted by strconv, and sets
+// its decimal separator.
+func (l *Locale) format(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	ip, fp := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		ip, fp = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	for i, r := range ip {
+		if i > 0 && (len(ip)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(r)
+	}
+	if fp != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(fp)
+	}
+	return sign + b.String()
+}
+
+// FormatDate formats t with the locale's date layout.
+func (l *Locale) FormatDate(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// FormatTime formats t with the locale's time layout.
+func (l *Locale) FormatTime(t time.Time) string {
+	return t.Format(l.TimeLayout)
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLocale(t *testing.T) {
+	defer SetLocale("en")
+
+	if s := CurrentLocale().FormatNumber(-1234567.891, 2); s != "-1,234,567.89" {
+		t.Errorf("unexpected english number %q", s)
+	}
+
+	if !SetLocale("de_DE.UTF-8") {
+		t.Fatal("de locale should match")
+	}
+	if s := CurrentLocale().FormatNumber(1234.5, 1); s != "1.234,5" {
+		t.Errorf("unexpected german number %q", s)
+	}
+	if s := Msg("paused"); s != "PAUSIERT" {
+		t.Errorf("unexpected german message %q", s)
+	}
+	if s := Msg("no-such-id"); s != "no-such-id" {
+		t.Errorf("unknown ids should fall back to themselves, got %q", s)
+	}
+	if s := shortenFloatVal(1.5); s != "1,50" {
+		t.Errorf("axis labels should be localized, got %q", s)
+	}
+
+	table := NewTable()
+	table.LocalizeNumbers = true
+	table.Rows = [][]string{{"host", "bytes"}, {"db-0", "-1234567.50"}, {"web", "10.0.0.1"}}
+	if s := CellsToStr(table.rowCell(1, 1, 0, 0)); s != "-1.234.567,50" {
+		t.Errorf("table numbers should be localized, got %q", s)
+	}
+	if s := CellsToStr(table.rowCell(2, 1, 0, 0)); s != "10.0.0.1" {
+		t.Errorf("only plain numbers should be localized, got %q", s)
+	}
+
+	if SetLocale("xx") {
+		t.Error("unknown locale should not match")
+	}
+}
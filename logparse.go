@@ -0,0 +1,271 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogLevel is the severity of a log record.
+type LogLevel int
+
+// Log levels, from the least severe.
+const (
+	LevelUnknown LogLevel = iota
+	LevelTrace
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var levelNames = [...]string{"", "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+func (l LogLevel) String() string {
+	if l < 0 || int(l) >= len(levelNames) {
+		return ""
+	}
+	return levelNames[l]
+}
+
+// ParseLevel returns the level named s, as written by common loggers:
+// "warning", "WARN", "W", "err", "panic"... or LevelUnknown.
+func ParseLevel(s string) LogLevel {
+	switch strings.ToLower(s) {
+	case "t", "trc", "trace":
+		return LevelTrace
+	case "d", "dbg", "debug":
+		return LevelDebug
+	case "i", "inf", "info", "notice":
+		return LevelInfo
+	case "w", "wrn", "warn", "warning":
+		return LevelWarn
+	case "e", "err", "error":
+		return LevelError
+	case "f", "ftl", "fatal", "panic", "crit", "critical", "alert", "emerg":
+		return LevelFatal
+	}
+	return LevelUnknown
+}
+
+// LogRecord is a log line broken down by a log parser.
+type LogRecord struct {
+	Time    string // as written in the line
+	Level   LogLevel
+	Message string
+	Fields  map[string]string
+}
+
+// setField files the field k of a record, picking the time, level and
+// message out of the usual keys.
+func (r *LogRecord) setField(k, v string) {
+	switch strings.ToLower(k) {
+	case "time", "ts", "t", "timestamp", "@timestamp":
+		if r.Time == "" {
+			r.Time = v
+			return
+		}
+	case "level", "lvl", "severity", "@level":
+		if r.Level == LevelUnknown {
+			r.Level = ParseLevel(v)
+			return
+		}
+	case "msg", "message", "@message":
+		if r.Message == "" {
+			r.Message = v
+			return
+		}
+	}
+	if r.Fields == nil {
+		r.Fields = make(map[string]string)
+	}
+	r.Fields[k] = v
+}
+
+// String returns the record as shown by LogViewer: time, level, message
+// and the other fields sorted by key.
+func (r LogRecord) String() string {
+	var ss []string
+	if r.Time != "" {
+		ss = append(ss, r.Time)
+	}
+	if r.Level != LevelUnknown {
+		ss = append(ss, fmt.Sprintf("%-5s", r.Level))
+	}
+	if r.Message != "" {
+		ss = append(ss, r.Message)
+	}
+	ks := make([]string, 0, len(r.Fields))
+	for k := range r.Fields {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	for _, k := range ks {
+		v := r.Fields[k]
+		if v == "" || strings.ContainsAny(v, " \"=") {
+			v = strconv.Quote(v)
+		}
+		ss = append(ss, k+"="+v)
+	}
+	return strings.Join(ss, " ")
+}
+
+// logfmtPairs returns the key=value pairs of s, values being bare or
+// quoted, or false if s has other words.
+func logfmtPairs(s string) ([][2]string, bool) {
+	var kvs [][2]string
+	for s = strings.TrimLeft(s, " "); s != ""; s = strings.TrimLeft(s, " ") {
+		eq := strings.IndexAny(s, "= ")
+		if eq <= 0 || s[eq] != '=' {
+			return nil, false
+		}
+		k, v := s[:eq], s[eq+1:]
+		if strings.HasPrefix(v, `"`) {
+			q, err := strconv.QuotedPrefix(v)
+			if err != nil {
+				return nil, false
+			}
+			s = v[len(q):]
+			v, _ = strconv.Unquote(q)
+		} else {
+			end := strings.IndexByte(v, ' ')
+			if end < 0 {
+				end = len(v)
+			}
+			v, s = v[:end], v[end:]
+		}
+		kvs = append(kvs, [2]string{k, v})
+	}
+	return kvs, true
+}
+
+// ParseLogfmt parses a logfmt line, such as written by logrus or go-kit:
+// time="2017-03-01T10:00:00Z" level=info msg="listening" port=8080
+func ParseLogfmt(line string) (LogRecord, bool) {
+	kvs, ok := logfmtPairs(line)
+	if !ok || len(kvs) < 2 {
+		return LogRecord{}, false
+	}
+	var r LogRecord
+	for _, kv := range kvs {
+		r.setField(kv[0], kv[1])
+	}
+	return r, true
+}
+
+// ParseJSONLog parses a line of JSON, such as written by zap or zerolog:
+// {"ts":"2017-03-01T10:00:00Z","level":"warn","msg":"slow","took":"2s"}
+func ParseJSONLog(line string) (LogRecord, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return LogRecord{}, false
+	}
+	d := json.NewDecoder(strings.NewReader(line))
+	d.UseNumber()
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		return LogRecord{}, false
+	}
+	var r LogRecord
+	for k, v := range m {
+		switch v := v.(type) {
+		case string:
+			r.setField(k, v)
+		case nil:
+			r.setField(k, "null")
+		case map[string]interface{}, []interface{}:
+			var b bytes.Buffer
+			json.NewEncoder(&b).Encode(v)
+			r.setField(k, strings.TrimSpace(b.String()))
+		default:
+			r.setField(k, fmt.Sprint(v))
+		}
+	}
+	return r, true
+}
+
+// logTimeLayouts are the time formats tried by ParseLogTime.
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006/01/02 15:04:05.999999999",
+	"0102 15:04:05.999999999", // klog
+	time.StampNano,            // syslog
+}
+
+// ParseLogTime parses the Time of a record: RFC 3339 and similar layouts,
+// klog and syslog times or Unix times in seconds or milliseconds. Times
+// without a zone are in the location of now, those without a year within
+// the year up to now.
+func ParseLogTime(s string, now time.Time) (time.Time, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if f > 1e12 {
+			f /= 1000
+		}
+		sec := int64(f)
+		return time.Unix(sec, int64((f-float64(sec))*1e9)), true
+	}
+	for _, l := range logTimeLayouts {
+		t, err := time.ParseInLocation(l, s, now.Location())
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			t = t.AddDate(now.Year(), 0, 0)
+			if t.After(now.Add(24 * time.Hour)) {
+				t = t.AddDate(-1, 0, 0)
+			}
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+var klogLine = regexp.MustCompile(`^([IWEF])(\d{4} \d{2}:\d{2}:\d{2}\.\d+)\s+(\d+) ([^ \]]+)\] ?(.*)$`)
+
+// ParseKlog parses a line of klog or glog, such as written by Kubernetes,
+// structured or not:
+// I0301 10:00:00.123456   12345 server.go:42] "Listening" port=8080
+func ParseKlog(line string) (LogRecord, bool) {
+	m := klogLine.FindStringSubmatch(line)
+	if m == nil {
+		return LogRecord{}, false
+	}
+	r := LogRecord{
+		Time:    m[2],
+		Level:   ParseLevel(m[1]),
+		Message: m[5],
+		Fields:  map[string]string{"thread": m[3], "source": m[4]},
+	}
+	if q, err := strconv.QuotedPrefix(m[5]); err == nil {
+		if kvs, ok := logfmtPairs(m[5][len(q):]); ok {
+			r.Message, _ = strconv.Unquote(q)
+			for _, kv := range kvs {
+				r.Fields[kv[0]] = kv[1]
+			}
+		}
+	}
+	return r, true
+}
+
+// ParseLog parses line with the first of ParseJSONLog, ParseKlog and
+// ParseLogfmt that recognizes its format.
+func ParseLog(line string) (LogRecord, bool) {
+	for _, p := range []func(string) (LogRecord, bool){ParseJSONLog, ParseKlog, ParseLogfmt} {
+		if r, ok := p(line); ok {
+			return r, true
+		}
+	}
+	return LogRecord{}, false
+}
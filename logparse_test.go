@@ -0,0 +1,42 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestParseLog(t *testing.T) {
+	cases := []struct {
+		line  string
+		level LogLevel
+		msg   string
+		field [2]string
+	}{
+		{`time="2017-03-01T10:00:00Z" level=warning msg="disk at 91%" dev=sda1`, LevelWarn, "disk at 91%", [2]string{"dev", "sda1"}},
+		{`{"ts":"2017-03-01T10:00:00Z","level":"error","msg":"dial failed","retry":3}`, LevelError, "dial failed", [2]string{"retry", "3"}},
+		{`I0301 10:00:00.123456   12345 server.go:42] listening`, LevelInfo, "listening", [2]string{"source", "server.go:42"}},
+		{`E0301 10:00:00.123456       1 pod.go:7] "Sync failed" pod="kube-system/dns" err="timeout"`, LevelError, "Sync failed", [2]string{"pod", "kube-system/dns"}},
+	}
+	for _, c := range cases {
+		r, ok := ParseLog(c.line)
+		if !ok {
+			t.Errorf("%s: not parsed", c.line)
+			continue
+		}
+		if r.Level != c.level || r.Message != c.msg || r.Fields[c.field[0]] != c.field[1] {
+			t.Errorf("%s: got %+v", c.line, r)
+		}
+	}
+
+	if _, ok := ParseLog("plain text = not logfmt"); ok {
+		t.Error("plain text should not parse")
+	}
+}
+
+func TestLogRecordString(t *testing.T) {
+	r, _ := ParseLogfmt(`level=info msg=up b="x y" a=1 ts=10:00`)
+	if s := r.String(); s != `10:00 INFO  up a=1 b="x y"` {
+		t.Errorf("got %q", s)
+	}
+}
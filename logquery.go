@@ -0,0 +1,291 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LogQuery selects log records with an expression such as
+//
+//	level>=warn AND (msg~"timeout" OR pod=api-7) AND NOT retry=0
+//
+// Comparisons take a field, an operator and a value, bare or quoted. The
+// fields are level, msg, time and those of the records. Levels compare by
+// severity, values that are both numbers numerically, others as strings;
+// ~ and !~ match regular expressions. A lone value matches the records
+// containing it, ignoring case. Comparisons combine with AND, OR, NOT and
+// parentheses, AND binding tighter than OR.
+type LogQuery struct {
+	src   string
+	match func(LogRecord) bool
+}
+
+// ParseLogQuery parses the query q.
+func ParseLogQuery(q string) (*LogQuery, error) {
+	toks, err := lexQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks}
+	if len(toks) == 0 {
+		return &LogQuery{src: q, match: func(LogRecord) bool { return true }}, nil
+	}
+	m, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(toks) {
+		return nil, fmt.Errorf("termui: unexpected %q in query", toks[p.pos].s)
+	}
+	return &LogQuery{src: q, match: m}, nil
+}
+
+// String returns the query as parsed.
+func (q *LogQuery) String() string {
+	return q.src
+}
+
+// Match tells if r is selected by q.
+func (q *LogQuery) Match(r LogRecord) bool {
+	return q.match(r)
+}
+
+// field returns the value of the field k of r for queries.
+func (r LogRecord) field(k string) (string, bool) {
+	switch strings.ToLower(k) {
+	case "level", "lvl":
+		return r.Level.String(), r.Level != LevelUnknown
+	case "msg", "message":
+		return r.Message, true
+	case "time", "ts":
+		return r.Time, r.Time != ""
+	}
+	v, ok := r.Fields[k]
+	return v, ok
+}
+
+type queryTok struct {
+	s      string
+	quoted bool
+}
+
+func (t queryTok) is(s string) bool {
+	return !t.quoted && strings.EqualFold(t.s, s)
+}
+
+var queryOps = []string{"!=", ">=", "<=", "!~", "=", ">", "<", "~"}
+
+func lexQuery(q string) ([]queryTok, error) {
+	var toks []queryTok
+	for q = strings.TrimLeft(q, " \t"); q != ""; q = strings.TrimLeft(q, " \t") {
+		switch {
+		case q[0] == '"':
+			s, err := strconv.QuotedPrefix(q)
+			if err != nil {
+				return nil, fmt.Errorf("termui: unterminated string in query: %s", q)
+			}
+			q = q[len(s):]
+			s, _ = strconv.Unquote(s)
+			toks = append(toks, queryTok{s, true})
+			continue
+		case q[0] == '(' || q[0] == ')':
+			toks = append(toks, queryTok{s: q[:1]})
+			q = q[1:]
+			continue
+		}
+		op := ""
+		for _, o := range queryOps {
+			if strings.HasPrefix(q, o) {
+				op = o
+				break
+			}
+		}
+		if op != "" {
+			toks = append(toks, queryTok{s: op})
+			q = q[len(op):]
+			continue
+		}
+		end := strings.IndexAny(q, " \t()\"=!<>~")
+		if end < 0 {
+			end = len(q)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("termui: unexpected %q in query", q[:1])
+		}
+		toks = append(toks, queryTok{s: q[:end]})
+		q = q[end:]
+	}
+	return toks, nil
+}
+
+type queryParser struct {
+	toks []queryTok
+	pos  int
+}
+
+func (p *queryParser) peek() (queryTok, bool) {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos], true
+	}
+	return queryTok{}, false
+}
+
+func (p *queryParser) accept(s string) bool {
+	if t, ok := p.peek(); ok && t.is(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) or() (func(LogRecord) bool, error) {
+	m, err := p.and()
+	for err == nil && p.accept("OR") {
+		var r func(LogRecord) bool
+		if r, err = p.and(); err == nil {
+			l := m
+			m = func(rec LogRecord) bool { return l(rec) || r(rec) }
+		}
+	}
+	return m, err
+}
+
+func (p *queryParser) and() (func(LogRecord) bool, error) {
+	m, err := p.unary()
+	for err == nil && p.accept("AND") {
+		var r func(LogRecord) bool
+		if r, err = p.unary(); err == nil {
+			l := m
+			m = func(rec LogRecord) bool { return l(rec) && r(rec) }
+		}
+	}
+	return m, err
+}
+
+func (p *queryParser) unary() (func(LogRecord) bool, error) {
+	switch {
+	case p.accept("NOT"):
+		m, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(r LogRecord) bool { return !m(r) }, nil
+	case p.accept("("):
+		m, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("termui: missing ) in query")
+		}
+		return m, nil
+	}
+	return p.comparison()
+}
+
+func (p *queryParser) comparison() (func(LogRecord) bool, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("termui: incomplete query")
+	}
+	if t.is(")") || t.is("AND") || t.is("OR") || isQueryOp(t) {
+		return nil, fmt.Errorf("termui: unexpected %q in query", t.s)
+	}
+	p.pos++
+
+	op, ok := p.peek()
+	if !ok || !isQueryOp(op) {
+		// a lone value
+		s := strings.ToLower(t.s)
+		return func(r LogRecord) bool {
+			return strings.Contains(strings.ToLower(r.String()), s)
+		}, nil
+	}
+	p.pos++
+	v, ok := p.peek()
+	if !ok || isQueryOp(v) || v.is("(") || v.is(")") {
+		return nil, fmt.Errorf("termui: missing value after %s%s in query", t.s, op.s)
+	}
+	p.pos++
+	return compareField(t.s, op.s, v.s)
+}
+
+func isQueryOp(t queryTok) bool {
+	if t.quoted {
+		return false
+	}
+	for _, o := range queryOps {
+		if t.s == o {
+			return true
+		}
+	}
+	return false
+}
+
+// compareField returns a matcher comparing the field k of records to v.
+func compareField(k, op, v string) (func(LogRecord) bool, error) {
+	if op == "~" || op == "!~" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return nil, fmt.Errorf("termui: bad pattern in query: %v", err)
+		}
+		return func(r LogRecord) bool {
+			f, _ := r.field(k)
+			return re.MatchString(f) == (op == "~")
+		}, nil
+	}
+
+	if lk := strings.ToLower(k); lk == "level" || lk == "lvl" {
+		l := ParseLevel(v)
+		if l == LevelUnknown {
+			return nil, fmt.Errorf("termui: unknown level %q in query", v)
+		}
+		return func(r LogRecord) bool {
+			return r.Level != LevelUnknown && compareOrder(op, int(r.Level)-int(l))
+		}, nil
+	}
+
+	vf, verr := strconv.ParseFloat(v, 64)
+	return func(r LogRecord) bool {
+		f, ok := r.field(k)
+		if !ok {
+			return op == "!="
+		}
+		if ff, err := strconv.ParseFloat(f, 64); err == nil && verr == nil {
+			switch {
+			case ff < vf:
+				return compareOrder(op, -1)
+			case ff > vf:
+				return compareOrder(op, 1)
+			}
+			return compareOrder(op, 0)
+		}
+		return compareOrder(op, strings.Compare(f, v))
+	}, nil
+}
+
+// compareOrder tells if op holds for a comparison result c, as returned by
+// strings.Compare.
+func compareOrder(op string, c int) bool {
+	switch op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLogQuery(t *testing.T) {
+	recs := []string{
+		`level=error msg="dial tcp: i/o timeout" pod=api-7 retry=3`,
+		`level=warn msg="slow request" pod=api-7 took=12`,
+		`level=info msg="request timeout ignored" pod=db-0 took=3`,
+	}
+	cases := []struct {
+		q    string
+		want string // matching records
+	}{
+		{`level>=warn AND msg~"timeout"`, "100"},
+		{`level>=warn`, "110"},
+		{`NOT level=info`, "110"},
+		{`took>5 OR retry=3`, "110"},
+		{`took<5`, "001"},
+		{`pod!=api-7`, "001"},
+		{`(pod=db-0 OR retry=3) AND level<error`, "001"},
+		{`TIMEOUT`, "101"},
+		{``, "111"},
+	}
+	for _, c := range cases {
+		q, err := ParseLogQuery(c.q)
+		if err != nil {
+			t.Errorf("%s: %v", c.q, err)
+			continue
+		}
+		got := ""
+		for _, l := range recs {
+			r, _ := ParseLogfmt(l)
+			if q.Match(r) {
+				got += "1"
+			} else {
+				got += "0"
+			}
+		}
+		if got != c.want {
+			t.Errorf("%s: matched %s, want %s", c.q, got, c.want)
+		}
+	}
+
+	for _, q := range []string{`level>=`, `level=loud`, `msg~"("`, `(a=1`, `a=1 AND`, `"open`} {
+		if _, err := ParseLogQuery(q); err == nil {
+			t.Errorf("%s: expected an error", q)
+		}
+	}
+}
@@ -0,0 +1,496 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+)
+
+// LogViewer shows the tail of a stream of lines, following the newest ones
+// as they are appended unless scrolled back. With SetFlushStrategy(FlushScroll)
+// appending to a full, following LogViewer scrolls its pane on the terminal
+// and only draws the new lines.
+/*
+  lv := termui.NewLogViewer()
+  lv.BorderLabel = "logs"
+  lv.Cap = 5000
+  lv.Height = 20
+  lv.Width = termui.TermWidth()
+
+  termui.SetFlushStrategy(termui.FlushScroll)
+  termui.Handle("/log", func(e termui.Event) {
+      lv.Append(e.Data.(string))
+      termui.Render(lv)
+  })
+  termui.Handle("/sys/kbd/<up>", func(termui.Event) { lv.ScrollUp(); termui.Render(lv) })
+  termui.Handle("/sys/kbd/<end>", func(termui.Event) { lv.ScrollBottom(); termui.Render(lv) })
+
+  // logfmt, JSON or klog lines, colored by level, warnings of the api only
+  lv.Parser = termui.ParseLog
+  lv.MinLevel = termui.LevelWarn
+  lv.Where = map[string]string{"component": "api"}
+
+  // errors stay in sight in a pane at the top while the rest scrolls by
+  lv.SetPin("level>=error")
+
+  // "/" opens a filter bar taking queries such as level>=warn AND msg~"timeout"
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      if lv.HandleKey(e.Data.(termui.EvtKbd)) {
+          termui.Render(lv)
+      }
+  })
+*/
+type LogViewer struct {
+	Block
+	Cap         int  // number of lines kept by Append, 0 keeps all
+	Follow      bool // show the newest lines as they are appended
+	TextFgColor Attribute
+	TextBgColor Attribute
+
+	// Parser, e.g. ParseLog, breaks lines down into records, shown with their
+	// level colored by LevelColors. Lines it rejects are shown as they are.
+	Parser      func(line string) (LogRecord, bool)
+	LevelColors map[LogLevel]Attribute
+	MinLevel    LogLevel          // hides the records of a lower level
+	Where       map[string]string // shows only the records with these fields
+	Window      TimeWindow        // shows only the records within, see TimeRange
+	PinRows     int               // newest lines matching the pin kept, see SetPin
+
+	query    *LogQuery
+	queryErr error
+	bar      *TextInput // filter bar, nil until opened
+	barOpen  bool
+
+	pin      *LogQuery
+	pinned   []string
+	pinCount int // lines matching the pin so far
+
+	lines  []string
+	total  int // lines appended so far, including the dropped ones
+	offset int // lines scrolled back from the newest when not following
+
+	// state of the last frame, to hint the scroll of the pane
+	drawnArea  image.Rectangle
+	drawnTotal int
+	drawnFull  bool
+}
+
+// NewLogViewer returns a new *LogViewer with current theme, following its
+// newest lines.
+func NewLogViewer() *LogViewer {
+	lv := &LogViewer{Block: *NewBlock()}
+	lv.Cap = 1000
+	lv.Follow = true
+	lv.PinRows = 5
+	lv.TextFgColor = ThemeAttr("logviewer.text.fg")
+	lv.TextBgColor = ThemeAttr("logviewer.text.bg")
+	lv.LevelColors = make(map[LogLevel]Attribute)
+	for l := LevelTrace; l <= LevelFatal; l++ {
+		lv.LevelColors[l] = ThemeAttr("logviewer.level." + strings.ToLower(l.String()) + ".fg")
+	}
+	return lv
+}
+
+// Append adds lines after the newest one, dropping the oldest ones beyond
+// Cap. A LogViewer scrolled back keeps showing the same lines.
+func (lv *LogViewer) Append(lines ...string) {
+	lv.lines = append(lv.lines, lines...)
+	lv.pinLines(lines)
+	n := 0
+	for _, l := range lines {
+		if lv.shown(l) {
+			n++
+		}
+	}
+	lv.total += n
+	if !lv.Follow {
+		lv.offset += n
+	}
+	if lv.Cap > 0 && len(lv.lines) > lv.Cap {
+		lv.lines = lv.lines[len(lv.lines)-lv.Cap:]
+	}
+	lv.offset = clamp(lv.offset, 0, lv.maxOffset())
+}
+
+// Lines returns the lines kept, the oldest first.
+func (lv *LogViewer) Lines() []string {
+	return lv.lines
+}
+
+// Clear drops all lines.
+func (lv *LogViewer) Clear() {
+	lv.lines = nil
+	lv.pinned, lv.pinCount = nil, 0
+	lv.offset = 0
+}
+
+// filtered tells if MinLevel, Where, Window or the query may hide lines.
+func (lv *LogViewer) filtered() bool {
+	return lv.query != nil || lv.Parser != nil && (lv.MinLevel != LevelUnknown || len(lv.Where) > 0 || !lv.Window.IsZero())
+}
+
+// record returns the record of l, or false and a record whose Message is l
+// if Parser is unset or rejects l.
+func (lv *LogViewer) record(l string) (LogRecord, bool) {
+	if lv.Parser != nil {
+		if r, ok := lv.Parser(l); ok {
+			return r, true
+		}
+	}
+	return LogRecord{Message: l}, false
+}
+
+// shown tells if l passes MinLevel, Where, Window and the query. Records of
+// an unknown level pass MinLevel, of an unknown time Window, lines rejected
+// by Parser fail Where.
+func (lv *LogViewer) shown(l string) bool {
+	if !lv.filtered() {
+		return true
+	}
+	r, ok := lv.record(l)
+	if lv.Parser != nil {
+		if r.Level != LevelUnknown && r.Level < lv.MinLevel {
+			return false
+		}
+		for k, v := range lv.Where {
+			if f, found := r.Fields[k]; !ok || !found || f != v {
+				return false
+			}
+		}
+		if !lv.Window.IsZero() && r.Time != "" {
+			now := time.Now()
+			if t, ok := ParseLogTime(r.Time, now); ok && !lv.Window.Contains(t, now) {
+				return false
+			}
+		}
+	}
+	return lv.query == nil || lv.query.Match(r)
+}
+
+// visible returns the lines kept that pass the filters.
+func (lv *LogViewer) visible() []string {
+	if !lv.filtered() {
+		return lv.lines
+	}
+	var ls []string
+	for _, l := range lv.lines {
+		if lv.shown(l) {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+// lineCells returns the cells l is drawn with.
+func (lv *LogViewer) lineCells(l string) []Cell {
+	fg, bg := lv.TextFgColor, lv.TextBgColor
+	r, ok := lv.record(l)
+	if !ok {
+		return DefaultTxBuilder.Build(l, fg, bg)
+	}
+	var cs []Cell
+	if r.Time != "" {
+		cs = append(cs, TextCells(r.Time+" ", fg, bg)...)
+	}
+	if r.Level != LevelUnknown {
+		cs = append(cs, TextCells(fmt.Sprintf("%-5s ", r.Level), lv.LevelColors[r.Level], bg)...)
+	}
+	r.Time, r.Level = "", LevelUnknown
+	return append(cs, TextCells(r.String(), fg, bg)...)
+}
+
+// SetQuery shows only the lines selected by the LogQuery q, or all of them
+// if q is empty. The query in use is kept if q does not parse.
+func (lv *LogViewer) SetQuery(q string) error {
+	if strings.TrimSpace(q) == "" {
+		lv.query, lv.queryErr = nil, nil
+		return nil
+	}
+	lq, err := ParseLogQuery(q)
+	lv.queryErr = err
+	if err != nil {
+		return err
+	}
+	lv.query = lq
+	lv.offset = clamp(lv.offset, 0, lv.maxOffset())
+	return nil
+}
+
+// Query returns the query in use, empty if none.
+func (lv *LogViewer) Query() string {
+	if lv.query == nil {
+		return ""
+	}
+	return lv.query.String()
+}
+
+// Matches returns the number of lines kept that pass the filters, and the
+// number of lines kept.
+func (lv *LogViewer) Matches() (n, total int) {
+	return len(lv.visible()), len(lv.lines)
+}
+
+// HandleKey edits the query in a filter bar at the bottom, opened by "/",
+// applying it as it is typed. <enter> closes the bar, <escape> also drops
+// the query. It tells if the key was consumed.
+func (lv *LogViewer) HandleKey(k EvtKbd) bool {
+	if !lv.barOpen {
+		if k.KeyStr != "/" {
+			return false
+		}
+		lv.filterBar().Text = lv.Query()
+		lv.bar.End()
+		lv.barOpen = true
+		return true
+	}
+
+	switch k.KeyStr {
+	case "<escape>":
+		lv.barOpen = false
+		lv.SetQuery("")
+	case "<enter>":
+		lv.barOpen = false
+		lv.queryErr = nil
+	default:
+		if !lv.bar.HandleKey(k) {
+			return false
+		}
+		lv.SetQuery(lv.bar.Text)
+	}
+	return true
+}
+
+func (lv *LogViewer) filterBar() *TextInput {
+	if lv.bar == nil {
+		lv.bar = NewTextInput()
+		lv.bar.Border = false
+		lv.bar.Placeholder = `level>=warn AND msg~"timeout"`
+	}
+	return lv.bar
+}
+
+// SetPin mirrors the lines selected by the LogQuery q into a pane at the top
+// showing the PinRows newest, while the rest of the LogViewer keeps
+// following all lines, so that e.g. errors are not lost in the stream.
+// Pinned lines stay in the pane once dropped beyond Cap. An empty q removes
+// the pane.
+func (lv *LogViewer) SetPin(q string) error {
+	lv.pin, lv.pinned, lv.pinCount = nil, nil, 0
+	if strings.TrimSpace(q) == "" {
+		return nil
+	}
+	lq, err := ParseLogQuery(q)
+	if err != nil {
+		return err
+	}
+	lv.pin = lq
+	lv.pinLines(lv.lines)
+	return nil
+}
+
+// Pinned returns the newest lines matching the pin, the oldest first.
+func (lv *LogViewer) Pinned() []string {
+	return lv.pinned
+}
+
+// pinLines keeps the lines matching the pin.
+func (lv *LogViewer) pinLines(lines []string) {
+	if lv.pin == nil {
+		return
+	}
+	for _, l := range lines {
+		if r, _ := lv.record(l); lv.pin.Match(r) {
+			lv.pinned = append(lv.pinned, l)
+			lv.pinCount++
+		}
+	}
+	if len(lv.pinned) > lv.PinRows {
+		lv.pinned = append([]string(nil), lv.pinned[len(lv.pinned)-lv.PinRows:]...)
+	}
+}
+
+// pinRows returns the number of rows of the pinned pane, taking up to half
+// of the LogViewer, 0 without a pin.
+func (lv *LogViewer) pinRows() int {
+	if lv.pin == nil {
+		return 0
+	}
+	return clamp(lv.PinRows, 0, (lv.innerArea.Dy()-1)/2)
+}
+
+// drawPinned draws the pinned pane and the line under it.
+func (lv *LogViewer) drawPinned(buf Buffer, n int) {
+	pinned := lv.pinned
+	if len(pinned) > n {
+		pinned = pinned[len(pinned)-n:]
+	}
+	for y, l := range pinned {
+		x := lv.innerArea.Min.X
+		for _, c := range TruncateRight(lv.lineCells(l), lv.innerArea.Dx()) {
+			buf.Set(x, lv.innerArea.Min.Y+y, c)
+			x += c.Width()
+		}
+	}
+
+	y := lv.innerArea.Min.Y + n
+	for x := lv.innerArea.Min.X; x < lv.innerArea.Max.X; x++ {
+		buf.Set(x, y, Cell{Ch: HORIZONTAL_LINE, Fg: lv.BorderFg, Bg: lv.BorderBg})
+	}
+	label := TextCells(fmt.Sprintf(" %s (%d) ", lv.pin, lv.pinCount), lv.BorderLabelFg, lv.BorderLabelBg)
+	x := lv.innerArea.Min.X + 1
+	for _, c := range TruncateRight(label, lv.innerArea.Dx()-2) {
+		buf.Set(x, y, c)
+		x += c.Width()
+	}
+}
+
+// showBar tells if the filter bar is drawn: while editing or filtering.
+func (lv *LogViewer) showBar() bool {
+	return lv.barOpen || lv.query != nil
+}
+
+// mainArea returns the area of the lines followed: below the pinned pane,
+// above the filter bar.
+func (lv *LogViewer) mainArea() image.Rectangle {
+	a := lv.innerArea
+	if n := lv.pinRows(); n > 0 {
+		a.Min.Y += n + 1
+	}
+	if lv.showBar() && a.Dy() > 1 {
+		a.Max.Y--
+	}
+	return a
+}
+
+// rows returns the number of lines followed shown.
+func (lv *LogViewer) rows() int {
+	return lv.mainArea().Dy()
+}
+
+// drawBar draws the filter bar on the row y with the count of matches.
+func (lv *LogViewer) drawBar(buf Buffer, y, n int) {
+	count := TextCells(fmt.Sprintf(" %d/%d", n, len(lv.lines)), lv.TextFgColor, lv.TextBgColor)
+	if lv.queryErr != nil {
+		count = TextCells(" "+lv.queryErr.Error(), ThemeAttr("state.error.fg"), lv.TextBgColor)
+	}
+	count = TruncateLeft(count, lv.innerArea.Dx()/2)
+
+	x := lv.innerArea.Min.X
+	for _, c := range TextCells("/", lv.TextFgColor, lv.TextBgColor) {
+		buf.Set(x, y, c)
+		x++
+	}
+	if !lv.barOpen {
+		lv.filterBar().Text = lv.Query()
+	}
+	lv.bar.SetX(x)
+	lv.bar.SetY(y)
+	lv.bar.Width = lv.innerArea.Max.X - x - cellsWidth(count)
+	lv.bar.Height = 1
+	lv.bar.ShowCursor = lv.barOpen
+	buf.Merge(lv.bar.Buffer())
+
+	x = lv.innerArea.Max.X - cellsWidth(count)
+	for _, c := range count {
+		buf.Set(x, y, c)
+		x += c.Width()
+	}
+}
+
+func (lv *LogViewer) maxOffset() int {
+	if n := len(lv.visible()) - lv.rows(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// ScrollUp scrolls back by one line, which stops following.
+func (lv *LogViewer) ScrollUp() {
+	lv.scrollBy(1)
+}
+
+// ScrollDown scrolls forward by one line, following again at the newest.
+func (lv *LogViewer) ScrollDown() {
+	lv.scrollBy(-1)
+}
+
+// PageUp scrolls back by a page.
+func (lv *LogViewer) PageUp() {
+	lv.scrollBy(lv.innerArea.Dy())
+}
+
+// PageDown scrolls forward by a page.
+func (lv *LogViewer) PageDown() {
+	lv.scrollBy(-lv.innerArea.Dy())
+}
+
+// ScrollTop shows the oldest lines.
+func (lv *LogViewer) ScrollTop() {
+	lv.scrollBy(len(lv.lines))
+}
+
+// ScrollBottom shows the newest lines and follows them.
+func (lv *LogViewer) ScrollBottom() {
+	lv.scrollBy(-len(lv.lines))
+}
+
+func (lv *LogViewer) scrollBy(n int) {
+	lv.offset = clamp(lv.offset+n, 0, lv.maxOffset())
+	lv.Follow = lv.offset == 0
+}
+
+// Buffer implements Bufferer interface.
+func (lv *LogViewer) Buffer() Buffer {
+	buf := lv.Block.Buffer()
+	if lv.drawState(buf) {
+		return buf
+	}
+	if lv.Skeleton && len(lv.lines) == 0 {
+		lv.drawSkeleton(buf, false)
+		return buf
+	}
+
+	area := lv.mainArea()
+	rows := area.Dy()
+	lines := lv.visible()
+	lv.offset = clamp(lv.offset, 0, lv.maxOffset())
+	if lv.Follow {
+		lv.offset = 0
+	}
+	end := len(lines) - lv.offset
+	start := end - rows
+	if start < 0 {
+		start = 0
+	}
+
+	for y, l := range lines[start:end] {
+		x := area.Min.X
+		for _, c := range TruncateRight(lv.lineCells(l), area.Dx()) {
+			buf.Set(x, area.Min.Y+y, c)
+			x += c.Width()
+		}
+	}
+
+	// a full pane following its lines moved up by the lines appended
+	full := end-start == rows
+	if lv.Follow && full && lv.drawnFull && area == lv.drawnArea {
+		if n := lv.total - lv.drawnTotal; n > 0 && n < rows {
+			scrollHint(area, n)
+		}
+	}
+	lv.drawnArea, lv.drawnTotal, lv.drawnFull = area, lv.total, full
+
+	if n := lv.pinRows(); n > 0 {
+		lv.drawPinned(buf, n)
+	}
+	if area.Max.Y < lv.innerArea.Max.Y {
+		lv.drawBar(buf, area.Max.Y, len(lines))
+	}
+
+	return buf
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"image"
+	"testing"
+)
+
+func newTestLogViewer() *LogViewer {
+	lv := NewLogViewer()
+	lv.Border = false
+	lv.Width = 10
+	lv.Height = 3
+	lv.Cap = 5
+	return lv
+}
+
+func TestLogViewerFollow(t *testing.T) {
+	lv := newTestLogViewer()
+	for i := 0; i < 7; i++ {
+		lv.Append(fmt.Sprint(i))
+	}
+	if ls := lv.Lines(); len(ls) != 5 || ls[0] != "2" {
+		t.Fatalf("Append should keep the Cap newest lines, got %v", ls)
+	}
+	buf := lv.Buffer()
+	if c := buf.At(0, 2); c.Ch != '6' {
+		t.Errorf("expected the newest line at the bottom, got %q", c.Ch)
+	}
+
+	lv.ScrollUp()
+	if lv.Follow {
+		t.Error("scrolling back should stop following")
+	}
+	lv.Append("7")
+	buf = lv.Buffer()
+	if c := buf.At(0, 2); c.Ch != '5' {
+		t.Errorf("a scrolled back viewer should keep its lines, got %q", c.Ch)
+	}
+
+	lv.ScrollBottom()
+	buf = lv.Buffer()
+	if !lv.Follow || buf.At(0, 2).Ch != '7' {
+		t.Errorf("ScrollBottom should follow the newest line, got %q", buf.At(0, 2).Ch)
+	}
+}
+
+func TestLogViewerScrollHint(t *testing.T) {
+	scrollScreen.setStrategy(FlushScroll)
+	defer scrollScreen.setStrategy(FlushDiff)
+
+	lv := newTestLogViewer()
+	lv.Append("a", "b", "c")
+	lv.Buffer()
+	lv.Append("d")
+	lv.Buffer()
+
+	scrollScreen.Lock()
+	hs := scrollScreen.hints
+	scrollScreen.Unlock()
+	if len(hs) != 1 || hs[0].n != 1 || hs[0].r != image.Rect(0, 0, 10, 3) {
+		t.Errorf("expected the pane to be hinted one row up, got %v", hs)
+	}
+}
+
+func TestLogViewerLevels(t *testing.T) {
+	lv := newTestLogViewer()
+	lv.Width = 30
+	lv.Parser = ParseLog
+	lv.MinLevel = LevelWarn
+	lv.Where = map[string]string{"svc": "api"}
+	lv.Append(
+		"level=error msg=a svc=api",
+		"level=info msg=b svc=api",
+		"level=warn msg=c svc=db",
+		"level=warn msg=d svc=api",
+	)
+	buf := lv.Buffer()
+	if c := buf.At(0, 0); c.Ch != 'E' || c.Fg != lv.LevelColors[LevelError] {
+		t.Errorf("expected the error first in its level color, got %+v", c)
+	}
+	if c := buf.At(6, 1); c.Ch != 'd' {
+		t.Errorf("expected the api warning next, got %q", c.Ch)
+	}
+	if c := buf.At(0, 2); c.Ch != ' ' && c.Ch != 0 {
+		t.Errorf("expected the other lines hidden, got %q", c.Ch)
+	}
+}
+
+func TestLogViewerFilterBar(t *testing.T) {
+	lv := newTestLogViewer()
+	lv.Width = 30
+	lv.Parser = ParseLog
+	lv.Append("level=error msg=a", "level=info msg=b", "level=error msg=c")
+	for _, k := range []string{"/", "l", "e", "v", "e", "l", "=", "e", "r", "r"} {
+		if !lv.HandleKey(EvtKbd{KeyStr: k}) {
+			t.Fatalf("key %q not consumed", k)
+		}
+	}
+	if n, total := lv.Matches(); n != 2 || total != 3 {
+		t.Errorf("got %d/%d matches, want 2/3", n, total)
+	}
+	buf := lv.Buffer()
+	if c := buf.At(6, 1); c.Ch != 'c' {
+		t.Errorf("expected the last error above the bar, got %q", c.Ch)
+	}
+	var rs []rune
+	for x := 0; x < lv.Width; x++ {
+		rs = append(rs, buf.At(x, 2).Ch)
+	}
+	if row := string(rs); row != "/level=err                 2/3" {
+		t.Errorf("got bar %q", row)
+	}
+
+	lv.HandleKey(EvtKbd{KeyStr: "<escape>"})
+	if lv.Query() != "" {
+		t.Error("<escape> should drop the query")
+	}
+	if n, _ := lv.Matches(); n != 3 {
+		t.Errorf("got %d matches without a query", n)
+	}
+}
+
+func TestLogViewerPin(t *testing.T) {
+	lv := newTestLogViewer()
+	lv.Width = 30
+	lv.Height = 7
+	lv.PinRows = 2
+	lv.Parser = ParseLog
+	if err := lv.SetPin("level>=error"); err != nil {
+		t.Fatal(err)
+	}
+	lv.Append("level=error msg=e1")
+	for i := 0; i < 8; i++ {
+		lv.Append(fmt.Sprintf("level=info msg=i%d", i))
+	}
+	if p := lv.Pinned(); len(p) != 1 || p[0] != "level=error msg=e1" {
+		t.Fatalf("pinned %v", p)
+	}
+	buf := lv.Buffer()
+	if c := buf.At(0, 0); c.Ch != 'E' {
+		t.Errorf("expected the error pinned at the top, got %q", c.Ch)
+	}
+	if c := buf.At(0, 2); c.Ch != HORIZONTAL_LINE {
+		t.Errorf("expected a line under the pinned pane, got %q", c.Ch)
+	}
+	// the main pane follows the newest lines below
+	if c, d := buf.At(6, 6), buf.At(7, 6); c.Ch != 'i' || d.Ch != '7' {
+		t.Errorf("expected the newest line at the bottom, got %q%q", c.Ch, d.Ch)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+// Macros records keyboard event sequences passing through an EvtStream
+// and replays them on demand.
+/*
+  m := termui.NewMacros(termui.DefaultEvtStream)
+  m.Bind("q", "@") // q starts/stops recording, @ replays
+  m.Define("top", "g", "g", "<enter>") // chained commands, play with m.Play("top")
+*/
+type Macros struct {
+	sync.Mutex
+	Delay     time.Duration // pause between replayed events
+	es        *EvtStream
+	recording bool
+	cur       []Event
+	macros    map[string][]Event
+	ignored   map[string]bool // paths of keys controlling m
+	replay    chan Event
+}
+
+// NewMacros returns *Macros recording the keyboard events of es.
+func NewMacros(es *EvtStream) *Macros {
+	m := &Macros{
+		es:      es,
+		macros:  make(map[string][]Event),
+		ignored: make(map[string]bool),
+		replay:  make(chan Event),
+	}
+
+	prev := es.hook
+	es.Hook(func(e Event) {
+		m.tap(e)
+		if prev != nil {
+			prev(e)
+		}
+	})
+	es.Merge("macro", m.replay)
+	return m
+}
+
+func (m *Macros) tap(e Event) {
+	m.Lock()
+	defer m.Unlock()
+	if m.recording && e.From == "termbox" && e.Type == "keyboard" && !m.ignored[e.Path] {
+		m.cur = append(m.cur, e)
+	}
+}
+
+// Recording tells if m is recording.
+func (m *Macros) Recording() bool {
+	m.Lock()
+	defer m.Unlock()
+	return m.recording
+}
+
+// Record starts recording a new macro, discarding unsaved events.
+func (m *Macros) Record() {
+	m.Lock()
+	m.recording = true
+	m.cur = nil
+	m.Unlock()
+}
+
+// Stop stops recording and saves the recorded events under name.
+func (m *Macros) Stop(name string) {
+	m.Lock()
+	m.recording = false
+	m.macros[name] = m.cur
+	m.cur = nil
+	m.Unlock()
+}
+
+// Define saves a macro made of the given key strings, e.g. "j", "C-d".
+func (m *Macros) Define(name string, keys ...string) {
+	es := make([]Event, len(keys))
+	for i, k := range keys {
+		es[i] = Event{
+			Type: "keyboard",
+			Path: "/sys/kbd/" + k,
+			Data: EvtKbd{KeyStr: k},
+		}
+	}
+	m.Lock()
+	m.macros[name] = es
+	m.Unlock()
+}
+
+// Play replays the macro saved under name. Events are delivered by the
+// event loop in order, after the current handler returns.
+func (m *Macros) Play(name string) {
+	m.Lock()
+	es := m.macros[name]
+	delay := m.Delay
+	m.Unlock()
+
+	go func() {
+		for _, e := range es {
+			e.Time = time.Now().Unix()
+			m.replay <- e
+			if delay > 0 {
+				time.Sleep(delay)
+			}
+		}
+	}()
+}
+
+// Bind makes recordKey toggle recording of a macro which playKey replays.
+// The keys themselves are never recorded.
+func (m *Macros) Bind(recordKey, playKey string) {
+	m.es.Handle("/sys/kbd/"+recordKey, func(Event) {
+		if m.Recording() {
+			m.Stop(playKey)
+		} else {
+			m.Record()
+		}
+	})
+	m.es.Handle("/sys/kbd/"+playKey, func(Event) {
+		if !m.Recording() {
+			m.Play(playKey)
+		}
+	})
+
+	m.Lock()
+	m.ignored[cleanPath("/sys/kbd/"+recordKey)] = true
+	m.ignored[cleanPath("/sys/kbd/"+playKey)] = true
+	m.Unlock()
+}
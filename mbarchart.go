@@ -146,6 +146,13 @@ func (bc *MBarChart) SetMax(max int) {
 // Buffer implements Bufferer interface.
 func (bc *MBarChart) Buffer() Buffer {
 	buf := bc.Block.Buffer()
+	if bc.drawState(buf) {
+		return buf
+	}
+	if bc.Skeleton && len(bc.Data) == 0 {
+		bc.drawSkeleton(buf, true)
+		return buf
+	}
 	bc.layout()
 	var oftX int
 
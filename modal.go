@@ -0,0 +1,131 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/go-wordwrap"
+)
+
+// Modal is a message box centered on the terminal, e.g. to surface an
+// error. It draws nothing while hidden, so it can always be passed to
+// Render after the widgets it covers, which are dimmed as told by Dim while
+// it is shown.
+/*
+  m := termui.NewModal()
+  m.Dim = termui.BackdropDim
+  m.Show("Error", err.Error())
+  termui.Handle("/sys/kbd/<enter>", func(termui.Event) {
+      m.Hide()
+      termui.Clear()
+      termui.Render(termui.Body, m)
+  })
+*/
+type Modal struct {
+	Block
+	sync.Mutex
+	Text        string
+	Hint        string
+	TextFgColor Attribute
+	HintFgColor Attribute
+	// Dim is how the widgets rendered before m are drawn while it is shown.
+	Dim     Backdrop
+	visible bool
+}
+
+// NewModal returns a new hidden *Modal with current theme.
+func NewModal() *Modal {
+	m := &Modal{Block: *NewBlock()}
+	m.TextFgColor = ThemeAttr("par.text.fg")
+	m.HintFgColor = ThemeAttr("par.text.fg") | AttrBold
+	m.BorderFg = ColorRed | AttrBold
+	m.Width = 50
+	m.Height = 7
+	return m
+}
+
+// Show sets the title and text of m and makes it visible.
+func (m *Modal) Show(title, text string) {
+	m.Lock()
+	m.BorderLabel = title
+	m.Text = text
+	m.visible = true
+	m.Unlock()
+}
+
+// Hide hides m.
+func (m *Modal) Hide() {
+	m.Lock()
+	m.visible = false
+	m.Unlock()
+}
+
+// Visible tells if m is shown.
+func (m *Modal) Visible() bool {
+	m.Lock()
+	defer m.Unlock()
+	return m.visible
+}
+
+// HandleKey hides a visible m on <enter> or <escape> and tells if the key
+// was consumed.
+func (m *Modal) HandleKey(k EvtKbd) bool {
+	if !m.Visible() || k.KeyStr != "<enter>" && k.KeyStr != "<escape>" {
+		return false
+	}
+	m.Hide()
+	return true
+}
+
+// Backdrop implements Overlay interface.
+func (m *Modal) Backdrop() Backdrop {
+	m.Lock()
+	defer m.Unlock()
+	if !m.visible {
+		return BackdropNone
+	}
+	return m.Dim
+}
+
+// Buffer implements Bufferer interface.
+func (m *Modal) Buffer() Buffer {
+	m.Lock()
+	defer m.Unlock()
+	if !m.visible {
+		return NewBuffer()
+	}
+
+	if r := TermRect(); !r.Empty() {
+		m.X = (r.Dx() - m.Width) / 2
+		m.Y = (r.Dy() - m.Height) / 2
+	}
+	buf := m.Block.Buffer()
+
+	hint := m.Hint
+	if hint == "" {
+		hint = Msg("dismiss")
+	}
+	lines := strings.Split(wordwrap.WrapString(m.Text, uint(m.innerArea.Dx())), "\n")
+	for i, l := range lines {
+		if i >= m.innerArea.Dy()-1 {
+			break
+		}
+		x := m.innerArea.Min.X
+		for _, c := range TruncateRight(TextCells(l, m.TextFgColor, m.Bg), m.innerArea.Dx()) {
+			buf.Set(x, m.innerArea.Min.Y+i, c)
+			x += c.Width()
+		}
+	}
+
+	cs := TruncateRight(TextCells(hint, m.HintFgColor, m.Bg), m.innerArea.Dx())
+	x := m.innerArea.Max.X - cellsWidth(cs)
+	for _, c := range cs {
+		buf.Set(x, m.innerArea.Max.Y-1, c)
+		x += c.Width()
+	}
+	return buf
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Router opens views of the app from slash separated paths such as
+// "logs/pod/foo", so that scripts can start the app on a given workspace,
+// tab or widget. A path is handled by the route with the longest matching
+// prefix of segments, which gets the remaining segments. Router is a
+// flag.Value: the path given on the command line is opened by Open.
+/*
+  r := termui.NewRouter()
+  ws.Routes(r) // "overview", "logs"...
+  r.Route("logs/pod", func(rest []string) error {
+      if len(rest) != 1 {
+          return errors.New("logs/pod takes a pod name")
+      }
+      ws.Switch("logs")
+      lv.Clear()
+      follow(rest[0])
+      return nil
+  })
+  flag.Var(r, "open", "view to open, e.g. logs/pod/foo")
+  flag.Parse()
+
+  termui.Init()
+  defer termui.Close()
+  // build the UI
+  if err := r.Open(); err != nil {
+      ...
+  }
+  termui.Render(termui.Body)
+*/
+type Router struct {
+	mu      sync.Mutex
+	routes  map[string]func(rest []string) error
+	pending string
+}
+
+// NewRouter returns a *Router without routes.
+func NewRouter() *Router {
+	return &Router{routes: make(map[string]func([]string) error)}
+}
+
+func splitRoute(path string) []string {
+	var segs []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+	return segs
+}
+
+// Route registers f to open the paths starting with prefix. f is called
+// with the segments following prefix.
+func (r *Router) Route(prefix string, f func(rest []string) error) {
+	r.mu.Lock()
+	r.routes[strings.Join(splitRoute(prefix), "/")] = f
+	r.mu.Unlock()
+}
+
+// Navigate opens path with the route with the longest matching prefix.
+func (r *Router) Navigate(path string) error {
+	segs := splitRoute(path)
+	r.mu.Lock()
+	var f func([]string) error
+	n := len(segs)
+	for ; n >= 0; n-- {
+		if f = r.routes[strings.Join(segs[:n], "/")]; f != nil {
+			break
+		}
+	}
+	r.mu.Unlock()
+	if f == nil {
+		return fmt.Errorf("termui: no route to %q", path)
+	}
+	return f(segs[n:])
+}
+
+// String implements flag.Value, returning the path to open.
+func (r *Router) String() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pending
+}
+
+// Set implements flag.Value, keeping path to be opened by Open once the UI
+// is built.
+func (r *Router) Set(path string) error {
+	r.mu.Lock()
+	r.pending = path
+	r.mu.Unlock()
+	return nil
+}
+
+// Open navigates to the path given by Set, if any.
+func (r *Router) Open() error {
+	path := r.String()
+	if path == "" {
+		return nil
+	}
+	return r.Navigate(path)
+}
+
+// Routes registers a route to every workspace of ws, named like it.
+func (ws *Workspaces) Routes(r *Router) {
+	for _, name := range ws.Names() {
+		name := name
+		r.Route(name, func([]string) error {
+			ws.Switch(name)
+			return nil
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	var got []string
+	r := NewRouter()
+	r.Route("logs", func(rest []string) error {
+		got = append([]string{"logs"}, rest...)
+		return nil
+	})
+	r.Route("/logs/pod/", func(rest []string) error {
+		got = append([]string{"pod"}, rest...)
+		return nil
+	})
+
+	for path, want := range map[string]string{
+		"logs":             "logs",
+		"logs/node/a":      "logs node a",
+		"/logs/pod/foo":    "pod foo",
+		"logs//pod/foo/x/": "pod foo x",
+	} {
+		got = nil
+		if err := r.Navigate(path); err != nil || strings.Join(got, " ") != want {
+			t.Errorf("Navigate(%q) = %v, %v, want %q", path, got, err, want)
+		}
+	}
+	if err := r.Navigate("configs"); err == nil {
+		t.Error("expected an error without route")
+	}
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.Var(r, "open", "view to open")
+	if err := fs.Parse([]string{"-open", "logs/pod/bar"}); err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	if err := r.Open(); err != nil || strings.Join(got, " ") != "pod bar" {
+		t.Errorf("Open() opened %v, %v", got, err)
+	}
+}
+
+func TestWorkspacesRoutes(t *testing.T) {
+	old := Body
+	defer func() { Body = old }()
+
+	ws := NewWorkspaces()
+	ws.Emit = nil
+	ws.Add("overview", NewGrid())
+	ws.Add("logs", NewGrid())
+	r := NewRouter()
+	ws.Routes(r)
+	if err := r.Navigate("logs/pod/foo"); err != nil || ws.Current() != "logs" {
+		t.Errorf("expected the logs workspace, got %q, %v", ws.Current(), err)
+	}
+}
@@ -4,12 +4,31 @@
 
 package termui
 
+import (
+	"regexp"
+	"strconv"
+)
+
 // Par displays a paragraph.
 /*
   par := termui.NewPar("Simple Text")
   par.Height = 3
   par.Width = 17
   par.BorderLabel = "Label"
+
+  help := termui.NewPar("-v  print more details about what is being done")
+  help.WrapLength = -1
+  help.TextAlign = termui.AlignJustify
+  help.HangingIndent = 4
+
+  docs := termui.NewPar("see the [manual](https://example.com/manual) or [FAQ](https://example.com/faq)")
+  docs.Footnotes = true
+  termui.OpenLink = func(url string) error {
+      return exec.Command("xdg-open", url).Start()
+  }
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      docs.HandleKey(e.Data.(termui.EvtKbd)) // 1 opens the manual
+  })
 */
 type Par struct {
 	Block
@@ -17,8 +36,20 @@ type Par struct {
 	TextFgColor Attribute
 	TextBgColor Attribute
 	WrapLength  int // words wrap limit. Note it may not work properly with multi-width char
+	// TextAlign aligns the lines after wrapping: AlignLeft (default),
+	// AlignRight, AlignCenter or AlignJustify.
+	TextAlign Align
+	// HangingIndent indents the lines continuing a wrapped one.
+	HangingIndent int
+	// Footnotes numbers the links of the text, written [text](url), and
+	// lists their URLs at the bottom, to be opened with HandleKey.
+	Footnotes   bool
+	LinkFgColor Attribute
 }
 
+// OpenLink opens the links of Pars with Footnotes, e.g. in a browser.
+var OpenLink func(url string) error
+
 // NewPar returns a new *Par with given text as its content.
 func NewPar(s string) *Par {
 	return &Par{
@@ -27,6 +58,7 @@ func NewPar(s string) *Par {
 		TextFgColor: ThemeAttr("par.text.fg"),
 		TextBgColor: ThemeAttr("par.text.bg"),
 		WrapLength:  0,
+		LinkFgColor: ThemeAttr("par.link.fg"),
 	}
 }
 
@@ -37,6 +69,22 @@ func (p *Par) Buffer() Buffer {
 	fg, bg := p.TextFgColor, p.TextBgColor
 	cs := DefaultTxBuilder.Build(p.Text, fg, bg)
 
+	if p.Footnotes {
+		var links []string
+		cs, links = p.buildLinks()
+		if n := p.drawLinks(buf, links); n > 0 {
+			// the text is drawn above the references
+			area := p.innerArea
+			p.innerArea.Max.Y -= n
+			defer func() { p.innerArea = area }()
+		}
+	}
+
+	if p.TextAlign&(AlignRight|AlignCenterHorizontal|AlignJustify) != 0 || p.HangingIndent > 0 {
+		p.drawAligned(buf, cs)
+		return buf
+	}
+
 	// wrap if WrapLength set
 	if p.WrapLength < 0 {
 		cs = wrapTx(cs, p.Width-2)
@@ -71,3 +119,211 @@ func (p *Par) Buffer() Buffer {
 
 	return buf
 }
+
+// linkMarkup matches the links of markup, [text](url), telling them from color
+// markup by the scheme of url.
+var linkMarkup = regexp.MustCompile(`\[([^\[\]]*)\]\(([a-zA-Z][a-zA-Z0-9+.-]*:[^()\s]*)\)`)
+
+// Links returns the URLs of the links of the text, the n-th footnote being
+// at index n-1.
+func (p *Par) Links() []string {
+	_, urls := p.buildLinks()
+	return urls
+}
+
+// buildLinks returns the cells of the text, with the links colored and
+// followed by their footnote number, and the URLs of the links.
+func (p *Par) buildLinks() ([]Cell, []string) {
+	fg, bg := p.TextFgColor, p.TextBgColor
+	var cs []Cell
+	var urls []string
+	last := 0
+	for _, m := range linkMarkup.FindAllStringSubmatchIndex(p.Text, -1) {
+		cs = append(cs, DefaultTxBuilder.Build(p.Text[last:m[0]], fg, bg)...)
+		url := p.Text[m[4]:m[5]]
+		n := 0
+		for i, u := range urls {
+			if u == url {
+				n = i + 1
+			}
+		}
+		if n == 0 {
+			urls = append(urls, url)
+			n = len(urls)
+		}
+		cs = append(cs, DefaultTxBuilder.Build(p.Text[m[2]:m[3]], p.LinkFgColor, bg)...)
+		cs = append(cs, TextCells("["+strconv.Itoa(n)+"]", p.LinkFgColor, bg)...)
+		last = m[1]
+	}
+	cs = append(cs, DefaultTxBuilder.Build(p.Text[last:], fg, bg)...)
+	return cs, urls
+}
+
+// drawLinks lists urls at the bottom of p, using up to half of its height,
+// and returns the number of lines used.
+func (p *Par) drawLinks(buf Buffer, urls []string) int {
+	n := len(urls)
+	if n > p.innerArea.Dy()/2 {
+		n = p.innerArea.Dy() / 2
+	}
+	for i, u := range urls[:n] {
+		y := p.innerArea.Max.Y - n + i
+		cs := TextCells("["+strconv.Itoa(i+1)+"] ", p.LinkFgColor, p.TextBgColor)
+		cs = append(cs, TruncateMiddle(TextCells(u, p.TextFgColor, p.TextBgColor), p.innerArea.Dx()-cellsWidth(cs))...)
+		x := p.innerArea.Min.X
+		for _, c := range TruncateRight(cs, p.innerArea.Dx()) {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+	}
+	return n
+}
+
+// HandleKey opens the n-th link of a Par with Footnotes with OpenLink on
+// the key n, from 1 to 9, and tells if the key was consumed.
+func (p *Par) HandleKey(k EvtKbd) bool {
+	n, err := strconv.Atoi(k.KeyStr)
+	if !p.Footnotes || err != nil || n < 1 || n > 9 {
+		return false
+	}
+	urls := p.Links()
+	if n > len(urls) || OpenLink == nil {
+		return false
+	}
+	OpenLink(urls[n-1])
+	return true
+}
+
+// parLine is a line of a Par once wrapped.
+type parLine struct {
+	cells []Cell
+	width int
+	cont  bool // continues a wrapped line
+	end   bool // ends a paragraph
+}
+
+func isBlank(c Cell) bool {
+	return c.Ch == ' ' || c.Ch == '\t'
+}
+
+// wrapLines splits cs into lines of w columns at most, breaking them
+// between words and hard breaking words longer than a line. Lines
+// continuing a wrapped one are indent columns narrower.
+func wrapLines(cs []Cell, w, indent int) []parLine {
+	if indent >= w {
+		indent = 0
+	}
+	var ls []parLine
+	var cur parLine
+	push := func(end bool) {
+		for len(cur.cells) > 0 && isBlank(cur.cells[len(cur.cells)-1]) {
+			cur.width -= cur.cells[len(cur.cells)-1].Width()
+			cur.cells = cur.cells[:len(cur.cells)-1]
+		}
+		cur.end = end
+		ls = append(ls, cur)
+		cur = parLine{cont: !end}
+	}
+	avail := func() int {
+		if cur.cont {
+			return w - indent
+		}
+		return w
+	}
+
+	for i := 0; i < len(cs); {
+		if cs[i].Ch == '\n' {
+			push(true)
+			i++
+			continue
+		}
+		// the next word or run of blanks
+		j := i + 1
+		for j < len(cs) && cs[j].Ch != '\n' && isBlank(cs[j]) == isBlank(cs[i]) {
+			j++
+		}
+		tok := cs[i:j]
+		i = j
+
+		if isBlank(tok[0]) {
+			if !(cur.cont && len(cur.cells) == 0) {
+				cur.cells = append(cur.cells, tok...)
+				cur.width += cellsWidth(tok)
+			}
+			continue
+		}
+		if tw := cellsWidth(tok); cur.width+tw > avail() && cur.width > 0 {
+			push(false)
+		}
+		for _, c := range tok {
+			if cur.width+c.Width() > avail() && cur.width > 0 {
+				push(false)
+			}
+			cur.cells = append(cur.cells, c)
+			cur.width += c.Width()
+		}
+	}
+	push(true)
+	return ls
+}
+
+// drawAligned draws cs wrapped at word boundaries, with TextAlign and
+// HangingIndent applied to the lines.
+func (p *Par) drawAligned(buf Buffer, cs []Cell) {
+	w := p.innerArea.Dx()
+	if p.WrapLength > 0 && p.WrapLength < w {
+		w = p.WrapLength
+	}
+	if w <= 0 {
+		return
+	}
+
+	for y, l := range wrapLines(cs, w, p.HangingIndent) {
+		if y >= p.innerArea.Dy() {
+			buf.Set(p.innerArea.Max.X-1, p.innerArea.Max.Y-1,
+				Cell{Ch: '…', Fg: p.TextFgColor, Bg: p.TextBgColor})
+			break
+		}
+		x := p.innerArea.Min.X
+		lw := w
+		if l.cont && p.HangingIndent < w {
+			x += p.HangingIndent
+			lw -= p.HangingIndent
+		}
+
+		// extra columns given to each gap between words when justifying
+		var gaps []int
+		switch {
+		case p.TextAlign&AlignJustify != 0 && !l.end:
+			n := 0
+			for i, c := range l.cells {
+				if i > 0 && isBlank(c) && !isBlank(l.cells[i-1]) {
+					n++
+				}
+			}
+			if n > 0 {
+				gaps = make([]int, n)
+				for i := range gaps {
+					gaps[i] = (lw - l.width) / n
+					if i < (lw-l.width)%n {
+						gaps[i]++
+					}
+				}
+			}
+		case p.TextAlign&AlignRight != 0:
+			x += lw - l.width
+		case p.TextAlign&AlignCenterHorizontal != 0:
+			x += (lw - l.width) / 2
+		}
+
+		gap := 0
+		for i, c := range l.cells {
+			if gaps != nil && i > 0 && isBlank(c) && !isBlank(l.cells[i-1]) {
+				x += gaps[gap]
+				gap++
+			}
+			buf.Set(x, p.innerArea.Min.Y+y, c)
+			x += c.Width()
+		}
+	}
+}
@@ -4,7 +4,10 @@
 
 package termui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestPar_NoBorderBackground(t *testing.T) {
 	par := NewPar("a")
@@ -22,3 +25,72 @@ func TestPar_NoBorderBackground(t *testing.T) {
 		}
 	}
 }
+
+func parLines(p *Par) []string {
+	buf := p.Buffer()
+	var ls []string
+	for y := 0; y < p.Height; y++ {
+		var rs []rune
+		for x := 0; x < p.Width; x++ {
+			c := buf.At(x, y)
+			if c.Ch == 0 {
+				c.Ch = ' '
+			}
+			rs = append(rs, c.Ch)
+		}
+		ls = append(ls, string(rs))
+	}
+	return ls
+}
+
+func TestParAlign(t *testing.T) {
+	cases := []struct {
+		align  Align
+		indent int
+		want   []string
+	}{
+		{AlignRight, 0, []string{"   the quick", " brown fox a", "       jumps"}},
+		{AlignCenter, 0, []string{" the quick  ", "brown fox a ", "   jumps    "}},
+		{AlignJustify, 0, []string{"the    quick", "brown fox a ", "jumps       "}},
+		{AlignLeft, 2, []string{"the quick   ", "  brown fox ", "  a        …"}},
+	}
+	for _, c := range cases {
+		p := NewPar("the quick brown fox a\njumps")
+		p.Border = false
+		p.Width = 12
+		p.Height = 3
+		p.TextAlign = c.align
+		p.HangingIndent = c.indent
+		got := parLines(p)
+		for i := range c.want {
+			if got[i] != c.want[i] {
+				t.Errorf("align %v indent %d: line %d is %q, want %q", c.align, c.indent, i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestParFootnotes(t *testing.T) {
+	p := NewPar("see [docs](https://a.io/x) and [more](https://a.io/x) or [b](http://b.io)")
+	p.Border = false
+	p.Width = 40
+	p.Height = 4
+	p.Footnotes = true
+	want := []string{"see docs[1] and more[1] or b[2]", "", "[1] https://a.io/x", "[2] http://b.io"}
+	got := parLines(p)
+	for i := range want {
+		if strings.TrimRight(got[i], " ") != want[i] {
+			t.Errorf("line %d is %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	var opened string
+	OpenLink = func(url string) error { opened = url; return nil }
+	defer func() { OpenLink = nil }()
+	if !p.HandleKey(EvtKbd{KeyStr: "2"}) || opened != "http://b.io" {
+		t.Errorf("key 2 opened %q", opened)
+	}
+	if p.HandleKey(EvtKbd{KeyStr: "3"}) {
+		t.Error("key 3 was consumed without a third link")
+	}
+}
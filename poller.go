@@ -0,0 +1,194 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrPollTimeout is the error of a fetch that did not finish in time.
+var ErrPollTimeout = errors.New("termui: poll timed out")
+
+// PollResult is the Data of the "/poll/<name>" events.
+type PollResult struct {
+	Name     string
+	Value    interface{}
+	Err      error
+	Failures int // consecutive failed fetches, 0 on success
+}
+
+// Poll is a data source fetched periodically by a Poller.
+type Poll struct {
+	Name     string
+	Interval time.Duration
+	Jitter   time.Duration // random extra delay, spreads out sources
+	Timeout  time.Duration // 0 means no timeout
+	// MaxBackoff caps the delay after consecutive failures, which doubles
+	// from Interval on. It defaults to 16 times Interval.
+	MaxBackoff time.Duration
+	Fetch      func(ctx context.Context) (interface{}, error)
+}
+
+// delay returns the time to wait before the next fetch, given the number of
+// consecutive failures and a random number in [0, 1).
+func (p *Poll) delay(failures int, r float64) time.Duration {
+	d := p.Interval
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = 16 * p.Interval
+	}
+	for i := 0; i < failures && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d + time.Duration(r*float64(p.Jitter))
+}
+
+// Poller fetches data sources on their own intervals, independently of the
+// render rate, and delivers the results as "/poll/<name>" events, so they
+// are handled on the UI goroutine.
+/*
+  p := termui.NewPoller()
+  p.Add(&termui.Poll{
+      Name:     "cpu",
+      Interval: 2 * time.Second,
+      Jitter:   200 * time.Millisecond,
+      Timeout:  time.Second,
+      Fetch: func(ctx context.Context) (interface{}, error) {
+          return readCPU(ctx)
+      },
+  })
+  p.Start()
+  defer p.Stop()
+
+  termui.Handle("/poll/cpu", func(e termui.Event) {
+      r := e.Data.(termui.PollResult)
+      if r.Err == nil {
+          g.Percent = r.Value.(int)
+          termui.Render(g)
+      }
+  })
+*/
+type Poller struct {
+	// Emit delivers the results, it defaults to SendCustomEvt.
+	Emit   func(path string, data interface{})
+	mu     sync.Mutex
+	polls  []*Poll
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPoller returns an empty *Poller.
+func NewPoller() *Poller {
+	return &Poller{Emit: SendCustomEvt}
+}
+
+// Add registers p. Polls added while running start right away.
+func (pr *Poller) Add(p *Poll) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.polls = append(pr.polls, p)
+	if pr.cancel != nil {
+		pr.start(p)
+	}
+}
+
+// Start starts fetching all polls, each one immediately.
+func (pr *Poller) Start() {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if pr.cancel != nil {
+		return
+	}
+	var ctx context.Context
+	ctx, pr.cancel = context.WithCancel(context.Background())
+	pr.ctx = ctx
+	for _, p := range pr.polls {
+		pr.start(p)
+	}
+}
+
+// Stop stops all polls and waits for running fetches to be abandoned.
+func (pr *Poller) Stop() {
+	pr.mu.Lock()
+	cancel := pr.cancel
+	pr.cancel = nil
+	pr.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		pr.wg.Wait()
+	}
+}
+
+func (pr *Poller) start(p *Poll) {
+	pr.wg.Add(1)
+	go pr.loop(pr.ctx, p)
+}
+
+func (pr *Poller) loop(ctx context.Context, p *Poll) {
+	defer pr.wg.Done()
+	failures := 0
+	for {
+		v, err := fetch(ctx, p)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			failures++
+		} else {
+			failures = 0
+		}
+		if pr.Emit != nil {
+			pr.Emit("/poll/"+p.Name, PollResult{Name: p.Name, Value: v, Err: err, Failures: failures})
+		}
+
+		// idle apps poll less often, until the user comes back
+		factor, wake := DefaultEvtStream.idleWait()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(factor) * p.delay(failures, rand.Float64())):
+		case <-wake:
+		}
+	}
+}
+
+// fetch runs p.Fetch, giving up after p.Timeout even if Fetch ignores its
+// context.
+func fetch(ctx context.Context, p *Poll) (interface{}, error) {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		v   interface{}
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		v, err := p.Fetch(ctx)
+		ch <- result{v, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, ErrPollTimeout
+		}
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPollDelay(t *testing.T) {
+	p := &Poll{Interval: time.Second, Jitter: 100 * time.Millisecond, MaxBackoff: 5 * time.Second}
+	cases := []struct {
+		failures int
+		r        float64
+		want     time.Duration
+	}{
+		{0, 0, time.Second},
+		{0, 0.5, time.Second + 50*time.Millisecond},
+		{2, 0, 4 * time.Second},
+		{10, 0, 5 * time.Second},
+	}
+	for _, c := range cases {
+		if d := p.delay(c.failures, c.r); d != c.want {
+			t.Errorf("delay(%d, %v): expected %v, got %v", c.failures, c.r, c.want, d)
+		}
+	}
+}
+
+func TestPoller(t *testing.T) {
+	results := make(chan PollResult, 10)
+	pr := NewPoller()
+	pr.Emit = func(path string, data interface{}) {
+		results <- data.(PollResult)
+	}
+
+	var calls int32
+	pr.Add(&Poll{
+		Name:     "flaky",
+		Interval: time.Millisecond,
+		Timeout:  20 * time.Millisecond,
+		Fetch: func(ctx context.Context) (interface{}, error) {
+			n := atomic.AddInt32(&calls, 1)
+			switch n {
+			case 1:
+				return nil, errors.New("down")
+			case 2:
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return int(n), nil
+		},
+	})
+	pr.Start()
+
+	r1, r2, r3 := <-results, <-results, <-results
+	pr.Stop()
+
+	if r1.Err == nil || r1.Failures != 1 {
+		t.Errorf("expected a first failure, got %+v", r1)
+	}
+	if r2.Err != ErrPollTimeout || r2.Failures != 2 {
+		t.Errorf("expected a timeout, got %+v", r2)
+	}
+	if r3.Err != nil || r3.Value != 3 || r3.Failures != 0 {
+		t.Errorf("expected a success, got %+v", r3)
+	}
+}
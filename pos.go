@@ -19,6 +19,8 @@ const (
 	AlignCenterVertical
 	AlignCenterHorizontal
 	AlignCenter = AlignCenterVertical | AlignCenterHorizontal
+	// AlignJustify spreads the words of wrapped text lines to the full width.
+	AlignJustify Align = AlignCenterHorizontal << 1
 )
 
 func AlignArea(parent, child image.Rectangle, a Align) image.Rectangle {
@@ -0,0 +1,132 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"sync"
+)
+
+// ProgressEntry is one line of a MultiProgress.
+type ProgressEntry struct {
+	Label   string
+	Percent int
+}
+
+// MultiProgress shows a progress bar per running job, one line each. It is
+// safe to update from several goroutines.
+/*
+  mp := termui.NewMultiProgress()
+  dl := mp.Add("download")
+  mp.Set(dl, 40)
+  ...
+  mp.Remove(dl)
+*/
+type MultiProgress struct {
+	Block
+	sync.Mutex
+	BarColor     Attribute
+	LabelColor   Attribute
+	PercentColor Attribute
+	entries      []*ProgressEntry
+}
+
+// NewMultiProgress returns a new *MultiProgress with current theme.
+func NewMultiProgress() *MultiProgress {
+	mp := &MultiProgress{Block: *NewBlock()}
+	mp.BarColor = ThemeAttr("gauge.bar.bg")
+	mp.LabelColor = ThemeAttr("par.text.fg")
+	mp.PercentColor = ThemeAttr("gauge.percent.fg")
+	mp.Height = 5
+	return mp
+}
+
+// Add appends an entry for label at 0% and returns it.
+func (mp *MultiProgress) Add(label string) *ProgressEntry {
+	mp.Lock()
+	defer mp.Unlock()
+	e := &ProgressEntry{Label: label}
+	mp.entries = append(mp.entries, e)
+	return e
+}
+
+// Set updates the percentage of e, clamped to 0..100.
+func (mp *MultiProgress) Set(e *ProgressEntry, percent int) {
+	mp.Lock()
+	e.Percent = clamp(percent, 0, 100)
+	mp.Unlock()
+}
+
+// Remove removes e.
+func (mp *MultiProgress) Remove(e *ProgressEntry) {
+	mp.Lock()
+	defer mp.Unlock()
+	for i, o := range mp.entries {
+		if o == e {
+			mp.entries = append(mp.entries[:i], mp.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Entries returns a copy of the current entries.
+func (mp *MultiProgress) Entries() []ProgressEntry {
+	mp.Lock()
+	defer mp.Unlock()
+	es := make([]ProgressEntry, len(mp.entries))
+	for i, e := range mp.entries {
+		es[i] = *e
+	}
+	return es
+}
+
+// Buffer implements Bufferer interface.
+func (mp *MultiProgress) Buffer() Buffer {
+	buf := mp.Block.Buffer()
+	es := mp.Entries()
+
+	labelW := 0
+	for _, e := range es {
+		if w := strWidth(e.Label); w > labelW {
+			labelW = w
+		}
+	}
+	if max := mp.innerArea.Dx() / 3; labelW > max {
+		labelW = max
+	}
+
+	for i, e := range es {
+		if i >= mp.innerArea.Dy() {
+			break
+		}
+		y := mp.innerArea.Min.Y + i
+		x := mp.innerArea.Min.X
+		for _, c := range DTrimTxCls(TextCells(e.Label, mp.LabelColor, mp.Bg), labelW) {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+
+		pct := strconv.Itoa(e.Percent) + "%"
+		x0 := mp.innerArea.Min.X + labelW + 1
+		barW := mp.innerArea.Max.X - x0 - 5
+		if barW <= 0 {
+			continue
+		}
+		fill := barW * e.Percent / 100
+		for j := 0; j < barW; j++ {
+			if j < fill {
+				buf.Set(x0+j, y, Cell{Ch: ' ', Fg: ColorDefault, Bg: mp.BarColor})
+			} else {
+				buf.Set(x0+j, y, Cell{Ch: '░', Fg: mp.BarColor, Bg: mp.Bg})
+			}
+		}
+		x = mp.innerArea.Max.X - strWidth(pct)
+		for _, c := range TextCells(pct, mp.PercentColor, mp.Bg) {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+	}
+	return buf
+}
@@ -0,0 +1,558 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "errors"
+
+// QRLevel is the error correction level of a QR code.
+type QRLevel int
+
+// All supported error correction levels, recovering about 7%, 15%, 25%
+// and 30% of damaged modules.
+const (
+	QRLevelL QRLevel = iota
+	QRLevelM
+	QRLevelQ
+	QRLevelH
+)
+
+// qrECCPerBlock and qrBlocks are indexed by level and version (1..40).
+var qrECCPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var qrBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// qrFormatLevel is the level as written in the format information.
+var qrFormatLevel = [4]int{1, 0, 3, 2}
+
+// ErrQRTooLong is returned when the text does not fit a version 40 code.
+var ErrQRTooLong = errors.New("termui: text too long for a QR code")
+
+// qrRawModules returns the number of data and ecc bits in a version ver
+// symbol, i.e. all modules not used by function patterns.
+func qrRawModules(ver int) int {
+	n := (16*ver+128)*ver + 64
+	if ver >= 2 {
+		align := ver/7 + 2
+		n -= (25*align-10)*align - 55
+		if ver >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+func qrDataCodewords(ver int, l QRLevel) int {
+	return qrRawModules(ver)/8 - qrECCPerBlock[l][ver]*qrBlocks[l][ver]
+}
+
+// gfMul multiplies in GF(2^8) modulo x^8+x^4+x^3+x^2+1.
+func gfMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11d)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+func rsDivisor(degree int) []byte {
+	d := make([]byte, degree)
+	d[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range d {
+			d[j] = gfMul(d[j], root)
+			if j+1 < len(d) {
+				d[j] ^= d[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return d
+}
+
+func rsRemainder(data, div []byte) []byte {
+	r := make([]byte, len(div))
+	for _, b := range data {
+		f := b ^ r[0]
+		copy(r, r[1:])
+		r[len(r)-1] = 0
+		for i := range r {
+			r[i] ^= gfMul(div[i], f)
+		}
+	}
+	return r
+}
+
+type qrBits []bool
+
+func (bs *qrBits) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bs = append(*bs, (v>>uint(i))&1 == 1)
+	}
+}
+
+// qrCodewords encodes data in byte mode into the interleaved data and ecc
+// codewords of a version ver symbol.
+func qrCodewords(data []byte, ver int, l QRLevel) []byte {
+	var bs qrBits
+	bs.append(4, 4)
+	if ver <= 9 {
+		bs.append(len(data), 8)
+	} else {
+		bs.append(len(data), 16)
+	}
+	for _, b := range data {
+		bs.append(int(b), 8)
+	}
+
+	capBits := qrDataCodewords(ver, l) * 8
+	term := capBits - len(bs)
+	if term > 4 {
+		term = 4
+	}
+	bs.append(0, term)
+	bs.append(0, (8-len(bs)%8)%8)
+	for pad := 0xec; len(bs) < capBits; pad ^= 0xec ^ 0x11 {
+		bs.append(pad, 8)
+	}
+
+	cws := make([]byte, len(bs)/8)
+	for i, b := range bs {
+		if b {
+			cws[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+
+	// split into blocks, append ecc and interleave
+	nblocks, eccLen := qrBlocks[l][ver], qrECCPerBlock[l][ver]
+	raw := qrRawModules(ver) / 8
+	nshort := nblocks - raw%nblocks
+	shortLen := raw / nblocks
+	div := rsDivisor(eccLen)
+
+	blocks := make([][]byte, nblocks)
+	k := 0
+	for i := range blocks {
+		n := shortLen - eccLen
+		if i >= nshort {
+			n++
+		}
+		dat := append([]byte{}, cws[k:k+n]...)
+		k += n
+		ecc := rsRemainder(dat, div)
+		if i < nshort {
+			dat = append(dat, 0)
+		}
+		blocks[i] = append(dat, ecc...)
+	}
+
+	out := make([]byte, 0, raw)
+	for i := range blocks[0] {
+		for j, b := range blocks {
+			if i != shortLen-eccLen || j >= nshort {
+				out = append(out, b[i])
+			}
+		}
+	}
+	return out
+}
+
+// qrSymbol is the module matrix of a QR code under construction.
+type qrSymbol struct {
+	size     int
+	dark     [][]bool
+	function [][]bool
+}
+
+func newQRSymbol(ver int) *qrSymbol {
+	s := &qrSymbol{size: ver*4 + 17}
+	s.dark = make([][]bool, s.size)
+	s.function = make([][]bool, s.size)
+	for i := range s.dark {
+		s.dark[i] = make([]bool, s.size)
+		s.function[i] = make([]bool, s.size)
+	}
+	return s
+}
+
+func (s *qrSymbol) set(x, y int, dark bool) {
+	s.dark[y][x] = dark
+	s.function[y][x] = true
+}
+
+// ring returns the Chebyshev distance of (dx, dy) from a pattern center.
+func ring(dx, dy int) int {
+	if abs(dy) > abs(dx) {
+		return abs(dy)
+	}
+	return abs(dx)
+}
+
+func (s *qrSymbol) drawFunctionPatterns(ver int) {
+	for i := 0; i < s.size; i++ {
+		s.set(6, i, i%2 == 0)
+		s.set(i, 6, i%2 == 0)
+	}
+
+	finder := func(cx, cy int) {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := cx+dx, cy+dy
+				if x < 0 || x >= s.size || y < 0 || y >= s.size {
+					continue
+				}
+				d := ring(dx, dy)
+				s.set(x, y, d != 2 && d != 4)
+			}
+		}
+	}
+	finder(3, 3)
+	finder(s.size-4, 3)
+	finder(3, s.size-4)
+
+	pos := qrAlignment(ver)
+	last := len(pos) - 1
+	for i, x := range pos {
+		for j, y := range pos {
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					s.set(x+dx, y+dy, ring(dx, dy) != 1)
+				}
+			}
+		}
+	}
+
+	// reserve the format areas, drawn for real once the mask is known
+	s.drawFormat(QRLevelL, 0)
+
+	if ver >= 7 {
+		r := ver
+		for i := 0; i < 12; i++ {
+			r = (r << 1) ^ ((r >> 11) * 0x1f25)
+		}
+		bits := ver<<12 | r
+		for i := 0; i < 18; i++ {
+			dark := (bits>>uint(i))&1 == 1
+			a, b := s.size-11+i%3, i/3
+			s.set(a, b, dark)
+			s.set(b, a, dark)
+		}
+	}
+}
+
+// qrAlignment returns the centers of the alignment patterns of version ver.
+func qrAlignment(ver int) []int {
+	if ver == 1 {
+		return nil
+	}
+	n := ver/7 + 2
+	step := (ver*8 + n*3 + 5) / (n*4 - 4) * 2
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, ver*4+17-7; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// qrFormatBits returns the 15 bit format information of level l and mask.
+func qrFormatBits(l QRLevel, mask int) int {
+	data := qrFormatLevel[l]<<3 | mask
+	r := data
+	for i := 0; i < 10; i++ {
+		r = (r << 1) ^ ((r >> 9) * 0x537)
+	}
+	return (data<<10 | r) ^ 0x5412
+}
+
+func (s *qrSymbol) drawFormat(l QRLevel, mask int) {
+	bits := qrFormatBits(l, mask)
+	bit := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		s.set(8, i, bit(i))
+	}
+	s.set(8, 7, bit(6))
+	s.set(8, 8, bit(7))
+	s.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		s.set(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		s.set(s.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		s.set(8, s.size-15+i, bit(i))
+	}
+	s.set(8, s.size-8, true)
+}
+
+// drawCodewords places the data bits in the zigzag order of the standard.
+func (s *qrSymbol) drawCodewords(cws []byte) {
+	i := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < s.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = s.size - 1 - vert
+				}
+				if !s.function[y][x] && i < len(cws)*8 {
+					s.dark[y][x] = (cws[i/8]>>uint(7-i%8))&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+func qrMasked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask xors the data modules with mask; applying it twice undoes it.
+func (s *qrSymbol) applyMask(mask int) {
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if !s.function[y][x] && qrMasked(mask, x, y) {
+				s.dark[y][x] = !s.dark[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan, lower is better.
+func (s *qrSymbol) penalty() int {
+	p := 0
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return s.dark[x][y]
+		}
+		return s.dark[y][x]
+	}
+
+	// runs of 5 or more and finder-like patterns, in rows and columns
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for _, t := range []bool{false, true} {
+		for y := 0; y < s.size; y++ {
+			run := 1
+			for x := 1; x <= s.size; x++ {
+				if x < s.size && at(x, y, t) == at(x-1, y, t) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					p += run - 2
+				}
+				run = 1
+			}
+			for x := 0; x+7 <= s.size; x++ {
+				match := true
+				for i, d := range finderLike {
+					if at(x+i, y, t) != d {
+						match = false
+						break
+					}
+				}
+				if match && (s.lightRun(x-4, x, y, t, at) || s.lightRun(x+7, x+11, y, t, at)) {
+					p += 40
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of the same color
+	for y := 0; y+1 < s.size; y++ {
+		for x := 0; x+1 < s.size; x++ {
+			c := s.dark[y][x]
+			if c == s.dark[y][x+1] && c == s.dark[y+1][x] && c == s.dark[y+1][x+1] {
+				p += 3
+			}
+		}
+	}
+
+	// balance of dark and light modules
+	dark := 0
+	for _, row := range s.dark {
+		for _, d := range row {
+			if d {
+				dark++
+			}
+		}
+	}
+	total := s.size * s.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return p + k*10
+}
+
+// lightRun tells if modules [x0, x1) of row y are light, counting the
+// quiet zone outside the symbol as light.
+func (s *qrSymbol) lightRun(x0, x1, y int, t bool, at func(x, y int, t bool) bool) bool {
+	for x := x0; x < x1; x++ {
+		if x >= 0 && x < s.size && at(x, y, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeQR encodes text as a byte mode QR code of the smallest version that
+// fits at the given level, returning its modules (true is dark) row by row.
+func EncodeQR(text string, l QRLevel) ([][]bool, error) {
+	data := []byte(text)
+	ver := 1
+	for ; ver <= 40; ver++ {
+		bits := 4 + 8 + 8*len(data)
+		if ver > 9 {
+			bits += 8
+		}
+		if bits <= qrDataCodewords(ver, l)*8 {
+			break
+		}
+	}
+	if ver > 40 {
+		return nil, ErrQRTooLong
+	}
+
+	s := newQRSymbol(ver)
+	s.drawFunctionPatterns(ver)
+	s.drawCodewords(qrCodewords(data, ver, l))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		s.applyMask(mask)
+		s.drawFormat(l, mask)
+		if p := s.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		s.applyMask(mask)
+	}
+	s.applyMask(best)
+	s.drawFormat(l, best)
+	return s.dark, nil
+}
+
+// QRCode shows text, e.g. a URL or a pairing code, as a scannable QR code.
+// Two modules are drawn per cell with half blocks, which keeps them about
+// square on common terminal fonts.
+/*
+  qr := termui.NewQRCode("https://github.com/gizak/termui")
+  qr.Width = 37
+  qr.Height = 21
+*/
+type QRCode struct {
+	Block
+	Text       string
+	Level      QRLevel
+	QuietZone  int // light modules around the code, 4 by the standard
+	DarkColor  Attribute
+	LightColor Attribute
+	modules    [][]bool
+	encoded    string
+	encodedL   QRLevel
+	err        error
+}
+
+// NewQRCode returns a new *QRCode with given text and current theme.
+func NewQRCode(s string) *QRCode {
+	qr := &QRCode{Block: *NewBlock(), Text: s}
+	qr.Level = QRLevelM
+	qr.QuietZone = 4
+	qr.DarkColor = ColorBlack
+	qr.LightColor = ColorWhite
+	qr.encodedL = -1
+	return qr
+}
+
+// Modules returns the encoded modules of qr, re-encoding if Text or Level
+// changed since the last call.
+func (qr *QRCode) Modules() ([][]bool, error) {
+	if qr.Text != qr.encoded || qr.Level != qr.encodedL {
+		qr.modules, qr.err = EncodeQR(qr.Text, qr.Level)
+		qr.encoded, qr.encodedL = qr.Text, qr.Level
+	}
+	return qr.modules, qr.err
+}
+
+// Buffer implements Bufferer interface.
+func (qr *QRCode) Buffer() Buffer {
+	buf := qr.Block.Buffer()
+	ms, err := qr.Modules()
+	if err != nil {
+		x := qr.innerArea.Min.X
+		for _, c := range TruncateRight(TextCells(err.Error(), ColorRed, qr.Bg), qr.innerArea.Dx()) {
+			buf.Set(x, qr.innerArea.Min.Y, c)
+			x += c.Width()
+		}
+		return buf
+	}
+
+	q := qr.QuietZone
+	n := len(ms) + 2*q
+	dark := func(x, y int) bool {
+		x, y = x-q, y-q
+		return x >= 0 && y >= 0 && x < len(ms) && y < len(ms) && ms[y][x]
+	}
+	color := func(d bool) Attribute {
+		if d {
+			return qr.DarkColor
+		}
+		return qr.LightColor
+	}
+
+	x0 := qr.innerArea.Min.X + (qr.innerArea.Dx()-n)/2
+	y0 := qr.innerArea.Min.Y + (qr.innerArea.Dy()-(n+1)/2)/2
+	for y := 0; y < n; y += 2 {
+		for x := 0; x < n; x++ {
+			px, py := x0+x, y0+y/2
+			if px < qr.innerArea.Min.X || px >= qr.innerArea.Max.X ||
+				py < qr.innerArea.Min.Y || py >= qr.innerArea.Max.Y {
+				continue
+			}
+			bottom := qr.LightColor
+			if y+1 < n {
+				bottom = color(dark(x, y+1))
+			}
+			buf.Set(px, py, Cell{Ch: '▀', Fg: color(dark(x, y)), Bg: bottom})
+		}
+	}
+	return buf
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// HELLO WORLD as a 1-M symbol
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	got := rsRemainder(data, rsDivisor(10))
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected ecc %v, got %v", want, got)
+		}
+	}
+}
+
+func TestQRFormatBits(t *testing.T) {
+	if b := qrFormatBits(QRLevelL, 0); b != 0x77c4 {
+		t.Errorf("L/0: expected %015b, got %015b", 0x77c4, b)
+	}
+	if b := qrFormatBits(QRLevelM, 0); b != 0x5412 {
+		t.Errorf("M/0: expected %015b, got %015b", 0x5412, b)
+	}
+}
+
+func TestEncodeQR(t *testing.T) {
+	ms, err := EncodeQR("https://github.com/gizak/termui", QRLevelM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 29 {
+		t.Errorf("expected a version 3 symbol, got size %d", len(ms))
+	}
+	// finder pattern in the top left corner
+	for x, d := range []bool{true, true, true, true, true, true, true, false} {
+		if ms[0][x] != d || ms[x][0] != d {
+			t.Errorf("unexpected finder module at %d", x)
+		}
+	}
+
+	if _, err := EncodeQR(strings.Repeat("x", 3000), QRLevelH); err != ErrQRTooLong {
+		t.Errorf("expected ErrQRTooLong, got %v", err)
+	}
+}
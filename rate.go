@@ -0,0 +1,123 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"time"
+)
+
+// Rate is a goroutine-safe event counter which turns its raw count into an
+// exponentially decayed per-second rate every time it is ticked. The sampled
+// rates are kept as a history that can be fed into a Sparkline or LineChart.
+/*
+  r := termui.NewRate(0.5, 100)
+  go func() {
+      for range requests {
+          r.Inc()
+      }
+  }()
+
+  termui.Handle("/timer/1s", func(e termui.Event) {
+      r.Tick()
+      spl.Lines[0].Data = r.Ints()
+      lc.Data["req/s"] = r.Floats()
+      termui.Render(spl, lc)
+  })
+*/
+type Rate struct {
+	sync.Mutex
+	Decay   float64 // weight of the newest sample, in (0, 1]
+	Cap     int     // max number of samples kept in history
+	count   float64
+	rate    float64
+	last    time.Time
+	primed  bool
+	history []float64
+}
+
+// NewRate returns a *Rate with the given decay weight and history capacity.
+func NewRate(decay float64, cap int) *Rate {
+	if decay <= 0 || decay > 1 {
+		decay = 1
+	}
+	return &Rate{
+		Decay:   decay,
+		Cap:     cap,
+		last:    time.Now(),
+		history: make([]float64, 0, cap),
+	}
+}
+
+// Add adds n to the pending count.
+func (r *Rate) Add(n float64) {
+	r.Lock()
+	r.count += n
+	r.Unlock()
+}
+
+// Inc increments the pending count by one.
+func (r *Rate) Inc() {
+	r.Add(1)
+}
+
+// Tick samples the pending count into the decayed rate, appends the result
+// to history and resets the count. It returns the new rate.
+func (r *Rate) Tick() float64 {
+	return r.tick(time.Now())
+}
+
+func (r *Rate) tick(now time.Time) float64 {
+	r.Lock()
+	defer r.Unlock()
+
+	elapsed := now.Sub(r.last).Seconds()
+	r.last = now
+	if elapsed <= 0 {
+		return r.rate
+	}
+
+	cur := r.count / elapsed
+	r.count = 0
+	if r.primed {
+		r.rate = r.Decay*cur + (1-r.Decay)*r.rate
+	} else {
+		r.rate = cur
+		r.primed = true
+	}
+
+	r.history = append(r.history, r.rate)
+	if r.Cap > 0 && len(r.history) > r.Cap {
+		r.history = r.history[len(r.history)-r.Cap:]
+	}
+	return r.rate
+}
+
+// Rate returns the last sampled per-second rate.
+func (r *Rate) Rate() float64 {
+	r.Lock()
+	defer r.Unlock()
+	return r.rate
+}
+
+// Floats returns a copy of the sampled history, suitable for LineChart.Data.
+func (r *Rate) Floats() []float64 {
+	r.Lock()
+	defer r.Unlock()
+	fs := make([]float64, len(r.history))
+	copy(fs, r.history)
+	return fs
+}
+
+// Ints returns the sampled history rounded to ints, suitable for Sparkline.Data.
+func (r *Rate) Ints() []int {
+	r.Lock()
+	defer r.Unlock()
+	is := make([]int, len(r.history))
+	for i, v := range r.history {
+		is[i] = int(v + 0.5)
+	}
+	return is
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateTick(t *testing.T) {
+	r := NewRate(0.5, 2)
+	now := r.last
+
+	r.Add(10)
+	if v := r.tick(now.Add(time.Second)); v != 10 {
+		t.Errorf("first tick should prime the rate: expected 10, got %v", v)
+	}
+
+	r.Add(20)
+	if v := r.tick(now.Add(2 * time.Second)); v != 15 {
+		t.Errorf("expected decayed rate 15, got %v", v)
+	}
+
+	r.tick(now.Add(3 * time.Second))
+	fs := r.Floats()
+	if len(fs) != 2 || fs[0] != 15 || fs[1] != 7.5 {
+		t.Errorf("history should be capped to the last 2 samples, got %v", fs)
+	}
+	if is := r.Ints(); is[0] != 15 || is[1] != 8 {
+		t.Errorf("expected rounded ints [15 8], got %v", is)
+	}
+}
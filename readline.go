@@ -0,0 +1,241 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"unicode"
+)
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordStart returns the start of the word before i in rs, skipping the
+// separators right before i.
+func wordStart(rs []rune, i int) int {
+	for i > 0 && !isWordRune(rs[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(rs[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after i in rs, skipping the
+// separators right after i.
+func wordEnd(rs []rune, i int) int {
+	for i < len(rs) && !isWordRune(rs[i]) {
+		i++
+	}
+	for i < len(rs) && isWordRune(rs[i]) {
+		i++
+	}
+	return i
+}
+
+// cut deletes the runes of Text from lo to hi and puts the cursor at lo.
+func (ti *TextInput) cut(lo, hi int) {
+	rs := []rune(ti.Text)
+	lo, hi = clamp(lo, 0, len(rs)), clamp(hi, 0, len(rs))
+	if lo >= hi {
+		return
+	}
+	ti.record("")
+	ti.Text = string(append(rs[:lo], rs[hi:]...))
+	ti.Cursor = lo
+}
+
+// WordLeft moves the cursor to the start of the word before it.
+func (ti *TextInput) WordLeft() {
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	ti.Cursor = wordStart(rs, ti.Cursor)
+	ti.moved()
+}
+
+// WordRight moves the cursor to the end of the word after it.
+func (ti *TextInput) WordRight() {
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	ti.Cursor = wordEnd(rs, ti.Cursor)
+	ti.moved()
+}
+
+// KillLine deletes the text from the cursor to the end.
+func (ti *TextInput) KillLine() {
+	ti.clampCursor([]rune(ti.Text))
+	ti.cut(ti.Cursor, len([]rune(ti.Text)))
+}
+
+// KillToStart deletes the text from the start to the cursor.
+func (ti *TextInput) KillToStart() {
+	ti.clampCursor([]rune(ti.Text))
+	ti.cut(0, ti.Cursor)
+}
+
+// KillWordBack deletes the word before the cursor.
+func (ti *TextInput) KillWordBack() {
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	ti.cut(wordStart(rs, ti.Cursor), ti.Cursor)
+}
+
+// KillWord deletes the word after the cursor.
+func (ti *TextInput) KillWord() {
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	ti.cut(ti.Cursor, wordEnd(rs, ti.Cursor))
+}
+
+// recallLine replaces Text with a line of the Recall history, or with the
+// draft typed before recalling when i is past the last line.
+func (ti *TextInput) recallLine(i int) {
+	if i == ti.Recall.Len() {
+		ti.Text = ti.draft
+	} else {
+		ti.Text = ti.Recall.entries[i]
+	}
+	ti.recalled = ti.Recall.Len() - i
+	ti.End()
+}
+
+// HistoryPrev recalls the line submitted before the one shown, and tells
+// if there was one.
+func (ti *TextInput) HistoryPrev() bool {
+	if ti.Recall == nil {
+		return false
+	}
+	i := ti.recallIndex()
+	if i == 0 {
+		return false
+	}
+	if i == ti.Recall.Len() {
+		ti.draft = ti.Text
+	}
+	ti.recallLine(i - 1)
+	return true
+}
+
+// HistoryNext recalls the line submitted after the one shown, or the draft
+// after the last one, and tells if there was one.
+func (ti *TextInput) HistoryNext() bool {
+	if ti.Recall == nil {
+		return false
+	}
+	i := ti.recallIndex()
+	if i >= ti.Recall.Len() {
+		return false
+	}
+	ti.recallLine(i + 1)
+	return true
+}
+
+// recallIndex returns the index of the line of Recall shown, its length
+// for the draft.
+func (ti *TextInput) recallIndex() int {
+	return ti.Recall.Len() - clamp(ti.recalled, 0, ti.Recall.Len())
+}
+
+// submit adds Text to the Recall history and calls OnSubmit.
+func (ti *TextInput) submit() {
+	if ti.Recall != nil {
+		ti.Recall.Add(ti.Text)
+		ti.recalled, ti.draft = 0, ""
+	}
+	if ti.OnSubmit != nil {
+		ti.OnSubmit(ti.Text)
+	}
+}
+
+// historySearch is the state of a reverse search of a TextInput's Recall
+// history.
+type historySearch struct {
+	query   string
+	match   int // index of the line shown, -1 while none matched
+	failing bool
+	orig    textState
+}
+
+// Searching tells if a reverse history search is running, see C-r.
+func (ti *TextInput) Searching() bool {
+	return ti.search != nil
+}
+
+// StartSearch starts a reverse search of the Recall history, showing the
+// newest line containing what is typed next.
+func (ti *TextInput) StartSearch() bool {
+	if ti.Recall == nil {
+		return false
+	}
+	ti.search = &historySearch{match: -1, orig: textState{ti.Text, ti.Cursor}}
+	return true
+}
+
+// findSearch shows the newest line containing the query before the index
+// before.
+func (ti *TextInput) findSearch(before int) {
+	s := ti.search
+	i, ok := ti.Recall.search(s.query, before)
+	s.failing = !ok
+	if !ok {
+		return
+	}
+	s.match = i
+	ti.Text = ti.Recall.entries[i]
+	ti.Cursor = len([]rune(ti.Text[:strings.Index(ti.Text, s.query)]))
+}
+
+// searchKey applies k to the running search and tells if it was consumed.
+// Keys that are not consumed end the search, keeping the line found.
+func (ti *TextInput) searchKey(k EvtKbd) bool {
+	s := ti.search
+	before := s.match + 1
+	if s.match < 0 {
+		before = ti.Recall.Len()
+	}
+	switch k.KeyStr {
+	case "C-r":
+		if s.match >= 0 {
+			before = s.match
+		}
+		ti.findSearch(before)
+	case "<escape>", "C-g":
+		ti.Text, ti.Cursor = s.orig.text, s.orig.cursor
+		ti.search = nil
+	case "<backspace>", "C-8":
+		if rs := []rune(s.query); len(rs) > 0 {
+			s.query = string(rs[:len(rs)-1])
+			ti.findSearch(ti.Recall.Len())
+		}
+	case "<space>":
+		s.query += " "
+		ti.findSearch(before)
+	default:
+		if len([]rune(k.KeyStr)) != 1 {
+			ti.search = nil
+			if s.match >= 0 {
+				ti.recalled = ti.Recall.Len() - s.match
+			}
+			if ti.History != nil && ti.Text != s.orig.text {
+				ti.History.Record("", s.orig)
+			}
+			return false
+		}
+		s.query += k.KeyStr
+		ti.findSearch(before)
+	}
+	return true
+}
+
+// searchPrompt returns the prompt shown before the line found while
+// searching.
+func (ti *TextInput) searchPrompt() string {
+	if ti.search.failing {
+		return "(failing reverse-i-search)`" + ti.search.query + "': "
+	}
+	return "(reverse-i-search)`" + ti.search.query + "': "
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func keys(ti *TextInput, ks ...string) {
+	for _, k := range ks {
+		ti.HandleKey(EvtKbd{KeyStr: k})
+	}
+}
+
+func TestTextInputReadlineKeys(t *testing.T) {
+	ti := NewTextInput()
+	ti.Insert("foo bar-baz qux")
+	for _, c := range []struct {
+		keys   []string
+		text   string
+		cursor int
+	}{
+		{[]string{"M-b"}, "foo bar-baz qux", 12},
+		{[]string{"M-b", "M-b"}, "foo bar-baz qux", 4},
+		{[]string{"M-f"}, "foo bar-baz qux", 7},
+		{[]string{"C-w"}, "foo -baz qux", 4},
+		{[]string{"M-d"}, "foo  qux", 4},
+		{[]string{"C-k"}, "foo ", 4},
+		{[]string{"C-b", "C-u"}, " ", 0},
+	} {
+		keys(ti, c.keys...)
+		if ti.Text != c.text || ti.Cursor != c.cursor {
+			t.Errorf("%v: expected %q at %d, got %q at %d", c.keys, c.text, c.cursor, ti.Text, ti.Cursor)
+		}
+	}
+}
+
+func TestTextInputRecall(t *testing.T) {
+	ti := NewTextInput()
+	ti.Recall = NewInputHistory(10)
+	var saved []string
+	ti.Recall.OnAdd = func(l string) { saved = append(saved, l) }
+	for _, l := range []string{"ls", "git status", "git log", "git log"} {
+		ti.Text = l
+		keys(ti, "<enter>")
+	}
+	if ti.Recall.Len() != 3 || len(saved) != 3 {
+		t.Fatalf("unexpected history %q, saved %q", ti.Recall.Entries(), saved)
+	}
+
+	ti.Text, ti.Cursor = "dra", 3
+	keys(ti, "<up>", "<up>")
+	if ti.Text != "git status" {
+		t.Errorf("unexpected recalled line %q", ti.Text)
+	}
+	keys(ti, "<down>", "<down>")
+	if ti.Text != "dra" {
+		t.Errorf("expected the draft back, got %q", ti.Text)
+	}
+
+	keys(ti, "C-r", "g", "i", "t")
+	if ti.Text != "git log" || !ti.Searching() {
+		t.Errorf("unexpected match %q", ti.Text)
+	}
+	cs, _ := ti.cells()
+	if s := CellsToStr(cs); !strings.HasPrefix(s, "(reverse-i-search)`git': git log") {
+		t.Errorf("unexpected search line %q", s)
+	}
+	keys(ti, "C-r")
+	if ti.Text != "git status" {
+		t.Errorf("expected an older match, got %q", ti.Text)
+	}
+	keys(ti, "<end>")
+	if ti.Searching() || ti.Cursor != len("git status") {
+		t.Error("expected the search accepted and the key applied")
+	}
+	keys(ti, "<up>")
+	if ti.Text != "ls" {
+		t.Errorf("expected recalling from the match, got %q", ti.Text)
+	}
+
+	keys(ti, "C-r", "x", "<escape>")
+	if ti.Text != "ls" || ti.Searching() {
+		t.Errorf("expected the search cancelled, got %q", ti.Text)
+	}
+}
+
+func TestInputHistoryPersistence(t *testing.T) {
+	h := NewInputHistory(2)
+	if err := h.Load(strings.NewReader("a\nb\n\nc\n")); err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	h.Save(&b)
+	if b.String() != "b\nc\n" {
+		t.Errorf("unexpected saved history %q", b.String())
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"os"
+)
+
+// RawRenderer is a Bufferer that owns a rectangle of the screen and writes
+// raw escape sequences into it after every frame is flushed. The compositor
+// does not draw any cell inside RawArea.
+type RawRenderer interface {
+	Bufferer
+	RawArea() image.Rectangle
+	RenderRaw(w io.Writer) error
+}
+
+// rawOut is where raw regions are written to.
+var rawOut io.Writer = os.Stdout
+
+// rawAreas returns the RawArea of every RawRenderer of bs, laid out once
+// per frame.
+func rawAreas(bs []Bufferer) ([]RawRenderer, []image.Rectangle) {
+	raws := []RawRenderer{}
+	areas := []image.Rectangle{}
+	for _, b := range bs {
+		if r, ok := b.(RawRenderer); ok {
+			raws = append(raws, r)
+			areas = append(areas, r.RawArea())
+		}
+	}
+	return raws, areas
+}
+
+func inRawArea(areas []image.Rectangle, p image.Point) bool {
+	for _, a := range areas {
+		if p.In(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderRaw positions the cursor at the top left of a, the area of r, and
+// lets r write into it, restoring the cursor afterwards.
+func renderRaw(r RawRenderer, a image.Rectangle) {
+	if a.Empty() {
+		return
+	}
+	w := bufio.NewWriter(rawOut)
+	fmt.Fprintf(w, "\0337\033[%d;%dH", a.Min.Y+1, a.Min.X+1)
+	r.RenderRaw(w)
+	io.WriteString(w, "\0338")
+	w.Flush()
+}
+
+// Region reserves a rectangle for an external renderer, e.g. another
+// library's output or sixel images. Draw is called after every frame with
+// the cursor placed at the top left corner of the inner area. Regions must
+// be passed to Render directly to be recognized, not nested in a Grid.
+/*
+  img := termui.NewRegion()
+  img.Width = 40
+  img.Height = 20
+  img.BorderLabel = "Plot"
+  img.Draw = func(w io.Writer, area image.Rectangle) error {
+      _, err := w.Write(sixelData)
+      return err
+  }
+  termui.Render(img)
+*/
+type Region struct {
+	Block
+	Draw func(w io.Writer, area image.Rectangle) error
+}
+
+// NewRegion returns a new *Region with current theme.
+func NewRegion() *Region {
+	return &Region{Block: *NewBlock()}
+}
+
+// Buffer implements Bufferer interface, only drawing the border (if any).
+func (r *Region) Buffer() Buffer {
+	return r.Block.Buffer()
+}
+
+// RawArea implements RawRenderer.
+func (r *Region) RawArea() image.Rectangle {
+	r.Align()
+	return r.innerArea
+}
+
+// RenderRaw implements RawRenderer.
+func (r *Region) RenderRaw(w io.Writer) error {
+	if r.Draw == nil {
+		return nil
+	}
+	return r.Draw(w, r.RawArea())
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"strings"
+	"testing"
+)
+
+// countedRegion counts the layouts of its raw area.
+type countedRegion struct {
+	*Region
+	layouts int
+}
+
+func (r *countedRegion) RawArea() image.Rectangle {
+	r.layouts++
+	return r.Region.RawArea()
+}
+
+func TestRegionRender(t *testing.T) {
+	h := NewHeadless(8, 4)
+	oldScreen, oldOut := screen, rawOut
+	out := &bytes.Buffer{}
+	screen, rawOut = h, out
+	defer func() { screen, rawOut = oldScreen, oldOut }()
+
+	p := NewPar(strings.Repeat("########", 4))
+	p.Border = false
+	p.Width, p.Height = 8, 4
+	var drawn image.Rectangle
+	r := &countedRegion{Region: NewRegion()}
+	r.Border = false
+	r.X, r.Y, r.Width, r.Height = 2, 1, 4, 2
+	r.Draw = func(w io.Writer, area image.Rectangle) error {
+		drawn = area
+		_, err := io.WriteString(w, "img")
+		return err
+	}
+
+	render(p, r)
+	want := "########\n##    ##\n##    ##\n########"
+	if s := h.Text(); s != want {
+		t.Errorf("expected the region left undrawn, got\n%s", s)
+	}
+	if drawn != image.Rect(2, 1, 6, 3) {
+		t.Errorf("expected Draw on the inner area, got %v", drawn)
+	}
+	if s := out.String(); s != "\0337\033[2;3Himg\0338" {
+		t.Errorf("expected the raw output at the region, got %q", s)
+	}
+	if r.layouts != 1 {
+		t.Errorf("expected the raw area laid out once per frame, got %d", r.layouts)
+	}
+}
@@ -5,8 +5,10 @@
 package termui
 
 import (
+	"context"
 	"image"
 	"io"
+	"strconv"
 	"sync"
 	"time"
 
@@ -27,15 +29,35 @@ type Bufferer interface {
 	Buffer() Buffer
 }
 
+// inputMode is the termbox input mode of the terminal: <escape> as a key
+// and mouse events, for clicks, gestures and hit testing.
+const inputMode = tm.InputEsc | tm.InputMouse
+
 // Init initializes termui library. This function should be called before any others.
 // After initialization, the library must be finalized by 'Close' function.
+// If TERMUI_HEADLESS is set to a size like 80x24, frames are drawn to an
+// in-memory Headless screen instead of the terminal, see cmd/termui-snap.
 func Init() error {
+	if size := os.Getenv("TERMUI_HEADLESS"); size != "" {
+		return initHeadlessEnv(size)
+	}
 	if err := tm.Init(); err != nil {
 		return err
 	}
+	tm.SetInputMode(inputMode)
+	screen = termboxBackend{}
+	start(true)
+	return nil
+}
 
+// start sets up the event stream and the render loop, drawing to screen.
+// Events are read from the terminal and job control signals handled if term
+// is set.
+func start(term bool) {
 	sysEvtChs = make([]chan Event, 0)
-	go hookTermboxEvt()
+	if term {
+		go hookTermboxEvt(tm.PollEvent)
+	}
 
 	renderJobs = make(chan []Bufferer)
 	//renderLock = new(sync.RWMutex)
@@ -50,6 +72,10 @@ func Init() error {
 	DefaultEvtStream.Merge("termbox", NewSysEvtCh())
 	DefaultEvtStream.Merge("timer", NewTimerCh(time.Second))
 	DefaultEvtStream.Merge("custom", usrEvtCh)
+	DefaultEvtStream.Merge("resize", resizeCh)
+	if term {
+		handleSignals()
+	}
 
 	DefaultEvtStream.Handle("/", DefaultHandler)
 	DefaultEvtStream.Handle("/sys/wnd/resize", func(e Event) {
@@ -58,29 +84,36 @@ func Init() error {
 	})
 
 	DefaultWgtMgr = NewWgtMgr()
-	DefaultEvtStream.Hook(DefaultWgtMgr.WgtHandlersHook())
+	wgtHook := DefaultWgtMgr.WgtHandlersHook()
+	DefaultEvtStream.Hook(func(e Event) {
+		wgtHook(e)
+		dispatchHit(e)
+	})
 
 	go func() {
 		for bs := range renderJobs {
+			if len(bs) == 1 && bs[0] == Bufferer(renderBarrier{}) {
+				continue
+			}
 			render(bs...)
 		}
 	}()
-
-	return nil
 }
 
 // Close finalizes termui library,
 // should be called after successful initialization when termui's functionality isn't required anymore.
 func Close() {
-	tm.Close()
+	disableFocusEvents()
+	screen.close()
+	resetCursor()
 }
 
 var renderLock sync.Mutex
 
 func termSync() {
 	renderLock.Lock()
-	tm.Sync()
-	termWidth, termHeight = tm.Size()
+	screen.sync()
+	termWidth, termHeight = screen.size()
 	renderLock.Unlock()
 }
 
@@ -96,6 +129,45 @@ func TermHeight() int {
 	return termHeight
 }
 
+// RenderHook is called at a frame boundary with the Bufferers of that frame.
+type RenderHook func(bs []Bufferer)
+
+var renderHooks struct {
+	sync.RWMutex
+	pre  []RenderHook
+	post []RenderHook
+}
+
+// RegisterPreRender adds a hook that runs before each frame is drawn,
+// e.g. to update derived widget state.
+func RegisterPreRender(h RenderHook) {
+	renderHooks.Lock()
+	renderHooks.pre = append(renderHooks.pre, h)
+	renderHooks.Unlock()
+}
+
+// RegisterPostRender adds a hook that runs after each frame is flushed to
+// the terminal, e.g. to collect timing metrics or take screenshots.
+func RegisterPostRender(h RenderHook) {
+	renderHooks.Lock()
+	renderHooks.post = append(renderHooks.post, h)
+	renderHooks.Unlock()
+}
+
+// ResetRenderHooks removes all registered pre and post render hooks.
+func ResetRenderHooks() {
+	renderHooks.Lock()
+	renderHooks.pre = nil
+	renderHooks.post = nil
+	renderHooks.Unlock()
+}
+
+func runRenderHooks(hs []RenderHook, bs []Bufferer) {
+	for _, h := range hs {
+		h(bs)
+	}
+}
+
 // Render renders all Bufferer in the given order from left to right,
 // right could overlap on left ones.
 func render(bs ...Bufferer) {
@@ -119,45 +191,105 @@ func render(bs ...Bufferer) {
 			os.Exit(1)
 		}
 	}()
-	for _, b := range bs {
 
-		buf := b.Buffer()
+	renderHooks.RLock()
+	pre, post := renderHooks.pre, renderHooks.post
+	renderHooks.RUnlock()
+
+	runRenderHooks(pre, bs)
+
+	frameLock.RLock()
+	roots := bs
+	bs = flatten(bs)
+
+	ctx, endFrame := span(context.Background(), "termui.frame", "widgets", strconv.Itoa(len(bs)))
+	defer endFrame()
+	traced := currentTracer() != nil
+
+	// regions owned by external renderers are left untouched
+	raws, areas := rawAreas(bs)
+
+	// cells drawn so far, kept for overlays to composite
+	var cells map[image.Point]Cell
+	for _, b := range bs {
+		if _, ok := b.(Overlay); ok {
+			cells = make(map[image.Point]Cell)
+			break
+		}
+	}
+	cache := bufCache{}
+	beginHits()
+	beginCursor()
+	drawn := make([]image.Rectangle, 0, len(bs))
+	for _, b := range bs {
+		if o, ok := b.(Overlay); ok {
+			if bd := o.Backdrop(); bd != BackdropNone {
+				composite(cells, bd)
+			}
+		}
+		endBuf := endNothing
+		if traced {
+			_, endBuf = span(ctx, "termui.buffer", "widget", fmt.Sprintf("%T", b))
+		}
+		buf := lastBufs.buffer(b, cache)
+		endBuf()
+		drawn = append(drawn, buf.Area)
 		// set cels in buf
 		for p, c := range buf.CellMap {
-			if p.In(buf.Area) {
+			if p.In(buf.Area) && !inRawArea(areas, p) {
+				if cells != nil {
+					cells[p] = c
+				}
 
-				tm.SetCell(p.X, p.Y, c.Ch, toTmAttr(c.Fg), toTmAttr(c.Bg))
+				screen.setCell(p.X, p.Y, c)
 
 			}
 		}
 
 	}
+	lastBufs = cache
+	endHits(drawn)
+	frameLock.RUnlock()
 
+	_, endFlush := span(ctx, "termui.flush")
 	renderLock.Lock()
 	// render
-	tm.Flush()
+	screen.flush()
+	for i, r := range raws {
+		renderRaw(r, areas[i])
+	}
+	placeCursor(drawn)
 	renderLock.Unlock()
+	endFlush()
+
+	runRenderHooks(post, roots)
 }
 
+// lastBufs are the buffers of the Dirtiers of the last frame.
+var lastBufs = bufCache{}
+
 func Clear() {
-	tm.Clear(tm.ColorDefault, toTmAttr(ThemeAttr("bg")))
+	screen.clear(ThemeAttr("bg"))
+	clearHits()
 }
 
 func clearArea(r image.Rectangle, bg Attribute) {
 	for i := r.Min.X; i < r.Max.X; i++ {
 		for j := r.Min.Y; j < r.Max.Y; j++ {
-			tm.SetCell(i, j, ' ', tm.ColorDefault, toTmAttr(bg))
+			screen.setCell(i, j, Cell{Ch: ' ', Bg: bg})
 		}
 	}
 }
 
 func ClearArea(r image.Rectangle, bg Attribute) {
 	clearArea(r, bg)
-	tm.Flush()
+	screen.flush()
 }
 
 var renderJobs chan []Bufferer
 
+// Render draws a frame of bs, which may be the roots of widget trees, see
+// Container.
 func Render(bs ...Bufferer) {
 	//go func() { renderJobs <- bs }()
 	renderJobs <- bs
@@ -0,0 +1,161 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// Resizer lets keyboard-only users resize the columns of a Grid. In resize
+// mode <left>/<right> shrink/grow the focused column at the expense of its
+// neighbour, <up>/<down> shrink/grow the focused widget's height, <tab>
+// moves the focus and <escape> leaves the mode.
+/*
+  rz := termui.NewResizer(termui.Body)
+  rz.Bind("C-r", func() {
+      termui.Body.Align()
+      termui.Clear()
+      termui.Render(termui.Body)
+  })
+*/
+type Resizer struct {
+	Grid   *Grid
+	Focus  int // index of the focused column, see Cols
+	active bool
+}
+
+// resizable is a column together with the row holding it.
+type resizable struct {
+	parent *Row
+	i      int
+}
+
+// NewResizer returns a *Resizer for g.
+func NewResizer(g *Grid) *Resizer {
+	return &Resizer{Grid: g}
+}
+
+// cols returns the resizable columns of the grid in depth-first order,
+// that is every column sharing its row with at least one other column.
+func (rz *Resizer) cols() []resizable {
+	cs := []resizable{}
+	for _, r := range rz.Grid.Rows {
+		r.walk(func(n *Row) {
+			if len(n.Cols) < 2 {
+				return
+			}
+			for i := range n.Cols {
+				cs = append(cs, resizable{n, i})
+			}
+		})
+	}
+	return cs
+}
+
+// Active tells if rz is in resize mode.
+func (rz *Resizer) Active() bool {
+	return rz.active
+}
+
+// Enter starts resize mode.
+func (rz *Resizer) Enter() {
+	rz.active = true
+}
+
+// Exit leaves resize mode.
+func (rz *Resizer) Exit() {
+	rz.active = false
+}
+
+// Next moves the focus to the next resizable column, wrapping around.
+func (rz *Resizer) Next() {
+	if n := len(rz.cols()); n > 0 {
+		rz.Focus = (rz.Focus + 1) % n
+	}
+}
+
+// Focused returns the focused column, nil if the grid has none.
+func (rz *Resizer) Focused() *Row {
+	cs := rz.cols()
+	if rz.Focus < 0 || rz.Focus >= len(cs) {
+		return nil
+	}
+	return cs[rz.Focus].parent.Cols[cs[rz.Focus].i]
+}
+
+// Grow widens the focused column by n spans (shrinks it if n < 0), taking
+// the space from its right neighbour, or its left one for the last column.
+// Spans never drop below 1.
+func (rz *Resizer) Grow(n int) {
+	cs := rz.cols()
+	if rz.Focus < 0 || rz.Focus >= len(cs) {
+		return
+	}
+	c := cs[rz.Focus]
+	nb := c.i + 1
+	if nb == len(c.parent.Cols) {
+		nb = c.i - 1
+	}
+	col, other := c.parent.Cols[c.i], c.parent.Cols[nb]
+	if col.Span+n < 1 || other.Span-n < 1 {
+		return
+	}
+	col.Span += n
+	other.Span -= n
+}
+
+// GrowHeight changes the height of the focused column's widget by n rows,
+// if the widget supports it (e.g. all Block based widgets).
+func (rz *Resizer) GrowHeight(n int) {
+	col := rz.Focused()
+	if col == nil {
+		return
+	}
+	col.walk(func(r *Row) {
+		if w, ok := r.Widget.(interface {
+			SetHeight(int)
+		}); ok && r.Widget.GetHeight()+n >= 1 {
+			w.SetHeight(r.Widget.GetHeight() + n)
+		}
+	})
+}
+
+// Bind registers key to enter resize mode on the default event stream.
+// While active, the resize keys are taken over; otherwise they go to the
+// handlers registered before Bind. redraw is called after every change.
+func (rz *Resizer) Bind(key string, redraw func()) {
+	if redraw == nil {
+		redraw = func() {}
+	}
+	keys := map[string]func(){
+		"<left>":   func() { rz.Grow(-1) },
+		"<right>":  func() { rz.Grow(1) },
+		"<up>":     func() { rz.GrowHeight(-1) },
+		"<down>":   func() { rz.GrowHeight(1) },
+		"<tab>":    rz.Next,
+		"<escape>": rz.Exit,
+	}
+
+	for k, f := range keys {
+		path := cleanPath("/sys/kbd/" + k)
+		prev := DefaultEvtStream.Handler(path)
+		f := f
+		Handle(path, func(e Event) {
+			if !rz.active {
+				if prev != nil {
+					prev(e)
+				}
+				return
+			}
+			f()
+			redraw()
+		})
+	}
+
+	Handle("/sys/kbd/"+key, func(Event) {
+		if rz.active {
+			rz.Exit()
+		} else {
+			rz.Enter()
+		}
+		redraw()
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestResizer(t *testing.T) {
+	b0, b1 := NewBlock(), NewBlock()
+	b1.Height = 4
+	g := NewGrid(NewRow(NewCol(6, 0, b0), NewCol(6, 0, b1)))
+	rz := NewResizer(g)
+
+	rz.Grow(2)
+	if g.Rows[0].Cols[0].Span != 8 || g.Rows[0].Cols[1].Span != 4 {
+		t.Errorf("focused col should grow at the expense of its neighbour: %v", g.Spans())
+	}
+
+	rz.Next()
+	rz.Grow(-4)
+	if g.Rows[0].Cols[1].Span != 4 {
+		t.Error("span should not drop below 1")
+	}
+	rz.Grow(1)
+	if g.Rows[0].Cols[0].Span != 7 || g.Rows[0].Cols[1].Span != 5 {
+		t.Errorf("last col should take space from its left neighbour: %v", g.Spans())
+	}
+
+	rz.GrowHeight(2)
+	if b1.Height != 6 {
+		t.Errorf("focused widget height should grow, got %d", b1.Height)
+	}
+}
+
+func TestResizerBind(t *testing.T) {
+	defer func(es *EvtStream) { DefaultEvtStream = es }(DefaultEvtStream)
+	es := NewEvtStream()
+	DefaultEvtStream = es
+
+	left := 0
+	es.Handle("/sys/kbd/<left>", func(Event) { left++ })
+	g := NewGrid(NewRow(NewCol(6, 0, NewBlock()), NewCol(6, 0, NewBlock())))
+	rz := NewResizer(g)
+
+	// binding while handlers are dispatched and registered must not race
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			es.dispatch(Event{Path: "/sys/kbd/<tab>"})
+			es.Handle("/sys/kbd/q", func(Event) {})
+		}
+	}()
+	rz.Bind("C-r", nil)
+	wg.Wait()
+
+	es.dispatch(Event{Path: "/sys/kbd/<left>"})
+	if left != 1 {
+		t.Errorf("<left> should go to the previous handler outside resize mode, got %d calls", left)
+	}
+	es.dispatch(Event{Path: "/sys/kbd/C-r"})
+	es.dispatch(Event{Path: "/sys/kbd/<left>"})
+	if left != 1 || !rz.Active() {
+		t.Error("<left> should be taken over in resize mode")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// pointRing keeps the last points of a series in a fixed buffer of twice
+// their number, moving them back to its start once it is full, so that
+// they are always a slice usable as Data without allocating per point.
+type pointRing struct {
+	buf []float64
+	n   int // number of points kept
+}
+
+// window returns the points kept, the oldest first.
+func (r *pointRing) window() []float64 {
+	if len(r.buf) < r.n {
+		return r.buf
+	}
+	return r.buf[len(r.buf)-r.n:]
+}
+
+func (r *pointRing) add(v float64) {
+	if len(r.buf) == cap(r.buf) {
+		w := r.window()
+		r.buf = r.buf[:copy(r.buf[:cap(r.buf)], w)]
+	}
+	r.buf = append(r.buf, v)
+}
+
+// AddPoint appends v to series, dropping its oldest point beyond MaxPoints,
+// so that the chart scrolls as points arrive. Data[series] is updated and
+// should be left as is between calls: if replaced, AddPoint starts over
+// from the new slice.
+/*
+  lc.MaxPoints = 300
+  termui.Handle("/timer/1s", func(termui.Event) {
+      lc.AddPoint("cpu", cpuLoad())
+      termui.Render(lc)
+  })
+*/
+func (lc *LineChart) AddPoint(series string, v float64) {
+	max := lc.MaxPoints
+	if max <= 0 {
+		max = 1
+	}
+	if lc.rings == nil {
+		lc.rings = make(map[string]*pointRing)
+	}
+	r, ok := lc.rings[series]
+	data := lc.Data[series]
+	if !ok || r.n != max || !sameSlice(r.window(), data) {
+		r = &pointRing{buf: make([]float64, 0, 2*max), n: max}
+		if len(data) > max {
+			data = data[len(data)-max:]
+		}
+		r.buf = append(r.buf, data...)
+		lc.rings[series] = r
+	}
+	r.add(v)
+	lc.Data[series] = r.window()
+	// the points moved back to the start of the ring, or others replaced
+	// them in a window of the same length
+	lc.changed(series, true)
+}
+
+func sameSlice(a, b []float64) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
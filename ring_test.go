@@ -0,0 +1,30 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLineChartAddPoint(t *testing.T) {
+	lc := NewLineChart()
+	lc.MaxPoints = 3
+	for i := 0; i < 10; i++ {
+		lc.AddPoint("cpu", float64(i))
+	}
+	d := lc.Data["cpu"]
+	if len(d) != 3 || d[0] != 7 || d[2] != 9 {
+		t.Fatalf("got %v, want the 3 newest points", d)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() { lc.AddPoint("cpu", 1) })
+	if allocs != 0 {
+		t.Errorf("AddPoint allocated %v times per point", allocs)
+	}
+
+	lc.Data["cpu"] = []float64{1, 2, 3, 4}
+	lc.AddPoint("cpu", 5)
+	if d := lc.Data["cpu"]; len(d) != 3 || d[0] != 3 || d[2] != 5 {
+		t.Errorf("got %v after replacing Data, want [3 4 5]", d)
+	}
+}
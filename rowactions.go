@@ -0,0 +1,150 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "time"
+
+// EvtRow is the Data of the events of RowActions: the selected row and its
+// item.
+type EvtRow struct {
+	Row  int
+	Item interface{}
+}
+
+// RowAction is a command on the selected row of a list or table, offered
+// for the rows Available tells, or all of them when it is nil.
+type RowAction struct {
+	Key       string
+	Name      string
+	Available func(row int) bool
+	handler   func(Event)
+}
+
+// RowActions is a hint bar of the actions available on the selected row of
+// a list or table, which dispatches them on their key. The handlers get an
+// event of path "/row/<name>" with an EvtRow.
+/*
+  ra := termui.NewRowActions(func() int { return pods.SelectedRow })
+  ra.Item = func(row int) interface{} { return podList[row] }
+  ra.Add("d", "delete", nil, func(e termui.Event) {
+      deletePod(e.Data.(termui.EvtRow).Item.(*Pod))
+  })
+  ra.Add("l", "logs", func(row int) bool { return podList[row].Running() }, showLogs)
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      if ra.HandleKey(e.Data.(termui.EvtKbd)) {
+          termui.Render(termui.Body)
+      }
+  })
+*/
+type RowActions struct {
+	Block
+	Selected    func() int // the selected row, -1 for none
+	Item        func(row int) interface{}
+	KeyFgColor  Attribute
+	TextFgColor Attribute
+	actions     []*RowAction
+}
+
+// NewRowActions returns a new *RowActions with current theme, for the row
+// selected tells.
+func NewRowActions(selected func() int) *RowActions {
+	ra := &RowActions{Block: *NewBlock(), Selected: selected}
+	ra.Border = false
+	ra.Height = 1
+	ra.KeyFgColor = ThemeAttr("rowactions.key.fg") | AttrBold
+	ra.TextFgColor = ThemeAttr("rowactions.text.fg")
+	return ra
+}
+
+// Add registers the action name on key, offered for the rows available
+// accepts. Adding an existing name replaces it.
+func (ra *RowActions) Add(key, name string, available func(row int) bool, f func(Event)) {
+	a := &RowAction{Key: key, Name: name, Available: available, handler: f}
+	for i, o := range ra.actions {
+		if o.Name == name {
+			ra.actions[i] = a
+			return
+		}
+	}
+	ra.actions = append(ra.actions, a)
+}
+
+// Remove unregisters the action name.
+func (ra *RowActions) Remove(name string) {
+	for i, a := range ra.actions {
+		if a.Name == name {
+			ra.actions = append(ra.actions[:i], ra.actions[i+1:]...)
+			return
+		}
+	}
+}
+
+// selected returns the selected row, -1 for none.
+func (ra *RowActions) selected() int {
+	if ra.Selected == nil {
+		return -1
+	}
+	return ra.Selected()
+}
+
+// Available returns the actions available on the selected row, in the
+// order they were added.
+func (ra *RowActions) Available() []RowAction {
+	row := ra.selected()
+	if row < 0 {
+		return nil
+	}
+	var as []RowAction
+	for _, a := range ra.actions {
+		if a.Available == nil || a.Available(row) {
+			as = append(as, *a)
+		}
+	}
+	return as
+}
+
+// HandleKey runs the action available on the selected row bound to the key
+// k, and tells if there was one.
+func (ra *RowActions) HandleKey(k EvtKbd) bool {
+	row := ra.selected()
+	for _, a := range ra.Available() {
+		if a.Key != k.KeyStr {
+			continue
+		}
+		e := EvtRow{Row: row}
+		if ra.Item != nil {
+			e.Item = ra.Item(row)
+		}
+		a.handler(Event{
+			Type: "row",
+			Path: "/row/" + a.Name,
+			From: "/sys",
+			Data: e,
+			Time: time.Now().Unix(),
+		})
+		return true
+	}
+	return false
+}
+
+// Buffer implements Bufferer interface.
+func (ra *RowActions) Buffer() Buffer {
+	buf := ra.Block.Buffer()
+
+	var cs []Cell
+	for i, a := range ra.Available() {
+		if i > 0 {
+			cs = append(cs, TextCells("  ", ra.TextFgColor, ra.Bg)...)
+		}
+		cs = append(cs, TextCells(a.Key, ra.KeyFgColor, ra.Bg)...)
+		cs = append(cs, TextCells(" "+a.Name, ra.TextFgColor, ra.Bg)...)
+	}
+	x := ra.innerArea.Min.X
+	for _, c := range TruncateRight(cs, ra.innerArea.Dx()) {
+		buf.Set(x, ra.innerArea.Min.Y, c)
+		x += c.Width()
+	}
+	return buf
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRowActions(t *testing.T) {
+	pods := []string{"web", "db"}
+	sel := 0
+	ra := NewRowActions(func() int { return sel })
+	ra.Item = func(row int) interface{} { return pods[row] }
+	ra.Width = 30
+
+	var got []string
+	ra.Add("d", "delete", nil, func(e Event) {
+		got = append(got, e.Path+" "+e.Data.(EvtRow).Item.(string))
+	})
+	ra.Add("l", "logs", func(row int) bool { return pods[row] != "db" }, func(e Event) {
+		got = append(got, e.Path+" "+e.Data.(EvtRow).Item.(string))
+	})
+
+	if s := hintBar(ra); s != "d delete  l logs" {
+		t.Errorf("hint bar %q", s)
+	}
+	sel = 1
+	if s := hintBar(ra); s != "d delete" {
+		t.Errorf("hint bar %q without logs on db", s)
+	}
+	if ra.HandleKey(EvtKbd{KeyStr: "l"}) {
+		t.Error("logs should not be available on db")
+	}
+	ra.HandleKey(EvtKbd{KeyStr: "d"})
+	sel = 0
+	ra.HandleKey(EvtKbd{KeyStr: "l"})
+	if len(got) != 2 || got[0] != "/row/delete db" || got[1] != "/row/logs web" {
+		t.Errorf("dispatched %q", got)
+	}
+
+	sel = -1
+	if ra.HandleKey(EvtKbd{KeyStr: "d"}) || len(ra.Available()) != 0 {
+		t.Error("no action without a selected row")
+	}
+}
+
+func hintBar(ra *RowActions) string {
+	buf := ra.Buffer()
+	var rs []rune
+	for x := 0; x < ra.Width; x++ {
+		if c := buf.At(x, 0); c.Ch != 0 {
+			rs = append(rs, c.Ch)
+		}
+	}
+	return strings.TrimRight(string(rs), " ")
+}
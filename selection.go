@@ -0,0 +1,36 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// selection keeps the selected row of a Table or List on the same item, as
+// told by its Keys, when its rows are replaced, e.g. by WatchList.Sync.
+type selection struct {
+	row int    // selected row when last seen
+	key string // key of its item, "" for none
+}
+
+// follow returns the row to select among the rows first to n, given the
+// selected one: it is kept if the app moved the selection since the last
+// call, else the row now holding the selected item is returned. The
+// selection stays at the same index when the item is gone, the last row
+// if there are fewer; -1 stays none.
+func (s *selection) follow(row, first, n int, key func(row int) string) int {
+	if row >= 0 && row == s.row && s.key != "" && (row >= n || key(row) != s.key) {
+		for i := first; i < n; i++ {
+			if key(i) == s.key {
+				row = i
+				break
+			}
+		}
+	}
+	if row >= n && n > first {
+		row = n - 1
+	}
+	s.row, s.key = row, ""
+	if row >= first && row < n {
+		s.key = key(row)
+	}
+	return row
+}
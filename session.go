@@ -0,0 +1,151 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Session is the persisted workspace of an app: the view state of its named
+// widgets and whether they are collapsed, the column spans of its named
+// grids and its rebound keys.
+/*
+  s, _ := termui.LoadSession("myapp") // a missing file yields an empty session
+  s.Restore(widgets)
+  s.RestoreGrid("body", termui.Body)
+  termui.Body.Align()
+
+  // before exiting
+  s.Save(widgets)
+  s.SaveGrid("body", termui.Body)
+  s.Write("myapp")
+*/
+type Session struct {
+	Views     ViewStates        `json:"views"`
+	Collapsed map[string]bool   `json:"collapsed,omitempty"`
+	Layouts   map[string][]int  `json:"layouts"`
+	Keys      map[string]string `json:"keys,omitempty"`
+}
+
+// NewSession returns an empty *Session.
+func NewSession() *Session {
+	return &Session{
+		Views:     ViewStates{},
+		Collapsed: make(map[string]bool),
+		Layouts:   make(map[string][]int),
+		Keys:      make(map[string]string),
+	}
+}
+
+// SessionPath returns the file a session for app is stored in.
+func SessionPath(app string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, app, "session.json"), nil
+}
+
+// LoadSession reads the session stored for app. A missing session file is
+// not an error; an empty session is returned instead.
+func LoadSession(app string) (*Session, error) {
+	s := NewSession()
+	p, err := SessionPath(app)
+	if err != nil {
+		return s, err
+	}
+
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	defer f.Close()
+
+	err = json.NewDecoder(f).Decode(s)
+	if s.Views == nil {
+		s.Views = ViewStates{}
+	}
+	if s.Collapsed == nil {
+		s.Collapsed = make(map[string]bool)
+	}
+	if s.Layouts == nil {
+		s.Layouts = make(map[string][]int)
+	}
+	if s.Keys == nil {
+		s.Keys = make(map[string]string)
+	}
+	return s, err
+}
+
+// Write stores s as the session of app, creating its directory if needed.
+func (s *Session) Write(app string) error {
+	p, err := SessionPath(app)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Save records the view state of the named widgets and, for those embedding
+// a Block, whether they are collapsed.
+func (s *Session) Save(ws map[string]Stater) {
+	for name, v := range SaveViewStates(ws) {
+		s.Views[name] = v
+	}
+	for name, w := range ws {
+		if b, ok := w.(interface {
+			GetBlock() *Block
+		}); ok {
+			s.Collapsed[name] = b.GetBlock().Collapsed
+		}
+	}
+}
+
+// Restore applies the recorded view states and collapsed states to the
+// named widgets.
+func (s *Session) Restore(ws map[string]Stater) {
+	s.Views.Restore(ws)
+	for name, w := range ws {
+		c, ok := s.Collapsed[name]
+		if !ok {
+			continue
+		}
+		if b, ok := w.(interface {
+			GetBlock() *Block
+		}); ok {
+			b.GetBlock().Collapsed = c
+		}
+	}
+}
+
+// SaveGrid records the column spans of g under name.
+func (s *Session) SaveGrid(name string, g *Grid) {
+	s.Layouts[name] = g.Spans()
+}
+
+// RestoreGrid applies the column spans recorded under name to g.
+// Call g.Align afterwards to recompute the layout.
+func (s *Session) RestoreGrid(name string, g *Grid) {
+	if spans, ok := s.Layouts[name]; ok {
+		g.SetSpans(spans)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestSessionCollapsed(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	logs, pods := NewList(), NewList()
+	logs.Items = []string{"a", "b", "c"}
+	logs.SelectedRow = 2
+	logs.Collapsed = true
+	s := NewSession()
+	s.Save(map[string]Stater{"logs": logs, "pods": pods})
+	if err := s.Write("termui-test"); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := LoadSession("termui-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs, pods = NewList(), NewList()
+	logs.Items = []string{"a", "b", "c"}
+	pods.Collapsed = true
+	l.Restore(map[string]Stater{"logs": logs, "pods": pods})
+	if !logs.Collapsed || logs.SelectedRow != 2 {
+		t.Errorf("logs collapsed %v, selected %d; want collapsed on row 2", logs.Collapsed, logs.SelectedRow)
+	}
+	if pods.Collapsed {
+		t.Error("pods saved expanded were left collapsed")
+	}
+}
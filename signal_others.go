@@ -0,0 +1,70 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !windows
+
+package termui
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	tm "github.com/nsf/termbox-go"
+)
+
+var suspendLock sync.Mutex
+
+// handleSignals restores the terminal on SIGTSTP and reinitializes and
+// repaints it on SIGCONT, so job control works with termui apps.
+func handleSignals() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGTSTP, syscall.SIGCONT)
+	go func() {
+		for s := range ch {
+			if s == syscall.SIGTSTP {
+				Suspend()
+			} else {
+				resume()
+			}
+		}
+	}()
+}
+
+// Suspend restores the terminal and stops the process like Ctrl-Z does in
+// a shell. The terminal is set up again and repainted when the process is
+// continued. In raw mode Ctrl-Z arrives as a key, so bind it to suspend:
+//
+//	termui.Handle("/sys/kbd/C-z", func(termui.Event) { termui.Suspend() })
+func Suspend() {
+	suspendLock.Lock()
+	renderLock.Lock()
+	tm.Close()
+	renderLock.Unlock()
+	suspendLock.Unlock()
+
+	syscall.Kill(os.Getpid(), syscall.SIGSTOP)
+}
+
+// resume reinitializes the terminal and posts a resize event so the app
+// repaints everything.
+func resume() {
+	suspendLock.Lock()
+	defer suspendLock.Unlock()
+
+	renderLock.Lock()
+	tm.Init()
+	tm.SetInputMode(inputMode)
+	tm.Sync()
+	termWidth, termHeight = tm.Size()
+	renderLock.Unlock()
+
+	postResize(Event{
+		Type: "window",
+		Path: "/sys/wnd/resize",
+		From: "/sys",
+		Data: EvtWnd{Width: termWidth, Height: termHeight},
+	})
+}
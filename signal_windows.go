@@ -0,0 +1,13 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build windows
+
+package termui
+
+// handleSignals is a no-op, Windows has no job control signals.
+func handleSignals() {}
+
+// Suspend is a no-op on Windows.
+func Suspend() {}
@@ -0,0 +1,55 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "time"
+
+// skeletonWidths are the lengths of placeholder rows and bars, in percent
+// of the inner area.
+var skeletonWidths = []int{70, 45, 90, 60, 80, 35, 65}
+
+// shimmer tells if column x is inside the highlight band sweeping across an
+// area w cells wide at time t.
+func shimmer(x, w int, t time.Time) bool {
+	const band = 6
+	pos := int(t.UnixNano()/int64(40*time.Millisecond)) % (w + 2*band)
+	return x >= pos-2*band && x < pos-band
+}
+
+// drawSkeleton draws shimmering placeholders in the inner area of b, as
+// rows for tables and lists or as vertical bars for charts. It is used by
+// widgets with Skeleton set until their first data arrives; like the
+// loading spinner it animates when rendered on a timer.
+func (b *Block) drawSkeleton(buf Buffer, bars bool) {
+	fg := ThemeAttr("skeleton.fg")
+	now := time.Now()
+	cell := func(x, y int) {
+		ch := '░'
+		if shimmer(x-b.innerArea.Min.X, b.innerArea.Dx(), now) {
+			ch = '▒'
+		}
+		buf.Set(x, y, Cell{Ch: ch, Fg: fg, Bg: b.Bg})
+	}
+
+	if bars {
+		i := 0
+		for x := b.innerArea.Min.X + 1; x+1 < b.innerArea.Max.X; x += 3 {
+			h := b.innerArea.Dy() * skeletonWidths[i%len(skeletonWidths)] / 100
+			for y := b.innerArea.Max.Y - h; y < b.innerArea.Max.Y; y++ {
+				cell(x, y)
+				cell(x+1, y)
+			}
+			i++
+		}
+		return
+	}
+
+	for i := 0; 2*i < b.innerArea.Dy(); i++ {
+		w := b.innerArea.Dx() * skeletonWidths[i%len(skeletonWidths)] / 100
+		for x := b.innerArea.Min.X + 1; x < b.innerArea.Min.X+w; x++ {
+			cell(x, b.innerArea.Min.Y+2*i)
+		}
+	}
+}
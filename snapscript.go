@@ -0,0 +1,156 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizak/termui/cell"
+)
+
+// SnapTimeout bounds how long a snap script waits for the app to draw.
+var SnapTimeout = 5 * time.Second
+
+// snapQuiet is how long the screen must not change to be considered drawn.
+const snapQuiet = 50 * time.Millisecond
+
+// RunScript drives the app drawing to h with a script of one command per
+// line, blank lines and lines starting with # being ignored:
+//
+//	key <key>...            inject key presses, e.g. key <down> <down> q
+//	mouse <x> <y> <press>   inject a mouse event, e.g. mouse 3 4 left
+//	resize <w> <h>          resize the screen
+//	wait <duration>         sleep, e.g. wait 200ms
+//	snap <name>             wait for the screen to settle and call snap
+//
+// snap first waits for the events injected before it to be handled and the
+// frames they ask for to be drawn. It is used by cmd/termui-snap through
+// the TERMUI_HEADLESS and TERMUI_SNAP_SCRIPT environment variables, see
+// Init.
+func (h *Headless) RunScript(r io.Reader, snap func(name string, buf Buffer) error) error {
+	h.WaitFrame(1, SnapTimeout)
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		fs := strings.Fields(sc.Text())
+		if len(fs) == 0 || strings.HasPrefix(fs[0], "#") {
+			continue
+		}
+		if err := h.runCommand(fs, snap); err != nil {
+			return fmt.Errorf("line %d: %v", n, err)
+		}
+	}
+	return sc.Err()
+}
+
+func (h *Headless) runCommand(fs []string, snap func(string, Buffer) error) error {
+	args := fs[1:]
+	ints := func(n int) ([]int, error) {
+		if len(args) < n {
+			return nil, fmt.Errorf("%s takes %d arguments", fs[0], n)
+		}
+		is := make([]int, n)
+		for i := range is {
+			v, err := strconv.Atoi(args[i])
+			if err != nil {
+				return nil, err
+			}
+			is[i] = v
+		}
+		return is, nil
+	}
+
+	switch fs[0] {
+	case "key":
+		for _, k := range args {
+			h.Key(k)
+		}
+	case "mouse":
+		is, err := ints(2)
+		if err != nil {
+			return err
+		}
+		press := "left"
+		if len(args) > 2 {
+			press = args[2]
+		}
+		h.Mouse(is[0], is[1], press)
+	case "resize":
+		is, err := ints(2)
+		if err != nil {
+			return err
+		}
+		h.Resize(is[0], is[1])
+	case "wait":
+		if len(args) != 1 {
+			return fmt.Errorf("wait takes a duration")
+		}
+		d, err := time.ParseDuration(args[0])
+		if err != nil {
+			return err
+		}
+		time.Sleep(d)
+	case "snap":
+		if len(args) != 1 {
+			return fmt.Errorf("snap takes a name")
+		}
+		if !h.handled(SnapTimeout) {
+			return fmt.Errorf("events were not handled for snap %s", args[0])
+		}
+		if !h.Settle(snapQuiet, SnapTimeout) {
+			return fmt.Errorf("screen did not settle for snap %s", args[0])
+		}
+		return snap(args[0], h.Screen())
+	default:
+		return fmt.Errorf("unknown command %q", fs[0])
+	}
+	return nil
+}
+
+// SnapErrFile is the file of TERMUI_SNAP_DIR the error of a snap script is
+// written to, for cmd/termui-snap to report.
+const SnapErrFile = "script.err"
+
+// initHeadlessEnv initializes termui on a Headless screen of size, given as
+// WIDTHxHEIGHT. If TERMUI_SNAP_SCRIPT is set, the script is run against the
+// app, snaps are written as text files into TERMUI_SNAP_DIR and the event
+// loop is stopped when the script is done, an error of the script being
+// written to SnapErrFile.
+func initHeadlessEnv(size string) error {
+	var w, ht int
+	if _, err := fmt.Sscanf(size, "%dx%d", &w, &ht); err != nil {
+		return fmt.Errorf("termui: bad TERMUI_HEADLESS size %q", size)
+	}
+	h := NewHeadless(w, ht)
+	InitHeadless(h)
+
+	path := os.Getenv("TERMUI_SNAP_SCRIPT")
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	dir := os.Getenv("TERMUI_SNAP_DIR")
+	go func() {
+		defer f.Close()
+		err := h.RunScript(f, func(name string, buf Buffer) error {
+			return os.WriteFile(filepath.Join(dir, name+".txt"), []byte(cell.Text(buf)+"\n"), 0644)
+		})
+		if err != nil {
+			os.WriteFile(filepath.Join(dir, SnapErrFile), []byte(err.Error()+"\n"), 0644)
+		}
+		StopLoop()
+	}()
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// Snapshot freezes a copy of the current series of lc. The frozen series
+// are drawn under the live ones in SnapshotColor, dashed unless
+// SnapshotDashed is unset, to compare "now" against the time of the
+// snapshot, e.g. before and after a deploy.
+/*
+  termui.Handle("/sys/kbd/s", func(termui.Event) {
+      if lc.HasSnapshot() {
+          lc.ClearSnapshot()
+      } else {
+          lc.Snapshot()
+      }
+      termui.Render(lc)
+  })
+*/
+func (lc *LineChart) Snapshot() {
+	lc.snapshot = make(map[string][]float64, len(lc.Data))
+	for name, data := range lc.Data {
+		lc.snapshot[name] = append([]float64(nil), data...)
+	}
+}
+
+// ClearSnapshot removes the frozen series.
+func (lc *LineChart) ClearSnapshot() {
+	lc.snapshot = nil
+}
+
+// HasSnapshot tells if lc has frozen series.
+func (lc *LineChart) HasSnapshot() bool {
+	return lc.snapshot != nil
+}
+
+func (lc *LineChart) snapshotColor(string) Attribute {
+	return lc.SnapshotColor
+}
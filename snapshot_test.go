@@ -0,0 +1,39 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLineChartSnapshot(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 30
+	lc.Height = 10
+	lc.Data["cpu"] = []float64{5, 5, 5, 5, 5, 5}
+	lc.Snapshot()
+	if !lc.HasSnapshot() {
+		t.Fatal("expected a snapshot")
+	}
+	lc.Data["cpu"] = []float64{1, 1, 1, 1, 1, 9}
+	if lc.snapshot["cpu"][0] != 5 {
+		t.Error("snapshot should not alias live data")
+	}
+
+	buf := lc.Buffer()
+	found := false
+	for _, c := range buf.CellMap {
+		if c.Fg == lc.SnapshotColor && c.Ch != ' ' {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("snapshot series should be drawn in SnapshotColor")
+	}
+
+	lc.ClearSnapshot()
+	if lc.HasSnapshot() {
+		t.Error("ClearSnapshot should drop the snapshot")
+	}
+}
@@ -5,12 +5,15 @@
 package termui
 
 // Sparkline is like: ▅▆▂▂▅▇▂▂▃▆▆▆▅▃. The data points should be non-negative integers.
+// In braille Mode it is like: ⣠⣴⣾⣿⣷⣤⣀, two points per column with four
+// levels per row, doubling the density of the default block Mode.
 /*
   data := []int{4, 2, 1, 6, 3, 9, 1, 4, 2, 15, 14, 9, 8, 6, 10, 13, 15, 12, 10, 5, 3, 6, 1}
   spl := termui.NewSparkline()
   spl.Data = data
   spl.Title = "Sparkline 0"
   spl.LineColor = termui.ColorGreen
+  spl.Mode = "braille"
 */
 type Sparkline struct {
 	Data          []int
@@ -18,6 +21,7 @@ type Sparkline struct {
 	Title         string
 	TitleColor    Attribute
 	LineColor     Attribute
+	Mode          string // block | braille
 	displayHeight int
 	scale         float32
 	max           int
@@ -47,6 +51,7 @@ func (s *Sparklines) Add(sl Sparkline) {
 func NewSparkline() Sparkline {
 	return Sparkline{
 		Height:     1,
+		Mode:       "block",
 		TitleColor: ThemeAttr("sparkline.title.fg"),
 		LineColor:  ThemeAttr("sparkline.line.fg")}
 }
@@ -90,16 +95,77 @@ func (sl *Sparklines) update() {
 		}
 		sl.Lines[i].max = max
 		if max != 0 {
-			sl.Lines[i].scale = float32(8*sl.Lines[i].Height) / float32(max)
+			sl.Lines[i].scale = float32(sl.Lines[i].levels()*sl.Lines[i].Height) / float32(max)
 		} else { // when all negative
 			sl.Lines[i].scale = 0
 		}
 	}
 }
 
+// levels returns the number of heights a row of the sparkline shows.
+func (l Sparkline) levels() int {
+	if l.Mode == "braille" {
+		return 4
+	}
+	return 8
+}
+
+// pointsPerCell returns the number of points drawn per column.
+func (l Sparkline) pointsPerCell() int {
+	if l.Mode == "braille" {
+		return 2
+	}
+	return 1
+}
+
+// renderBraille draws the points of l as braille bars, two per column, the
+// bottom row at y.
+func (sl *Sparklines) renderBraille(buf Buffer, l Sparkline, data []int, y int) {
+	dots := make([][]rune, l.Height)
+	for i := range dots {
+		dots[i] = make([]rune, (len(data)+1)/2)
+	}
+	for j, v := range data {
+		// display height of the data point, zero when data is negative
+		h := int(float32(v)*l.scale + 0.5)
+		if v < 0 {
+			h = 0
+		}
+		for k := 0; k < h && k < 4*l.Height; k++ {
+			dots[k/4][j/2] |= brailleDots[k%4][j%2]
+		}
+	}
+	for k, row := range dots {
+		for j, d := range row {
+			if d == 0 {
+				continue
+			}
+			x := sl.innerArea.Min.X + j
+			buf.Set(x, y-k, Cell{Ch: 0x2800 + d, Fg: l.LineColor, Bg: sl.Bg})
+		}
+	}
+}
+
+// empty tells if none of the lines has data.
+func (sl *Sparklines) empty() bool {
+	for _, l := range sl.Lines {
+		if len(l.Data) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // Buffer implements Bufferer interface.
 func (sl *Sparklines) Buffer() Buffer {
 	buf := sl.Block.Buffer()
+	if sl.drawState(buf) {
+		return buf
+	}
+	if sl.Skeleton && sl.empty() {
+		sl.drawSkeleton(buf, true)
+		return buf
+	}
 	sl.update()
 
 	oftY := 0
@@ -107,8 +173,8 @@ func (sl *Sparklines) Buffer() Buffer {
 		l := sl.Lines[i]
 		data := l.Data
 
-		if len(data) > sl.innerArea.Dx() {
-			data = data[len(data)-sl.innerArea.Dx():]
+		if n := sl.innerArea.Dx() * l.pointsPerCell(); len(data) > n {
+			data = data[len(data)-n:]
 		}
 
 		if l.Title != "" {
@@ -128,6 +194,11 @@ func (sl *Sparklines) Buffer() Buffer {
 			}
 		}
 
+		if l.Mode == "braille" {
+			sl.renderBraille(buf, l, data, sl.innerArea.Min.Y+oftY+l.Height)
+			oftY += l.displayHeight
+			continue
+		}
 		for j, v := range data {
 			// display height of the data point, zero when data is negative
 			h := int(float32(v)*l.scale + 0.5)
@@ -0,0 +1,26 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestSparklineBraille(t *testing.T) {
+	sl := NewSparkline()
+	sl.Mode = "braille"
+	sl.Data = []int{1, 1, 0, 4, 2, 1}
+	s := NewSparklines(sl)
+	s.Border = false
+	s.Width = 2
+	s.Height = 2
+
+	// two points per column, the oldest ones dropped
+	buf := s.Buffer()
+	if c := buf.At(0, 1); c.Ch != '⢸' {
+		t.Errorf("expected the first column of two points, got %q", c.Ch)
+	}
+	if c := buf.At(1, 1); c.Ch != '⣄' {
+		t.Errorf("expected the second column of two points, got %q", c.Ch)
+	}
+}
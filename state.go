@@ -0,0 +1,65 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ViewState is a serializable snapshot of a widget's view state: how far it
+// is scrolled, what is selected and which data window is zoomed in.
+// Widgets only fill the fields that make sense for them: List and Table
+// their scroll and selected row, LineChart how far it is panned and zoomed
+// out and the range its y axis is locked to, from ZoomMin to ZoomMax.
+type ViewState struct {
+	ScrollX  int               `json:"scroll_x,omitempty"`
+	ScrollY  int               `json:"scroll_y,omitempty"`
+	Selected int               `json:"selected,omitempty"`
+	Zoom     int               `json:"zoom,omitempty"`
+	ZoomMin  float64           `json:"zoom_min,omitempty"` // no window unless less than ZoomMax
+	ZoomMax  float64           `json:"zoom_max,omitempty"`
+	Extra    map[string]string `json:"extra,omitempty"`
+}
+
+// Stater is implemented by widgets whose view state can be saved and restored.
+type Stater interface {
+	SaveState() ViewState
+	RestoreState(ViewState)
+}
+
+// ViewStates maps app-chosen widget names to their saved ViewState.
+// Names are used instead of widget ids since ids are not stable across runs.
+type ViewStates map[string]ViewState
+
+// SaveViewStates snapshots the view state of all named widgets.
+func SaveViewStates(ws map[string]Stater) ViewStates {
+	vs := make(ViewStates, len(ws))
+	for name, w := range ws {
+		vs[name] = w.SaveState()
+	}
+	return vs
+}
+
+// Restore applies saved states to the named widgets, skipping unknown names.
+func (vs ViewStates) Restore(ws map[string]Stater) {
+	for name, w := range ws {
+		if s, ok := vs[name]; ok {
+			w.RestoreState(s)
+		}
+	}
+}
+
+// Encode writes vs to w as JSON.
+func (vs ViewStates) Encode(w io.Writer) error {
+	return json.NewEncoder(w).Encode(vs)
+}
+
+// DecodeViewStates reads ViewStates previously written by Encode.
+func DecodeViewStates(r io.Reader) (ViewStates, error) {
+	vs := ViewStates{}
+	err := json.NewDecoder(r).Decode(&vs)
+	return vs, err
+}
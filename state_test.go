@@ -0,0 +1,94 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"testing"
+)
+
+type fakeStater struct {
+	s ViewState
+}
+
+func (f *fakeStater) SaveState() ViewState     { return f.s }
+func (f *fakeStater) RestoreState(s ViewState) { f.s = s }
+
+func TestViewStatesRoundTrip(t *testing.T) {
+	a := &fakeStater{ViewState{ScrollY: 3, Selected: 2, ZoomMin: 1.5, ZoomMax: 9}}
+	vs := SaveViewStates(map[string]Stater{"list": a})
+
+	var b bytes.Buffer
+	if err := vs.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	vs2, err := DecodeViewStates(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &fakeStater{}
+	vs2.Restore(map[string]Stater{"list": c, "unknown": &fakeStater{}})
+	if c.s.ScrollY != 3 || c.s.Selected != 2 || c.s.ZoomMin != 1.5 || c.s.ZoomMax != 9 {
+		t.Errorf("state not restored: %+v", c.s)
+	}
+}
+
+func TestLineChartState(t *testing.T) {
+	lc := NewLineChart()
+	lc.YScale = "log"
+	lc.DataOffset = 12
+	lc.Zoom = 4
+	lc.SetYRange(1, 1000)
+	vs := SaveViewStates(map[string]Stater{"cpu": lc})
+
+	var b bytes.Buffer
+	if err := vs.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	vs, err := DecodeViewStates(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lc2 := NewLineChart()
+	lc2.YScale = "log"
+	vs.Restore(map[string]Stater{"cpu": lc2})
+	if lc2.DataOffset != 12 || lc2.Zoom != 4 || !lc2.YLocked() || lc2.yLock != lc.yLock {
+		t.Errorf("state not restored: offset %d, zoom %d, y range %+v", lc2.DataOffset, lc2.Zoom, lc2.yLock)
+	}
+
+	lc2.RestoreState(ViewState{})
+	if lc2.DataOffset != 0 || lc2.Zoom != 0 || lc2.YLocked() {
+		t.Error("an empty state should show the newest points on an auto y axis")
+	}
+}
+
+func TestTableState(t *testing.T) {
+	table := NewTable()
+	table.SelectedRow = 7
+	table.offset = 5
+	s := table.SaveState()
+
+	table2 := NewTable()
+	table2.RestoreState(s)
+	if table2.SelectedRow != 7 || table2.offset != 5 {
+		t.Errorf("state not restored: row %d, offset %d", table2.SelectedRow, table2.offset)
+	}
+}
+
+func TestListState(t *testing.T) {
+	l := NewList()
+	l.SelectedRow = -1
+	l.offset = 3
+	s := l.SaveState()
+
+	l2 := NewList()
+	l2.SelectedRow = 0
+	l2.RestoreState(s)
+	if l2.SelectedRow != -1 || l2.offset != 3 {
+		t.Errorf("state not restored: row %d, offset %d", l2.SelectedRow, l2.offset)
+	}
+}
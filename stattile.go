@@ -0,0 +1,158 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"math"
+)
+
+// StatTile is a monitoring tile showing a current value, its delta against
+// the previous period and a trend sparkline underneath.
+/*
+  st := termui.NewStatTile()
+  st.BorderLabel = "req/s"
+  st.Value = 1294
+  st.Previous = 1120
+  st.Trend = []int{900, 1020, 1100, 1120, 1294}
+  st.Width = 20
+  st.Height = 6
+*/
+type StatTile struct {
+	Block
+	Value       float64
+	Previous    float64
+	Unit        string
+	Trend       []int
+	FormatValue func(float64) string // defaults to FormatFloat
+	ValueColor  Attribute
+	TextColor   Attribute
+	UpColor     Attribute
+	DownColor   Attribute
+	TrendColor  Attribute
+	// InvertDelta colors a decrease as good, e.g. for latency or error tiles.
+	InvertDelta bool
+	// Flash highlights the value when it changed since the previous frame.
+	Flash *Flasher
+}
+
+// NewStatTile returns a new *StatTile with current theme.
+func NewStatTile() *StatTile {
+	st := &StatTile{Block: *NewBlock()}
+	st.FormatValue = FormatFloat
+	st.ValueColor = ThemeAttr("stattile.value.fg") | AttrBold
+	st.TextColor = ThemeAttr("stattile.text.fg")
+	st.UpColor = ColorGreen
+	st.DownColor = ColorRed
+	st.TrendColor = ThemeAttr("stattile.trend.fg")
+	st.Width = 20
+	st.Height = 6
+	return st
+}
+
+// Push moves the current value into Previous, sets v as the new value and
+// appends it to the trend.
+func (st *StatTile) Push(v float64) {
+	st.Previous = st.Value
+	st.Value = v
+	st.Trend = append(st.Trend, int(v+0.5))
+}
+
+// delta returns the text and color for the change since Previous.
+func (st *StatTile) delta() (string, Attribute) {
+	d := st.Value - st.Previous
+	switch {
+	case d == 0:
+		return "= 0", st.TextColor
+	case (d > 0) != st.InvertDelta:
+		return st.deltaStr(d), st.UpColor
+	default:
+		return st.deltaStr(d), st.DownColor
+	}
+}
+
+func (st *StatTile) deltaStr(d float64) string {
+	arrow := "▲ +"
+	if d < 0 {
+		arrow = "▼ -"
+	}
+	s := arrow + st.format(math.Abs(d))
+	if st.Previous != 0 {
+		s += fmt.Sprintf(" (%s%%)", FormatFloat(math.Abs(d/st.Previous*100)))
+	}
+	return s
+}
+
+func (st *StatTile) format(v float64) string {
+	if st.FormatValue == nil {
+		return FormatFloat(v)
+	}
+	return st.FormatValue(v)
+}
+
+func (st *StatTile) setLine(buf Buffer, y int, s string, fg, bg Attribute) {
+	cs := TruncateRight(TextCells(s, fg, bg), st.innerArea.Dx())
+	x := st.innerArea.Min.X + (st.innerArea.Dx()-cellsWidth(cs))/2
+	for _, c := range cs {
+		buf.Set(x, y, c)
+		x += c.Width()
+	}
+}
+
+// Buffer implements Bufferer interface.
+func (st *StatTile) Buffer() Buffer {
+	buf := st.Block.Buffer()
+	if st.drawState(buf) {
+		return buf
+	}
+	if st.innerArea.Dy() <= 0 || st.innerArea.Dx() <= 0 {
+		return buf
+	}
+
+	y := st.innerArea.Min.Y
+	v := st.format(st.Value) + st.Unit
+	st.setLine(buf, y, v, st.ValueColor, flashBg(st.Flash, "value", v, st.Bg))
+
+	if st.innerArea.Dy() > 1 {
+		s, fg := st.delta()
+		st.setLine(buf, y+1, s, fg, st.Bg)
+	}
+
+	rows := st.innerArea.Dy() - 2
+	if rows <= 0 || len(st.Trend) == 0 {
+		return buf
+	}
+
+	data := st.Trend
+	if len(data) > st.innerArea.Dx() {
+		data = data[len(data)-st.innerArea.Dx():]
+	}
+	max := 0
+	for _, v := range data {
+		if v > max {
+			max = v
+		}
+	}
+	if max == 0 {
+		return buf
+	}
+
+	bottom := st.innerArea.Max.Y - 1
+	for i, v := range data {
+		if v < 0 {
+			continue
+		}
+		h := v * 8 * rows / max
+		x := st.innerArea.Min.X + i
+		for j := 0; j < h/8; j++ {
+			buf.Set(x, bottom-j, Cell{Ch: ' ', Bg: st.TrendColor})
+		}
+		if h%8 != 0 {
+			buf.Set(x, bottom-h/8, Cell{Ch: sparks[h%8-1], Fg: st.TrendColor, Bg: st.Bg})
+		}
+	}
+
+	return buf
+}
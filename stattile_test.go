@@ -0,0 +1,91 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func testStatTile() *StatTile {
+	st := NewStatTile()
+	st.Border = false
+	st.Width = 12
+	st.Height = 4
+	st.Unit = "ms"
+	return st
+}
+
+func TestStatTile(t *testing.T) {
+	st := testStatTile()
+	st.Value, st.Previous = 12, 10
+	st.Trend = []int{0, 6, 8}
+
+	buf := st.Buffer()
+	lines := treeLines(buf, 12, 4)
+	if lines[0] != "    12ms" {
+		t.Errorf("expected the value centered, got %q", lines[0])
+	}
+	if lines[1] != " ▲ +2 (20%)" {
+		t.Errorf("expected the delta centered, got %q", lines[1])
+	}
+	if c := buf.At(1, 1); c.Fg != st.UpColor {
+		t.Errorf("expected an increase in UpColor, got %v", c.Fg)
+	}
+
+	// 2 rows of trend scaled to the largest value, 8 eighths a row
+	if c := buf.At(0, 3); c.Bg == st.TrendColor {
+		t.Error("expected no bar for 0")
+	}
+	if c := buf.At(1, 3); c.Bg != st.TrendColor {
+		t.Errorf("expected a full cell under 6, got %+v", c)
+	}
+	if c := buf.At(1, 2); c.Ch != '▄' || c.Fg != st.TrendColor {
+		t.Errorf("expected half a cell on top of 6, got %+v", c)
+	}
+	if c := buf.At(2, 2); c.Bg != st.TrendColor {
+		t.Errorf("expected 8 to fill both rows, got %+v", c)
+	}
+}
+
+func TestStatTileDelta(t *testing.T) {
+	cases := []struct {
+		value, previous float64
+		invert          bool
+		text            string
+		up              bool // colored UpColor rather than DownColor
+	}{
+		{8, 10, false, " ▼ -2 (20%)", false},
+		{8, 10, true, " ▼ -2 (20%)", true},
+		{12, 10, true, " ▲ +2 (20%)", false},
+		{3, 0, false, "    ▲ +3", true},
+	}
+	for _, c := range cases {
+		st := testStatTile()
+		st.Value, st.Previous, st.InvertDelta = c.value, c.previous, c.invert
+		buf := st.Buffer()
+		if l := treeLines(buf, 12, 4)[1]; l != c.text {
+			t.Errorf("%v after %v: expected %q, got %q", c.value, c.previous, c.text, l)
+		}
+		fg := st.DownColor
+		if c.up {
+			fg = st.UpColor
+		}
+		arrow := len(c.text) - len(strings.TrimLeft(c.text, " "))
+		if got := buf.At(arrow, 1).Fg; got != fg {
+			t.Errorf("%v after %v, invert %v: expected fg %v, got %v", c.value, c.previous, c.invert, fg, got)
+		}
+	}
+
+	st := testStatTile()
+	st.Value, st.Previous = 5, 5
+	buf := st.Buffer()
+	if l := treeLines(buf, 12, 4)[1]; l != "    = 0" {
+		t.Errorf("expected no change, got %q", l)
+	}
+	if c := buf.At(4, 1); c.Fg != st.TextColor {
+		t.Errorf("expected no change in TextColor, got %v", c.Fg)
+	}
+}
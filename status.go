@@ -0,0 +1,116 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-wordwrap"
+)
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// spinner returns the spinner frame for t; it advances every 100ms, so a
+// loading widget animates when it is rendered on a timer.
+func spinner(t time.Time) rune {
+	return spinnerFrames[t.UnixNano()/int64(100*time.Millisecond)%int64(len(spinnerFrames))]
+}
+
+// SetLoading switches the loading state of the widget. While loading, data
+// driven widgets draw a spinner instead of their data.
+/*
+  lc.SetLoading(true)
+  go func() {
+      data, err := fetch()
+      lc.SetError(err)
+      lc.Data["cpu"] = data
+      lc.SetLoading(false)
+      termui.Render(lc)
+  }()
+*/
+func (b *Block) SetLoading(on bool) {
+	b.loading = on
+}
+
+// Loading tells if the widget is loading.
+func (b *Block) Loading() bool {
+	return b.loading
+}
+
+// SetError sets the error the widget shows instead of its data, with the
+// "retry" message as a hint underneath if it has a retry handler, see
+// OnRetry. A nil err clears it.
+func (b *Block) SetError(err error) {
+	b.err = err
+}
+
+// Err returns the error set with SetError.
+func (b *Block) Err() error {
+	return b.err
+}
+
+// OnRetry sets f to be called by Retry, typically fetching the data again.
+// The "retry" hint tells about the r key, which the app binds to Retry:
+/*
+  lc.OnRetry(load)
+  termui.Handle("/sys/kbd/r", func(termui.Event) {
+      lc.Retry()
+  })
+*/
+func (b *Block) OnRetry(f func()) {
+	b.retry = f
+}
+
+// Retry calls the retry handler if the widget shows an error, and tells if
+// it did.
+func (b *Block) Retry() bool {
+	if b.err == nil || b.retry == nil {
+		return false
+	}
+	b.retry()
+	return true
+}
+
+// drawState draws the error or loading state of b centered in its inner
+// area and tells if it did, in which case the data must not be drawn.
+func (b *Block) drawState(buf Buffer) bool {
+	var lines []string
+	var fg Attribute
+	switch {
+	case b.err != nil:
+		w := b.innerArea.Dx()
+		if w < 1 {
+			w = 1
+		}
+		lines = strings.Split(wordwrap.WrapString(Msg("error")+": "+b.err.Error(), uint(w)), "\n")
+		if hint := Msg("retry"); hint != "" && b.retry != nil {
+			lines = append(lines, hint)
+		}
+		fg = ThemeAttr("state.error.fg")
+	case b.loading:
+		lines = []string{string(spinner(time.Now())) + " " + Msg("loading")}
+		fg = ThemeAttr("state.loading.fg")
+	default:
+		return false
+	}
+
+	y := b.innerArea.Min.Y + (b.innerArea.Dy()-len(lines))/2
+	if y < b.innerArea.Min.Y {
+		y = b.innerArea.Min.Y
+	}
+	for i, l := range lines {
+		if y+i >= b.innerArea.Max.Y {
+			break
+		}
+		cs := TruncateRight(TextCells(l, fg, b.Bg), b.innerArea.Dx())
+		x := b.innerArea.Min.X + (b.innerArea.Dx()-cellsWidth(cs))/2
+		for _, c := range cs {
+			buf.Set(x, y+i, c)
+			x += c.Width()
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWidgetStates(t *testing.T) {
+	l := NewList()
+	l.Items = []string{"item"}
+	l.Width = 30
+	l.Height = 5
+
+	l.SetLoading(true)
+	if s := ExportText(l); strings.Contains(s, "item") || !strings.Contains(s, Msg("loading")) {
+		t.Errorf("expected a spinner instead of items, got\n%s", s)
+	}
+
+	l.SetError(errors.New("timeout"))
+	if s := ExportText(l); !strings.Contains(s, "timeout") || strings.Contains(s, Msg("retry")) {
+		t.Errorf("expected the error without a retry hint, got\n%s", s)
+	}
+	retried := false
+	l.OnRetry(func() { retried = true })
+	if s := ExportText(l); !strings.Contains(s, "timeout") || !strings.Contains(s, Msg("retry")) {
+		t.Errorf("expected the error and retry hint, got\n%s", s)
+	}
+	if !l.Retry() || !retried {
+		t.Error("Retry should call the retry handler")
+	}
+
+	l.SetError(nil)
+	l.SetLoading(false)
+	if s := ExportText(l); !strings.Contains(s, "item") {
+		t.Errorf("expected items, got\n%s", s)
+	}
+}
+
+func TestSkeleton(t *testing.T) {
+	table := NewTable()
+	table.Skeleton = true
+	table.Width = 20
+	table.Height = 6
+	if s := ExportText(table); !strings.ContainsAny(s, "░▒") {
+		t.Errorf("expected placeholders, got\n%s", s)
+	}
+
+	table.Rows = [][]string{{"a"}}
+	if s := ExportText(table); strings.ContainsAny(s, "░▒") {
+		t.Errorf("expected no placeholders once data arrived, got\n%s", s)
+	}
+}
@@ -4,7 +4,10 @@
 
 package termui
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 /* Table is like:
 
@@ -34,6 +37,14 @@ Example:
 	table.Y = 0
 	table.X = 0
 	table.Border = true
+
+	// scrolled with the keys, sorted by Col2, highest first
+	table.SelectedRow = 1
+	table.SortBy(2, true)
+	termui.Handle("/sys/kbd", func(e termui.Event) {
+		table.HandleKey(e.Data.(termui.EvtKbd))
+		termui.Render(table)
+	})
 */
 
 // Table tracks all the attributes of a Table instance
@@ -47,6 +58,53 @@ type Table struct {
 	BgColors  []Attribute
 	Separator bool
 	TextAlign Align
+	// MaxCellWidth truncates the cells wider than it, 0 never does.
+	MaxCellWidth int
+	// LocalizeNumbers writes the cells that are plain numbers, e.g. 1234.5,
+	// with the separators of the current Locale, 1.234,5 in German.
+	LocalizeNumbers bool
+	// Flash highlights cells whose text changed since the previous frame.
+	Flash *Flasher
+	// Keys identify the rows, so that Flash follows them when they move;
+	// rows without a key are identified by their index.
+	Keys []string
+	// Footer aggregates the body rows into a footer pinned below them,
+	// one Aggregator per column, e.g. AggSum.
+	Footer        []Aggregator
+	FooterFgColor Attribute
+	FooterBgColor Attribute
+	// Filters maps columns to filter expressions, see SetFilter.
+	Filters         map[int]string
+	FilterColumn    int
+	FilterKey       string
+	ClearFiltersKey string
+	FilterFgColor   Attribute
+	filterInput     *TextInput
+	// History is the edit history of SetCell, nil keeps none.
+	History *UndoStack
+	// BorderTheme replaces the frame, dividers and separators with a
+	// bundled or custom set of characters, e.g. TableBorderDouble.
+	BorderTheme *TableBorder
+	// ColumnAlign aligns the text of single columns, TextAlign the others.
+	ColumnAlign []Align
+	// ColumnWidth fixes the width of single columns, truncating their
+	// text; 0 fits a column to its text.
+	ColumnWidth []int
+	// SortColumn is the column the body rows are shown sorted by, -1 for
+	// none, see SortBy. SortKey sorts by the next column, ReverseSortKey
+	// reverses the order.
+	SortColumn     int
+	SortDesc       bool
+	SortKey        string
+	ReverseSortKey string
+	// SelectedRow is the index in Rows of the row highlighted, -1 for
+	// none. The body rows scroll to keep it shown, below the header. With
+	// Keys, it follows its row when Rows are replaced.
+	SelectedRow     int
+	SelectedFgColor Attribute
+	SelectedBgColor Attribute
+	offset          int // body rows scrolled past
+	sel             selection
 }
 
 // NewTable returns a new Table instance
@@ -55,6 +113,18 @@ func NewTable() *Table {
 	table.FgColor = ColorWhite
 	table.BgColor = ColorDefault
 	table.Separator = true
+	table.FooterFgColor = ColorWhite | AttrBold
+	table.FooterBgColor = ColorDefault
+	table.FilterKey = "/"
+	table.ClearFiltersKey = "C-l"
+	table.FilterFgColor = ColorYellow | AttrBold
+	table.History = NewUndoStack()
+	table.SortColumn = -1
+	table.SortKey = "s"
+	table.ReverseSortKey = "S"
+	table.SelectedRow = -1
+	table.SelectedFgColor = ColorBlack
+	table.SelectedBgColor = ColorWhite
 	return table
 }
 
@@ -67,6 +137,48 @@ func cellsWidth(cells []Cell) int {
 	return width
 }
 
+// buildCell returns the cells of the text s, truncated to MaxCellWidth.
+func (table *Table) buildCell(s string, fg, bg Attribute) []Cell {
+	if table.LocalizeNumbers {
+		s = CurrentLocale().localizeNumber(s)
+	}
+	cs := DefaultTxBuilder.Build(s, fg, bg)
+	if table.MaxCellWidth > 0 {
+		cs = TruncateRight(cs, table.MaxCellWidth)
+	}
+	return cs
+}
+
+// rowCell returns the cells of the text of column x in row y: the header
+// of the SortColumn ends with the order, and columns of a fixed
+// ColumnWidth are truncated to it.
+func (table *Table) rowCell(y, x int, fg, bg Attribute) []Cell {
+	cs := table.buildCell(table.Rows[y][x], fg, bg)
+	if y == 0 && x == table.SortColumn {
+		cs = append(cs, TextCells(table.sortMark(), fg, bg)...)
+	}
+	if w := table.columnWidth(x); w > 0 {
+		cs = TruncateRight(cs, w)
+	}
+	return cs
+}
+
+// columnWidth returns the ColumnWidth of column x, 0 if it has none.
+func (table *Table) columnWidth(x int) int {
+	if x < len(table.ColumnWidth) && table.ColumnWidth[x] > 0 {
+		return table.ColumnWidth[x]
+	}
+	return 0
+}
+
+// columnAlign returns the alignment of column x.
+func (table *Table) columnAlign(x int) Align {
+	if x < len(table.ColumnAlign) {
+		return table.ColumnAlign[x]
+	}
+	return table.TextAlign
+}
+
 // Analysis generates and returns an array of []Cell that represent all columns in the Table
 func (table *Table) Analysis() [][]Cell {
 	var rowCells [][]Cell
@@ -91,8 +203,8 @@ func (table *Table) Analysis() [][]Cell {
 		if table.BgColors[y] == 0 {
 			table.BgColors[y] = table.BgColor
 		}
-		for x, str := range row {
-			cells := DefaultTxBuilder.Build(str, table.FgColors[y], table.BgColors[y])
+		for x := range row {
+			cells := table.rowCell(y, x, table.FgColors[y], table.BgColors[y])
 			cw := cellsWidth(cells)
 			if cellWidths[x] < cw {
 				cellWidths[x] = cw
@@ -100,6 +212,17 @@ func (table *Table) Analysis() [][]Cell {
 			rowCells = append(rowCells, cells)
 		}
 	}
+	for x, str := range table.FooterRow() {
+		cw := cellsWidth(table.buildCell(str, table.FooterFgColor, table.FooterBgColor))
+		if x < len(cellWidths) && cellWidths[x] < cw {
+			cellWidths[x] = cw
+		}
+	}
+	for x := range cellWidths {
+		if w := table.columnWidth(x); w > 0 {
+			cellWidths[x] = w
+		}
+	}
 	table.CellWidth = cellWidths
 	return rowCells
 }
@@ -107,11 +230,14 @@ func (table *Table) Analysis() [][]Cell {
 // SetSize calculates the table size and sets the internal value
 func (table *Table) SetSize() {
 	length := len(table.Rows)
-	if table.Separator {
+	if table.BorderTheme != nil && length > 0 {
+		table.Height = table.rowY(length-1) + 3
+	} else if table.Separator {
 		table.Height = length*2 + 1
 	} else {
 		table.Height = length + 2
 	}
+	table.Height += table.footerRows()
 	table.Width = 2
 	if length != 0 {
 		for _, cellWidth := range table.CellWidth {
@@ -122,39 +248,76 @@ func (table *Table) SetSize() {
 
 // CalculatePosition ...
 func (table *Table) CalculatePosition(x int, y int, coordinateX *int, coordinateY *int, cellStart *int) {
-	if table.Separator {
-		*coordinateY = table.innerArea.Min.Y + y*2
-	} else {
-		*coordinateY = table.innerArea.Min.Y + y
-	}
+	table.calculatePosition(x, y, y, coordinateX, coordinateY, cellStart)
+}
+
+// calculatePosition places cell x of row y shown as the pos-th row.
+func (table *Table) calculatePosition(x, y, pos int, coordinateX *int, coordinateY *int, cellStart *int) {
+	*coordinateY = table.innerArea.Min.Y + table.rowY(pos)
 	if x == 0 {
 		*cellStart = table.innerArea.Min.X
 	} else {
 		*cellStart += table.CellWidth[x-1] + 3
 	}
 
-	switch table.TextAlign {
+	switch table.columnAlign(x) {
 	case AlignRight:
-		*coordinateX = *cellStart + (table.CellWidth[x] - len(table.Rows[y][x])) + 2
+		*coordinateX = *cellStart + (table.CellWidth[x] - cellsWidth(table.rowCell(y, x, 0, 0))) + 2
 	case AlignCenter:
-		*coordinateX = *cellStart + (table.CellWidth[x]-len(table.Rows[y][x]))/2 + 2
+		*coordinateX = *cellStart + (table.CellWidth[x]-cellsWidth(table.rowCell(y, x, 0, 0)))/2 + 2
 	default:
 		*coordinateX = *cellStart + 2
 	}
 }
 
+// rowKey returns the key identifying row y.
+// key returns the key of row y in Keys, "" if it has none.
+func (table *Table) key(y int) string {
+	if y < len(table.Keys) {
+		return table.Keys[y]
+	}
+	return ""
+}
+
+func (table *Table) rowKey(y int) string {
+	if y < len(table.Keys) && table.Keys[y] != "" {
+		return table.Keys[y]
+	}
+	return strconv.Itoa(y)
+}
+
 // Buffer ...
 func (table *Table) Buffer() Buffer {
 	buffer := table.Block.Buffer()
+	if table.drawState(buffer) {
+		return buffer
+	}
+	if table.Skeleton && len(table.Rows) == 0 {
+		table.drawSkeleton(buffer, false)
+		return buffer
+	}
 	rowCells := table.Analysis()
 	pointerX := table.innerArea.Min.X + 2
 	pointerY := table.innerArea.Min.Y
 	borderPointerX := table.innerArea.Min.X
-	for y, row := range table.Rows {
+	footer := len(table.Footer) > 0
+	for pos, y := range table.shown() {
+		row := table.Rows[y]
+		if footer && table.innerArea.Min.Y+table.rowY(pos) >= table.bodyEnd() {
+			break
+		}
+		fg, rowBg := table.FgColors[y], table.BgColors[y]
+		if y > 0 && y == table.SelectedRow {
+			fg, rowBg = table.SelectedFgColor, table.SelectedBgColor
+		}
 		for x := range row {
-			table.CalculatePosition(x, y, &pointerX, &pointerY, &borderPointerX)
-			background := DefaultTxBuilder.Build(strings.Repeat(" ", table.CellWidth[x]+3), table.BgColors[y], table.BgColors[y])
+			table.calculatePosition(x, y, pos, &pointerX, &pointerY, &borderPointerX)
+			bg := flashBg(table.Flash, table.rowKey(y)+","+strconv.Itoa(x), row[x], rowBg)
+			background := DefaultTxBuilder.Build(strings.Repeat(" ", table.CellWidth[x]+3), bg, bg)
 			cells := rowCells[y*len(row)+x]
+			if fg != table.FgColors[y] || bg != table.BgColors[y] {
+				cells = table.rowCell(y, x, fg, bg)
+			}
 			for i, back := range background {
 				buffer.Set(borderPointerX+i, pointerY, back)
 			}
@@ -166,14 +329,18 @@ func (table *Table) Buffer() Buffer {
 			}
 
 			if x != 0 {
-				dividors := DefaultTxBuilder.Build("|", table.FgColors[y], table.BgColors[y])
+				divider := "|"
+				if table.BorderTheme != nil {
+					divider = string(table.BorderTheme.V)
+				}
+				dividors := DefaultTxBuilder.Build(divider, fg, rowBg)
 				for _, dividor := range dividors {
 					buffer.Set(borderPointerX, pointerY, dividor)
 				}
 			}
 		}
 
-		if table.Separator {
+		if table.Separator && table.BorderTheme == nil && (!footer || pointerY+1 < table.bodyEnd()) {
 			border := DefaultTxBuilder.Build(strings.Repeat("─", table.Width-2), table.FgColor, table.BgColor)
 			for i, cell := range border {
 				buffer.Set(i+1, pointerY+1, cell)
@@ -181,5 +348,12 @@ func (table *Table) Buffer() Buffer {
 		}
 	}
 
+	if table.BorderTheme != nil {
+		table.drawBorderTheme(buffer)
+	}
+	if footer {
+		table.drawFooter(buffer, table.FooterRow())
+	}
+	table.drawFilters(buffer)
 	return buffer
 }
@@ -0,0 +1,181 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "strings"
+
+// TableBorder is a set of characters a Table draws its frame, column
+// dividers and row separators with.
+type TableBorder struct {
+	H, V           rune // row separator and column divider
+	HOuter, VOuter rune // top/bottom and left/right frame lines
+	Cross          rune // separator meets divider
+	Left, Right    rune // separator meets the left/right frame
+	Top, Bottom    rune // divider meets the top/bottom frame
+	TopLeft        rune
+	TopRight       rune
+	BottomLeft     rune
+	BottomRight    rune
+	HeaderOnly     bool // only the header row is separated from the others
+}
+
+// Bundled table border themes.
+var (
+	TableBorderNone = &TableBorder{
+		' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', false}
+	TableBorderASCII = &TableBorder{
+		'-', '|', '-', '|', '+', '+', '+', '+', '+', '+', '+', '+', '+', false}
+	TableBorderLight = &TableBorder{
+		'─', '│', '─', '│', '┼', '├', '┤', '┬', '┴', '┌', '┐', '└', '┘', false}
+	TableBorderHeavy = &TableBorder{
+		'━', '┃', '━', '┃', '╋', '┣', '┫', '┳', '┻', '┏', '┓', '┗', '┛', false}
+	TableBorderDouble = &TableBorder{
+		'═', '║', '═', '║', '╬', '╠', '╣', '╦', '╩', '╔', '╗', '╚', '╝', false}
+	TableBorderMarkdown = &TableBorder{
+		'-', '|', ' ', '|', '|', '|', '|', ' ', ' ', ' ', ' ', ' ', ' ', true}
+)
+
+// rowY returns the offset of row y from the top of the inner area.
+func (table *Table) rowY(y int) int {
+	switch {
+	case !table.Separator:
+		return y
+	case table.BorderTheme != nil && table.BorderTheme.HeaderOnly && y > 0:
+		return y + 1
+	case table.BorderTheme != nil && table.BorderTheme.HeaderOnly:
+		return y
+	default:
+		return y * 2
+	}
+}
+
+// dividerXs returns the columns of the dividers between cells.
+func (table *Table) dividerXs() []int {
+	var xs []int
+	x := table.innerArea.Min.X
+	for i := 0; i+1 < len(table.CellWidth); i++ {
+		x += table.CellWidth[i] + 3
+		xs = append(xs, x)
+	}
+	return xs
+}
+
+// drawBorderTheme draws the row separators and the frame of table with its
+// BorderTheme, joining them with the theme's junction characters.
+func (table *Table) drawBorderTheme(buf Buffer) {
+	t := table.BorderTheme
+	fg, bg := table.BorderFg, table.BorderBg
+	x0, x1 := table.area.Min.X, table.area.Max.X-1
+	y0, y1 := table.area.Min.Y, table.area.Max.Y-1
+	xs := table.dividerXs()
+
+	if table.Separator {
+		n := len(table.shown())
+		for y := 0; y < n; y++ {
+			if y+1 == n || t.HeaderOnly && y > 0 {
+				break
+			}
+			sy := table.innerArea.Min.Y + table.rowY(y) + 1
+			if sy >= table.bodyEnd() {
+				break
+			}
+			for x := table.innerArea.Min.X; x < table.innerArea.Max.X; x++ {
+				buf.Set(x, sy, Cell{Ch: t.H, Fg: table.FgColor, Bg: table.BgColor})
+			}
+			for _, x := range xs {
+				buf.Set(x, sy, Cell{Ch: t.Cross, Fg: table.FgColor, Bg: table.BgColor})
+			}
+			if table.Border {
+				buf.Set(x0, sy, Cell{Ch: t.Left, Fg: fg, Bg: bg})
+				buf.Set(x1, sy, Cell{Ch: t.Right, Fg: fg, Bg: bg})
+			}
+		}
+	}
+
+	if !table.Border || x1 <= x0 || y1 <= y0 {
+		return
+	}
+	for x := x0; x <= x1; x++ {
+		buf.Set(x, y0, Cell{Ch: t.HOuter, Fg: fg, Bg: bg})
+		buf.Set(x, y1, Cell{Ch: t.HOuter, Fg: fg, Bg: bg})
+	}
+	for y := y0; y <= y1; y++ {
+		if c := buf.At(x0, y).Ch; c != t.Left {
+			buf.Set(x0, y, Cell{Ch: t.VOuter, Fg: fg, Bg: bg})
+		}
+		if c := buf.At(x1, y).Ch; c != t.Right {
+			buf.Set(x1, y, Cell{Ch: t.VOuter, Fg: fg, Bg: bg})
+		}
+	}
+	for _, x := range xs {
+		if x < x1 {
+			buf.Set(x, y0, Cell{Ch: t.Top, Fg: fg, Bg: bg})
+			buf.Set(x, y1, Cell{Ch: t.Bottom, Fg: fg, Bg: bg})
+		}
+	}
+	buf.Set(x0, y0, Cell{Ch: t.TopLeft, Fg: fg, Bg: bg})
+	buf.Set(x1, y0, Cell{Ch: t.TopRight, Fg: fg, Bg: bg})
+	buf.Set(x0, y1, Cell{Ch: t.BottomLeft, Fg: fg, Bg: bg})
+	buf.Set(x1, y1, Cell{Ch: t.BottomRight, Fg: fg, Bg: bg})
+	table.Block.drawBorderLabel(buf)
+}
+
+// Markdown returns the rows table currently shows as a GitHub flavored markdown table,
+// the first row being the header. Color markup is stripped and pipes are
+// escaped.
+func (table *Table) Markdown() string {
+	rows := table.viewRows()
+	if len(rows) == 0 {
+		return ""
+	}
+
+	n := 0
+	for _, row := range rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	text := make([][]string, len(rows))
+	ws := make([]int, n)
+	for y, row := range rows {
+		text[y] = make([]string, n)
+		for x, s := range row {
+			s = strings.Replace(plainText(s), "|", `\|`, -1)
+			text[y][x] = s
+			if w := strWidth(s); w > ws[x] {
+				ws[x] = w
+			}
+		}
+	}
+
+	var sb strings.Builder
+	line := func(cells []string) {
+		for x, s := range cells {
+			sb.WriteString("| " + s + strings.Repeat(" ", ws[x]-strWidth(s)) + " ")
+		}
+		sb.WriteString("|\n")
+	}
+
+	line(text[0])
+	rule := make([]string, n)
+	for x := range rule {
+		if ws[x] < 3 {
+			ws[x] = 3
+		}
+		switch table.columnAlign(x) {
+		case AlignRight:
+			rule[x] = strings.Repeat("-", ws[x]-1) + ":"
+		case AlignCenter:
+			rule[x] = ":" + strings.Repeat("-", ws[x]-2) + ":"
+		default:
+			rule[x] = strings.Repeat("-", ws[x])
+		}
+	}
+	line(rule)
+	for _, row := range text[1:] {
+		line(row)
+	}
+	return sb.String()
+}
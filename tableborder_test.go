@@ -0,0 +1,143 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTableBorderTheme(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"a", "b"}, {"1", "2"}}
+	table.BorderTheme = TableBorderLight
+	table.Analysis()
+	table.SetSize()
+
+	buf := table.Buffer()
+	want := []string{
+		"┌────┬───┐",
+		"│  a │ b │",
+		"├────┼───┤",
+		"│  1 │ 2 │",
+		"└────┴───┘",
+	}
+	for y, line := range want {
+		x := 0
+		for _, c := range line {
+			if got := buf.At(x, y).Ch; got != c {
+				t.Errorf("(%d, %d): expected %q, got %q", x, y, c, got)
+			}
+			x++
+		}
+	}
+}
+
+func TestTableMarkdown(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"name", "cmd"}, {"ls", "a|b"}}
+	want := "| name | cmd  |\n| ---- | ---- |\n| ls   | a\\|b |\n"
+	if s := table.Markdown(); s != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, s)
+	}
+}
+
+func TestTableFooter(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"host", "cpu"}, {"a", "1.5"}, {"b", "2"}, {"c", "n/a"}}
+	table.Footer = []Aggregator{AggLabel("Total"), AggSum}
+	table.BorderTheme = TableBorderLight
+	table.Analysis()
+	table.SetSize()
+	table.Height = 7 // room for two body rows only
+
+	buf := table.Buffer()
+	want := []string{
+		"┌────────┬─────┐",
+		"│  host  │ cpu │",
+		"├────────┼─────┤",
+		"│  a     │ 1.5 │",
+		"├────────┼─────┤",
+		"│  Total │ 3.5 │",
+		"└────────┴─────┘",
+	}
+	for y, line := range want {
+		x := 0
+		for _, c := range line {
+			if got := buf.At(x, y).Ch; got != c {
+				t.Errorf("(%d, %d): expected %q, got %q", x, y, c, got)
+			}
+			x++
+		}
+	}
+}
+
+func TestTableExport(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"name", "state"}, {"[web](fg-green)", "up, ok"}}
+
+	var out bytes.Buffer
+	table.Export(&out, ExportCSV)
+	if s := out.String(); s != "name,state\nweb,\"up, ok\"\n" {
+		t.Errorf("unexpected csv %q", s)
+	}
+
+	out.Reset()
+	table.Export(&out, ExportJSON)
+	if s := out.String(); s != "[\n  {\n    \"name\": \"web\",\n    \"state\": \"up, ok\"\n  }\n]\n" {
+		t.Errorf("unexpected json %q", s)
+	}
+
+	if err := table.Export(&out, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestTableFilters(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{
+		{"name", "cpu"},
+		{"nginx", "12"},
+		{"Nginx-worker", "55"},
+		{"postgres", "70"},
+	}
+
+	table.FilterColumn = 1
+	for _, k := range []string{"/", ">", "=", "5", "0", "<tab>", "n", "g", "<enter>"} {
+		table.HandleFilterKey(EvtKbd{KeyStr: k})
+	}
+	if table.EditingFilter() {
+		t.Error("expected the filter input to be closed")
+	}
+	rows := table.viewRows()
+	if len(rows) != 2 || rows[1][0] != "Nginx-worker" {
+		t.Errorf("unexpected rows %v", rows)
+	}
+
+	table.HandleFilterKey(EvtKbd{KeyStr: "C-l"})
+	if len(table.viewRows()) != 4 {
+		t.Errorf("expected all rows after clearing, got %v", table.viewRows())
+	}
+}
+
+func TestTableMaxCellWidth(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"id", "image"}, {"1", "registry.local/app:v1"}}
+	table.MaxCellWidth = 8
+	table.Analysis()
+	if w := table.CellWidth[1]; w != 8 {
+		t.Fatalf("expected the column capped to 8, got %d", w)
+	}
+	table.SetSize()
+	buf := table.Buffer()
+	var got []rune
+	for x := 0; x < table.Width; x++ {
+		got = append(got, buf.At(x, 3).Ch)
+	}
+	if !strings.Contains(string(got), "registr…") {
+		t.Errorf("expected the cell truncated, got %q", string(got))
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// copyRows returns a copy of rows, cells included.
+func copyRows(rows [][]string) [][]string {
+	cp := make([][]string, len(rows))
+	for i, r := range rows {
+		cp[i] = append([]string(nil), r...)
+	}
+	return cp
+}
+
+// SetCell sets the text of the cell of column x in row y, saving the rows
+// before the edit to the History.
+func (table *Table) SetCell(y, x int, s string) {
+	if y < 0 || y >= len(table.Rows) || x < 0 || x >= len(table.Rows[y]) || table.Rows[y][x] == s {
+		return
+	}
+	if table.History != nil {
+		table.History.Record("", copyRows(table.Rows))
+	}
+	table.Rows[y][x] = s
+}
+
+// Undo reverts the last SetCell and tells if there was one.
+func (table *Table) Undo() bool {
+	if table.History == nil {
+		return false
+	}
+	rows, ok := table.History.Undo(copyRows(table.Rows))
+	if ok {
+		table.Rows = rows.([][]string)
+	}
+	return ok
+}
+
+// Redo applies again the last SetCell undone and tells if there was one.
+func (table *Table) Redo() bool {
+	if table.History == nil {
+		return false
+	}
+	rows, ok := table.History.Redo(copyRows(table.Rows))
+	if ok {
+		table.Rows = rows.([][]string)
+	}
+	return ok
+}
+
+// HandleUndoKey undoes or redoes a cell edit on UndoKey or RedoKey, and
+// tells if the key was consumed. While the filter input is open, its own
+// edits are.
+func (table *Table) HandleUndoKey(k EvtKbd) bool {
+	if ti := table.filterInput; ti != nil {
+		return (k.KeyStr == UndoKey || k.KeyStr == RedoKey) && ti.HandleKey(k)
+	}
+	switch k.KeyStr {
+	case UndoKey:
+		table.Undo()
+	case RedoKey:
+		table.Redo()
+	default:
+		return false
+	}
+	return true
+}
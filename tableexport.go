@@ -0,0 +1,104 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportFormat is a file format Table.Export writes.
+type ExportFormat string
+
+// All supported export formats.
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportTSV  ExportFormat = "tsv"
+	ExportJSON ExportFormat = "json"
+)
+
+// view returns the indices of the rows currently shown, in display order:
+// filtered, then sorted by SortColumn. The header row is always shown
+// first.
+func (table *Table) view() []int {
+	v := make([]int, 0, len(table.Rows))
+	for y, row := range table.Rows {
+		if y == 0 || table.matchFilters(row) {
+			v = append(v, y)
+		}
+	}
+	if len(v) > 1 {
+		table.sortRows(v[1:])
+	}
+	return v
+}
+
+// viewRows returns the rows currently shown, header first.
+func (table *Table) viewRows() [][]string {
+	v := table.view()
+	rows := make([][]string, len(v))
+	for i, y := range v {
+		rows[i] = table.Rows[y]
+	}
+	return rows
+}
+
+// plainText strips color markup from s.
+func plainText(s string) string {
+	return CellsToStr(DefaultTxBuilder.Build(s, ColorDefault, ColorDefault))
+}
+
+// Export writes the rows table currently shows to w, with color markup
+// stripped. CSV and TSV keep the header as their first record; JSON writes
+// an array of objects keyed by the header cells.
+/*
+  f, _ := os.Create("procs.csv")
+  defer f.Close()
+  table.Export(f, termui.ExportCSV)
+*/
+func (table *Table) Export(w io.Writer, format ExportFormat) error {
+	rows := table.viewRows()
+	text := make([][]string, len(rows))
+	for y, row := range rows {
+		text[y] = make([]string, len(row))
+		for x, s := range row {
+			text[y][x] = plainText(s)
+		}
+	}
+
+	switch format {
+	case ExportCSV, ExportTSV:
+		cw := csv.NewWriter(w)
+		if format == ExportTSV {
+			cw.Comma = '\t'
+		}
+		if err := cw.WriteAll(text); err != nil {
+			return err
+		}
+		return cw.Error()
+
+	case ExportJSON:
+		objs := make([]map[string]string, 0, len(text))
+		if len(text) > 0 {
+			for _, row := range text[1:] {
+				o := make(map[string]string, len(row))
+				for x, s := range row {
+					key := fmt.Sprintf("col%d", x)
+					if x < len(text[0]) && text[0][x] != "" {
+						key = text[0][x]
+					}
+					o[key] = s
+				}
+				objs = append(objs, o)
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(objs)
+	}
+	return fmt.Errorf("termui: unknown export format %q", format)
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// filterOps are the numeric comparisons a column filter can start with,
+// longest first.
+var filterOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// matchFilter tells if the cell text s passes the filter expression expr.
+// An expression starting with a comparison operator followed by a number
+// compares numerically, anything else matches cells containing it,
+// ignoring case.
+func matchFilter(expr, s string) bool {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return true
+	}
+	s = strings.TrimSpace(plainText(s))
+
+	for _, op := range filterOps {
+		if !strings.HasPrefix(expr, op) {
+			continue
+		}
+		want, err := strconv.ParseFloat(strings.TrimSpace(expr[len(op):]), 64)
+		if err != nil {
+			break
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return false
+		}
+		switch op {
+		case ">=":
+			return v >= want
+		case "<=":
+			return v <= want
+		case "!=":
+			return v != want
+		case ">":
+			return v > want
+		case "<":
+			return v < want
+		default:
+			return v == want
+		}
+	}
+	return strings.Contains(strings.ToLower(s), strings.ToLower(expr))
+}
+
+// matchFilters tells if row passes all column filters.
+func (table *Table) matchFilters(row []string) bool {
+	for x, expr := range table.Filters {
+		s := ""
+		if x < len(row) {
+			s = row[x]
+		}
+		if !matchFilter(expr, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetFilter sets the filter expression of column x, e.g. "nginx", ">= 50"
+// or "!= 0". An empty expression removes the filter. Rows must pass the
+// filters of all columns to be shown.
+func (table *Table) SetFilter(x int, expr string) {
+	if strings.TrimSpace(expr) == "" {
+		delete(table.Filters, x)
+		return
+	}
+	if table.Filters == nil {
+		table.Filters = make(map[int]string)
+	}
+	table.Filters[x] = expr
+}
+
+// ClearFilters removes all column filters.
+func (table *Table) ClearFilters() {
+	table.Filters = nil
+}
+
+// EditFilter opens the filter input on the header of column x.
+func (table *Table) EditFilter(x int) {
+	table.FilterColumn = x
+	table.filterInput = NewTextInput()
+	table.filterInput.Border = false
+	table.filterInput.Height = 1
+	table.filterInput.Text = table.Filters[x]
+	table.filterInput.End()
+}
+
+// EditingFilter tells if the filter input is open.
+func (table *Table) EditingFilter() bool {
+	return table.filterInput != nil
+}
+
+// HandleFilterKey applies a keyboard event to the column filters and tells
+// if it was consumed. FilterKey opens the input on FilterColumn, where
+// <enter> applies the filter, <tab> applies it and moves to the next
+// column and <escape> discards the edit. ClearFiltersKey removes all
+// filters. Filters apply as they are typed.
+func (table *Table) HandleFilterKey(k EvtKbd) bool {
+	ti := table.filterInput
+	if ti == nil {
+		switch k.KeyStr {
+		case table.FilterKey:
+			table.EditFilter(table.FilterColumn)
+		case table.ClearFiltersKey:
+			table.ClearFilters()
+		default:
+			return false
+		}
+		return true
+	}
+
+	switch k.KeyStr {
+	case "<escape>":
+		table.filterInput = nil
+		return true
+	case "<enter>", "<tab>":
+		ti.Commit()
+		table.SetFilter(table.FilterColumn, ti.Text)
+		table.filterInput = nil
+		if k.KeyStr == "<tab>" && len(table.Rows) > 0 && len(table.Rows[0]) > 0 {
+			table.EditFilter((table.FilterColumn + 1) % len(table.Rows[0]))
+		}
+		return true
+	}
+	return ti.HandleKey(k)
+}
+
+// headerCell returns the x range [x0, x1) of the header cell of column x.
+func (table *Table) headerCell(x int) (int, int) {
+	x0 := table.innerArea.Min.X
+	for i := 0; i < x && i < len(table.CellWidth); i++ {
+		x0 += table.CellWidth[i] + 3
+	}
+	w := 0
+	if x < len(table.CellWidth) {
+		w = table.CellWidth[x] + 3
+	}
+	return x0, x0 + w
+}
+
+// drawFilters marks the headers of filtered columns, shows the number of
+// active filters on the top border and draws the open filter input.
+func (table *Table) drawFilters(buf Buffer) {
+	y := table.innerArea.Min.Y
+	for x := range table.Filters {
+		x0, x1 := table.headerCell(x)
+		for cx := x0 + 1; cx < x1; cx++ {
+			c := buf.At(cx, y)
+			c.Fg = table.FilterFgColor
+			buf.Set(cx, y, c)
+		}
+	}
+
+	if n := len(table.Filters); n > 0 && table.Border && table.BorderTop {
+		badge := TextCells(" ≡"+strconv.Itoa(n)+" ", table.FilterFgColor, table.BorderBg)
+		x := table.area.Max.X - 1 - cellsWidth(badge)
+		if x > table.area.Min.X {
+			for _, c := range badge {
+				buf.Set(x, table.area.Min.Y, c)
+				x += c.Width()
+			}
+		}
+	}
+
+	if ti := table.filterInput; ti != nil {
+		x0, x1 := table.headerCell(table.FilterColumn)
+		ti.X = x0 + 1
+		ti.Y = y
+		ti.Width = x1 - x0 - 1
+		if ti.X+ti.Width > table.innerArea.Max.X {
+			ti.Width = table.innerArea.Max.X - ti.X
+		}
+		ti.TextFgColor = table.FilterFgColor
+		ti.TextBgColor = table.BgColor
+		ti.Bg = table.BgColor
+		buf.Merge(ti.Buffer())
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Aggregator computes the footer cell of a Table column from the text of
+// its body cells.
+type Aggregator func(values []string) string
+
+// numbers returns the values that parse as numbers, ignoring color markup.
+func numbers(values []string) []float64 {
+	var ns []float64
+	for _, v := range values {
+		s := strings.TrimSpace(plainText(v))
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+// AggSum sums the numeric values of a column.
+func AggSum(values []string) string {
+	sum := 0.0
+	for _, n := range numbers(values) {
+		sum += n
+	}
+	return FormatFloat(sum)
+}
+
+// AggAvg averages the numeric values of a column.
+func AggAvg(values []string) string {
+	ns := numbers(values)
+	if len(ns) == 0 {
+		return ""
+	}
+	sum := 0.0
+	for _, n := range ns {
+		sum += n
+	}
+	return FormatFloat(sum / float64(len(ns)))
+}
+
+// AggMin returns the smallest numeric value of a column.
+func AggMin(values []string) string {
+	ns := numbers(values)
+	if len(ns) == 0 {
+		return ""
+	}
+	min := ns[0]
+	for _, n := range ns[1:] {
+		if n < min {
+			min = n
+		}
+	}
+	return FormatFloat(min)
+}
+
+// AggMax returns the largest numeric value of a column.
+func AggMax(values []string) string {
+	ns := numbers(values)
+	if len(ns) == 0 {
+		return ""
+	}
+	max := ns[0]
+	for _, n := range ns[1:] {
+		if n > max {
+			max = n
+		}
+	}
+	return FormatFloat(max)
+}
+
+// AggCount counts the non-empty cells of a column.
+func AggCount(values []string) string {
+	n := 0
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			n++
+		}
+	}
+	return strconv.Itoa(n)
+}
+
+// AggLabel returns an Aggregator always yielding s, e.g. "Total" for the
+// first column.
+func AggLabel(s string) Aggregator {
+	return func([]string) string { return s }
+}
+
+// FooterRow returns the footer cells computed by table.Footer over the body
+// rows currently shown, all but the header row. Columns without an
+// Aggregator are empty.
+func (table *Table) FooterRow() []string {
+	row := make([]string, len(table.Footer))
+	rows := table.viewRows()
+	if len(rows) == 0 {
+		return row
+	}
+	for x, agg := range table.Footer {
+		if agg == nil {
+			continue
+		}
+		col := make([]string, 0, len(rows)-1)
+		for _, r := range rows[1:] {
+			if x < len(r) {
+				col = append(col, r[x])
+			}
+		}
+		row[x] = agg(col)
+	}
+	return row
+}
+
+// footerRows returns the number of inner rows the footer takes.
+func (table *Table) footerRows() int {
+	switch {
+	case len(table.Footer) == 0:
+		return 0
+	case table.Separator:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// bodyEnd returns the first inner row body rows must not be drawn on.
+func (table *Table) bodyEnd() int {
+	return table.innerArea.Max.Y - table.footerRows()
+}
+
+// drawFooter draws the footer row pinned to the bottom of the inner area,
+// below a separator if the table has them.
+func (table *Table) drawFooter(buf Buffer, row []string) {
+	y := table.innerArea.Max.Y - 1
+	if y < table.innerArea.Min.Y {
+		return
+	}
+	fg, bg := table.FooterFgColor, table.FooterBgColor
+
+	for x := table.innerArea.Min.X; x < table.innerArea.Max.X; x++ {
+		buf.Set(x, y, Cell{Ch: ' ', Fg: fg, Bg: bg})
+	}
+	start := table.innerArea.Min.X
+	for x, s := range row {
+		if x >= len(table.CellWidth) {
+			break
+		}
+		if x > 0 {
+			start += table.CellWidth[x-1] + 3
+			v := '|'
+			if table.BorderTheme != nil {
+				v = table.BorderTheme.V
+			}
+			buf.Set(start, y, Cell{Ch: v, Fg: fg, Bg: bg})
+		}
+		cs := table.buildCell(s, fg, bg)
+		if w := table.columnWidth(x); w > 0 {
+			cs = TruncateRight(cs, w)
+		}
+		cx := start + 2
+		switch table.columnAlign(x) {
+		case AlignRight:
+			cx += table.CellWidth[x] - cellsWidth(cs)
+		case AlignCenter:
+			cx += (table.CellWidth[x] - cellsWidth(cs)) / 2
+		}
+		for _, c := range cs {
+			buf.Set(cx, y, c)
+			cx += c.Width()
+		}
+	}
+
+	if !table.Separator || y-1 < table.innerArea.Min.Y {
+		return
+	}
+	h, cross := HORIZONTAL_LINE, HORIZONTAL_LINE
+	if t := table.BorderTheme; t != nil {
+		h, cross = t.H, t.Cross
+	}
+	for x := table.innerArea.Min.X; x < table.innerArea.Max.X; x++ {
+		buf.Set(x, y-1, Cell{Ch: h, Fg: table.FgColor, Bg: table.BgColor})
+	}
+	if t := table.BorderTheme; t != nil {
+		for _, x := range table.dividerXs() {
+			buf.Set(x, y-1, Cell{Ch: cross, Fg: table.FgColor, Bg: table.BgColor})
+		}
+		if table.Border {
+			buf.Set(table.area.Min.X, y-1, Cell{Ch: t.Left, Fg: table.BorderFg, Bg: table.BorderBg})
+			buf.Set(table.area.Max.X-1, y-1, Cell{Ch: t.Right, Fg: table.BorderFg, Bg: table.BorderBg})
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// fitRows returns the number of body rows the inner area shows below the
+// header, above the footer.
+func (table *Table) fitRows() int {
+	n := 0
+	for table.innerArea.Min.Y+table.rowY(n+1) < table.bodyEnd() {
+		n++
+	}
+	return n
+}
+
+// shown returns the indices of the rows drawn, in display order: the
+// header and the body rows of view scrolled to, keeping the SelectedRow
+// shown.
+func (table *Table) shown() []int {
+	table.follow()
+	v := table.view()
+	if len(v) == 0 {
+		return v
+	}
+	body, fit := v[1:], table.fitRows()
+	if i := indexOf(body, table.SelectedRow); i >= 0 {
+		if i < table.offset {
+			table.offset = i
+		}
+		if i >= table.offset+fit {
+			table.offset = i - fit + 1
+		}
+	}
+	table.offset = clamp(table.offset, 0, clamp(len(body)-fit, 0, len(body)))
+	end := clamp(table.offset+fit, 0, len(body))
+	return append([]int{v[0]}, body[table.offset:end]...)
+}
+
+// follow moves the selection to the row of its key after Rows changed,
+// see selection.
+func (table *Table) follow() {
+	table.SelectedRow = table.sel.follow(table.SelectedRow, 1, len(table.Rows), table.key)
+}
+
+// indexOf returns the index of y in ys, -1 if it is not there.
+func indexOf(ys []int, y int) int {
+	for i, v := range ys {
+		if v == y {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveSelection selects the body row n rows below the selected one in
+// display order, above it if n is negative. Without a row selected, the
+// rows scroll by n instead.
+func (table *Table) moveSelection(n int) {
+	table.follow()
+	v := table.view()
+	if len(v) < 2 {
+		return
+	}
+	body := v[1:]
+	if table.SelectedRow < 0 {
+		table.offset = clamp(table.offset+n, 0, len(body)-1)
+		return
+	}
+	i := indexOf(body, table.SelectedRow)
+	if i < 0 {
+		i = 0
+	} else {
+		i = clamp(i+n, 0, len(body)-1)
+	}
+	table.SelectedRow = body[i]
+}
+
+// ScrollDown selects the row n rows below the selected one.
+func (table *Table) ScrollDown(n int) {
+	table.moveSelection(n)
+}
+
+// ScrollUp selects the row n rows above the selected one.
+func (table *Table) ScrollUp(n int) {
+	table.moveSelection(-n)
+}
+
+// HandleKey applies a keyboard event to the selection and the order of the
+// rows, and tells if it was consumed: <up>, <down>, <pageup>, <pagedown>,
+// <home> and <end> move the selection, or scroll without one; SortKey and
+// ReverseSortKey change the order.
+/*
+  table.SelectedRow = 1
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      if table.HandleKey(e.Data.(termui.EvtKbd)) {
+          termui.Render(table)
+      }
+  })
+*/
+func (table *Table) HandleKey(k EvtKbd) bool {
+	page := table.fitRows()
+	if page < 1 {
+		page = 1
+	}
+	switch k.KeyStr {
+	case "<up>", "k":
+		table.ScrollUp(k.Step())
+	case "<down>", "j":
+		table.ScrollDown(k.Step())
+	case "<pageup>", "<previous>":
+		table.ScrollUp(page)
+	case "<pagedown>", "<next>":
+		table.ScrollDown(page)
+	case "<home>":
+		table.ScrollUp(len(table.Rows))
+	case "<end>":
+		table.ScrollDown(len(table.Rows))
+	case table.SortKey:
+		table.NextSort()
+	case table.ReverseSortKey:
+		table.ReverseSort()
+	default:
+		return false
+	}
+	return true
+}
+
+// SaveState implements Stater, saving the SelectedRow and the scroll.
+func (table *Table) SaveState() ViewState {
+	table.follow()
+	return ViewState{Selected: table.SelectedRow, ScrollY: table.offset}
+}
+
+// RestoreState implements Stater. The scroll is fitted to the rows when
+// they are drawn, which may be loaded after.
+func (table *Table) RestoreState(s ViewState) {
+	table.SelectedRow = max(s.Selected, -1)
+	table.offset = max(s.ScrollY, 0)
+}
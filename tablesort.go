@@ -0,0 +1,91 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// compareCells orders the cell texts a and b: numbers by value and before
+// text, text ignoring case. Color markup is ignored.
+func compareCells(a, b string) int {
+	a, b = strings.TrimSpace(plainText(a)), strings.TrimSpace(plainText(b))
+	va, errA := strconv.ParseFloat(a, 64)
+	vb, errB := strconv.ParseFloat(b, 64)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case va < vb:
+			return -1
+		case va > vb:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// sortRows sorts the row indices ys by SortColumn, keeping the order of
+// rows with equal cells.
+func (table *Table) sortRows(ys []int) {
+	x := table.SortColumn
+	if x < 0 {
+		return
+	}
+	cell := func(y int) string {
+		if x < len(table.Rows[y]) {
+			return table.Rows[y][x]
+		}
+		return ""
+	}
+	sort.SliceStable(ys, func(i, j int) bool {
+		c := compareCells(cell(ys[i]), cell(ys[j]))
+		if table.SortDesc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// sortMark returns the mark of the order added to the header of the
+// SortColumn.
+func (table *Table) sortMark() string {
+	if table.SortDesc {
+		return " ▼"
+	}
+	return " ▲"
+}
+
+// SortBy shows the body rows sorted by the cells of column x, in
+// descending order if desc; x -1 shows them in the order of Rows.
+func (table *Table) SortBy(x int, desc bool) {
+	table.SortColumn = x
+	table.SortDesc = desc
+}
+
+// NextSort sorts by the column after SortColumn, in ascending order; after
+// the last column, the rows are shown in the order of Rows again.
+func (table *Table) NextSort() {
+	n := 0
+	if len(table.Rows) > 0 {
+		n = len(table.Rows[0])
+	}
+	x := table.SortColumn + 1
+	if x >= n {
+		x = -1
+	}
+	table.SortBy(x, false)
+}
+
+// ReverseSort reverses the order of the rows sorted.
+func (table *Table) ReverseSort() {
+	table.SortDesc = !table.SortDesc
+}
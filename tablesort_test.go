@@ -0,0 +1,92 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableSort(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"name", "cpu"}, {"b", "10"}, {"A", "9.5"}, {"c", "n/a"}, {"d", "10"}}
+
+	table.SortBy(1, false)
+	if got, want := table.view(), []int{0, 2, 1, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending: %v, want %v", got, want)
+	}
+	table.ReverseSort()
+	if got, want := table.view(), []int{0, 3, 1, 4, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("descending: %v, want %v", got, want)
+	}
+	table.HandleKey(EvtKbd{KeyStr: "s"})
+	if table.SortColumn != -1 || table.SortDesc {
+		t.Errorf("expected the last column to cycle back to no sort, got %d", table.SortColumn)
+	}
+	table.HandleKey(EvtKbd{KeyStr: "s"})
+	if got, want := table.view(), []int{0, 2, 1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("by name ignoring case: %v, want %v", got, want)
+	}
+
+	table.Analysis()
+	if table.CellWidth[0] != len("name ▲")-2 {
+		t.Errorf("expected the header to make room for the order, got width %d", table.CellWidth[0])
+	}
+}
+
+func TestTableScroll(t *testing.T) {
+	table := NewTable()
+	table.Separator = false
+	table.Rows = [][]string{{"n"}, {"1"}, {"2"}, {"3"}, {"4"}, {"5"}}
+	table.Width = 8
+	table.Height = 5 // the header and two body rows
+	table.SelectedRow = 1
+
+	table.HandleKey(EvtKbd{KeyStr: "<down>"})
+	table.HandleKey(EvtKbd{KeyStr: "<down>"})
+	if table.SelectedRow != 3 {
+		t.Fatalf("selected %d, want 3", table.SelectedRow)
+	}
+	buf := table.Buffer()
+	col := ""
+	for y := 1; y < 4; y++ {
+		col += string(buf.At(3, y).Ch)
+	}
+	if col != "n23" {
+		t.Errorf("expected the rows scrolled to the selection under the header, got %q", col)
+	}
+	if c := buf.At(3, 3); c.Bg != table.SelectedBgColor {
+		t.Errorf("expected the selected row highlighted, got bg %v", c.Bg)
+	}
+	table.HandleKey(EvtKbd{KeyStr: "<end>"})
+	if table.SelectedRow != 5 {
+		t.Errorf("selected %d, want the last row", table.SelectedRow)
+	}
+	table.HandleKey(EvtKbd{KeyStr: "<pageup>"})
+	if table.SelectedRow != 3 {
+		t.Errorf("selected %d, want a page up", table.SelectedRow)
+	}
+}
+
+func TestTableColumns(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"name", "n"}, {"longer", "7"}}
+	table.ColumnWidth = []int{3}
+	table.ColumnAlign = []Align{AlignLeft, AlignRight}
+	table.Analysis()
+	table.SetSize()
+	if table.CellWidth[0] != 3 {
+		t.Fatalf("width %d, want the fixed 3", table.CellWidth[0])
+	}
+	buf := table.Buffer()
+	row := ""
+	for x := 0; x < table.Width; x++ {
+		row += string(buf.At(x, 3).Ch)
+	}
+	if !strings.HasPrefix(row, "│  lo… | 7") {
+		t.Errorf("expected the text truncated and aligned, got %q", row)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TaskResult is the Data of the "/task/done" and "/task/error" events.
+type TaskResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Task is a job submitted to Tasks.
+type Task struct {
+	Name  string
+	tasks *Tasks
+	entry *ProgressEntry
+	f     func(*Task) error
+}
+
+// Progress reports the completion of t in percent and sends a
+// "/task/progress" event.
+func (t *Task) Progress(percent int) {
+	t.tasks.Progress.Set(t.entry, percent)
+	t.tasks.emit("/task/progress", t.Name)
+}
+
+// Tasks runs submitted jobs on a fixed pool of workers. Every job gets a
+// MultiProgress entry while it is queued or running; a failed job is
+// surfaced in Modal. Tasks sends "/task/progress", "/task/done" and
+// "/task/error" events, so the UI goroutine knows when to render again.
+/*
+  tasks := termui.NewTasks(4)
+  tasks.Progress.BorderLabel = "Jobs"
+
+  tasks.Submit("fetch", func(t *termui.Task) error {
+      for i := 0; i <= 10; i++ {
+          t.Progress(i * 10)
+          time.Sleep(100 * time.Millisecond)
+      }
+      return nil
+  })
+
+  termui.Handle("/task", func(termui.Event) {
+      termui.Render(tasks.Progress, tasks.Modal)
+  })
+*/
+type Tasks struct {
+	Progress *MultiProgress
+	Modal    *Modal
+	// Emit sends the task events, it defaults to SendCustomEvt.
+	Emit    func(path string, data interface{})
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending []*Task
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewTasks returns a *Tasks running jobs on the given number of workers.
+func NewTasks(workers int) *Tasks {
+	if workers < 1 {
+		workers = 1
+	}
+	ts := &Tasks{
+		Progress: NewMultiProgress(),
+		Modal:    NewModal(),
+		Emit:     SendCustomEvt,
+	}
+	ts.cond = sync.NewCond(&ts.mu)
+	for i := 0; i < workers; i++ {
+		go ts.work()
+	}
+	return ts
+}
+
+func (ts *Tasks) emit(path string, data interface{}) {
+	if ts.Emit != nil {
+		ts.Emit(path, data)
+	}
+}
+
+// Submit queues f to run as the job name and returns its Task.
+func (ts *Tasks) Submit(name string, f func(*Task) error) *Task {
+	t := &Task{Name: name, tasks: ts, entry: ts.Progress.Add(name), f: f}
+	ts.wg.Add(1)
+
+	ts.mu.Lock()
+	ts.pending = append(ts.pending, t)
+	ts.mu.Unlock()
+	ts.cond.Signal()
+	return t
+}
+
+// Wait blocks until all submitted jobs are finished.
+func (ts *Tasks) Wait() {
+	ts.wg.Wait()
+}
+
+// Close stops the workers once the queued jobs are finished.
+func (ts *Tasks) Close() {
+	ts.mu.Lock()
+	ts.closed = true
+	ts.mu.Unlock()
+	ts.cond.Broadcast()
+}
+
+func (ts *Tasks) work() {
+	for {
+		ts.mu.Lock()
+		for len(ts.pending) == 0 && !ts.closed {
+			ts.cond.Wait()
+		}
+		if len(ts.pending) == 0 {
+			ts.mu.Unlock()
+			return
+		}
+		t := ts.pending[0]
+		ts.pending = ts.pending[1:]
+		ts.mu.Unlock()
+
+		ts.run(t)
+	}
+}
+
+func (ts *Tasks) run(t *Task) {
+	defer ts.wg.Done()
+
+	start := time.Now()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return t.f(t)
+	}()
+
+	ts.Progress.Remove(t.entry)
+	res := TaskResult{Name: t.Name, Err: err, Duration: time.Since(start)}
+	if err != nil {
+		ts.Modal.Show(Msg("error")+": "+t.Name, err.Error())
+		ts.emit("/task/error", res)
+		return
+	}
+	ts.emit("/task/done", res)
+}
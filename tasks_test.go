@@ -0,0 +1,42 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestTasks(t *testing.T) {
+	ts := NewTasks(2)
+	defer ts.Close()
+
+	var mu sync.Mutex
+	events := make(map[string]int)
+	ts.Emit = func(path string, data interface{}) {
+		mu.Lock()
+		events[path]++
+		mu.Unlock()
+	}
+
+	ts.Submit("ok", func(t *Task) error {
+		t.Progress(50)
+		return nil
+	})
+	ts.Submit("fail", func(*Task) error { return errors.New("boom") })
+	ts.Submit("panic", func(*Task) error { panic("oops") })
+	ts.Wait()
+
+	if events["/task/done"] != 1 || events["/task/error"] != 2 || events["/task/progress"] != 1 {
+		t.Errorf("unexpected events %v", events)
+	}
+	if n := len(ts.Progress.Entries()); n != 0 {
+		t.Errorf("expected no progress entries, got %d", n)
+	}
+	if !ts.Modal.Visible() {
+		t.Error("expected the error modal to be shown")
+	}
+}
@@ -0,0 +1,539 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "unicode/utf8"
+
+// TextArea is a multi-line text field, the TextInput of free-form text:
+// <enter> starts a new line, the cursor moves over lines as well as runes
+// and the text scrolls to keep it in view. With Wrap, lines longer than
+// the field is wide break at words, else they scroll sideways. Keyboard
+// events are fed through HandleKey, which leaves <tab> to the app, e.g. to
+// move the focus.
+/*
+  ta := termui.NewTextArea()
+  ta.BorderLabel = "Commit message"
+  ta.Width = 60
+  ta.Height = 10
+  ta.Placeholder = "Describe the change"
+
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      k := e.Data.(termui.EvtKbd)
+      switch k.KeyStr {
+      case "C-s":
+          save(ta.Text)
+      case "M-z":
+          ta.ToggleWrap()
+      default:
+          ta.HandleKey(k)
+      }
+      termui.Render(ta)
+  })
+*/
+type TextArea struct {
+	Block
+	composer
+	Text        string
+	Cursor      int // rune index of the cursor in Text
+	Placeholder string
+	TextFgColor Attribute
+	TextBgColor Attribute
+	ShowCursor  bool
+	Wrap        bool // break long lines at words, else scroll sideways
+	// HardwareCursor shows the terminal cursor instead of drawing a soft
+	// one, see SoftCursorCell; both are of CursorStyle.
+	HardwareCursor bool
+	CursorStyle    CursorStyle
+	// History is the edit history undone by UndoKey and redone by RedoKey,
+	// nil keeps none.
+	History *UndoStack
+	goal    int // column kept over vertical moves, -1 for the cursor's
+	offset  int // first visible row
+	offsetX int // first visible cell of the rows, without Wrap
+}
+
+// NewTextArea returns a new *TextArea with current theme, wrapping lines.
+func NewTextArea() *TextArea {
+	ta := &TextArea{Block: *NewBlock()}
+	ta.TextFgColor = ThemeAttr("textarea.text.fg")
+	ta.TextBgColor = ThemeAttr("textarea.text.bg")
+	ta.ShowCursor = true
+	ta.Wrap = true
+	ta.History = NewUndoStack()
+	ta.goal = -1
+	ta.Height = 5
+	return ta
+}
+
+// textRow is a row of a TextArea: the runes of Text from lo to hi, end
+// telling if it ends a line rather than breaking it.
+type textRow struct {
+	lo, hi int
+	end    bool
+}
+
+// rows returns the rows of rs, lines broken to w cells with Wrap.
+func (ta *TextArea) rows(rs []rune, w int) []textRow {
+	var rows []textRow
+	lo := 0
+	for i := 0; i <= len(rs); i++ {
+		if i < len(rs) && rs[i] != '\n' {
+			continue
+		}
+		if ta.Wrap && w > 0 {
+			rows = append(rows, wrapRow(rs, lo, i, w)...)
+		} else {
+			rows = append(rows, textRow{lo: lo, hi: i, end: true})
+		}
+		lo = i + 1
+	}
+	return rows
+}
+
+// wrapRow returns the rows of the line of rs from lo to hi, broken after
+// the last blank fitting in w cells, or where the line fills them if
+// there is none.
+func wrapRow(rs []rune, lo, hi, w int) []textRow {
+	var rows []textRow
+	width, brk := 0, -1 // brk is the rune after the last blank
+	for i := lo; i < hi; i++ {
+		cw := charWidth(rs[i])
+		if width+cw > w && i > lo {
+			at := i
+			if brk > lo {
+				at = brk
+			}
+			rows = append(rows, textRow{lo: lo, hi: at})
+			lo, width, brk = at, 0, -1
+			for j := lo; j < i; j++ {
+				width += charWidth(rs[j])
+				if rs[j] == ' ' {
+					brk = j + 1
+				}
+			}
+		}
+		width += cw
+		if rs[i] == ' ' {
+			brk = i + 1
+		}
+	}
+	return append(rows, textRow{lo: lo, hi: hi, end: true})
+}
+
+// rowOf returns the index of the row of rows holding rune i, the start of
+// the next row for the end of a broken one.
+func rowOf(rows []textRow, i int) int {
+	for r, row := range rows {
+		if i >= row.lo && (i < row.hi || i == row.hi && row.end) {
+			return r
+		}
+	}
+	return len(rows) - 1
+}
+
+// runeAt returns the rune of row closest to column col of rs, left of it.
+func runeAt(rs []rune, row textRow, col int) int {
+	hi := row.hi
+	if !row.end && hi > row.lo {
+		// the end of a broken row is the start of the next one
+		hi--
+	}
+	i, x := row.lo, 0
+	for i < hi && x+charWidth(rs[i]) <= col {
+		x += charWidth(rs[i])
+		i++
+	}
+	return i
+}
+
+// record saves the state before an edit of kind to the History.
+func (ta *TextArea) record(kind string) {
+	ta.goal = -1
+	if ta.History != nil {
+		ta.History.Record(kind, textState{ta.Text, ta.Cursor})
+	}
+}
+
+// moved ends the current step of the History, so that edits after a
+// cursor move are undone on their own, and forgets the column kept.
+func (ta *TextArea) moved() {
+	ta.goal = -1
+	if ta.History != nil {
+		ta.History.Break()
+	}
+}
+
+// Undo reverts the last edit and tells if there was one.
+func (ta *TextArea) Undo() bool {
+	if ta.History == nil {
+		return false
+	}
+	s, ok := ta.History.Undo(textState{ta.Text, ta.Cursor})
+	if ok {
+		ta.Text, ta.Cursor = s.(textState).text, s.(textState).cursor
+		ta.goal = -1
+	}
+	return ok
+}
+
+// Redo applies again the last edit undone and tells if there was one.
+func (ta *TextArea) Redo() bool {
+	if ta.History == nil {
+		return false
+	}
+	s, ok := ta.History.Redo(textState{ta.Text, ta.Cursor})
+	if ok {
+		ta.Text, ta.Cursor = s.(textState).text, s.(textState).cursor
+		ta.goal = -1
+	}
+	return ok
+}
+
+func (ta *TextArea) clampCursor(rs []rune) {
+	ta.Cursor = clamp(ta.Cursor, 0, len(rs))
+}
+
+// Insert inserts s at the cursor. Combining marks are composed with the
+// rune before the cursor when possible.
+func (ta *TextArea) Insert(s string) {
+	if s == "" {
+		return
+	}
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	ta.record("insert")
+	for _, r := range s {
+		if _, ok := combiningDead[r]; ok && ta.Cursor > 0 {
+			if cr, ok := Compose(r, rs[ta.Cursor-1]); ok {
+				rs[ta.Cursor-1] = cr
+				continue
+			}
+		}
+		rs = append(rs[:ta.Cursor], append([]rune{r}, rs[ta.Cursor:]...)...)
+		ta.Cursor++
+	}
+	ta.Text = string(rs)
+}
+
+// Type feeds a typed rune through dead key composition.
+func (ta *TextArea) Type(r rune) {
+	if rs := ta.feed(r); len(rs) > 0 {
+		ta.Insert(string(rs))
+	}
+}
+
+// Commit inserts the pending preedit text at the cursor.
+func (ta *TextArea) Commit() {
+	ta.Insert(string(ta.commit()))
+}
+
+// cut deletes the runes of Text from lo to hi and puts the cursor at lo.
+func (ta *TextArea) cut(kind string, lo, hi int) {
+	rs := []rune(ta.Text)
+	lo, hi = clamp(lo, 0, len(rs)), clamp(hi, 0, len(rs))
+	if lo >= hi {
+		return
+	}
+	ta.record(kind)
+	ta.Text = string(append(rs[:lo], rs[hi:]...))
+	ta.Cursor = lo
+}
+
+// Backspace deletes the rune before the cursor, or cancels a pending
+// composition.
+func (ta *TextArea) Backspace() {
+	if len(ta.preedit) > 0 {
+		ta.commit()
+		return
+	}
+	ta.clampCursor([]rune(ta.Text))
+	ta.cut("delete", ta.Cursor-1, ta.Cursor)
+}
+
+// Delete deletes the rune under the cursor, joining the next line at the
+// end of one.
+func (ta *TextArea) Delete() {
+	ta.clampCursor([]rune(ta.Text))
+	ta.cut("delete", ta.Cursor, ta.Cursor+1)
+}
+
+// MoveCursor moves the cursor by n runes, over line ends.
+func (ta *TextArea) MoveCursor(n int) {
+	ta.Cursor += n
+	ta.clampCursor([]rune(ta.Text))
+	ta.moved()
+}
+
+// MoveLines moves the cursor n rows down, up if n is negative, keeping
+// its column over shorter rows.
+func (ta *TextArea) MoveLines(n int) {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	rows := ta.rows(rs, ta.innerArea.Dx())
+	r := rowOf(rows, ta.Cursor)
+	if ta.goal < 0 {
+		ta.goal = strWidth(string(rs[rows[r].lo:ta.Cursor]))
+	}
+	goal := ta.goal
+	ta.Cursor = runeAt(rs, rows[clamp(r+n, 0, len(rows)-1)], goal)
+	ta.moved()
+	ta.goal = goal
+}
+
+// line returns the start and end of the line of the cursor in rs.
+func (ta *TextArea) line(rs []rune) (int, int) {
+	lo, hi := ta.Cursor, ta.Cursor
+	for lo > 0 && rs[lo-1] != '\n' {
+		lo--
+	}
+	for hi < len(rs) && rs[hi] != '\n' {
+		hi++
+	}
+	return lo, hi
+}
+
+// LineStart moves the cursor to the start of its line.
+func (ta *TextArea) LineStart() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	ta.Cursor, _ = ta.line(rs)
+	ta.moved()
+}
+
+// LineEnd moves the cursor to the end of its line.
+func (ta *TextArea) LineEnd() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	_, ta.Cursor = ta.line(rs)
+	ta.moved()
+}
+
+// Home moves the cursor to the start of the text.
+func (ta *TextArea) Home() {
+	ta.Cursor = 0
+	ta.moved()
+}
+
+// End moves the cursor to the end of the text.
+func (ta *TextArea) End() {
+	ta.Cursor = utf8.RuneCountInString(ta.Text)
+	ta.moved()
+}
+
+// WordLeft moves the cursor to the start of the word before it.
+func (ta *TextArea) WordLeft() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	ta.Cursor = wordStart(rs, ta.Cursor)
+	ta.moved()
+}
+
+// WordRight moves the cursor to the end of the word after it.
+func (ta *TextArea) WordRight() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	ta.Cursor = wordEnd(rs, ta.Cursor)
+	ta.moved()
+}
+
+// KillLine deletes the text from the cursor to the end of its line, or
+// the line end if the cursor is there.
+func (ta *TextArea) KillLine() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	_, hi := ta.line(rs)
+	if hi == ta.Cursor {
+		hi++
+	}
+	ta.cut("", ta.Cursor, hi)
+}
+
+// KillToStart deletes the text from the start of the line of the cursor
+// to the cursor.
+func (ta *TextArea) KillToStart() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	lo, _ := ta.line(rs)
+	ta.cut("", lo, ta.Cursor)
+}
+
+// KillWordBack deletes the word before the cursor.
+func (ta *TextArea) KillWordBack() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	ta.cut("", wordStart(rs, ta.Cursor), ta.Cursor)
+}
+
+// KillWord deletes the word after the cursor.
+func (ta *TextArea) KillWord() {
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	ta.cut("", ta.Cursor, wordEnd(rs, ta.Cursor))
+}
+
+// ToggleWrap switches between breaking long lines and scrolling them
+// sideways.
+func (ta *TextArea) ToggleWrap() {
+	ta.Wrap = !ta.Wrap
+	ta.offsetX = 0
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed. Besides
+// the arrows, <home> and <end>, which go to the ends of the line, <pageup>,
+// <pagedown>, <backspace>, <delete> and <enter>, it handles the readline
+// keys: C-a and C-e move to the ends of the line, C-b and C-f by rune, C-p
+// and C-n by row, M-b and M-f by word, M-< and M-> to the ends of the
+// text; C-k, C-u, C-w and M-d delete to the end of the line, to its start,
+// the word before and the word after.
+func (ta *TextArea) HandleKey(k EvtKbd) bool {
+	page := ta.innerArea.Dy()
+	if page < 1 {
+		page = 1
+	}
+	switch k.KeyStr {
+	case UndoKey:
+		ta.Undo()
+	case RedoKey:
+		ta.Redo()
+	case "<left>", "C-b":
+		ta.MoveCursor(-k.Step())
+	case "<right>", "C-f":
+		ta.MoveCursor(k.Step())
+	case "<up>", "C-p":
+		ta.MoveLines(-k.Step())
+	case "<down>", "C-n":
+		ta.MoveLines(k.Step())
+	case "<pageup>", "<previous>":
+		ta.MoveLines(-page)
+	case "<pagedown>", "<next>":
+		ta.MoveLines(page)
+	case "M-b":
+		ta.WordLeft()
+	case "M-f":
+		ta.WordRight()
+	case "<home>", "C-a":
+		ta.LineStart()
+	case "<end>", "C-e":
+		ta.LineEnd()
+	case "M-<":
+		ta.Home()
+	case "M->":
+		ta.End()
+	case "C-k":
+		ta.KillLine()
+	case "C-u":
+		ta.KillToStart()
+	case "C-w":
+		ta.KillWordBack()
+	case "M-d":
+		ta.KillWord()
+	case "<backspace>", "C-8", "C-h":
+		ta.Backspace()
+	case "<delete>", "C-d":
+		ta.Delete()
+	case "<space>":
+		ta.Type(' ')
+	case "<enter>":
+		ta.Commit()
+		ta.Insert("\n")
+	default:
+		if utf8.RuneCountInString(k.KeyStr) != 1 {
+			return false
+		}
+		r, _ := utf8.DecodeRuneInString(k.KeyStr)
+		ta.Type(r)
+	}
+	CursorBlinker.Reset()
+	return true
+}
+
+// Buffer implements Bufferer interface.
+func (ta *TextArea) Buffer() Buffer {
+	buf := ta.Block.Buffer()
+	if ta.drawState(buf) {
+		return buf
+	}
+	w, h := ta.innerArea.Dx(), ta.innerArea.Dy()
+	if w <= 0 || h <= 0 {
+		return buf
+	}
+	fg, bg := ta.TextFgColor, ta.TextBgColor
+	if ta.Text == "" && len(ta.preedit) == 0 && ta.Placeholder != "" {
+		cs := TruncateRight(TextCells(ta.Placeholder, ThemeAttr("textarea.placeholder.fg"), bg), w)
+		if ta.ShowCursor && !ta.HardwareCursor {
+			cs[0] = SoftCursorCell(cs[0], ta.CursorStyle)
+		}
+		x := ta.innerArea.Min.X
+		for _, c := range cs {
+			buf.Set(x, ta.innerArea.Min.Y, c)
+			x += c.Width()
+		}
+		if ta.ShowCursor && ta.HardwareCursor {
+			PlaceCursor(ta.innerArea.Min.X, ta.innerArea.Min.Y, ta.CursorStyle)
+		}
+		return buf
+	}
+
+	rs := []rune(ta.Text)
+	ta.clampCursor(rs)
+	rows := ta.rows(rs, w)
+	cr := rowOf(rows, ta.Cursor)
+
+	// scroll to keep the cursor visible
+	if cr < ta.offset {
+		ta.offset = cr
+	}
+	if cr >= ta.offset+h {
+		ta.offset = cr - h + 1
+	}
+	ta.offset = clamp(ta.offset, 0, clamp(len(rows)-h, 0, len(rows)))
+	col := strWidth(string(rs[rows[cr].lo:ta.Cursor]))
+	if ta.Wrap {
+		ta.offsetX = 0
+	} else {
+		if col < ta.offsetX {
+			ta.offsetX = col
+		}
+		if col >= ta.offsetX+w {
+			ta.offsetX = col - w + 1
+		}
+	}
+
+	for r := ta.offset; r < len(rows) && r < ta.offset+h; r++ {
+		row := rows[r]
+		y := ta.innerArea.Min.Y + r - ta.offset
+		var cs []Cell
+		cur := -1
+		if r == cr {
+			cs = TextCells(string(rs[row.lo:ta.Cursor]), fg, bg)
+			cs = append(cs, TextCells(string(ta.preedit), fg|AttrUnderline, bg)...)
+			cur = len(cs)
+			cs = append(cs, TextCells(string(rs[ta.Cursor:row.hi]), fg, bg)...)
+			if cur == len(cs) {
+				cs = append(cs, Cell{Ch: ' ', Fg: fg, Bg: bg})
+			}
+			if ta.ShowCursor && !ta.HardwareCursor {
+				cs[cur] = SoftCursorCell(cs[cur], ta.CursorStyle)
+			}
+		} else {
+			cs = TextCells(string(rs[row.lo:row.hi]), fg, bg)
+		}
+
+		x := ta.innerArea.Min.X - ta.offsetX
+		for i, c := range cs {
+			if x+c.Width() > ta.innerArea.Max.X {
+				break
+			}
+			if x >= ta.innerArea.Min.X {
+				if i == cur && ta.ShowCursor && ta.HardwareCursor {
+					PlaceCursor(x, y, ta.CursorStyle)
+				}
+				buf.Set(x, y, c)
+			}
+			x += c.Width()
+		}
+	}
+	return buf
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func testTextArea(text string) *TextArea {
+	ta := NewTextArea()
+	ta.Border = false
+	ta.Width = 10
+	ta.Height = 3
+	ta.ShowCursor = false
+	ta.Text = text
+	ta.Buffer()
+	return ta
+}
+
+func TestTextAreaEditing(t *testing.T) {
+	ta := testTextArea("")
+	for _, k := range []string{"a", "b", "<enter>", "c", "<up>", "<end>", "x", "C-n", "<backspace>", "<backspace>"} {
+		ta.HandleKey(EvtKbd{KeyStr: k})
+	}
+	if ta.Text != "abx" || ta.Cursor != 3 {
+		t.Errorf("unexpected text %q at %d", ta.Text, ta.Cursor)
+	}
+	ta.HandleKey(EvtKbd{KeyStr: "C-a"})
+	ta.HandleKey(EvtKbd{KeyStr: "C-k"})
+	if ta.Text != "" {
+		t.Errorf("C-k left %q", ta.Text)
+	}
+	ta.Undo()
+	if ta.Text != "abx" {
+		t.Errorf("undo gave %q", ta.Text)
+	}
+}
+
+func TestTextAreaWrap(t *testing.T) {
+	ta := testTextArea("one two three four\nfive")
+	var got []string
+	rs := []rune(ta.Text)
+	for _, r := range ta.rows(rs, 10) {
+		got = append(got, string(rs[r.lo:r.hi]))
+	}
+	if s := strings.Join(got, "|"); s != "one two |three four|five" {
+		t.Errorf("rows %q", s)
+	}
+
+	// the column is kept over the shorter row
+	ta.Cursor = 7
+	ta.MoveLines(2)
+	if ta.Cursor != 23 {
+		t.Errorf("expected the end of five, got %d", ta.Cursor)
+	}
+	ta.MoveLines(-1)
+	if ta.Cursor != 15 {
+		t.Errorf("expected the column kept at 7, got %d", ta.Cursor)
+	}
+
+	ta.ToggleWrap()
+	ta.End()
+	ta.Cursor = 17
+	buf := ta.Buffer()
+	if got := strings.Join(treeLines(buf, 10, 2), "|"); got != "three four|" {
+		t.Errorf("expected the line scrolled sideways, got %q", got)
+	}
+}
+
+func TestTextAreaScroll(t *testing.T) {
+	ta := testTextArea("1\n2\n3\n4\n5")
+	ta.HandleKey(EvtKbd{KeyStr: "M->"})
+	if got := strings.Join(treeLines(ta.Buffer(), 10, 3), ","); got != "3,4,5" {
+		t.Errorf("expected the last rows shown, got %s", got)
+	}
+	ta.HandleKey(EvtKbd{KeyStr: "<pageup>"})
+	if got := strings.Join(treeLines(ta.Buffer(), 10, 3), ","); got != "2,3,4" {
+		t.Errorf("expected the view to follow the cursor up, got %s", got)
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextInput is a single line text field. Keyboard events are fed through
+// HandleKey; input method preedit text, and dead keys with DeadKeys, are
+// composed before they are committed to Text.
+/*
+  ti := termui.NewTextInput()
+  ti.BorderLabel = "Name"
+  ti.Width = 30
+  ti.Height = 3
+  ti.OnSubmit = func(s string) { ... }
+
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      ti.HandleKey(e.Data.(termui.EvtKbd))
+      termui.Render(ti)
+  })
+*/
+type TextInput struct {
+	Block
+	composer
+	Text        string
+	Cursor      int // rune index of the cursor in Text
+	Placeholder string
+	TextFgColor Attribute
+	TextBgColor Attribute
+	ShowCursor  bool
+	// HardwareCursor shows the terminal cursor instead of drawing a soft
+	// one, see SoftCursorCell; both are of CursorStyle.
+	HardwareCursor bool
+	CursorStyle    CursorStyle
+	OnSubmit       func(string) // called on <enter>
+	// History is the edit history undone by UndoKey and redone by RedoKey,
+	// nil keeps none.
+	History *UndoStack
+	// Recall is the history of the lines submitted, recalled by <up> and
+	// <down> and searched by C-r, nil keeps none.
+	Recall   *InputHistory
+	recalled int    // lines back in Recall shown, 0 for the draft
+	draft    string // text typed before recalling
+	search   *historySearch
+	// Completer completes the word at the cursor on <tab>, see Complete.
+	Completer  Completer
+	completion *completion
+	offset     int // first visible cell
+
+	// Mask hides every rune of Text behind itself when not 0, e.g. '*'.
+	Mask rune
+	// RevealKey momentarily shows a masked Text until the next key.
+	RevealKey string
+	Revealed  bool
+	// ShowStrength draws a password strength meter on the row below the
+	// text, the input needs an inner height of at least 2.
+	ShowStrength bool
+}
+
+// NewTextInput returns a new *TextInput with current theme.
+func NewTextInput() *TextInput {
+	ti := &TextInput{Block: *NewBlock()}
+	ti.TextFgColor = ThemeAttr("textinput.text.fg")
+	ti.TextBgColor = ThemeAttr("textinput.text.bg")
+	ti.ShowCursor = true
+	ti.RevealKey = "C-r"
+	ti.History = NewUndoStack()
+	ti.Height = 3
+	return ti
+}
+
+// textState is the state of a TextInput saved in its History.
+type textState struct {
+	text   string
+	cursor int
+}
+
+// record saves the state before an edit of kind to the History.
+func (ti *TextInput) record(kind string) {
+	if ti.History != nil {
+		ti.History.Record(kind, textState{ti.Text, ti.Cursor})
+	}
+}
+
+// moved ends the current step of the History, so that edits after a
+// cursor move are undone on their own.
+func (ti *TextInput) moved() {
+	if ti.History != nil {
+		ti.History.Break()
+	}
+}
+
+// Undo reverts the last edit and tells if there was one.
+func (ti *TextInput) Undo() bool {
+	if ti.History == nil {
+		return false
+	}
+	s, ok := ti.History.Undo(textState{ti.Text, ti.Cursor})
+	if ok {
+		ti.Text, ti.Cursor = s.(textState).text, s.(textState).cursor
+	}
+	return ok
+}
+
+// Redo applies again the last edit undone and tells if there was one.
+func (ti *TextInput) Redo() bool {
+	if ti.History == nil {
+		return false
+	}
+	s, ok := ti.History.Redo(textState{ti.Text, ti.Cursor})
+	if ok {
+		ti.Text, ti.Cursor = s.(textState).text, s.(textState).cursor
+	}
+	return ok
+}
+
+func (ti *TextInput) clampCursor(rs []rune) {
+	if ti.Cursor < 0 {
+		ti.Cursor = 0
+	}
+	if ti.Cursor > len(rs) {
+		ti.Cursor = len(rs)
+	}
+}
+
+// Insert inserts s at the cursor. Combining marks are composed with the
+// rune before the cursor when possible.
+func (ti *TextInput) Insert(s string) {
+	if s == "" {
+		return
+	}
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	ti.record("insert")
+	for _, r := range s {
+		if _, ok := combiningDead[r]; ok && ti.Cursor > 0 {
+			if cr, ok := Compose(r, rs[ti.Cursor-1]); ok {
+				rs[ti.Cursor-1] = cr
+				continue
+			}
+		}
+		rs = append(rs[:ti.Cursor], append([]rune{r}, rs[ti.Cursor:]...)...)
+		ti.Cursor++
+	}
+	ti.Text = string(rs)
+}
+
+// Type feeds a typed rune through dead key composition.
+func (ti *TextInput) Type(r rune) {
+	if rs := ti.feed(r); len(rs) > 0 {
+		ti.Insert(string(rs))
+	}
+}
+
+// Commit inserts the pending preedit text at the cursor.
+func (ti *TextInput) Commit() {
+	ti.Insert(string(ti.commit()))
+}
+
+// Backspace deletes the rune before the cursor, or cancels a pending
+// composition.
+func (ti *TextInput) Backspace() {
+	if len(ti.preedit) > 0 {
+		ti.commit()
+		return
+	}
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	if ti.Cursor == 0 {
+		return
+	}
+	ti.record("delete")
+	ti.Text = string(append(rs[:ti.Cursor-1], rs[ti.Cursor:]...))
+	ti.Cursor--
+}
+
+// Delete deletes the rune under the cursor.
+func (ti *TextInput) Delete() {
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	if ti.Cursor == len(rs) {
+		return
+	}
+	ti.record("delete")
+	ti.Text = string(append(rs[:ti.Cursor], rs[ti.Cursor+1:]...))
+}
+
+// MoveCursor moves the cursor by n runes.
+func (ti *TextInput) MoveCursor(n int) {
+	ti.Cursor += n
+	ti.clampCursor([]rune(ti.Text))
+	ti.moved()
+}
+
+// Home moves the cursor to the start of the text.
+func (ti *TextInput) Home() {
+	ti.Cursor = 0
+	ti.moved()
+}
+
+// End moves the cursor to the end of the text.
+func (ti *TextInput) End() {
+	ti.Cursor = utf8.RuneCountInString(ti.Text)
+	ti.moved()
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed. Besides
+// the arrows, <home>, <end>, <backspace> and <delete>, it handles the
+// readline keys: C-a, C-e, C-b and C-f move by rune, M-b and M-f by word,
+// C-k, C-u, C-w and M-d delete to the end, to the start, the word before
+// and the word after; C-p and C-n recall the lines of Recall like <up> and
+// <down>, C-r searches them. <tab> completes the word at the cursor.
+func (ti *TextInput) HandleKey(k EvtKbd) bool {
+	if ti.Mask != 0 && ti.RevealKey != "" && k.KeyStr == ti.RevealKey {
+		ti.Revealed = !ti.Revealed
+		return true
+	}
+	ti.Revealed = false
+	if ti.search != nil && ti.searchKey(k) {
+		return true
+	}
+	if ti.completion != nil {
+		if ti.completionKey(k) {
+			return true
+		}
+		defer ti.followCompletion(ti.Text)
+	}
+
+	switch k.KeyStr {
+	case UndoKey:
+		ti.Undo()
+	case RedoKey:
+		ti.Redo()
+	case "<left>", "C-b":
+		ti.MoveCursor(-1)
+	case "<right>", "C-f":
+		ti.MoveCursor(1)
+	case "M-b":
+		ti.WordLeft()
+	case "M-f":
+		ti.WordRight()
+	case "C-k":
+		ti.KillLine()
+	case "C-u":
+		ti.KillToStart()
+	case "C-w":
+		ti.KillWordBack()
+	case "M-d":
+		ti.KillWord()
+	case "<up>", "C-p":
+		return ti.HistoryPrev()
+	case "<down>", "C-n":
+		return ti.HistoryNext()
+	case "C-r":
+		return ti.StartSearch()
+	case "<tab>":
+		return ti.Complete()
+	case "<home>", "C-a":
+		ti.Home()
+	case "<end>", "C-e":
+		ti.End()
+	case "<backspace>", "C-8", "C-h":
+		ti.Backspace()
+	case "<delete>", "C-d":
+		ti.Delete()
+	case "<space>":
+		ti.Type(' ')
+	case "<enter>":
+		ti.Commit()
+		ti.submit()
+	default:
+		if utf8.RuneCountInString(k.KeyStr) != 1 {
+			return false
+		}
+		r, _ := utf8.DecodeRuneInString(k.KeyStr)
+		ti.Type(r)
+	}
+	CursorBlinker.Reset()
+	return true
+}
+
+// cells returns the cells to display and the index of the cursor cell.
+func (ti *TextInput) cells() ([]Cell, int) {
+	fg, bg := ti.TextFgColor, ti.TextBgColor
+	rs := []rune(ti.Text)
+	ti.clampCursor(rs)
+	if ti.Mask != 0 && !ti.Revealed {
+		for i := range rs {
+			rs[i] = ti.Mask
+		}
+	}
+
+	if ti.search != nil {
+		cs := TextCells(ti.searchPrompt(), ThemeAttr("textinput.placeholder.fg"), bg)
+		cur := len(cs) + ti.Cursor
+		cs = append(cs, TextCells(string(rs), fg, bg)...)
+		if cur == len(cs) {
+			cs = append(cs, Cell{Ch: ' ', Fg: fg, Bg: bg})
+		}
+		return cs, cur
+	}
+	if len(rs) == 0 && len(ti.preedit) == 0 && ti.Placeholder != "" {
+		cs := TextCells(ti.Placeholder, ThemeAttr("textinput.placeholder.fg"), bg)
+		return cs, 0
+	}
+
+	cs := TextCells(string(rs[:ti.Cursor]), fg, bg)
+	cs = append(cs, TextCells(string(ti.preedit), fg|AttrUnderline, bg)...)
+	cur := len(cs)
+	cs = append(cs, TextCells(string(rs[ti.Cursor:]), fg, bg)...)
+	if cur == len(cs) {
+		cs = append(cs, Cell{Ch: ' ', Fg: fg, Bg: bg})
+	}
+	return cs, cur
+}
+
+// Buffer implements Bufferer interface.
+func (ti *TextInput) Buffer() Buffer {
+	buf := ti.Block.Buffer()
+	w := ti.innerArea.Dx()
+	if w <= 0 || ti.innerArea.Dy() <= 0 {
+		return buf
+	}
+
+	cs, cur := ti.cells()
+	if ti.ShowCursor && !ti.HardwareCursor {
+		cs[cur] = SoftCursorCell(cs[cur], ti.CursorStyle)
+	}
+
+	// scroll horizontally to keep the cursor visible
+	if cur < ti.offset {
+		ti.offset = cur
+	}
+	for ti.offset < cur && cellsWidth(cs[ti.offset:cur+1]) > w {
+		ti.offset++
+	}
+	if ti.offset > len(cs) {
+		ti.offset = 0
+	}
+
+	x := ti.innerArea.Min.X
+	for i, c := range cs[ti.offset:] {
+		if x+c.Width() > ti.innerArea.Max.X {
+			break
+		}
+		if ti.ShowCursor && ti.HardwareCursor && ti.offset+i == cur {
+			PlaceCursor(x, ti.innerArea.Min.Y, ti.CursorStyle)
+		}
+		buf.Set(x, ti.innerArea.Min.Y, c)
+		x += c.Width()
+	}
+
+	if ti.ShowStrength && ti.innerArea.Dy() > 1 {
+		ti.drawStrength(buf, ti.innerArea.Min.Y+1)
+	}
+	return buf
+}
+
+var strengthColors = []Attribute{ColorRed, ColorRed, ColorYellow, ColorGreen, ColorGreen}
+
+// drawStrength draws a meter of PasswordStrength(Text) with its label on row y.
+func (ti *TextInput) drawStrength(buf Buffer, y int) {
+	n := PasswordStrength(ti.Text)
+	label := Msg([]string{"strength.weak", "strength.weak", "strength.fair", "strength.good", "strength.strong"}[n])
+	lcs := TextCells(" "+label, strengthColors[n], ti.Bg)
+
+	barW := ti.innerArea.Dx() - cellsWidth(lcs)
+	if barW < 0 {
+		barW = 0
+	}
+	fill := barW * n / 4
+	x := ti.innerArea.Min.X
+	for i := 0; i < barW; i++ {
+		c := Cell{Ch: '─', Fg: ThemeAttr("textinput.strength.empty.fg"), Bg: ti.Bg}
+		if i < fill {
+			c = Cell{Ch: '━', Fg: strengthColors[n], Bg: ti.Bg}
+		}
+		buf.Set(x, y, c)
+		x++
+	}
+	for _, c := range lcs {
+		if x+c.Width() > ti.innerArea.Max.X {
+			break
+		}
+		buf.Set(x, y, c)
+		x += c.Width()
+	}
+}
+
+// PasswordStrength rates s from 0 (very weak) to 4 (strong) by its length
+// and the variety of character classes it uses.
+func PasswordStrength(s string) int {
+	var lower, upper, digit, other bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+	for _, b := range []bool{lower, upper, digit, other} {
+		if b {
+			classes++
+		}
+	}
+
+	n := utf8.RuneCountInString(s)
+	score := 0
+	switch {
+	case n >= 16:
+		score = 3
+	case n >= 12:
+		score = 2
+	case n >= 8:
+		score = 1
+	}
+	if classes >= 3 {
+		score++
+	}
+	if classes == 1 && score > 1 {
+		score--
+	}
+	if score > 4 {
+		score = 4
+	}
+	return score
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestTextInputEditing(t *testing.T) {
+	ti := NewTextInput()
+	for _, k := range []string{"h", "l", "<left>", "e", "l", "<end>", "o", "<home>", "<delete>", "H"} {
+		ti.HandleKey(EvtKbd{KeyStr: k})
+	}
+	if ti.Text != "Hello" || ti.Cursor != 1 {
+		t.Errorf("unexpected text %q at %d", ti.Text, ti.Cursor)
+	}
+}
+
+func TestTextInputComposition(t *testing.T) {
+	ti := NewTextInput()
+	for _, r := range "caf´e" {
+		ti.Type(r)
+	}
+	if ti.Text != "caf´e" {
+		t.Errorf("dead keys composed by default: %q", ti.Text)
+	}
+
+	ti.Text, ti.Cursor = "", 0
+	ti.DeadKeys = true
+	for _, r := range "caf´e ¨x cd ~alice 2^o" {
+		ti.Type(r)
+	}
+	if ti.Text != "café ¨x cd ~alice 2^o" {
+		t.Errorf("dead keys not composed: %q", ti.Text)
+	}
+
+	ti.Text, ti.Cursor = "", 0
+	ti.Insert("niño")
+	if ti.Text != "niño" {
+		t.Errorf("combining mark not composed: %q", ti.Text)
+	}
+
+	ti.SetPreedit("日本")
+	if ti.Text != "niño" || ti.Preedit() != "日本" {
+		t.Error("preedit should not be committed yet")
+	}
+	ti.Commit()
+	if ti.Text != "niño日本" || ti.Preedit() != "" {
+		t.Errorf("preedit not committed: %q", ti.Text)
+	}
+}
+
+func TestTextInputMask(t *testing.T) {
+	ti := NewTextInput()
+	ti.Mask = '*'
+	ti.Insert("pw")
+
+	cs, _ := ti.cells()
+	if CellsToStr(cs) != "** " {
+		t.Errorf("text should be masked, got %q", CellsToStr(cs))
+	}
+
+	ti.HandleKey(EvtKbd{KeyStr: "C-r"})
+	cs, _ = ti.cells()
+	if CellsToStr(cs) != "pw " {
+		t.Errorf("text should be revealed, got %q", CellsToStr(cs))
+	}
+
+	ti.HandleKey(EvtKbd{KeyStr: "x"})
+	if ti.Revealed {
+		t.Error("any other key should hide the text again")
+	}
+}
+
+func TestPasswordStrength(t *testing.T) {
+	for s, n := range map[string]int{
+		"":                  0,
+		"abc":               0,
+		"abcdefgh":          1,
+		"abcdefghijklmnop":  2,
+		"Abcdef1!":          2,
+		"Correct-Horse-42x": 4,
+	} {
+		if got := PasswordStrength(s); got != n {
+			t.Errorf("strength of %q: expected %d, got %d", s, n, got)
+		}
+	}
+}
@@ -96,6 +96,34 @@ var ColorMap = map[string]Attribute{
 	"label.fg":     ColorGreen,
 	"par.fg":       ColorYellow,
 	"par.label.bg": ColorWhite,
+	"par.link.fg":  ColorCyan | AttrUnderline,
+
+	"state.error.fg":   ColorRed,
+	"state.loading.fg": ColorYellow,
+	"skeleton.fg":      ColorWhite,
+	"alert.border.fg":  ColorRed | AttrBold,
+	"alert.label.fg":   ColorRed | AttrBold,
+	"backdrop.fg":      ColorBlack | AttrBold,
+	"backdrop.bg":      ColorDefault,
+
+	"linechart.snapshot.fg": ColorBlack | AttrBold,
+
+	"timerange.selected.fg":  ColorCyan,
+	"detailview.key.fg":      ColorCyan,
+	"heatmap.value.fg":       ColorBlack,
+	"completion.desc.fg":     ColorCyan,
+	"completion.selected.bg": ColorBlue,
+	"console.prompt.fg":      ColorGreen,
+	"list.selected.bg":       ColorBlue,
+	"tree.guide.fg":          ColorBlack | AttrBold,
+	"tree.selected.bg":       ColorBlue,
+
+	"level.trace.fg": ColorBlack | AttrBold,
+	"level.debug.fg": ColorBlue,
+	"level.info.fg":  ColorGreen,
+	"level.warn.fg":  ColorYellow,
+	"level.error.fg": ColorRed,
+	"level.fatal.fg": ColorRed | AttrBold | AttrReverse,
 }
 
 func ThemeAttr(name string) Attribute {
@@ -139,6 +167,19 @@ func ColorRGB(r, g, b int) Attribute {
 	return Attribute(0x0f + 36*r + 6*g + b)
 }
 
+// ColorGrayscale returns one of the 24 grayscale shades of a 256 color
+// terminal, 0 <= level <= 23 from dark to light. Useful for subtle chart
+// background bands.
+func ColorGrayscale(level int) Attribute {
+	if level < 0 {
+		level = 0
+	}
+	if level > 23 {
+		level = 23
+	}
+	return Attribute(0xe9 + level)
+}
+
 // Convert from familiar 24 bit colors into 6 bit terminal colors
 func ColorRGB24(r, g, b int) Attribute {
 	return ColorRGB(r/51, g/51, b/51)
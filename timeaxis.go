@@ -0,0 +1,129 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sort"
+	"time"
+)
+
+// timeSteps are the spacings tried for time ticks, from the finest.
+var timeSteps = []time.Duration{
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second,
+	15 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute,
+	15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour,
+	24 * time.Hour, 2 * 24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour,
+}
+
+// timeLabel is a time tick label of the x axis, starting at column x.
+type timeLabel struct {
+	x int
+	s []rune
+}
+
+// timeFormat returns the layout of labels for ticks step apart.
+func timeFormat(step time.Duration) string {
+	switch {
+	case step < time.Minute:
+		return "15:04:05"
+	case step < 24*time.Hour:
+		return "15:04"
+	}
+	return "Jan 2"
+}
+
+// firstTick returns the first multiple of step at or after t, counting days
+// in the location of t.
+func firstTick(t time.Time, step time.Duration) time.Time {
+	if step < 24*time.Hour {
+		f := t.Truncate(step)
+		if f.Before(t) {
+			f = f.Add(step)
+		}
+		return f
+	}
+	f := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if f.Before(t) {
+		f = f.AddDate(0, 0, 1)
+	}
+	return f
+}
+
+// calcTimeLabels places time ticks evenly over the visible part of Times,
+// as many as the axis width allows, each label centered on the column of
+// the first point at or after its time.
+func (lc *LineChart) calcTimeLabels() {
+	lc.timeLabels = nil
+	n := len(lc.Times)
+	perCell := lc.pointsPerCell()
+	origX := lc.innerArea.Min.X + lc.labelYSpace
+	lastX := lc.innerArea.Max.X - 1
+	cols := lastX - origX
+	if n == 0 || cols <= 0 {
+		return
+	}
+	lo := n - cols*perCell
+	if lo < 0 {
+		lo = 0
+	}
+	t0, t1 := lc.Times[lo], lc.Times[n-1]
+	span := t1.Sub(t0)
+	if span <= 0 {
+		return
+	}
+
+	step := timeSteps[len(timeSteps)-1]
+	for _, s := range timeSteps {
+		if int(span/s)+1 <= lc.labelCount("x", cols, strWidth(timeFormat(s))) {
+			step = s
+			break
+		}
+	}
+	layout := timeFormat(step)
+
+	end := origX
+	for t := firstTick(t0, step); !t.After(t1); {
+		i := lo + sort.Search(n-lo, func(i int) bool { return !lc.Times[lo+i].Before(t) })
+		s := str2runes(t.Format(layout))
+		x := lastX - (n-1-i)/perCell - len(s)/2
+		if x > lastX-len(s)+1 {
+			x = lastX - len(s) + 1
+		}
+		if x >= end && x > origX {
+			lc.timeLabels = append(lc.timeLabels, timeLabel{x: x, s: s})
+			end = x + len(s) + lc.axisXLabelGap
+		}
+		if step < 24*time.Hour {
+			t = t.Add(step)
+		} else {
+			t = t.AddDate(0, 0, int(step/(24*time.Hour)))
+		}
+	}
+}
+
+// windowed returns the series and Times of lc cut to Window at the time now,
+// the last point of every series being at the last of Times.
+func (lc *LineChart) windowed(now time.Time) (map[string][]float64, []time.Time) {
+	n := len(lc.Times)
+	from, to := lc.Window.Bounds(now)
+	lo, hi := 0, n
+	if !from.IsZero() {
+		lo = sort.Search(n, func(i int) bool { return !lc.Times[i].Before(from) })
+	}
+	if !to.IsZero() {
+		hi = sort.Search(n, func(i int) bool { return lc.Times[i].After(to) })
+	}
+	if hi < lo {
+		hi = lo
+	}
+	data := make(map[string][]float64, len(lc.Data))
+	for name, d := range lc.Data {
+		off := n - len(d)
+		data[name] = d[clamp(lo-off, 0, len(d)):clamp(hi-off, 0, len(d))]
+	}
+	return data, lc.Times[lo:hi]
+}
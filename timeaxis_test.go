@@ -0,0 +1,51 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLineChartTimeLabels(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 42
+	lc.Height = 10
+	start := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 160; i++ {
+		lc.Data["cpu"] = append(lc.Data["cpu"], float64(i%7))
+		lc.Times = append(lc.Times, start.Add(time.Duration(i)*time.Minute))
+	}
+	lc.Buffer()
+	if len(lc.timeLabels) < 2 {
+		t.Fatalf("got %d time labels, want several", len(lc.timeLabels))
+	}
+	end := 0
+	for _, l := range lc.timeLabels {
+		if len(l.s) != len("15:04") || l.s[2] != ':' {
+			t.Errorf("label %q is not a time of day", string(l.s))
+		}
+		if l.x < end || l.x+len(l.s) > lc.innerArea.Max.X {
+			t.Errorf("label %q at %d overlaps or overflows", string(l.s), l.x)
+		}
+		end = l.x + len(l.s)
+	}
+
+	// wider charts get denser ticks
+	n := len(lc.timeLabels)
+	lc.Width = 120
+	lc.Buffer()
+	if len(lc.timeLabels) <= n {
+		t.Errorf("got %d labels on a wider chart, want more than %d", len(lc.timeLabels), n)
+	}
+
+	for i := range lc.Times {
+		lc.Times[i] = start.Add(time.Duration(i) * 12 * time.Hour)
+	}
+	lc.Buffer()
+	if len(lc.timeLabels) == 0 || string(lc.timeLabels[0].s[:3]) != "Mar" {
+		t.Errorf("multi-day span labels: %v", lc.timeLabels)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeWindow is a span of time shown by widgets: the Last duration up to
+// now, or from From to To when Last is 0. The zero TimeWindow shows all.
+type TimeWindow struct {
+	Last     time.Duration
+	From, To time.Time
+}
+
+// IsZero tells if w shows all times.
+func (w TimeWindow) IsZero() bool {
+	return w.Last == 0 && w.From.IsZero() && w.To.IsZero()
+}
+
+// Bounds returns the start and end of w at the time now.
+func (w TimeWindow) Bounds(now time.Time) (from, to time.Time) {
+	if w.Last != 0 {
+		return now.Add(-w.Last), now
+	}
+	return w.From, w.To
+}
+
+// Contains tells if t is in w at the time now. A zero From or To leaves
+// the window open on that side.
+func (w TimeWindow) Contains(t, now time.Time) bool {
+	if w.IsZero() {
+		return true
+	}
+	from, to := w.Bounds(now)
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// String returns the window as shown by TimeRange, e.g. "1h" or
+// "10:00-11:30".
+func (w TimeWindow) String() string {
+	switch {
+	case w.IsZero():
+		return "all"
+	case w.Last != 0:
+		return shortDuration(w.Last)
+	}
+	layout := "15:04"
+	if w.To.Sub(w.From) >= 24*time.Hour {
+		layout = "Jan 2 15:04"
+	}
+	return w.From.Format(layout) + "-" + w.To.Format(layout)
+}
+
+// shortDuration formats d in its largest whole unit: "90s", "5m", "24h".
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// TimeRange lets the user pick the time window of a dashboard among
+// Presets, or a custom one given by SetRange, and tells its subscribers so
+// that log lines and charts show the same span of time.
+/*
+  tr := termui.NewTimeRange()
+  tr.Subscribe(func(w termui.TimeWindow) {
+      lv.Window = w
+      lc.Window = w
+  })
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      if tr.HandleKey(e.Data.(termui.EvtKbd)) {
+          termui.Render(termui.Body)
+      }
+  })
+*/
+type TimeRange struct {
+	Block
+	Presets       []time.Duration
+	TextFgColor   Attribute
+	TextBgColor   Attribute
+	SelectedColor Attribute
+
+	cur    int // index in Presets, len(Presets) for the custom window
+	custom TimeWindow
+	subs   []func(TimeWindow)
+}
+
+// NewTimeRange returns a new *TimeRange with current theme, showing the
+// last hour.
+func NewTimeRange() *TimeRange {
+	tr := &TimeRange{Block: *NewBlock()}
+	tr.Presets = []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+	tr.cur = 2
+	tr.TextFgColor = ThemeAttr("timerange.text.fg")
+	tr.TextBgColor = ThemeAttr("timerange.text.bg")
+	tr.SelectedColor = ThemeAttr("timerange.selected.fg") | AttrReverse
+	tr.Height = 3
+	return tr
+}
+
+// Window returns the selected window.
+func (tr *TimeRange) Window() TimeWindow {
+	if tr.cur < len(tr.Presets) {
+		return TimeWindow{Last: tr.Presets[tr.cur]}
+	}
+	return tr.custom
+}
+
+// Subscribe calls f with the selected window now and whenever it changes.
+func (tr *TimeRange) Subscribe(f func(TimeWindow)) {
+	tr.subs = append(tr.subs, f)
+	f(tr.Window())
+}
+
+func (tr *TimeRange) changed() {
+	w := tr.Window()
+	for _, f := range tr.subs {
+		f(w)
+	}
+}
+
+// SetLast selects the window of the last d, adding d to Presets if needed.
+func (tr *TimeRange) SetLast(d time.Duration) {
+	i := 0
+	for i < len(tr.Presets) && tr.Presets[i] != d {
+		i++
+	}
+	if i == len(tr.Presets) {
+		tr.Presets = append(tr.Presets, d)
+	}
+	tr.cur = i
+	tr.changed()
+}
+
+// SetRange selects the custom window from from to to.
+func (tr *TimeRange) SetRange(from, to time.Time) {
+	tr.custom = TimeWindow{From: from, To: to}
+	tr.cur = len(tr.Presets)
+	tr.changed()
+}
+
+// entries returns the number of windows to pick from.
+func (tr *TimeRange) entries() int {
+	if tr.custom.IsZero() {
+		return len(tr.Presets)
+	}
+	return len(tr.Presets) + 1
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed. <left>
+// and <right> pick the previous and next window, 1 to 9 the presets.
+func (tr *TimeRange) HandleKey(k EvtKbd) bool {
+	n := tr.entries()
+	if n == 0 {
+		return false
+	}
+	switch k.KeyStr {
+	case "<left>":
+		tr.cur = (tr.cur + n - 1) % n
+	case "<right>":
+		tr.cur = (tr.cur + 1) % n
+	default:
+		i, err := strconv.Atoi(k.KeyStr)
+		if err != nil || i < 1 || i > len(tr.Presets) {
+			return false
+		}
+		tr.cur = i - 1
+	}
+	tr.changed()
+	return true
+}
+
+// Buffer implements Bufferer interface.
+func (tr *TimeRange) Buffer() Buffer {
+	buf := tr.Block.Buffer()
+
+	var cs []Cell
+	for i := 0; i < tr.entries(); i++ {
+		s := ""
+		if i < len(tr.Presets) {
+			s = shortDuration(tr.Presets[i])
+		} else {
+			s = tr.custom.String()
+		}
+		fg := tr.TextFgColor
+		if i == tr.cur {
+			fg = tr.SelectedColor
+		}
+		cs = append(cs, TextCells(" "+s+" ", fg, tr.TextBgColor)...)
+		cs = append(cs, Cell{Ch: ' ', Fg: tr.TextFgColor, Bg: tr.TextBgColor})
+	}
+
+	x := tr.innerArea.Min.X
+	for _, c := range TruncateRight(cs, tr.innerArea.Dx()) {
+		buf.Set(x, tr.innerArea.Min.Y, c)
+		x += c.Width()
+	}
+	return buf
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeRange(t *testing.T) {
+	tr := NewTimeRange()
+	var got []TimeWindow
+	tr.Subscribe(func(w TimeWindow) { got = append(got, w) })
+	if len(got) != 1 || got[0].Last != time.Hour {
+		t.Fatalf("subscribers should get the current window, got %v", got)
+	}
+	tr.HandleKey(EvtKbd{KeyStr: "<left>"})
+	tr.HandleKey(EvtKbd{KeyStr: "5"})
+	if len(got) != 3 || got[1].Last != 15*time.Minute || got[2].Last != 24*time.Hour {
+		t.Errorf("got %v", got)
+	}
+
+	from := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	tr.SetRange(from, from.Add(90*time.Minute))
+	if w := tr.Window(); w.String() != "10:00-11:30" || !w.Contains(from.Add(time.Hour), time.Now()) {
+		t.Errorf("custom window %v", w)
+	}
+	tr.HandleKey(EvtKbd{KeyStr: "<right>"})
+	if w := tr.Window(); w.Last != 5*time.Minute {
+		t.Errorf("<right> after the custom window should wrap, got %v", w)
+	}
+}
+
+func TestTimeWindowFilters(t *testing.T) {
+	now := time.Now()
+	w := TimeWindow{Last: 5 * time.Minute}
+
+	lv := newTestLogViewer()
+	lv.Parser = ParseLog
+	lv.Window = w
+	lv.Append(
+		"ts="+now.Add(-time.Hour).Format(time.RFC3339)+" msg=old",
+		"ts="+now.Add(-time.Minute).Format(time.RFC3339)+" msg=new",
+	)
+	if n, _ := lv.Matches(); n != 1 {
+		t.Errorf("got %d log lines in the last 5m, want 1", n)
+	}
+
+	lc := NewLineChart()
+	lc.Window = w
+	for i := 10; i > 0; i-- {
+		lc.Times = append(lc.Times, now.Add(-time.Duration(i)*time.Minute))
+		lc.Data["a"] = append(lc.Data["a"], float64(i))
+	}
+	lc.Data["b"] = []float64{1, 2}
+	data, times := lc.windowed(now)
+	if len(times) != 5 || len(data["a"]) != 5 || data["a"][0] != 5 || len(data["b"]) != 2 {
+		t.Errorf("got times %d, series %v", len(times), data)
+	}
+}
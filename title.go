@@ -0,0 +1,106 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"os"
+	"time"
+)
+
+// TitleSegment is a styled piece of a Block's top border. Its text is either
+// the static Text or, when set, the result of Provider evaluated every time
+// the block is drawn. Markdown color syntax is supported in both.
+/*
+  b.TitleSegments = []termui.TitleSegment{
+      termui.ClockSegment("15:04:05", termui.AlignRight),
+      termui.HostnameSegment(termui.AlignCenter),
+      {Text: "[3 alerts](fg-red)", Align: termui.AlignLeft},
+  }
+*/
+type TitleSegment struct {
+	Text     string
+	Provider func() string
+	Fg       Attribute
+	Bg       Attribute
+	Align    Align // AlignLeft (default), AlignCenter or AlignRight
+}
+
+func (ts TitleSegment) text() string {
+	if ts.Provider != nil {
+		return ts.Provider()
+	}
+	return ts.Text
+}
+
+// ClockSegment returns a segment showing the current time in layout.
+func ClockSegment(layout string, a Align) TitleSegment {
+	return TitleSegment{
+		Provider: func() string { return time.Now().Format(layout) },
+		Fg:       ThemeAttr("label.fg"),
+		Bg:       ThemeAttr("label.bg"),
+		Align:    a,
+	}
+}
+
+// HostnameSegment returns a segment showing the machine's hostname.
+func HostnameSegment(a Align) TitleSegment {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "?"
+	}
+	return TitleSegment{
+		Text:  host,
+		Fg:    ThemeAttr("label.fg"),
+		Bg:    ThemeAttr("label.bg"),
+		Align: a,
+	}
+}
+
+// buildSegments builds the cells of all segments with alignment a,
+// separated by a space.
+func (b Block) buildSegments(a Align) []Cell {
+	cs := []Cell{}
+	for _, ts := range b.TitleSegments {
+		if ts.Align&(AlignCenterHorizontal|AlignRight) != a&(AlignCenterHorizontal|AlignRight) {
+			continue
+		}
+		if len(cs) > 0 {
+			cs = append(cs, Cell{Ch: ' ', Fg: ts.Fg, Bg: ts.Bg})
+		}
+		cs = append(cs, DefaultTxBuilder.Build(ts.text(), ts.Fg, ts.Bg)...)
+	}
+	return cs
+}
+
+// drawTitleSegments draws left segments after the border label, center
+// segments in the middle and right segments at the end of the top border.
+// Segments which no longer fit are trimmed.
+func (b Block) drawTitleSegments(buf Buffer, labelW int) {
+	if len(b.TitleSegments) == 0 || !b.Border || !b.BorderTop {
+		return
+	}
+	min, max := b.area.Min.X+1, b.area.Max.X-1
+	set := func(x int, cs []Cell) {
+		for _, c := range cs {
+			if x >= min && x+c.Width() <= max {
+				buf.Set(x, b.area.Min.Y, c)
+			}
+			x += c.Width()
+		}
+	}
+
+	left := b.buildSegments(AlignLeft)
+	lx := min + labelW
+	if labelW > 0 && len(left) > 0 {
+		lx++
+	}
+	set(lx, TruncateRight(left, max-lx))
+
+	right := b.buildSegments(AlignRight)
+	set(max-cellsWidth(right), right)
+
+	center := b.buildSegments(AlignCenter)
+	set(b.area.Min.X+(b.area.Dx()-cellsWidth(center))/2, center)
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracer receives spans for event handling ("termui.event"), layout
+// ("termui.layout"), frames ("termui.frame") with their buffer building
+// ("termui.buffer") and flush ("termui.flush"), and counters such as
+// "termui.events.dropped" for events coalesced before being handled.
+// termui does not depend on a tracing library: an adapter maps the calls
+// onto one, e.g. OpenTelemetry.
+/*
+  type otelTracer struct {
+      tracer  trace.Tracer
+      dropped metric.Int64Counter
+  }
+
+  func (o otelTracer) StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, func()) {
+      ctx, span := o.tracer.Start(ctx, name)
+      for k, v := range attrs {
+          span.SetAttributes(attribute.String(k, v))
+      }
+      return ctx, func() { span.End() }
+  }
+
+  func (o otelTracer) Count(name string, n int64) {
+      o.dropped.Add(context.Background(), n, metric.WithAttributes(attribute.String("name", name)))
+  }
+
+  termui.SetTracer(otelTracer{tracer: otel.Tracer("termui"), dropped: counter})
+*/
+type Tracer interface {
+	// StartSpan starts a span child of the one in ctx, if any, and returns
+	// the context of the new span and the function ending it.
+	StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, func())
+	// Count adds n to the counter name.
+	Count(name string, n int64)
+}
+
+var tracer struct {
+	sync.RWMutex
+	t Tracer
+}
+
+// SetTracer instruments termui with t; nil, the default, disables tracing.
+func SetTracer(t Tracer) {
+	tracer.Lock()
+	tracer.t = t
+	tracer.Unlock()
+}
+
+func currentTracer() Tracer {
+	tracer.RLock()
+	defer tracer.RUnlock()
+	return tracer.t
+}
+
+func endNothing() {}
+
+// span starts a span if a Tracer is set. attrs are key and value pairs.
+func span(ctx context.Context, name string, attrs ...string) (context.Context, func()) {
+	t := currentTracer()
+	if t == nil {
+		return ctx, endNothing
+	}
+	var m map[string]string
+	if len(attrs) > 1 {
+		m = make(map[string]string, len(attrs)/2)
+		for i := 0; i+1 < len(attrs); i += 2 {
+			m[attrs[i]] = attrs[i+1]
+		}
+	}
+	return t.StartSpan(ctx, name, m)
+}
+
+// count adds n to a counter if a Tracer is set.
+func count(name string, n int64) {
+	if t := currentTracer(); t != nil {
+		t.Count(name, n)
+	}
+}
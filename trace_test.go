@@ -0,0 +1,63 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type spanKey struct{}
+
+type testTracer struct {
+	sync.Mutex
+	spans  []string // name < parent name
+	counts map[string]int64
+}
+
+func (tt *testTracer) StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, func()) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	tt.Lock()
+	tt.spans = append(tt.spans, name+"<"+parent+attrs["widget"])
+	tt.Unlock()
+	return context.WithValue(ctx, spanKey{}, name), func() {}
+}
+
+func (tt *testTracer) Count(name string, n int64) {
+	tt.Lock()
+	tt.counts[name] += n
+	tt.Unlock()
+}
+
+func TestTracer(t *testing.T) {
+	tt := &testTracer{counts: make(map[string]int64)}
+	SetTracer(tt)
+	defer SetTracer(nil)
+
+	old := screen
+	screen = NewHeadless(10, 3)
+	defer func() { screen = old }()
+
+	render(NewPar("x"))
+	want := []string{"termui.frame<", "termui.buffer<termui.frame*termui.Par", "termui.flush<termui.frame"}
+	if len(tt.spans) != len(want) {
+		t.Fatalf("unexpected spans %q", tt.spans)
+	}
+	for i := range want {
+		if tt.spans[i] != want[i] {
+			t.Errorf("expected span %q, got %q", want[i], tt.spans[i])
+		}
+	}
+
+	es := NewEvtStream()
+	es.Pause()
+	tick := Event{Type: "timer", Path: "/timer/1s", From: "timer"}
+	es.hold(tick)
+	es.hold(tick)
+	if tt.counts["termui.events.dropped"] != 1 {
+		t.Errorf("the coalesced tick should be counted, got %v", tt.counts)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Container is a Bufferer made of others. Render draws its children in its
+// place, depth first, so that a whole widget tree can be passed as its
+// root: overlays, raw renderers, z-order and dirty tracking then work for
+// every widget of the tree as if it had been passed to Render itself.
+// A container without children, e.g. a collapsed one, is drawn by its
+// Buffer.
+/*
+  root := termui.NewGroup(
+      termui.Body,           // Grid of panes, laid out every frame
+      termui.NewGroup(help), // hidden until SetDisplay(true)
+      modal,                 // over the rest, with its backdrop
+  )
+  termui.Render(root)
+*/
+type Container interface {
+	Bufferer
+	Children() []Bufferer
+}
+
+// Layouter is a Container placing its children, which Render calls before
+// drawing them.
+type Layouter interface {
+	Layout()
+}
+
+// ZIndexer is a Bufferer drawn over the ones with a lower ZIndex, whatever
+// their order in the tree. Others have a ZIndex of 0.
+type ZIndexer interface {
+	ZIndex() int
+}
+
+// Dirtier is a Bufferer telling whether it changed since it was last
+// drawn; Render reuses its previous buffer while it did not.
+type Dirtier interface {
+	Dirty() bool
+}
+
+// flatten returns the leaves of the trees bs in drawing order: depth first,
+// then by ZIndex.
+func flatten(bs []Bufferer) []Bufferer {
+	var leaves []Bufferer
+	var walk func(b Bufferer)
+	walk = func(b Bufferer) {
+		c, ok := b.(Container)
+		if !ok {
+			leaves = append(leaves, b)
+			return
+		}
+		if l, ok := b.(Layouter); ok {
+			l.Layout()
+		}
+		cs := c.Children()
+		if cs == nil {
+			leaves = append(leaves, b)
+			return
+		}
+		for _, b := range cs {
+			walk(b)
+		}
+	}
+	for _, b := range bs {
+		walk(b)
+	}
+	sort.SliceStable(leaves, func(i, j int) bool {
+		return zIndex(leaves[i]) < zIndex(leaves[j])
+	})
+	return leaves
+}
+
+func zIndex(b Bufferer) int {
+	if z, ok := b.(ZIndexer); ok {
+		return z.ZIndex()
+	}
+	return 0
+}
+
+// cachedBuf is the buffer of a Dirtier with the hit regions and cursor it
+// registered.
+type cachedBuf struct {
+	buf    Buffer
+	hits   []hitRegion
+	cursor *cursorPlace
+}
+
+// bufCache keeps the buffers of the Dirtiers of the last frame.
+type bufCache map[Bufferer]cachedBuf
+
+// buffer returns the buffer of b, reused from the cache c if b is a clean
+// Dirtier, and records it in next.
+func (c bufCache) buffer(b Bufferer, next bufCache) Buffer {
+	d, ok := b.(Dirtier)
+	if !ok || !reflect.TypeOf(b).Comparable() {
+		return b.Buffer()
+	}
+	cb, cached := c[b]
+	if cached && !d.Dirty() {
+		addHits(cb.hits)
+		if cb.cursor != nil {
+			restoreCursor(cb.cursor)
+		}
+	} else {
+		n, cur := hitCount(), cursorRequest()
+		cb.buf = b.Buffer()
+		cb.hits = hitsSince(n)
+		cb.cursor = nil
+		if c := cursorRequest(); c != cur {
+			cb.cursor = c
+		}
+	}
+	next[b] = cb
+	return cb.buf
+}
+
+// Children implements Container, returning the displayed widgets.
+func (g *Group) Children() []Bufferer {
+	ws := []Bufferer{}
+	for _, w := range g.Widgets {
+		if b, ok := w.(interface {
+			GetBlock() *Block
+		}); ok && !b.GetBlock().Display {
+			continue
+		}
+		ws = append(ws, w)
+	}
+	return ws
+}
+
+// Children implements Container, returning the rows. A grid with
+// SharedBorders has none: it is drawn as a whole, for its Buffer to join
+// the borders of its widgets.
+func (g *Grid) Children() []Bufferer {
+	if g.SharedBorders {
+		return nil
+	}
+	rs := make([]Bufferer, len(g.Rows))
+	for i, r := range g.Rows {
+		rs[i] = r
+	}
+	return rs
+}
+
+// Layout implements Layouter, aligning the rows of a grid given a width.
+func (g *Grid) Layout() {
+	if g.Width > 0 {
+		g.Align()
+	}
+}
+
+// Children implements Container, returning the widget of r and its
+// columns. Collapsed widgets have none, their Buffer clips them.
+func (r *Row) Children() []Bufferer {
+	if r.isRenderableLeaf() {
+		if c, ok := r.Widget.(interface {
+			IsCollapsed() bool
+		}); ok && c.IsCollapsed() {
+			return nil
+		}
+		return []Bufferer{r.Widget}
+	}
+	ws := []Bufferer{}
+	if r.Widget != nil {
+		ws = append(ws, r.Widget)
+	}
+	if !r.isLeaf() {
+		for _, c := range r.Cols {
+			ws = append(ws, c)
+		}
+	}
+	return ws
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+type zPar struct {
+	*Par
+	z int
+}
+
+func (p zPar) ZIndex() int { return p.z }
+
+type dirtyPar struct {
+	*Par
+	dirty bool
+	calls int
+}
+
+func (p *dirtyPar) Dirty() bool { return p.dirty }
+
+func (p *dirtyPar) Buffer() Buffer {
+	p.calls++
+	return p.Par.Buffer()
+}
+
+func TestFlatten(t *testing.T) {
+	a, b, c, d := NewPar("a"), NewPar("b"), NewPar("c"), NewPar("d")
+	hidden := NewPar("hidden")
+	hidden.Display = false
+	top := zPar{d, 1}
+
+	g := NewGrid(NewRow(NewCol(6, 0, a), NewCol(6, 0, b)))
+	g.Width = 40
+	root := NewGroup(top, g, NewGroup(c, hidden))
+
+	got := flatten([]Bufferer{root})
+	want := []Bufferer{a, b, c, top}
+	if len(got) != len(want) {
+		t.Fatalf("%d leaves, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("leaf %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+	if b.X != 20 || b.Width != 20 {
+		t.Errorf("the grid was not laid out: b at %d, %d wide", b.X, b.Width)
+	}
+}
+
+func TestBufCache(t *testing.T) {
+	p := &dirtyPar{Par: NewPar("x")}
+	last := bufCache{}
+	for i := 0; i < 3; i++ {
+		next := bufCache{}
+		last.buffer(p, next)
+		last = next
+	}
+	if p.calls != 1 {
+		t.Errorf("Buffer called %d times while clean, want 1", p.calls)
+	}
+	p.dirty = true
+	last.buffer(p, bufCache{})
+	if p.calls != 2 {
+		t.Errorf("Buffer called %d times once dirty, want 2", p.calls)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+// TreeNode is a node of a Tree: its Text, with color markup, and the nodes
+// below it, shown while it is Expanded. Value is left to the app.
+type TreeNode struct {
+	Text     string
+	Children []*TreeNode
+	Expanded bool
+	Value    interface{}
+}
+
+// treeRow is a node of a Tree as shown: its node and the guides before it.
+type treeRow struct {
+	node   *TreeNode
+	parent *TreeNode
+	guide  string
+}
+
+// Tree shows hierarchical nodes, one per row, indented below their parent
+// with guides, and lets the user move among them and expand or collapse
+// them with the keys, see HandleKey.
+/*
+  tree := termui.NewTree()
+  tree.BorderLabel = "processes"
+  tree.Nodes = []*termui.TreeNode{{
+      Text:     "1 init",
+      Expanded: true,
+      Children: []*termui.TreeNode{{Text: "212 sshd"}, {Text: "380 [nginx](fg-green)"}},
+  }}
+  tree.OnSelect = func(n *termui.TreeNode) { showDetails(n.Value) }
+  termui.Handle("/sys/kbd", func(e termui.Event) {
+      if tree.HandleKey(e.Data.(termui.EvtKbd)) {
+          termui.Render(tree)
+      }
+  })
+*/
+type Tree struct {
+	Block
+	Nodes           []*TreeNode
+	TextFgColor     Attribute
+	TextBgColor     Attribute
+	GuideFgColor    Attribute
+	SelectedFgColor Attribute
+	SelectedBgColor Attribute
+	ShowGuides      bool                 // draw the lines joining nodes to their parent, else indent only
+	Selected        *TreeNode            // node highlighted, the first one if nil
+	OnSelect        func(node *TreeNode) // called on <enter> on a node without children
+	offset          int                  // rows scrolled past
+}
+
+// NewTree returns a new *Tree with current theme.
+func NewTree() *Tree {
+	t := &Tree{Block: *NewBlock()}
+	t.TextFgColor = ThemeAttr("tree.text.fg")
+	t.TextBgColor = ThemeAttr("tree.text.bg")
+	t.GuideFgColor = ThemeAttr("tree.guide.fg")
+	t.SelectedFgColor = ThemeAttr("tree.selected.fg")
+	t.SelectedBgColor = ThemeAttr("tree.selected.bg")
+	t.ShowGuides = true
+	return t
+}
+
+// rows returns the nodes shown, those of collapsed nodes left out.
+func (t *Tree) rows() []treeRow {
+	var rows []treeRow
+	var walk func(nodes []*TreeNode, parent *TreeNode, prefix string)
+	walk = func(nodes []*TreeNode, parent *TreeNode, prefix string) {
+		for i, n := range nodes {
+			last := i == len(nodes)-1
+			guide, next := prefix, prefix
+			switch {
+			case parent == nil:
+			case !t.ShowGuides:
+				guide, next = prefix+"  ", prefix+"  "
+			case last:
+				guide, next = prefix+"└─", prefix+"  "
+			default:
+				guide, next = prefix+"├─", prefix+"│ "
+			}
+			rows = append(rows, treeRow{node: n, parent: parent, guide: guide})
+			if n.Expanded {
+				walk(n.Children, n, next)
+			}
+		}
+	}
+	walk(t.Nodes, nil, "")
+	return rows
+}
+
+// marker returns what is drawn between the guides and the text of the node
+// of r.
+func (t *Tree) marker(r treeRow) string {
+	switch {
+	case len(r.node.Children) > 0 && r.node.Expanded:
+		return "▾ "
+	case len(r.node.Children) > 0:
+		return "▸ "
+	case r.parent != nil && t.ShowGuides:
+		return "─ "
+	}
+	return "  "
+}
+
+// selectedIndex returns the row of the Selected node, selecting the first
+// one if it is not shown.
+func (t *Tree) selectedIndex(rows []treeRow) int {
+	for i, r := range rows {
+		if r.node == t.Selected {
+			return i
+		}
+	}
+	if len(rows) == 0 {
+		t.Selected = nil
+		return -1
+	}
+	t.Selected = rows[0].node
+	return 0
+}
+
+// Move selects the node n rows below the selected one, above it if n is
+// negative.
+func (t *Tree) Move(n int) {
+	rows := t.rows()
+	i := t.selectedIndex(rows)
+	if i < 0 {
+		return
+	}
+	t.Selected = rows[clamp(i+n, 0, len(rows)-1)].node
+}
+
+// Toggle expands the selected node, or collapses it.
+func (t *Tree) Toggle() {
+	t.selectedIndex(t.rows())
+	if t.Selected != nil && len(t.Selected.Children) > 0 {
+		t.Selected.Expanded = !t.Selected.Expanded
+	}
+}
+
+// Expand shows the children of the selected node, or selects the first of
+// them if they are shown.
+func (t *Tree) Expand() {
+	t.selectedIndex(t.rows())
+	n := t.Selected
+	switch {
+	case n == nil || len(n.Children) == 0:
+	case !n.Expanded:
+		n.Expanded = true
+	default:
+		t.Selected = n.Children[0]
+	}
+}
+
+// Collapse hides the children of the selected node, or selects its parent
+// if they are hidden.
+func (t *Tree) Collapse() {
+	rows := t.rows()
+	i := t.selectedIndex(rows)
+	if i < 0 {
+		return
+	}
+	r := rows[i]
+	switch {
+	case len(r.node.Children) > 0 && r.node.Expanded:
+		r.node.Expanded = false
+	case r.parent != nil:
+		t.Selected = r.parent
+	}
+}
+
+// SetExpanded expands or collapses all the nodes.
+func (t *Tree) SetExpanded(expanded bool) {
+	var walk func(nodes []*TreeNode)
+	walk = func(nodes []*TreeNode) {
+		for _, n := range nodes {
+			n.Expanded = expanded && len(n.Children) > 0
+			walk(n.Children)
+		}
+	}
+	walk(t.Nodes)
+}
+
+// HandleKey applies a keyboard event and tells if it was consumed: <up>
+// and <down> move the selection, <right> expands the selected node, <left>
+// collapses it or moves to its parent, and <enter> toggles it, or calls
+// OnSelect if it has no children.
+func (t *Tree) HandleKey(k EvtKbd) bool {
+	page := t.innerArea.Dy()
+	if page < 1 {
+		page = 1
+	}
+	switch k.KeyStr {
+	case "<up>", "k":
+		t.Move(-k.Step())
+	case "<down>", "j":
+		t.Move(k.Step())
+	case "<pageup>", "<previous>":
+		t.Move(-page)
+	case "<pagedown>", "<next>":
+		t.Move(page)
+	case "<home>":
+		t.Move(-len(t.rows()))
+	case "<end>":
+		t.Move(len(t.rows()))
+	case "<right>", "l":
+		t.Expand()
+	case "<left>", "h":
+		t.Collapse()
+	case "<enter>", "<space>":
+		t.selectedIndex(t.rows())
+		if n := t.Selected; n != nil && len(n.Children) == 0 {
+			if t.OnSelect != nil {
+				t.OnSelect(n)
+			}
+			return true
+		}
+		t.Toggle()
+	default:
+		return false
+	}
+	return true
+}
+
+// Buffer implements Bufferer interface.
+func (t *Tree) Buffer() Buffer {
+	buf := t.Block.Buffer()
+	if t.drawState(buf) {
+		return buf
+	}
+	if t.Skeleton && len(t.Nodes) == 0 {
+		t.drawSkeleton(buf, false)
+		return buf
+	}
+	w, h := t.innerArea.Dx(), t.innerArea.Dy()
+	if w <= 0 || h <= 0 {
+		return buf
+	}
+
+	rows := t.rows()
+	sel := t.selectedIndex(rows)
+	if sel >= 0 && sel < t.offset {
+		t.offset = sel
+	}
+	if sel >= t.offset+h {
+		t.offset = sel - h + 1
+	}
+	t.offset = clamp(t.offset, 0, clamp(len(rows)-h, 0, len(rows)))
+
+	for i := t.offset; i < len(rows) && i < t.offset+h; i++ {
+		r := rows[i]
+		fg, bg := t.TextFgColor, t.TextBgColor
+		if i == sel {
+			fg, bg = t.SelectedFgColor, t.SelectedBgColor
+		}
+		cs := TextCells(r.guide, t.GuideFgColor, bg)
+		cs = append(cs, TextCells(t.marker(r), t.GuideFgColor, bg)...)
+		cs = append(cs, DefaultTxBuilder.Build(r.node.Text, fg, bg)...)
+		y := t.innerArea.Min.Y + i - t.offset
+		if i == sel {
+			for x := t.innerArea.Min.X; x < t.innerArea.Max.X; x++ {
+				buf.Set(x, y, Cell{Ch: ' ', Fg: fg, Bg: bg})
+			}
+		}
+		x := t.innerArea.Min.X
+		for _, c := range TruncateRight(cs, w) {
+			buf.Set(x, y, c)
+			x += c.Width()
+		}
+	}
+	return buf
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"testing"
+)
+
+func testTree() *Tree {
+	t := NewTree()
+	t.Border = false
+	t.Width = 20
+	t.Height = 5
+	t.Nodes = []*TreeNode{{
+		Text:     "init",
+		Expanded: true,
+		Children: []*TreeNode{
+			{Text: "sshd", Children: []*TreeNode{{Text: "bash"}}},
+			{Text: "cron"},
+		},
+	}, {Text: "kthreadd"}}
+	return t
+}
+
+func treeLines(buf Buffer, w, h int) []string {
+	var lines []string
+	for y := 0; y < h; y++ {
+		var sb strings.Builder
+		for x := 0; x < w; x++ {
+			sb.WriteRune(buf.At(x, y).Ch)
+		}
+		lines = append(lines, strings.TrimRight(sb.String(), " \x00"))
+	}
+	return lines
+}
+
+func TestTree(t *testing.T) {
+	tree := testTree()
+	tree.HandleKey(EvtKbd{KeyStr: "<down>"})
+	tree.HandleKey(EvtKbd{KeyStr: "<enter>"})
+	if !tree.Nodes[0].Children[0].Expanded {
+		t.Fatal("expected <enter> to expand sshd")
+	}
+	want := []string{
+		"▾ init",
+		"├─▾ sshd",
+		"│ └── bash",
+		"└── cron",
+		"  kthreadd",
+	}
+	if got := treeLines(tree.Buffer(), 20, 5); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+	if c := tree.Buffer().At(19, 1); c.Bg != tree.SelectedBgColor {
+		t.Errorf("expected the selected row highlighted, got bg %v", c.Bg)
+	}
+
+	tree.HandleKey(EvtKbd{KeyStr: "<right>"})
+	if tree.Selected.Text != "bash" {
+		t.Errorf("expected <right> on an expanded node to select its child, got %s", tree.Selected.Text)
+	}
+	var chosen *TreeNode
+	tree.OnSelect = func(n *TreeNode) { chosen = n }
+	tree.HandleKey(EvtKbd{KeyStr: "<enter>"})
+	if chosen == nil || chosen.Text != "bash" {
+		t.Errorf("expected <enter> on a leaf to call OnSelect, got %v", chosen)
+	}
+	tree.HandleKey(EvtKbd{KeyStr: "<left>"})
+	tree.HandleKey(EvtKbd{KeyStr: "<left>"})
+	if tree.Selected.Text != "sshd" || tree.Selected.Expanded {
+		t.Errorf("expected <left> to go to the parent, then collapse it, got %s", tree.Selected.Text)
+	}
+}
+
+func TestTreeScroll(t *testing.T) {
+	tree := testTree()
+	tree.Height = 2
+	tree.SetExpanded(true)
+	tree.HandleKey(EvtKbd{KeyStr: "<end>"})
+	if got := treeLines(tree.Buffer(), 20, 2); got[1] != "  kthreadd" || got[0] != "└── cron" {
+		t.Errorf("expected the rows scrolled to the selection, got %q", got)
+	}
+}
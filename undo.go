@@ -0,0 +1,111 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "time"
+
+// Keys undoing and redoing the last edit of the editing widgets.
+var (
+	UndoKey = "C-z"
+	RedoKey = "C-y"
+)
+
+// UndoStack is the edit history of a widget: the states of its value
+// before every edit, to go back to with Undo, and those undone, to go
+// forward to again with Redo. Consecutive edits of the same kind, e.g.
+// the keystrokes typing a word, are undone at once.
+/*
+  ti := termui.NewTextInput()
+  ti.History.Depth = 20
+  ti.History.Coalesce = 0 // undo key by key
+*/
+type UndoStack struct {
+	Depth    int           // states kept, 0 for no limit
+	Coalesce time.Duration // longest pause between edits undone at once
+	undo     []interface{}
+	redo     []interface{}
+	kind     string // kind of the last edit, "" after Undo, Redo or Break
+	last     time.Time
+}
+
+// NewUndoStack returns a *UndoStack of 100 states, coalescing edits a
+// second apart at most.
+func NewUndoStack() *UndoStack {
+	return &UndoStack{Depth: 100, Coalesce: time.Second}
+}
+
+// Record saves before, the state of the value before an edit of kind, e.g.
+// "insert" or "delete", and forgets the states undone.
+func (u *UndoStack) Record(kind string, before interface{}) {
+	u.record(kind, before, time.Now())
+}
+
+func (u *UndoStack) record(kind string, before interface{}, now time.Time) {
+	u.redo = nil
+	coalesced := kind != "" && kind == u.kind && now.Sub(u.last) < u.Coalesce
+	u.kind, u.last = kind, now
+	if coalesced {
+		return
+	}
+	u.undo = append(u.undo, before)
+	if u.Depth > 0 && len(u.undo) > u.Depth {
+		u.undo = u.undo[len(u.undo)-u.Depth:]
+	}
+}
+
+// Break makes the next edit start a new step, whatever its kind.
+func (u *UndoStack) Break() {
+	u.kind = ""
+}
+
+// Undo returns the state before the last edit, given the current one to
+// come back to with Redo, and false if there is none.
+func (u *UndoStack) Undo(current interface{}) (interface{}, bool) {
+	if len(u.undo) == 0 {
+		return nil, false
+	}
+	s := u.undo[len(u.undo)-1]
+	u.undo = u.undo[:len(u.undo)-1]
+	u.redo = append(u.redo, current)
+	u.kind = ""
+	return s, true
+}
+
+// Redo returns the state the last Undo left, given the current one to come
+// back to with Undo, and false if there is none.
+func (u *UndoStack) Redo(current interface{}) (interface{}, bool) {
+	if len(u.redo) == 0 {
+		return nil, false
+	}
+	s := u.redo[len(u.redo)-1]
+	u.redo = u.redo[:len(u.redo)-1]
+	u.undo = append(u.undo, current)
+	u.kind = ""
+	return s, true
+}
+
+// CanUndo tells if there is an edit to undo.
+func (u *UndoStack) CanUndo() bool {
+	return len(u.undo) > 0
+}
+
+// CanRedo tells if there is an edit to redo.
+func (u *UndoStack) CanRedo() bool {
+	return len(u.redo) > 0
+}
+
+// Clear forgets the whole history.
+func (u *UndoStack) Clear() {
+	u.undo, u.redo, u.kind = nil, nil, ""
+}
+
+// clone returns a *UndoStack with the settings of u and no history, or nil
+// if u is nil.
+func (u *UndoStack) clone() *UndoStack {
+	if u == nil {
+		return nil
+	}
+	return &UndoStack{Depth: u.Depth, Coalesce: u.Coalesce}
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUndoStackCoalesce(t *testing.T) {
+	u := NewUndoStack()
+	now := time.Now()
+	u.record("insert", "", now)
+	u.record("insert", "a", now.Add(100*time.Millisecond))
+	u.record("delete", "ab", now.Add(200*time.Millisecond))
+	u.record("insert", "a", now.Add(3*time.Second))
+
+	var got []interface{}
+	cur := interface{}("ac")
+	for {
+		s, ok := u.Undo(cur)
+		if !ok {
+			break
+		}
+		got = append(got, s)
+		cur = s
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "ab" || got[2] != "" {
+		t.Errorf("unexpected states %q", got)
+	}
+	if s, ok := u.Redo(cur); !ok || s != "ab" {
+		t.Errorf("unexpected redo %q, %v", s, ok)
+	}
+}
+
+func TestUndoStackDepth(t *testing.T) {
+	u := &UndoStack{Depth: 2}
+	for _, s := range []string{"a", "b", "c"} {
+		u.Record("", s)
+	}
+	s1, _ := u.Undo("d")
+	s2, _ := u.Undo(s1)
+	if _, ok := u.Undo(s2); ok || s1 != "c" || s2 != "b" {
+		t.Errorf("unexpected states %q %q", s1, s2)
+	}
+}
+
+func TestTextInputUndo(t *testing.T) {
+	ti := NewTextInput()
+	for _, k := range []string{"a", "b", "<left>", "c", "<backspace>"} {
+		ti.HandleKey(EvtKbd{KeyStr: k})
+	}
+	if ti.Text != "ab" {
+		t.Fatalf("unexpected text %q", ti.Text)
+	}
+	for _, want := range []string{"acb", "ab", ""} {
+		ti.HandleKey(EvtKbd{KeyStr: UndoKey})
+		if ti.Text != want {
+			t.Errorf("expected %q after undo, got %q", want, ti.Text)
+		}
+	}
+	ti.HandleKey(EvtKbd{KeyStr: RedoKey})
+	if ti.Text != "ab" || ti.Cursor != 1 {
+		t.Errorf("unexpected %q at %d after redo", ti.Text, ti.Cursor)
+	}
+}
+
+func TestTableUndo(t *testing.T) {
+	table := NewTable()
+	table.Rows = [][]string{{"a", "b"}}
+	table.SetCell(0, 1, "c")
+	table.SetCell(0, 0, "d")
+	table.HandleUndoKey(EvtKbd{KeyStr: UndoKey})
+	table.HandleUndoKey(EvtKbd{KeyStr: UndoKey})
+	if table.Rows[0][0] != "a" || table.Rows[0][1] != "b" {
+		t.Errorf("unexpected rows %q", table.Rows)
+	}
+	table.HandleUndoKey(EvtKbd{KeyStr: RedoKey})
+	if table.Rows[0][1] != "c" || table.Rows[0][0] != "a" {
+		t.Errorf("unexpected rows %q after redo", table.Rows)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+
+	tui "github.com/gizak/termui"
+)
+
+// Block is the v3 base of all widgets: a rectangle with an optional border
+// and title.
+type Block struct {
+	Border       bool
+	BorderStyle  Style
+	BorderLeft   bool
+	BorderRight  bool
+	BorderTop    bool
+	BorderBottom bool
+
+	PaddingLeft   int
+	PaddingRight  int
+	PaddingTop    int
+	PaddingBottom int
+
+	image.Rectangle
+	Inner image.Rectangle
+
+	Title      string
+	TitleStyle Style
+}
+
+// NewBlock returns a *Block with a border.
+func NewBlock() *Block {
+	return &Block{
+		Border:       true,
+		BorderStyle:  NewStyle(ColorWhite),
+		BorderLeft:   true,
+		BorderRight:  true,
+		BorderTop:    true,
+		BorderBottom: true,
+		TitleStyle:   NewStyle(ColorWhite),
+	}
+}
+
+// SetRect places the block between (x1, y1) and (x2, y2), the latter
+// excluded.
+func (b *Block) SetRect(x1, y1, x2, y2 int) {
+	b.Rectangle = image.Rect(x1, y1, x2, y2)
+	b.Inner = image.Rect(x1+b.PaddingLeft, y1+b.PaddingTop, x2-b.PaddingRight, y2-b.PaddingBottom)
+	if b.Border {
+		if b.BorderLeft {
+			b.Inner.Min.X++
+		}
+		if b.BorderRight {
+			b.Inner.Max.X--
+		}
+		if b.BorderTop {
+			b.Inner.Min.Y++
+		}
+		if b.BorderBottom {
+			b.Inner.Max.Y--
+		}
+	}
+}
+
+// GetRect returns the rectangle of the block.
+func (b *Block) GetRect() image.Rectangle {
+	return b.Rectangle
+}
+
+// Apply copies the geometry, border and title of b onto tb, the block of
+// the termui widget drawing a v3 widget.
+func (b *Block) Apply(tb *tui.Block) {
+	tb.X = b.Min.X
+	tb.Y = b.Min.Y
+	tb.Width = b.Dx()
+	tb.Height = b.Dy()
+	tb.Border = b.Border
+	tb.BorderLeft = b.BorderLeft
+	tb.BorderRight = b.BorderRight
+	tb.BorderTop = b.BorderTop
+	tb.BorderBottom = b.BorderBottom
+	tb.BorderFg, tb.BorderBg = b.BorderStyle.Attrs()
+	tb.BorderLabel = b.Title
+	tb.BorderLabelFg, tb.BorderLabelBg = b.TitleStyle.Attrs()
+	tb.PaddingLeft = b.PaddingLeft
+	tb.PaddingRight = b.PaddingRight
+	tb.PaddingTop = b.PaddingTop
+	tb.PaddingBottom = b.PaddingBottom
+}
+
+// Buffer implements termui's Bufferer interface.
+func (b *Block) Buffer() tui.Buffer {
+	tb := tui.NewBlock()
+	b.Apply(tb)
+	return tb.Buffer()
+}
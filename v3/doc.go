@@ -0,0 +1,37 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+/*
+Package termui is a migration shim exposing the core API of gizak/termui v3
+on top of this package, so v3 apps can switch by changing their imports:
+
+	import (
+		ui "github.com/gizak/termui/v3"
+		"github.com/gizak/termui/v3/widgets"
+	)
+
+	func main() {
+		if err := ui.Init(); err != nil {
+			log.Fatal(err)
+		}
+		defer ui.Close()
+
+		p := widgets.NewParagraph()
+		p.Title = "Hello"
+		p.Text = "PRESS q TO QUIT"
+		p.SetRect(0, 0, 25, 5)
+		ui.Render(p)
+
+		for e := range ui.PollEvents() {
+			if e.ID == "q" || e.ID == "<C-c>" {
+				return
+			}
+		}
+	}
+
+Widgets are drawn by the widgets of this package, so they render with its
+fixes and features. Custom v3 widgets implementing Draw(*Buffer) are not
+supported: implement Buffer() instead, see the termui Bufferer interface.
+*/
+package termui
@@ -0,0 +1,146 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strings"
+	"sync"
+
+	tui "github.com/gizak/termui"
+)
+
+// EventType is the kind of a v3 Event.
+type EventType uint
+
+// v3 event types.
+const (
+	KeyboardEvent EventType = iota
+	MouseEvent
+	ResizeEvent
+)
+
+// Event is a v3 event. ID is the key, e.g. "q", "<C-c>" or "<Up>", the
+// mouse button, e.g. "<MouseLeft>", or "<Resize>".
+type Event struct {
+	Type    EventType
+	ID      string
+	Payload interface{}
+}
+
+// Mouse is the Payload of mouse events.
+type Mouse struct {
+	Drag bool
+	X    int
+	Y    int
+}
+
+// Resize is the Payload of resize events.
+type Resize struct {
+	Width  int
+	Height int
+}
+
+// keyNames maps termui key names to v3 ones.
+var keyNames = map[string]string{
+	"<up>":        "Up",
+	"<down>":      "Down",
+	"<left>":      "Left",
+	"<right>":     "Right",
+	"<previous>":  "PageUp",
+	"<next>":      "PageDown",
+	"<home>":      "Home",
+	"<end>":       "End",
+	"<insert>":    "Insert",
+	"<delete>":    "Delete",
+	"<backspace>": "Backspace",
+	"<tab>":       "Tab",
+	"<enter>":     "Enter",
+	"<escape>":    "Escape",
+	"<space>":     "Space",
+}
+
+var mouseNames = map[string]string{
+	"left":      "<MouseLeft>",
+	"middle":    "<MouseMiddle>",
+	"right":     "<MouseRight>",
+	"release":   "<MouseRelease>",
+	"wheelup":   "<MouseWheelUp>",
+	"wheeldown": "<MouseWheelDown>",
+}
+
+// keyID converts a termui key string, e.g. "C-<space>", to a v3 ID.
+func keyID(k string) string {
+	mods := ""
+	for {
+		if strings.HasPrefix(k, "C-") && len(k) > 2 {
+			mods += "C-"
+		} else if strings.HasPrefix(k, "M-") && len(k) > 2 {
+			mods += "M-"
+		} else {
+			break
+		}
+		k = k[2:]
+	}
+	name, special := keyNames[k]
+	if !special && strings.HasPrefix(k, "<f") {
+		name, special = "F"+strings.Trim(k[2:], ">"), true
+	}
+	switch {
+	case special:
+		return "<" + mods + name + ">"
+	case mods != "":
+		return "<" + mods + k + ">"
+	default:
+		return k
+	}
+}
+
+// convertEvent returns the v3 event of a termui system event. Events termui
+// makes of others, like the gestures of mouse events, have none: v3 apps
+// get the events they are made of.
+func convertEvent(e tui.Event) (Event, bool) {
+	switch d := e.Data.(type) {
+	case tui.EvtKbd:
+		if strings.HasPrefix(e.Path, "/sys/kbd") {
+			return Event{Type: KeyboardEvent, ID: keyID(d.KeyStr)}, true
+		}
+	case tui.EvtMouse:
+		if e.Path == "/sys/mouse" {
+			id, ok := mouseNames[d.Press]
+			return Event{Type: MouseEvent, ID: id, Payload: Mouse{X: d.X, Y: d.Y}}, ok
+		}
+	case tui.EvtWnd:
+		if strings.HasPrefix(e.Path, "/sys/wnd") {
+			return Event{Type: ResizeEvent, ID: "<Resize>", Payload: Resize{Width: d.Width, Height: d.Height}}, true
+		}
+	}
+	return Event{}, false
+}
+
+var (
+	pollOnce sync.Once
+	events   chan Event
+)
+
+// PollEvents returns the channel of keyboard, mouse and resize events. The
+// first call starts termui's event loop.
+func PollEvents() <-chan Event {
+	pollOnce.Do(func() {
+		events = make(chan Event)
+		send := func(e tui.Event) {
+			if ev, ok := convertEvent(e); ok {
+				events <- ev
+			}
+		}
+		tui.Handle("/sys", send)
+		// replaces the handler registered by Init, which is more specific
+		tui.Handle("/sys/wnd/resize", func(e tui.Event) {
+			tui.Body.Width = e.Data.(tui.EvtWnd).Width
+			send(e)
+		})
+		go tui.Loop()
+	})
+	return events
+}
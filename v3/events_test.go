@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+
+	tui "github.com/gizak/termui"
+)
+
+func TestKeyID(t *testing.T) {
+	for k, id := range map[string]string{
+		"q":         "q",
+		"C-c":       "<C-c>",
+		"M-x":       "<M-x>",
+		"<up>":      "<Up>",
+		"<next>":    "<PageDown>",
+		"<f12>":     "<F12>",
+		"C-<space>": "<C-Space>",
+		"<enter>":   "<Enter>",
+	} {
+		if got := keyID(k); got != id {
+			t.Errorf("keyID(%q): expected %q, got %q", k, id, got)
+		}
+	}
+}
+
+func TestConvertEvent(t *testing.T) {
+	e, ok := convertEvent(tui.Event{Path: "/sys/mouse", Data: tui.EvtMouse{X: 3, Y: 4, Press: "left"}})
+	if !ok || e.Type != MouseEvent || e.ID != "<MouseLeft>" || e.Payload.(Mouse).X != 3 {
+		t.Errorf("unexpected mouse event %+v", e)
+	}
+	e, ok = convertEvent(tui.Event{Path: "/sys/kbd/C-c", Data: tui.EvtKbd{KeyStr: "C-c"}})
+	if !ok || e.Type != KeyboardEvent || e.ID != "<C-c>" {
+		t.Errorf("unexpected keyboard event %+v", e)
+	}
+	e, ok = convertEvent(tui.Event{Path: "/sys/wnd/resize", Data: tui.EvtWnd{Width: 80, Height: 24}})
+	if !ok || e.ID != "<Resize>" || e.Payload.(Resize).Height != 24 {
+		t.Errorf("unexpected resize event %+v", e)
+	}
+	for _, g := range []string{"dblclick", "tripleclick", "longpress"} {
+		if e, ok := convertEvent(tui.Event{Path: "/sys/gesture/" + g, Data: tui.EvtMouse{Press: "left"}}); ok {
+			t.Errorf("gesture %s should not make a v3 event, got %+v", g, e)
+		}
+	}
+}
+
+func TestStyleAttrs(t *testing.T) {
+	fg, bg := NewStyle(ColorRed, ColorClear, ModifierBold).Attrs()
+	if fg != tui.ColorRed|tui.AttrBold || bg != tui.ColorDefault {
+		t.Errorf("unexpected attributes %v %v", fg, bg)
+	}
+	// termbox draws attribute n with palette color n-1
+	if Color(196).Attr() != tui.Attribute(197) {
+		t.Errorf("unexpected palette attribute %v", Color(196).Attr())
+	}
+}
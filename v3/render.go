@@ -0,0 +1,47 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+
+	tui "github.com/gizak/termui"
+)
+
+// Drawable is a widget that can be passed to Render.
+type Drawable interface {
+	GetRect() image.Rectangle
+	SetRect(x1, y1, x2, y2 int)
+	tui.Bufferer
+}
+
+// Init initializes the terminal, see termui.Init.
+func Init() error {
+	return tui.Init()
+}
+
+// Close restores the terminal.
+func Close() {
+	tui.Close()
+}
+
+// Render draws items.
+func Render(items ...Drawable) {
+	bs := make([]tui.Bufferer, len(items))
+	for i, d := range items {
+		bs[i] = d
+	}
+	tui.Render(bs...)
+}
+
+// Clear clears the screen.
+func Clear() {
+	tui.Clear()
+}
+
+// TerminalDimensions returns the width and height of the terminal.
+func TerminalDimensions() (int, int) {
+	return tui.TermWidth(), tui.TermHeight()
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import tui "github.com/gizak/termui"
+
+// Color is a v3 color: -1 for the terminal default, 0-255 for the 256
+// color palette.
+type Color int
+
+// v3 colors.
+const (
+	ColorClear Color = -1
+	ColorBlack Color = iota - 1
+	ColorRed
+	ColorGreen
+	ColorYellow
+	ColorBlue
+	ColorMagenta
+	ColorCyan
+	ColorWhite
+)
+
+// Modifier is a v3 text style.
+type Modifier uint
+
+// v3 modifiers.
+const (
+	ModifierClear     Modifier = 0
+	ModifierBold      Modifier = Modifier(tui.AttrBold)
+	ModifierUnderline Modifier = Modifier(tui.AttrUnderline)
+	ModifierReverse   Modifier = Modifier(tui.AttrReverse)
+)
+
+// StandardColors are the colors v3 cycles through for chart series.
+var StandardColors = []Color{
+	ColorRed, ColorGreen, ColorYellow, ColorBlue, ColorMagenta, ColorCyan, ColorWhite,
+}
+
+// Style is a v3 cell style.
+type Style struct {
+	Fg       Color
+	Bg       Color
+	Modifier Modifier
+}
+
+// StyleClear is the default style of the terminal.
+var StyleClear = Style{Fg: ColorClear, Bg: ColorClear, Modifier: ModifierClear}
+
+// NewStyle takes 1 to 3 arguments: the foreground color, then optionally
+// the background color and the modifier.
+func NewStyle(fg Color, args ...interface{}) Style {
+	s := Style{Fg: fg, Bg: ColorClear}
+	if len(args) > 0 {
+		s.Bg = args[0].(Color)
+	}
+	if len(args) > 1 {
+		s.Modifier = args[1].(Modifier)
+	}
+	return s
+}
+
+// Attr returns c as a termui attribute.
+func (c Color) Attr() tui.Attribute {
+	if c < 0 {
+		return tui.ColorDefault
+	}
+	return tui.Attribute(c + 1)
+}
+
+// Attrs returns the termui foreground and background attributes of s.
+func (s Style) Attrs() (fg, bg tui.Attribute) {
+	return s.Fg.Attr() | tui.Attribute(s.Modifier), s.Bg.Attr()
+}
+
+// Alignment is a v3 text alignment.
+type Alignment uint
+
+// v3 alignments.
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// Align returns a as a termui alignment.
+func (a Alignment) Align() tui.Align {
+	switch a {
+	case AlignCenter:
+		return tui.AlignCenter
+	case AlignRight:
+		return tui.AlignRight
+	}
+	return tui.AlignLeft
+}
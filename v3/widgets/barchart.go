@@ -0,0 +1,63 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"math"
+
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// BarChart is the v3 BarChart, drawn by a termui BarChart. Values are
+// rounded to ints and bars take the first of BarColors, LabelStyles and
+// NumStyles; NumFormatter and MaxVal are kept for source compatibility.
+type BarChart struct {
+	ui.Block
+	BarColors    []ui.Color
+	LabelStyles  []ui.Style
+	NumStyles    []ui.Style
+	NumFormatter func(float64) string
+	Data         []float64
+	Labels       []string
+	BarWidth     int
+	BarGap       int
+	MaxVal       float64
+}
+
+// NewBarChart returns a new *BarChart.
+func NewBarChart() *BarChart {
+	return &BarChart{
+		Block:       *ui.NewBlock(),
+		BarColors:   ui.StandardColors,
+		LabelStyles: []ui.Style{ui.NewStyle(ui.ColorWhite)},
+		NumStyles:   []ui.Style{ui.NewStyle(ui.ColorBlack)},
+		BarWidth:    3,
+		BarGap:      1,
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (bc *BarChart) Buffer() tui.Buffer {
+	tb := tui.NewBarChart()
+	bc.Apply(&tb.Block)
+	tb.Data = make([]int, len(bc.Data))
+	for i, v := range bc.Data {
+		tb.Data[i] = int(math.Round(v))
+	}
+	tb.DataLabels = bc.Labels
+	tb.BarWidth = bc.BarWidth
+	tb.BarGap = bc.BarGap
+	if len(bc.BarColors) > 0 {
+		tb.BarColor = bc.BarColors[0].Attr()
+	}
+	if len(bc.LabelStyles) > 0 {
+		tb.TextColor, _ = bc.LabelStyles[0].Attrs()
+	}
+	if len(bc.NumStyles) > 0 {
+		tb.NumColor, _ = bc.NumStyles[0].Attrs()
+	}
+	return tb.Buffer()
+}
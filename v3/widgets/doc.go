@@ -0,0 +1,8 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package widgets is the v3 widgets part of the migration shim, see
+// package github.com/gizak/termui/v3. Every widget keeps the v3 fields and
+// is drawn by the matching widget of termui.
+package widgets
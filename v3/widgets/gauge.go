@@ -0,0 +1,42 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// Gauge is the v3 Gauge, drawn by a termui Gauge.
+type Gauge struct {
+	ui.Block
+	Percent    int
+	BarColor   ui.Color
+	Label      string
+	LabelStyle ui.Style
+}
+
+// NewGauge returns a new *Gauge.
+func NewGauge() *Gauge {
+	return &Gauge{
+		Block:      *ui.NewBlock(),
+		BarColor:   ui.ColorWhite,
+		LabelStyle: ui.NewStyle(ui.ColorWhite),
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (g *Gauge) Buffer() tui.Buffer {
+	tg := tui.NewGauge()
+	g.Apply(&tg.Block)
+	tg.Percent = g.Percent
+	tg.BarColor = g.BarColor.Attr()
+	tg.PercentColor, _ = g.LabelStyle.Attrs()
+	tg.Label = g.Label
+	if tg.Label == "" {
+		tg.Label = "{{percent}}%"
+	}
+	return tg.Buffer()
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// List is the v3 List, drawn by a termui List. Only the hidden overflow is
+// supported, WrapText is kept for source compatibility.
+// With RowID set, the selection follows the item it identifies when Rows
+// are refreshed or sorted, and the item keeps its place on screen.
+/*
+  l.RowID = func(i int) string { return pods[i].UID }
+  ...
+  sort.Slice(pods, byAge)
+  l.Rows = podRows(pods)
+  ui.Render(l) // still on the same pod
+*/
+type List struct {
+	ui.Block
+	Rows             []string
+	WrapText         bool
+	TextStyle        ui.Style
+	SelectedRow      int
+	SelectedRowStyle ui.Style
+	RowID            func(row int) string
+	topRow           int
+	sel              selection
+}
+
+// NewList returns a new *List.
+func NewList() *List {
+	return &List{
+		Block:            *ui.NewBlock(),
+		TextStyle:        ui.NewStyle(ui.ColorWhite),
+		SelectedRowStyle: ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierReverse),
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (l *List) Buffer() tui.Buffer {
+	tl := tui.NewList()
+	l.Apply(&tl.Block)
+	tl.ItemFgColor, tl.ItemBgColor = l.TextStyle.Attrs()
+	l.follow()
+
+	// keep the selected row in view
+	h := l.Inner.Dy()
+	if l.SelectedRow < l.topRow {
+		l.topRow = l.SelectedRow
+	} else if h > 0 && l.SelectedRow >= l.topRow+h {
+		l.topRow = l.SelectedRow - h + 1
+	}
+	if l.topRow < len(l.Rows) {
+		tl.Items = l.Rows[l.topRow:]
+	}
+	buf := tl.Buffer()
+
+	if l.SelectedRow >= 0 && l.SelectedRow < len(l.Rows) {
+		fg, bg := l.SelectedRowStyle.Attrs()
+		y := tl.InnerY() + l.SelectedRow - l.topRow
+		for x := tl.InnerX(); x < tl.InnerX()+tl.InnerWidth(); x++ {
+			c := buf.At(x, y)
+			if c.Ch == ' ' && bg == tui.ColorDefault && fg&tui.AttrReverse == 0 {
+				continue
+			}
+			c.Fg, c.Bg = fg, bg
+			buf.Set(x, y, c)
+		}
+	}
+	return buf
+}
+
+// follow moves the selection to its item after Rows changed, scrolling by
+// as many rows as the item moved.
+func (l *List) follow() {
+	row := l.sel.follow(l.SelectedRow, len(l.Rows), l.RowID)
+	if l.topRow += row - l.SelectedRow; l.topRow < 0 {
+		l.topRow = 0
+	}
+	l.SelectedRow = row
+}
+
+// ScrollAmount moves the selection by amount rows, negative ones up.
+func (l *List) ScrollAmount(amount int) {
+	l.follow()
+	l.SelectedRow += amount
+	if l.SelectedRow >= len(l.Rows) {
+		l.SelectedRow = len(l.Rows) - 1
+	}
+	if l.SelectedRow < 0 {
+		l.SelectedRow = 0
+	}
+	l.follow()
+}
+
+// ScrollUp moves the selection one row up.
+func (l *List) ScrollUp() {
+	l.ScrollAmount(-1)
+}
+
+// ScrollDown moves the selection one row down.
+func (l *List) ScrollDown() {
+	l.ScrollAmount(1)
+}
+
+// ScrollPageUp moves the selection one page up.
+func (l *List) ScrollPageUp() {
+	l.ScrollAmount(-l.Inner.Dy())
+}
+
+// ScrollPageDown moves the selection one page down.
+func (l *List) ScrollPageDown() {
+	l.ScrollAmount(l.Inner.Dy())
+}
+
+// ScrollHalfPageUp moves the selection half a page up.
+func (l *List) ScrollHalfPageUp() {
+	l.ScrollAmount(-l.Inner.Dy() / 2)
+}
+
+// ScrollHalfPageDown moves the selection half a page down.
+func (l *List) ScrollHalfPageDown() {
+	l.ScrollAmount(l.Inner.Dy() / 2)
+}
+
+// ScrollTop selects the first row.
+func (l *List) ScrollTop() {
+	l.SelectedRow = 0
+}
+
+// ScrollBottom selects the last row.
+func (l *List) ScrollBottom() {
+	l.SelectedRow = len(l.Rows) - 1
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"testing"
+
+	tui "github.com/gizak/termui"
+)
+
+func TestListSelection(t *testing.T) {
+	l := NewList()
+	l.Rows = []string{"a", "b", "c", "d"}
+	l.SetRect(0, 0, 5, 4)
+	l.ScrollBottom()
+
+	buf := l.Buffer()
+	if c := buf.At(1, 1); c.Ch != 'c' {
+		t.Errorf("the list should scroll to the selection, got %q", c.Ch)
+	}
+	if c := buf.At(1, 2); c.Ch != 'd' || c.Fg&tui.AttrReverse == 0 {
+		t.Errorf("the selected row should be highlighted, got %+v", c)
+	}
+
+	l.ScrollAmount(-10)
+	if l.SelectedRow != 0 {
+		t.Errorf("ScrollAmount should stop at the first row, got %d", l.SelectedRow)
+	}
+}
+
+func TestListSelectionFollowsRowID(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	l := NewList()
+	l.Rows = items
+	l.RowID = func(i int) string { return l.Rows[i] }
+	l.SetRect(0, 0, 5, 4)
+	l.ScrollAmount(3)
+	l.Buffer()
+	if l.SelectedRow != 3 || l.topRow != 2 {
+		t.Fatalf("selected %d from %d, want d at 3 from 2", l.SelectedRow, l.topRow)
+	}
+
+	// the rows are re-sorted: d moves to the top and stays selected there
+	l.Rows = []string{"e", "d", "c", "b", "a"}
+	buf := l.Buffer()
+	if l.SelectedRow != 1 {
+		t.Errorf("selected %d after sorting, want d at 1", l.SelectedRow)
+	}
+	if c := buf.At(1, 2); c.Ch != 'd' || c.Fg&tui.AttrReverse == 0 {
+		t.Errorf("d should stay selected on the same line, got %+v", c)
+	}
+
+	// d is gone: the selection stays at its index
+	l.Rows = []string{"e", "c", "b", "a"}
+	l.Buffer()
+	if l.SelectedRow != 1 || l.Rows[l.SelectedRow] != "c" {
+		t.Errorf("selected %d after deleting d, want 1", l.SelectedRow)
+	}
+
+	// the app moves the selection itself
+	l.SelectedRow = 3
+	l.Rows = []string{"a", "b", "c", "e"}
+	l.Buffer()
+	if l.SelectedRow != 3 {
+		t.Errorf("selected %d, want the row set by the app", l.SelectedRow)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// Paragraph is the v3 Paragraph, drawn by a termui Par.
+type Paragraph struct {
+	ui.Block
+	Text      string
+	TextStyle ui.Style
+	WrapText  bool
+}
+
+// NewParagraph returns a new *Paragraph.
+func NewParagraph() *Paragraph {
+	return &Paragraph{
+		Block:     *ui.NewBlock(),
+		TextStyle: ui.NewStyle(ui.ColorWhite),
+		WrapText:  true,
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (p *Paragraph) Buffer() tui.Buffer {
+	par := tui.NewPar(p.Text)
+	p.Apply(&par.Block)
+	par.TextFgColor, par.TextBgColor = p.TextStyle.Attrs()
+	if p.WrapText && p.Inner.Dx() > 0 {
+		par.WrapLength = p.Inner.Dx()
+	}
+	return par.Buffer()
+}
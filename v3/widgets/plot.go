@@ -0,0 +1,65 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"strconv"
+
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// PlotMarker is how a Plot draws points.
+type PlotMarker uint
+
+// Plot markers.
+const (
+	MarkerBraille PlotMarker = iota
+	MarkerDot
+)
+
+// Plot is the v3 Plot, drawn by a termui LineChart. Only line charts are
+// supported, with the axes always shown.
+type Plot struct {
+	ui.Block
+	Data          [][]float64
+	DataLabels    []string
+	LineColors    []ui.Color
+	AxesColor     ui.Color
+	Marker        PlotMarker
+	DotMarkerRune rune
+}
+
+// NewPlot returns a new *Plot.
+func NewPlot() *Plot {
+	return &Plot{
+		Block:         *ui.NewBlock(),
+		LineColors:    ui.StandardColors,
+		AxesColor:     ui.ColorWhite,
+		DotMarkerRune: '•',
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (p *Plot) Buffer() tui.Buffer {
+	lc := tui.NewLineChart()
+	p.Apply(&lc.Block)
+	lc.AxesColor = p.AxesColor.Attr()
+	if p.Marker == MarkerDot {
+		lc.Mode = "dot"
+		lc.DotStyle = p.DotMarkerRune
+	}
+	for i, data := range p.Data {
+		name := strconv.Itoa(i)
+		if i < len(p.DataLabels) {
+			name = p.DataLabels[i]
+		}
+		lc.Data[name] = data
+		if len(p.LineColors) > 0 {
+			lc.LineColor[name] = p.LineColors[i%len(p.LineColors)].Attr()
+		}
+	}
+	return lc.Buffer()
+}
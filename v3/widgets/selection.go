@@ -0,0 +1,38 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+// selection keeps the selected row of a widget on the same item, as told
+// by the RowID of the widget, when its rows are refreshed or sorted.
+type selection struct {
+	row int    // selected row when last seen
+	id  string // id of its item
+}
+
+// follow returns the row to select among n rows, given the selected one:
+// it is kept if the app moved the selection since the last call, else the
+// row now holding the selected item is returned. The selection stays at
+// the same index when the item is gone.
+func (s *selection) follow(row, n int, id func(row int) string) int {
+	if id == nil {
+		return row
+	}
+	if row == s.row && s.id != "" && (row < 0 || row >= n || id(row) != s.id) {
+		for i := 0; i < n; i++ {
+			if id(i) == s.id {
+				row = i
+				break
+			}
+		}
+	}
+	if row >= n {
+		row = n - 1
+	}
+	s.row, s.id = row, ""
+	if row >= 0 {
+		s.id = id(row)
+	}
+	return row
+}
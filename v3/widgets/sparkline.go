@@ -0,0 +1,71 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"math"
+
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// Sparkline is the v3 Sparkline, one line of a SparklineGroup. Values are
+// rounded to ints; MaxVal and MaxHeight are kept for source compatibility.
+type Sparkline struct {
+	Data       []float64
+	Title      string
+	TitleStyle ui.Style
+	LineColor  ui.Color
+	MaxVal     float64
+	MaxHeight  int
+}
+
+// SparklineGroup is the v3 SparklineGroup, drawn by termui Sparklines. The
+// inner height is shared evenly by the sparklines.
+type SparklineGroup struct {
+	ui.Block
+	Sparklines []*Sparkline
+}
+
+// NewSparkline returns a new *Sparkline.
+func NewSparkline() *Sparkline {
+	return &Sparkline{
+		TitleStyle: ui.NewStyle(ui.ColorWhite),
+		LineColor:  ui.ColorWhite,
+	}
+}
+
+// NewSparklineGroup returns a new *SparklineGroup of ss.
+func NewSparklineGroup(ss ...*Sparkline) *SparklineGroup {
+	return &SparklineGroup{
+		Block:      *ui.NewBlock(),
+		Sparklines: ss,
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (sg *SparklineGroup) Buffer() tui.Buffer {
+	ts := tui.NewSparklines()
+	sg.Apply(&ts.Block)
+	for _, s := range sg.Sparklines {
+		tl := tui.NewSparkline()
+		tl.Data = make([]int, len(s.Data))
+		for i, v := range s.Data {
+			tl.Data[i] = int(math.Round(v))
+		}
+		tl.Title = s.Title
+		tl.TitleColor, _ = s.TitleStyle.Attrs()
+		tl.LineColor = s.LineColor.Attr()
+		tl.Height = 1
+		if n := len(sg.Sparklines); n > 0 {
+			tl.Height = sg.Inner.Dy() / n
+		}
+		if tl.Title != "" && tl.Height > 1 {
+			tl.Height--
+		}
+		ts.Add(tl)
+	}
+	return ts.Buffer()
+}
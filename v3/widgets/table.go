@@ -0,0 +1,94 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	tui "github.com/gizak/termui"
+	ui "github.com/gizak/termui/v3"
+)
+
+// Table is the v3 Table, drawn by a termui Table. Columns are as wide as
+// their content unless ColumnWidths fixes them.
+// SelectedRow, -1 for none, is drawn with SelectedRowStyle; with RowID set
+// it follows the item it identifies when Rows are refreshed or sorted, and
+// the rows scroll to keep it shown.
+type Table struct {
+	ui.Block
+	Rows             [][]string
+	ColumnWidths     []int
+	TextStyle        ui.Style
+	RowSeparator     bool
+	TextAlignment    ui.Alignment
+	RowStyles        map[int]ui.Style
+	SelectedRow      int
+	SelectedRowStyle ui.Style
+	RowID            func(row int) string
+	sel              selection
+}
+
+// NewTable returns a new *Table.
+func NewTable() *Table {
+	return &Table{
+		Block:            *ui.NewBlock(),
+		TextStyle:        ui.NewStyle(ui.ColorWhite),
+		RowSeparator:     true,
+		TextAlignment:    ui.AlignLeft,
+		RowStyles:        make(map[int]ui.Style),
+		SelectedRow:      -1,
+		SelectedRowStyle: ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierReverse),
+		sel:              selection{row: -1},
+	}
+}
+
+// Buffer implements termui's Bufferer interface.
+func (t *Table) Buffer() tui.Buffer {
+	tt := tui.NewTable()
+	t.Apply(&tt.Block)
+	tt.Rows = t.Rows
+	tt.ColumnWidth = t.ColumnWidths
+	tt.FgColor, tt.BgColor = t.TextStyle.Attrs()
+	tt.Separator = t.RowSeparator
+	tt.TextAlign = t.TextAlignment.Align()
+	t.SelectedRow = t.sel.follow(t.SelectedRow, len(t.Rows), t.RowID)
+	tt.SelectedRow = t.SelectedRow
+	tt.SelectedFgColor, tt.SelectedBgColor = t.SelectedRowStyle.Attrs()
+	if len(t.RowStyles) > 0 || t.SelectedRow >= 0 {
+		tt.FgColors = make([]tui.Attribute, len(t.Rows))
+		tt.BgColors = make([]tui.Attribute, len(t.Rows))
+		for i := range t.Rows {
+			s, ok := t.RowStyles[i]
+			if !ok {
+				s = t.TextStyle
+			}
+			if i == t.SelectedRow {
+				s = t.SelectedRowStyle
+			}
+			tt.FgColors[i], tt.BgColors[i] = s.Attrs()
+		}
+	}
+	return tt.Buffer()
+}
+
+// ScrollUp selects the previous row.
+func (t *Table) ScrollUp() {
+	t.scrollBy(-1)
+}
+
+// ScrollDown selects the next row.
+func (t *Table) ScrollDown() {
+	t.scrollBy(1)
+}
+
+func (t *Table) scrollBy(n int) {
+	t.SelectedRow = t.sel.follow(t.SelectedRow, len(t.Rows), t.RowID)
+	row := t.SelectedRow + n
+	if row >= len(t.Rows) {
+		row = len(t.Rows) - 1
+	}
+	if row < 0 {
+		row = 0
+	}
+	t.SelectedRow = t.sel.follow(row, len(t.Rows), t.RowID)
+}
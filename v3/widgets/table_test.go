@@ -0,0 +1,38 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"testing"
+
+	tui "github.com/gizak/termui"
+)
+
+func TestTableSelectionFollowsRowID(t *testing.T) {
+	tb := NewTable()
+	tb.Rows = [][]string{{"NAME"}, {"web"}, {"db"}}
+	tb.RowID = func(i int) string { return tb.Rows[i][0] }
+	tb.SetRect(0, 0, 12, 8)
+	tb.ScrollDown()
+	tb.ScrollDown()
+	if tb.SelectedRow != 1 {
+		t.Fatalf("selected %d, want web at 1", tb.SelectedRow)
+	}
+
+	tb.Rows = [][]string{{"NAME"}, {"cache"}, {"db"}, {"web"}}
+	buf := tb.Buffer()
+	if tb.SelectedRow != 3 {
+		t.Fatalf("selected %d after a refresh, want web at 3", tb.SelectedRow)
+	}
+	found := false
+	for y := 0; y < 8; y++ {
+		if c := buf.At(3, y); c.Ch == 'w' {
+			found = c.Fg&tui.AttrReverse != 0
+		}
+	}
+	if !found {
+		t.Error("web should be drawn selected")
+	}
+}
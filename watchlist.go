@@ -0,0 +1,197 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WatchEventType is the kind of change a WatchEvent makes.
+type WatchEventType int
+
+// Watch event types, as sent by Kubernetes watches and the like.
+const (
+	WatchAdded WatchEventType = iota
+	WatchModified
+	WatchDeleted
+)
+
+// WatchEvent adds, modifies or deletes the object identified by Key.
+type WatchEvent struct {
+	Type   WatchEventType
+	Key    string
+	Object interface{}
+}
+
+// WatchList keeps the objects of a watch-like source, keyed and ordered,
+// and fills the Tables and Lists bound to it with one row per object. Rows
+// keep their key as they move, so that a Flasher of the widget highlights
+// the objects that changed rather than the rows that moved.
+// Apply may be called from any goroutine; Sync, which updates the widgets,
+// from the one rendering them.
+/*
+  wl := termui.NewWatchList(func(o interface{}) []string {
+      p := o.(*Pod)
+      return []string{p.Name, p.Status, p.Age()}
+  })
+  wl.Header = []string{"NAME", "STATUS", "AGE"}
+  wl.BindTable(table)
+  wl.OnChange = func() { termui.SendCustomEvt("/usr/pods", nil) }
+  termui.Handle("/usr/pods", func(termui.Event) {
+      wl.Sync()
+      termui.Render(table)
+  })
+  go func() {
+      for ev := range podEvents {
+          wl.Apply(termui.WatchEvent{Type: ev.Type, Key: ev.Pod.Name, Object: ev.Pod})
+      }
+  }()
+*/
+type WatchList struct {
+	sync.Mutex
+	Row      func(obj interface{}) []string // columns of the row of obj
+	Less     func(a, b interface{}) bool    // row order, by key if nil
+	Header   []string                       // first row of bound Tables
+	OnChange func()                         // called after each change
+	objs     map[string]interface{}
+	keys     []string
+	tables   []*Table
+	lists    []*List
+}
+
+// NewWatchList returns an empty *WatchList showing objects with row.
+func NewWatchList(row func(obj interface{}) []string) *WatchList {
+	return &WatchList{Row: row, objs: make(map[string]interface{})}
+}
+
+// BindTable makes Sync fill t with a header row and a row per object.
+func (wl *WatchList) BindTable(t *Table) {
+	wl.Lock()
+	defer wl.Unlock()
+	wl.tables = append(wl.tables, t)
+}
+
+// BindList makes Sync fill l with an item per object, its columns joined
+// by spaces.
+func (wl *WatchList) BindList(l *List) {
+	wl.Lock()
+	defer wl.Unlock()
+	wl.lists = append(wl.lists, l)
+}
+
+// Apply records ev and calls OnChange.
+func (wl *WatchList) Apply(ev WatchEvent) {
+	wl.Lock()
+	_, seen := wl.objs[ev.Key]
+	switch {
+	case ev.Type == WatchDeleted:
+		if seen {
+			delete(wl.objs, ev.Key)
+			i := wl.index(ev.Key)
+			wl.keys = append(wl.keys[:i], wl.keys[i+1:]...)
+		}
+	case seen:
+		wl.objs[ev.Key] = ev.Object
+		if wl.Less != nil {
+			wl.sortKeys()
+		}
+	default:
+		wl.objs[ev.Key] = ev.Object
+		wl.keys = append(wl.keys, ev.Key)
+		wl.sortKeys()
+	}
+	f := wl.OnChange
+	wl.Unlock()
+	if f != nil {
+		f()
+	}
+}
+
+// Reset replaces all the objects, e.g. after listing them again when the
+// watch was lost, and calls OnChange.
+func (wl *WatchList) Reset(objs map[string]interface{}) {
+	wl.Lock()
+	wl.objs = make(map[string]interface{}, len(objs))
+	wl.keys = wl.keys[:0]
+	for k, o := range objs {
+		wl.objs[k] = o
+		wl.keys = append(wl.keys, k)
+	}
+	wl.sortKeys()
+	f := wl.OnChange
+	wl.Unlock()
+	if f != nil {
+		f()
+	}
+}
+
+// index returns the position of key in the sorted keys.
+func (wl *WatchList) index(key string) int {
+	for i, k := range wl.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (wl *WatchList) sortKeys() {
+	sort.SliceStable(wl.keys, func(i, j int) bool {
+		a, b := wl.keys[i], wl.keys[j]
+		if wl.Less == nil {
+			return a < b
+		}
+		return wl.Less(wl.objs[a], wl.objs[b])
+	})
+}
+
+// Keys returns the keys of the objects, in row order.
+func (wl *WatchList) Keys() []string {
+	wl.Lock()
+	defer wl.Unlock()
+	return append([]string(nil), wl.keys...)
+}
+
+// Get returns the object of key.
+func (wl *WatchList) Get(key string) (interface{}, bool) {
+	wl.Lock()
+	defer wl.Unlock()
+	o, ok := wl.objs[key]
+	return o, ok
+}
+
+// Len returns the number of objects.
+func (wl *WatchList) Len() int {
+	wl.Lock()
+	defer wl.Unlock()
+	return len(wl.keys)
+}
+
+// Sync fills the bound widgets with the current objects. Their rows are
+// replaced in place, without clearing them first, so that they do not
+// flicker.
+func (wl *WatchList) Sync() {
+	wl.Lock()
+	defer wl.Unlock()
+	rows := make([][]string, len(wl.keys))
+	for i, k := range wl.keys {
+		rows[i] = wl.Row(wl.objs[k])
+	}
+	for _, t := range wl.tables {
+		t.Rows = append([][]string{wl.Header}, rows...)
+		t.Keys = append([]string{""}, wl.keys...)
+		// Analysis sizes the colors to the rows
+		t.FgColors, t.BgColors = nil, nil
+	}
+	for _, l := range wl.lists {
+		l.Items = make([]string, len(rows))
+		for i, r := range rows {
+			l.Items[i] = strings.Join(r, " ")
+		}
+		l.Keys = append([]string(nil), wl.keys...)
+	}
+}
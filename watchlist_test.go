@@ -0,0 +1,129 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatchList(t *testing.T) {
+	type pod struct{ name, status string }
+	wl := NewWatchList(func(o interface{}) []string {
+		p := o.(pod)
+		return []string{p.name, p.status}
+	})
+	wl.Header = []string{"NAME", "STATUS"}
+	changes := 0
+	wl.OnChange = func() { changes++ }
+	tbl := NewTable()
+	ls := NewList()
+	wl.BindTable(tbl)
+	wl.BindList(ls)
+
+	wl.Apply(WatchEvent{Type: WatchAdded, Key: "web", Object: pod{"web", "Pending"}})
+	wl.Apply(WatchEvent{Type: WatchAdded, Key: "db", Object: pod{"db", "Running"}})
+	wl.Apply(WatchEvent{Type: WatchAdded, Key: "cache", Object: pod{"cache", "Running"}})
+	wl.Sync()
+	want := [][]string{{"NAME", "STATUS"}, {"cache", "Running"}, {"db", "Running"}, {"web", "Pending"}}
+	if !reflect.DeepEqual(tbl.Rows, want) {
+		t.Fatalf("rows %v, want %v", tbl.Rows, want)
+	}
+	if !reflect.DeepEqual(ls.Items, []string{"cache Running", "db Running", "web Pending"}) {
+		t.Errorf("items %v", ls.Items)
+	}
+
+	// rows keep their identity as others come and go
+	f := NewFlasher()
+	tbl.Flash = f
+	now := time.Now()
+	for y, row := range tbl.Rows {
+		f.observe(tbl.rowKey(y)+",1", row[1], now)
+	}
+	wl.Apply(WatchEvent{Type: WatchDeleted, Key: "cache"})
+	wl.Apply(WatchEvent{Type: WatchModified, Key: "web", Object: pod{"web", "Running"}})
+	wl.Sync()
+	if changes != 5 {
+		t.Errorf("OnChange called %d times, want 5", changes)
+	}
+	for y, row := range tbl.Rows {
+		changed := f.observe(tbl.rowKey(y)+",1", row[1], now)
+		if changed != (row[0] == "web") {
+			t.Errorf("row %v changed: %v", row, changed)
+		}
+	}
+
+	wl.Less = func(a, b interface{}) bool { return a.(pod).status < b.(pod).status }
+	wl.Apply(WatchEvent{Type: WatchModified, Key: "db", Object: pod{"db", "Failed"}})
+	if keys := wl.Keys(); !reflect.DeepEqual(keys, []string{"db", "web"}) {
+		t.Errorf("keys %v, want db first", keys)
+	}
+}
+
+func TestWatchListTableSelection(t *testing.T) {
+	wl := NewWatchList(func(o interface{}) []string { return []string{o.(string)} })
+	wl.Header = []string{"NAME"}
+	tbl := NewTable()
+	tbl.Width, tbl.Height = 12, 8
+	wl.BindTable(tbl)
+	for _, k := range []string{"b", "c", "d"} {
+		wl.Apply(WatchEvent{Type: WatchAdded, Key: k, Object: k})
+	}
+	wl.Sync()
+	tbl.SelectedRow = 2 // c
+	tbl.Buffer()
+
+	wl.Apply(WatchEvent{Type: WatchAdded, Key: "a", Object: "a"})
+	wl.Sync()
+	tbl.Buffer()
+	if tbl.SelectedRow != 3 || tbl.Rows[tbl.SelectedRow][0] != "c" {
+		t.Errorf("selection should follow c to row 3, got row %d", tbl.SelectedRow)
+	}
+	tbl.ScrollDown(1)
+	if tbl.Rows[tbl.SelectedRow][0] != "d" {
+		t.Errorf("expected d below c, got %v", tbl.Rows[tbl.SelectedRow])
+	}
+
+	// a gone row leaves the selection at its index, within the rows
+	for _, k := range []string{"a", "c", "d"} {
+		wl.Apply(WatchEvent{Type: WatchDeleted, Key: k})
+	}
+	wl.Sync()
+	tbl.ScrollDown(0)
+	if tbl.SelectedRow != 1 {
+		t.Errorf("selection should be clamped to the last row, got %d", tbl.SelectedRow)
+	}
+}
+
+func TestWatchListListSelection(t *testing.T) {
+	wl := NewWatchList(func(o interface{}) []string { return []string{o.(string)} })
+	ls := NewList()
+	ls.Width, ls.Height = 12, 8
+	wl.BindList(ls)
+	for _, k := range []string{"b", "c", "d"} {
+		wl.Apply(WatchEvent{Type: WatchAdded, Key: k, Object: k})
+	}
+	wl.Sync()
+	ls.SelectedRow = 1 // c
+	ls.Buffer()
+
+	wl.Apply(WatchEvent{Type: WatchAdded, Key: "a", Object: "a"})
+	wl.Sync()
+	ls.Buffer()
+	if ls.SelectedRow != 2 || ls.Items[ls.SelectedRow] != "c" {
+		t.Errorf("selection should follow c to row 2, got row %d", ls.SelectedRow)
+	}
+
+	// a gone item leaves the selection at its index, within the items
+	for _, k := range []string{"a", "c", "d"} {
+		wl.Apply(WatchEvent{Type: WatchDeleted, Key: k})
+	}
+	wl.Sync()
+	ls.Buffer()
+	if ls.SelectedRow != 0 {
+		t.Errorf("selection should be clamped to the last item, got %d", ls.SelectedRow)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Workspaces are named full-screen layouts, e.g. an overview, logs and
+// configuration dashboards hosted by one app, of which one at a time is
+// Body. Switching keeps the widgets of the other ones, with their state, as
+// they are. Bind switches with M-1 to M-9 (Alt-1 to Alt-9).
+/*
+  ws := termui.NewWorkspaces()
+  ws.Add("overview", termui.NewGrid(termui.NewRow(termui.NewCol(12, 0, cpu))))
+  ws.Add("logs", termui.NewGrid(termui.NewRow(termui.NewCol(12, 0, lv))))
+  ws.Bind()
+
+  termui.Handle("/workspace/logs", func(termui.Event) {
+      status.Text = "logs"
+  })
+  termui.Render(termui.Body)
+*/
+type Workspaces struct {
+	// Emit is called with "/workspace/<name>" and the name of the
+	// workspace switched to, it defaults to SendCustomEvt.
+	Emit  func(path string, data interface{})
+	mu    sync.Mutex
+	names []string
+	grids []*Grid
+	cur   int
+}
+
+// NewWorkspaces returns an empty *Workspaces.
+func NewWorkspaces() *Workspaces {
+	return &Workspaces{Emit: SendCustomEvt, cur: -1}
+}
+
+// Add appends the workspace name laid out by g. The first one added
+// becomes Body.
+func (ws *Workspaces) Add(name string, g *Grid) {
+	ws.mu.Lock()
+	ws.names = append(ws.names, name)
+	ws.grids = append(ws.grids, g)
+	first := ws.cur < 0
+	ws.mu.Unlock()
+	if first {
+		ws.SwitchIndex(0)
+	}
+}
+
+// Names returns the names of the workspaces in the order they were added.
+func (ws *Workspaces) Names() []string {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return append([]string(nil), ws.names...)
+}
+
+// Current returns the name of the workspace shown, "" if there is none.
+func (ws *Workspaces) Current() string {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.cur < 0 {
+		return ""
+	}
+	return ws.names[ws.cur]
+}
+
+// Grid returns the layout of the workspace name, nil if there is none.
+func (ws *Workspaces) Grid(name string) *Grid {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for i, n := range ws.names {
+		if n == name {
+			return ws.grids[i]
+		}
+	}
+	return nil
+}
+
+// Switch makes the workspace name Body and tells if there is one.
+func (ws *Workspaces) Switch(name string) bool {
+	ws.mu.Lock()
+	i := -1
+	for j, n := range ws.names {
+		if n == name {
+			i = j
+			break
+		}
+	}
+	ws.mu.Unlock()
+	return i >= 0 && ws.SwitchIndex(i)
+}
+
+// SwitchIndex makes the i-th workspace, from 0, Body and tells if there
+// is one. Body is sized to the terminal and aligned. Emit is not called for
+// the first workspace added.
+func (ws *Workspaces) SwitchIndex(i int) bool {
+	ws.mu.Lock()
+	if i < 0 || i >= len(ws.grids) {
+		ws.mu.Unlock()
+		return false
+	}
+	switched := ws.cur >= 0 && i != ws.cur
+	ws.cur = i
+	g, name := ws.grids[i], ws.names[i]
+	ws.mu.Unlock()
+
+	Batch(func() {
+		Body = g
+		if w := termWidth; w > 0 {
+			g.Width = w
+		}
+	})
+	g.Align()
+	if switched && ws.Emit != nil {
+		ws.Emit("/workspace/"+name, name)
+	}
+	return true
+}
+
+// Bind switches to the n-th workspace on M-n, for n from 1 to 9, clearing
+// the screen and rendering Body.
+func (ws *Workspaces) Bind() {
+	for n := 1; n <= 9; n++ {
+		i := n - 1
+		Handle("/sys/kbd/M-"+strconv.Itoa(n), func(Event) {
+			if !ws.SwitchIndex(i) {
+				return
+			}
+			Clear()
+			Render(Body)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestWorkspaces(t *testing.T) {
+	old := Body
+	defer func() { Body = old }()
+
+	var paths []string
+	ws := NewWorkspaces()
+	ws.Emit = func(path string, data interface{}) { paths = append(paths, path) }
+
+	p := NewPar("logs")
+	p.Height = 3
+	overview, logs := NewGrid(), NewGrid(NewRow(NewCol(12, 0, p)))
+	ws.Add("overview", overview)
+	ws.Add("logs", logs)
+	if Body != overview || ws.Current() != "overview" {
+		t.Fatalf("the first workspace should be Body, got %q", ws.Current())
+	}
+
+	if !ws.Switch("logs") || Body != logs || ws.Current() != "logs" {
+		t.Fatalf("expected to switch to logs, got %q", ws.Current())
+	}
+	if ws.Switch("configs") || ws.SwitchIndex(2) {
+		t.Error("switching to a missing workspace should fail")
+	}
+	ws.SwitchIndex(0)
+	if Body != overview {
+		t.Error("expected Body to be the overview again")
+	}
+	if len(paths) != 2 || paths[0] != "/workspace/logs" || paths[1] != "/workspace/overview" {
+		t.Errorf("unexpected events %v", paths)
+	}
+	if ws.Grid("logs") != logs || p.Text != "logs" {
+		t.Error("the widgets of a workspace should be kept")
+	}
+}
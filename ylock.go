@@ -0,0 +1,59 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"math"
+	"time"
+)
+
+// yRange is the range a y axis of a LineChart is locked to, in the units
+// of its YScale.
+type yRange struct {
+	bottom, top float64
+	locked      bool
+}
+
+// SetYRange locks the left y axis to the range from min to max, whatever
+// the points shown, until AutoY or ToggleYLock returns it to AutoScale.
+func (lc *LineChart) SetYRange(min, max float64) {
+	if min > max {
+		min, max = max, min
+	}
+	lc.yLock = yRange{bottom: lc.yv(min), top: lc.yv(max), locked: true}
+}
+
+// AutoY returns the left y axis to AutoScale, fitting it to the points
+// shown again.
+func (lc *LineChart) AutoY() {
+	lc.yLock = yRange{}
+	lc.bottomValue, lc.topValue = math.Inf(1), math.Inf(-1)
+	lc.scaledAt = time.Time{}
+}
+
+// YLocked tells if the left y axis is locked, see SetYRange.
+func (lc *LineChart) YLocked() bool {
+	return lc.yLock.locked
+}
+
+// ToggleYLock locks the left y axis to the range it shows, to inspect the
+// chart while points come, or returns it to AutoScale if it is locked.
+/*
+  termui.Handle("/sys/kbd/l", func(termui.Event) {
+      lc.ToggleYLock()
+      termui.Render(lc)
+  })
+*/
+func (lc *LineChart) ToggleYLock() {
+	if lc.yLock.locked {
+		lc.AutoY()
+		return
+	}
+	if lc.bottomValue > lc.topValue {
+		// nothing drawn yet
+		return
+	}
+	lc.yLock = yRange{bottom: lc.bottomValue, top: lc.topValue, locked: true}
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "testing"
+
+func TestLineChartYLock(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 30
+	lc.Height = 10
+	lc.YPadding = 0
+	lc.Data["cpu"] = []float64{1, 5, 3}
+
+	lc.SetYRange(100, 0)
+	lc.Buffer()
+	if lc.bottomValue != 0 || lc.topValue != 100 || !lc.YLocked() {
+		t.Errorf("expected the range locked to 0..100, got %v..%v", lc.bottomValue, lc.topValue)
+	}
+	lc.ToggleYLock()
+	lc.Buffer()
+	if lc.YLocked() || lc.bottomValue != 1 || lc.topValue != 5 {
+		t.Errorf("expected the range fit again, got %v..%v", lc.bottomValue, lc.topValue)
+	}
+
+	// locked at the range shown, new extremes are left out
+	lc.ToggleYLock()
+	lc.Data["cpu"] = append(lc.Data["cpu"], 50)
+	lc.Buffer()
+	if !lc.YLocked() || lc.topValue != 5 {
+		t.Errorf("expected the range kept at its top 5, got %v", lc.topValue)
+	}
+}
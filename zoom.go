@@ -0,0 +1,113 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import "time"
+
+// maxZoom is the largest Zoom of a LineChart.
+const maxZoom = 1 << 16
+
+// zoom returns the number of points drawn as one, at least 1.
+func (lc *LineChart) zoom() int {
+	if lc.Zoom < 1 {
+		return 1
+	}
+	return lc.Zoom
+}
+
+// ZoomOut doubles the number of points per column, showing twice as much
+// history.
+func (lc *LineChart) ZoomOut() {
+	if z := lc.zoom(); z < maxZoom && z*lc.pointsPerCell() <= lc.maxOffset() {
+		lc.Zoom = 2 * z
+	}
+}
+
+// ZoomIn halves the number of points per column, down to the one or two
+// of the Mode.
+func (lc *LineChart) ZoomIn() {
+	lc.Zoom = lc.zoom() / 2
+	if lc.Zoom < 1 {
+		lc.Zoom = 1
+	}
+}
+
+// zoomSeries aggregates the points of d by groups of z, counted from the
+// last point: in braille mode every group becomes its minimum and maximum,
+// in the order they came in, so that a cell spans the whole group; in dot
+// mode, its maximum.
+func (lc *LineChart) zoomSeries(d []float64, z int) []float64 {
+	return lc.zoomIndexed(d, z, nil)
+}
+
+// zoomIndexed is zoomSeries finding the minimum and maximum of the groups
+// with the minMaxIndex x of d, if not nil.
+func (lc *LineChart) zoomIndexed(d []float64, z int, x *minMaxIndex) []float64 {
+	pair := lc.pointsPerCell() == 2
+	n := (len(d) + z - 1) / z
+	out := make([]float64, 0, 2*n)
+	for hi := len(d) - (n-1)*z; hi <= len(d); hi += z {
+		lo := hi - z
+		if lo < 0 {
+			lo = 0
+		}
+		var m minMax
+		if x != nil {
+			m = x.query(d, lo, hi)
+		} else {
+			m = scanMinMax(d, lo, hi)
+		}
+		imin, imax := m.imin, m.imax
+		switch {
+		case !pair:
+			out = append(out, d[imax])
+		case imin < imax:
+			out = append(out, d[imin], d[imax])
+		default:
+			out = append(out, d[imax], d[imin])
+		}
+	}
+	return out
+}
+
+// zoomTimes returns the times of the points of zoomSeries: those of the
+// first and last point of every group in braille mode, of the last one in
+// dot mode.
+func (lc *LineChart) zoomTimes(ts []time.Time, z int) []time.Time {
+	pair := lc.pointsPerCell() == 2
+	n := (len(ts) + z - 1) / z
+	out := make([]time.Time, 0, 2*n)
+	for hi := len(ts) - (n-1)*z; hi <= len(ts); hi += z {
+		lo := hi - z
+		if lo < 0 {
+			lo = 0
+		}
+		if pair {
+			out = append(out, ts[lo])
+		}
+		out = append(out, ts[hi-1])
+	}
+	return out
+}
+
+// zoomed returns the series, snapshot and Times of lc with Zoom applied,
+// reusing those of the last frame, see decimCache.
+func (lc *LineChart) zoomed() (data, snapshot map[string][]float64, times []time.Time) {
+	// a braille cell holds two points, each group gives two of them
+	z := lc.zoom() * lc.pointsPerCell()
+	prev := lc.decim.zoom
+	lc.decim.zoom = make(map[string]decimEntry, len(lc.Data))
+	data = make(map[string][]float64, len(lc.Data))
+	for name, d := range lc.Data {
+		data[name] = lc.cachedZoom(prev, "data/"+name, d, lc.gens[name], z, lc.indexOf(name, d))
+	}
+	if lc.snapshot != nil {
+		snapshot = make(map[string][]float64, len(lc.snapshot))
+		for name, d := range lc.snapshot {
+			snapshot[name] = lc.cachedZoom(prev, "snapshot/"+name, d, seriesGen{}, z, nil)
+		}
+	}
+	return data, snapshot, lc.cachedZoomTimes(lc.Times, z)
+}
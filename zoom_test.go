@@ -0,0 +1,68 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLineChartZoomSeries(t *testing.T) {
+	lc := NewLineChart()
+	d := []float64{7, 1, 5, 3, 9, 2, 4, 8, 6}
+
+	if got, want := lc.zoomSeries(d, 4), []float64{7, 7, 1, 9, 2, 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("braille groups of 4: %v, want %v", got, want)
+	}
+	if got := lc.zoomSeries(d, 2); !reflect.DeepEqual(got[1:], d) {
+		t.Errorf("braille groups of 2 should keep the points, got %v", got)
+	}
+	lc.Mode = "dot"
+	if got, want := lc.zoomSeries(d, 3), []float64{7, 9, 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dot groups of 3: %v, want %v", got, want)
+	}
+
+	t0 := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	var ts []time.Time
+	for i := range d {
+		ts = append(ts, t0.Add(time.Duration(i)*time.Minute))
+	}
+	if got := lc.zoomTimes(ts, 3); len(got) != 3 || !got[2].Equal(ts[8]) || !got[0].Equal(ts[2]) {
+		t.Errorf("dot times %v", got)
+	}
+}
+
+func TestLineChartZoom(t *testing.T) {
+	lc := NewLineChart()
+	lc.Width = 30
+	lc.Height = 10
+	for i := 0; i < 200; i++ {
+		lc.Data["up"] = append(lc.Data["up"], float64(i%10))
+	}
+	lc.ZoomIn()
+	if lc.Zoom != 1 {
+		t.Errorf("zoom %d, want 1 at most", lc.Zoom)
+	}
+	lc.ZoomOut()
+	lc.ZoomOut()
+	if lc.Zoom != 4 {
+		t.Errorf("zoom %d after zooming out twice, want 4", lc.Zoom)
+	}
+	lc.ScrollLeft()
+	if lc.DataOffset != 8 {
+		t.Errorf("offset %d, want a zoomed column of 8 points", lc.DataOffset)
+	}
+	lc.Buffer()
+	if len(lc.Data["up"]) != 200 {
+		t.Errorf("Buffer left %d points, want 200", len(lc.Data["up"]))
+	}
+	for i := 0; i < 20; i++ {
+		lc.ZoomOut()
+	}
+	if lc.Zoom != 128 {
+		t.Errorf("zoom %d, want 128 to fit 200 points", lc.Zoom)
+	}
+}